@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/Te8va/Tender/internal/tender/config"
+	"github.com/Te8va/Tender/internal/tender/contract"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/graph"
+	"github.com/Te8va/Tender/internal/tender/handler"
+	"github.com/Te8va/Tender/internal/tender/middleware"
+	"github.com/Te8va/Tender/internal/tender/notification"
+	"github.com/Te8va/Tender/internal/tender/ocds"
+	"github.com/Te8va/Tender/internal/tender/openapi"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/sealing"
+	"github.com/Te8va/Tender/internal/tender/service"
+	"github.com/Te8va/Tender/internal/tender/storage"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// app is the service wired over a migrated database: the HTTP API, the
+// services the gRPC server exposes and the notification dispatcher.
+type app struct {
+	handler    http.Handler
+	tenders    *service.Tender
+	bids       *service.Bid
+	dispatcher *notification.Dispatcher
+}
+
+// newApp wires the service. The dispatcher runs until backgroundCtx is done,
+// the closing reminder until reminderCtx is, both are tracked by wg.
+func newApp(backgroundCtx, reminderCtx context.Context, wg *sync.WaitGroup, cfg config.Config, pool *pgxpool.Pool) (*app, error) {
+	pg := repository.NewPostgres(pool)
+
+	pingRep := repository.NewPingProvider(pg)
+	pingService := service.NewPingProvider(pingRep)
+	pingHandler := handler.NewPingProvider(pingService)
+
+	var mailSender domain.MailSender = notification.NewLogSender()
+	if cfg.SMTPHost != "" {
+		mailSender = notification.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+	}
+
+	notificationRep := repository.NewNotificationService(pool)
+	savedSearchRep := repository.NewSavedSearchService(pool)
+	webhookSender := notification.NewHTTPWebhookSender(cfg.WebhookTimeout)
+	dispatcher, err := notification.NewDispatcher(notificationRep, savedSearchRep, mailSender, webhookSender, cfg.NotificationQueueSize, cfg.NotificationWorkers)
+	if err != nil {
+		return nil, err
+	}
+	dispatcher.Run(backgroundCtx, wg)
+	notification.NewClosingReminder(notificationRep, dispatcher, cfg.ClosingReminderBefore, cfg.ClosingReminderInterval).Run(reminderCtx, wg)
+
+	notificationService := service.NewNotification(notificationRep)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+
+	savedSearchService := service.NewSavedSearch(savedSearchRep)
+	savedSearchHandler := handler.NewSavedSearchHandler(savedSearchService)
+
+	var bidSealer domain.BidSealer
+	if cfg.BidSealKey != "" {
+		bidSealer, err = sealing.NewSealer(cfg.BidSealKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	serviceTypeRep := repository.NewServiceTypeService(pool)
+	serviceTypeService := service.NewServiceType(serviceTypeRep)
+	serviceTypeHandler := handler.NewServiceTypeHandler(serviceTypeService)
+
+	directoryRep := repository.NewDirectoryService(pool)
+	directoryService := service.NewDirectory(directoryRep)
+	directoryHandler := handler.NewDirectoryHandler(directoryService)
+
+	customFieldRep := repository.NewCustomFieldService(pool)
+	customFieldService := service.NewCustomField(customFieldRep)
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldService)
+
+	tenderRep := repository.NewTenderService(pool)
+	tenderService := service.NewTender(tenderRep, dispatcher, bidSealer, serviceTypeService, customFieldRep)
+	templateRep := repository.NewTemplateService(pool)
+	templateService := service.NewTemplate(templateRep, tenderRep)
+	templateHandler := handler.NewTemplateHandler(templateService)
+
+	exportRep := repository.NewExportService(pool)
+	exportService := service.NewExport(exportRep, tenderRep, bidSealer)
+
+	tenderHandler := handler.NewTenderHandler(tenderService, templateService, exportService)
+
+	importRep := repository.NewImportService(pool)
+	importService := service.NewImport(importRep, serviceTypeService, customFieldRep, bidSealer, cfg.ImportMaxRows)
+	importHandler := handler.NewImportHandler(importService, cfg.ImportMaxSize)
+
+	bidRep := repository.NewBidService(pool)
+	bidService := service.NewBid(bidRep, tenderRep, dispatcher, bidSealer)
+	bidHandler := handler.NewBidHandler(bidService, exportService)
+
+	evaluationRep := repository.NewEvaluationService(pool)
+	evaluationService := service.NewEvaluation(evaluationRep, tenderRep, bidSealer)
+	evaluationHandler := handler.NewEvaluationHandler(evaluationService)
+
+	auctionRep := repository.NewAuctionService(pool)
+	auctionService := service.NewAuction(auctionRep)
+	auctionHandler := handler.NewAuctionHandler(auctionService, cfg.AuctionAllowedOrigins)
+
+	contractRenderer, err := contract.NewRenderer(cfg.ContractTemplate, cfg.ContractFont)
+	if err != nil {
+		return nil, err
+	}
+
+	awardRep := repository.NewAwardService(pool)
+	awardService := service.NewAward(awardRep, tenderRep, bidSealer, contractRenderer)
+	awardHandler := handler.NewAwardHandler(awardService)
+
+	ocdsRep := repository.NewOCDSService(pool)
+	ocdsService := service.NewOCDS(ocdsRep, tenderRep, bidSealer, ocds.NewEncoder(cfg.OCDSPrefix, cfg.OCDSPublisher, cfg.OCDSCurrency))
+	ocdsHandler := handler.NewOCDSHandler(ocdsService)
+
+	lotRep := repository.NewLotService(pool)
+	lotService := service.NewLot(lotRep)
+	lotHandler := handler.NewLotHandler(lotService)
+
+	invitationRep := repository.NewInvitationService(pool)
+	invitationService := service.NewInvitation(invitationRep)
+	invitationHandler := handler.NewInvitationHandler(invitationService)
+
+	questionRep := repository.NewQuestionService(pool)
+	questionService := service.NewQuestion(questionRep)
+	questionHandler := handler.NewQuestionHandler(questionService)
+
+	var fileStorage domain.FileStorage
+	switch cfg.AttachmentStorage {
+	case "s3":
+		fileStorage, err = storage.NewS3(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3PathStyle)
+	default:
+		fileStorage, err = storage.NewLocal(cfg.AttachmentDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentRep := repository.NewAttachmentService(pool)
+	attachmentService := service.NewAttachment(attachmentRep, fileStorage, bidSealer, cfg.AttachmentMaxSize, cfg.AttachmentAllowedTypes)
+	tenderAttachmentHandler := handler.NewAttachmentHandler(attachmentService, domain.AttachmentOwnerTender, "tenderId")
+	bidAttachmentHandler := handler.NewAttachmentHandler(attachmentService, domain.AttachmentOwnerBid, "bidId")
+
+	graphRep := repository.NewGraphService(pool)
+	graphService := service.NewGraph(graphRep, bidSealer)
+	graphHandler := graph.NewHandler(tenderService, bidService, graphService)
+
+	apiDoc := openapi.Build()
+	spec, err := apiDoc.JSON()
+	if err != nil {
+		return nil, err
+	}
+	openAPIHandler, err := handler.NewOpenAPIHandler(spec, cfg.SwaggerUIURL)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle("/api/ping", middleware.Log(http.HandlerFunc(pingHandler.PingHandler)))
+	mux.Handle("GET /api/openapi.json", middleware.Log(http.HandlerFunc(openAPIHandler.SpecHandler)))
+	mux.Handle("GET /api/docs", middleware.Log(http.HandlerFunc(openAPIHandler.SwaggerUIHandler)))
+
+	mux.Handle("GET /api/tenders", middleware.Log(http.HandlerFunc(tenderHandler.ListTenderHandler)))
+	mux.Handle("POST /api/tender/new", middleware.Log(http.HandlerFunc(tenderHandler.CreateTenderHandler)))
+	mux.Handle("GET /api/tenders/my", middleware.Log(http.HandlerFunc(tenderHandler.GetUserTendersHandler)))
+	mux.Handle("PATCH /api/tenders/{tenderId}/edit", middleware.Log(http.HandlerFunc(tenderHandler.UpdatePartTenderHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}/status", middleware.Log(http.HandlerFunc(tenderHandler.GetTenderStatusHandler)))
+	mux.Handle("PUT /api/tenders/{tenderId}/status", middleware.Log(http.HandlerFunc(tenderHandler.UpdateTenderStatusHandler)))
+	mux.Handle("PUT /api/tenders/{tenderId}/rollback/{version}", middleware.Log(http.HandlerFunc(tenderHandler.RollbackTenderHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}", middleware.Log(http.HandlerFunc(tenderHandler.GetTenderHandler)))
+	mux.Handle("POST /api/tenders/status:batch", middleware.Log(http.HandlerFunc(tenderHandler.UpdateTenderStatusesHandler)))
+	mux.Handle("POST /api/tenders/import", middleware.Log(http.HandlerFunc(importHandler.ImportTendersHandler)))
+	mux.Handle("POST /api/tenders/{tenderId}/clone", middleware.Log(http.HandlerFunc(tenderHandler.CloneTenderHandler)))
+
+	mux.Handle("GET /api/service-types", middleware.Log(http.HandlerFunc(serviceTypeHandler.ListServiceTypesHandler)))
+	mux.Handle("POST /api/service-types", middleware.Log(http.HandlerFunc(serviceTypeHandler.CreateServiceTypeHandler)))
+	mux.Handle("GET /api/service-types/{code}", middleware.Log(http.HandlerFunc(serviceTypeHandler.GetServiceTypeHandler)))
+	mux.Handle("PATCH /api/service-types/{code}", middleware.Log(http.HandlerFunc(serviceTypeHandler.UpdateServiceTypeHandler)))
+	mux.Handle("DELETE /api/service-types/{code}", middleware.Log(http.HandlerFunc(serviceTypeHandler.DeleteServiceTypeHandler)))
+
+	mux.Handle("GET /api/organizations", middleware.Log(http.HandlerFunc(directoryHandler.ListOrganizationsHandler)))
+	mux.Handle("POST /api/organizations", middleware.Log(http.HandlerFunc(directoryHandler.CreateOrganizationHandler)))
+	mux.Handle("PATCH /api/organizations/{organizationId}", middleware.Log(http.HandlerFunc(directoryHandler.UpdateOrganizationHandler)))
+	mux.Handle("DELETE /api/organizations/{organizationId}", middleware.Log(http.HandlerFunc(directoryHandler.DeleteOrganizationHandler)))
+	mux.Handle("PUT /api/organizations/{organizationId}/responsibles/{employee}", middleware.Log(http.HandlerFunc(directoryHandler.AddOrganizationResponsibleHandler)))
+	mux.Handle("DELETE /api/organizations/{organizationId}/responsibles/{employee}", middleware.Log(http.HandlerFunc(directoryHandler.RemoveOrganizationResponsibleHandler)))
+	mux.Handle("GET /api/employees", middleware.Log(http.HandlerFunc(directoryHandler.ListEmployeesHandler)))
+	mux.Handle("POST /api/employees", middleware.Log(http.HandlerFunc(directoryHandler.CreateEmployeeHandler)))
+	mux.Handle("PATCH /api/employees/{employee}", middleware.Log(http.HandlerFunc(directoryHandler.UpdateEmployeeHandler)))
+	mux.Handle("DELETE /api/employees/{employee}", middleware.Log(http.HandlerFunc(directoryHandler.DeleteEmployeeHandler)))
+
+	mux.Handle("POST /api/organizations/{organizationId}/templates", middleware.Log(http.HandlerFunc(templateHandler.CreateTemplateHandler)))
+	mux.Handle("GET /api/organizations/{organizationId}/templates", middleware.Log(http.HandlerFunc(templateHandler.ListTemplatesHandler)))
+	mux.Handle("DELETE /api/organizations/{organizationId}/templates/{templateId}", middleware.Log(http.HandlerFunc(templateHandler.DeleteTemplateHandler)))
+
+	mux.Handle("POST /api/organizations/{organizationId}/custom-fields", middleware.Log(http.HandlerFunc(customFieldHandler.CreateCustomFieldHandler)))
+	mux.Handle("GET /api/organizations/{organizationId}/custom-fields", middleware.Log(http.HandlerFunc(customFieldHandler.ListCustomFieldsHandler)))
+	mux.Handle("DELETE /api/organizations/{organizationId}/custom-fields/{key}", middleware.Log(http.HandlerFunc(customFieldHandler.DeleteCustomFieldHandler)))
+
+	mux.Handle("POST /api/tenders/{tenderId}/attachments", middleware.Log(http.HandlerFunc(tenderAttachmentHandler.UploadAttachmentHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}/attachments", middleware.Log(http.HandlerFunc(tenderAttachmentHandler.ListAttachmentsHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}/attachments/{attachmentId}", middleware.Log(http.HandlerFunc(tenderAttachmentHandler.DownloadAttachmentHandler)))
+	mux.Handle("DELETE /api/tenders/{tenderId}/attachments/{attachmentId}", middleware.Log(http.HandlerFunc(tenderAttachmentHandler.DeleteAttachmentHandler)))
+
+	mux.Handle("GET /api/tenders/{tenderId}/lots", middleware.Log(http.HandlerFunc(lotHandler.ListLotsHandler)))
+	mux.Handle("PUT /api/tenders/{tenderId}/lots/{lotId}/award", middleware.Log(http.HandlerFunc(lotHandler.AwardLotHandler)))
+
+	mux.Handle("PUT /api/tenders/{tenderId}/award", middleware.Log(http.HandlerFunc(awardHandler.AwardTenderHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}/award", middleware.Log(http.HandlerFunc(awardHandler.GetAwardsHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}/award/contract", middleware.Log(http.HandlerFunc(awardHandler.ContractHandler)))
+
+	mux.Handle("GET /api/tenders/{tenderId}/ocds", middleware.Log(http.HandlerFunc(ocdsHandler.TenderPackageHandler)))
+	mux.Handle("GET /api/ocds/releases", middleware.Log(http.HandlerFunc(ocdsHandler.ListReleasesHandler)))
+
+	mux.Handle("GET /api/tenders/{tenderId}/evaluation", middleware.Log(http.HandlerFunc(evaluationHandler.GetEvaluationHandler)))
+	mux.Handle("PUT /api/bids/{bidId}/scores", middleware.Log(http.HandlerFunc(evaluationHandler.ScoreBidHandler)))
+
+	mux.Handle("GET /api/tenders/{tenderId}/auction", middleware.Log(http.HandlerFunc(auctionHandler.GetAuctionHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}/auction/offers", middleware.Log(http.HandlerFunc(auctionHandler.ListOffersHandler)))
+	mux.Handle("POST /api/tenders/{tenderId}/auction/offers", middleware.Log(http.HandlerFunc(auctionHandler.PlaceOfferHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}/auction/live", middleware.Log(http.HandlerFunc(auctionHandler.LiveAuctionHandler)))
+
+	mux.Handle("POST /api/tenders/{tenderId}/invitations", middleware.Log(http.HandlerFunc(invitationHandler.CreateInvitationHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}/invitations", middleware.Log(http.HandlerFunc(invitationHandler.ListInvitationsHandler)))
+	mux.Handle("DELETE /api/tenders/{tenderId}/invitations/{organizationId}", middleware.Log(http.HandlerFunc(invitationHandler.DeleteInvitationHandler)))
+
+	mux.Handle("POST /api/tenders/{tenderId}/questions", middleware.Log(http.HandlerFunc(questionHandler.CreateQuestionHandler)))
+	mux.Handle("GET /api/tenders/{tenderId}/questions", middleware.Log(http.HandlerFunc(questionHandler.ListQuestionsHandler)))
+	mux.Handle("PUT /api/tenders/{tenderId}/questions/{questionId}/answer", middleware.Log(http.HandlerFunc(questionHandler.AnswerQuestionHandler)))
+
+	mux.Handle("POST /api/bids/new", middleware.Log(http.HandlerFunc(bidHandler.CreateBidHandler)))
+	mux.Handle("GET /api/bids/my", middleware.Log(http.HandlerFunc(bidHandler.GetUserBidsHandler)))
+	mux.Handle("GET /api/bids/{tenderId}/list", middleware.Log(http.HandlerFunc(bidHandler.ListTenderBidsHandler)))
+	mux.Handle("GET /api/bids/{tenderId}/count", middleware.Log(http.HandlerFunc(bidHandler.CountTenderBidsHandler)))
+
+	mux.Handle("POST /api/bids/{bidId}/attachments", middleware.Log(http.HandlerFunc(bidAttachmentHandler.UploadAttachmentHandler)))
+	mux.Handle("GET /api/bids/{bidId}/attachments", middleware.Log(http.HandlerFunc(bidAttachmentHandler.ListAttachmentsHandler)))
+	mux.Handle("GET /api/bids/{bidId}/attachments/{attachmentId}", middleware.Log(http.HandlerFunc(bidAttachmentHandler.DownloadAttachmentHandler)))
+	mux.Handle("DELETE /api/bids/{bidId}/attachments/{attachmentId}", middleware.Log(http.HandlerFunc(bidAttachmentHandler.DeleteAttachmentHandler)))
+
+	mux.Handle("GET /api/notifications/settings", middleware.Log(http.HandlerFunc(notificationHandler.GetSettingsHandler)))
+	mux.Handle("PUT /api/notifications/settings", middleware.Log(http.HandlerFunc(notificationHandler.UpdateSettingsHandler)))
+	mux.Handle("GET /api/notifications", middleware.Log(http.HandlerFunc(notificationHandler.ListNotificationsHandler)))
+	mux.Handle("PUT /api/notifications/{notificationId}/read", middleware.Log(http.HandlerFunc(notificationHandler.MarkNotificationReadHandler)))
+
+	mux.Handle("POST /api/searches", middleware.Log(http.HandlerFunc(savedSearchHandler.CreateSavedSearchHandler)))
+	mux.Handle("GET /api/searches", middleware.Log(http.HandlerFunc(savedSearchHandler.ListSavedSearchesHandler)))
+	mux.Handle("PUT /api/searches/{searchId}/subscription", middleware.Log(http.HandlerFunc(savedSearchHandler.SetSubscriptionHandler)))
+	mux.Handle("DELETE /api/searches/{searchId}", middleware.Log(http.HandlerFunc(savedSearchHandler.DeleteSavedSearchHandler)))
+
+	mux.Handle("GET /api/graphql", middleware.Log(graphHandler))
+	mux.Handle("POST /api/graphql", middleware.Log(graphHandler))
+
+	return &app{
+		handler:    middleware.Validate(openapi.NewValidator(apiDoc), mux),
+		tenders:    tenderService,
+		bids:       bidService,
+		dispatcher: dispatcher,
+	}, nil
+}
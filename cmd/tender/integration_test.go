@@ -0,0 +1,909 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caarlos0/env/v6"
+	"github.com/gorilla/websocket"
+
+	"github.com/Te8va/Tender/internal/tender/config"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/openapi"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+const (
+	owner     = repotest.Owner
+	outsider  = repotest.Outsider
+	admin     = "contract_admin"
+	ownerOrg  = repotest.Organization
+	bidderOrg = repotest.OtherOrganization
+
+	// bidSealKey is a master key of 32 zero bytes, enough to seal bids in
+	// the tests.
+	bidSealKey = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if err := repotest.StopPostgres(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	os.Exit(code)
+}
+
+// client talks to the service under test and records the routes of the
+// specification its requests were matched to.
+type client struct {
+	server *httptest.Server
+
+	mu  sync.Mutex
+	hit map[string]bool
+}
+
+// newClient serves the whole service over a database of its own.
+func newClient(t *testing.T) *client {
+	t.Helper()
+
+	pool := repotest.Postgres(t)
+	_, err := pool.Exec(context.Background(), `INSERT INTO employee (username, first_name, last_name, is_admin) VALUES ($1, 'Anna', 'Admin', TRUE)`, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.SMTPHost = ""
+	cfg.BidSealKey = bidSealKey
+	cfg.AttachmentStorage = "local"
+	cfg.AttachmentDir = t.TempDir()
+
+	var wg sync.WaitGroup
+	backgroundCtx, cancelBackgroundCtx := context.WithCancel(context.Background())
+	reminderCtx, stopReminder := context.WithCancel(backgroundCtx)
+
+	app, err := newApp(backgroundCtx, reminderCtx, &wg, cfg, pool)
+	if err != nil {
+		cancelBackgroundCtx()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		stopReminder()
+		app.dispatcher.Close()
+		wg.Wait()
+		cancelBackgroundCtx()
+	})
+
+	specified := http.NewServeMux()
+	for _, route := range openapi.Routes() {
+		specified.Handle(route, http.NotFoundHandler())
+	}
+
+	c := &client{hit: map[string]bool{}}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := specified.Handler(r); pattern != "" {
+			c.mu.Lock()
+			c.hit[pattern] = true
+			c.mu.Unlock()
+		}
+		app.handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(c.server.Close)
+
+	return c
+}
+
+// send fails the test unless the request is answered with the status want.
+func (c *client) send(t *testing.T, method, target, contentType string, body io.Reader, want int) (http.Header, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, c.server.URL+target, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != want {
+		t.Fatalf("%s %s: status %d, want %d: %s", method, target, resp.StatusCode, want, data)
+	}
+
+	return resp.Header, data
+}
+
+// json sends in as the body, when not nil, and decodes the response into
+// out, when not nil.
+func (c *client) json(t *testing.T, method, target string, in any, want int, out any) {
+	t.Helper()
+
+	var body io.Reader
+	contentType := ""
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, contentType = bytes.NewReader(data), "application/json"
+	}
+
+	_, data := c.send(t, method, target, contentType, body, want)
+	if out == nil {
+		return
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("%s %s: %v: %s", method, target, err, data)
+	}
+}
+
+// upload attaches a text file under the multipart field the API expects.
+func (c *client) upload(t *testing.T, target, fileName, content string) domain.Attachment {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(part, content)
+	if err := form.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var attachment domain.Attachment
+	_, data := c.send(t, http.MethodPost, target, form.FormDataContentType(), &body, http.StatusCreated)
+	if err := json.Unmarshal(data, &attachment); err != nil {
+		t.Fatal(err)
+	}
+
+	return attachment
+}
+
+// export fetches a list as a file and checks its media type.
+func (c *client) export(t *testing.T, target, mediaType string) {
+	t.Helper()
+
+	header, data := c.send(t, http.MethodGet, target, "", nil, http.StatusOK)
+	if got, _, _ := mime.ParseMediaType(header.Get("Content-Type")); got != mediaType {
+		t.Errorf("GET %s: Content-Type %q, want %q", target, got, mediaType)
+	}
+	if len(data) == 0 {
+		t.Errorf("GET %s: empty export", target)
+	}
+}
+
+// live opens the live feed of an auction and returns the events it sends.
+func (c *client) live(t *testing.T, target string) <-chan domain.AuctionEvent {
+	t.Helper()
+
+	conn, resp, err := websocket.DefaultDialer.Dial("ws://"+c.server.Listener.Addr().String()+target, nil)
+	if err != nil {
+		t.Fatalf("GET %s: %v", target, err)
+	}
+	resp.Body.Close()
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	events := make(chan domain.AuctionEvent, 16)
+	go func() {
+		defer close(events)
+		for {
+			var event domain.AuctionEvent
+			if err := conn.ReadJSON(&event); err != nil {
+				return
+			}
+			events <- event
+		}
+	}()
+
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan domain.AuctionEvent) domain.AuctionEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("live feed closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event on the live feed")
+	}
+
+	return domain.AuctionEvent{}
+}
+
+func as(username string) string {
+	return "username=" + url.QueryEscape(username)
+}
+
+func price(p float64) *float64 { return &p }
+
+// TestIntegration runs the service end to end against Postgres and fails
+// for every route of the specification it did not exercise.
+func TestIntegration(t *testing.T) {
+	c := newClient(t)
+
+	t.Run("System", func(t *testing.T) { testSystem(t, c) })
+	t.Run("ServiceTypes", func(t *testing.T) { testServiceTypes(t, c) })
+	t.Run("Directory", func(t *testing.T) { testDirectory(t, c) })
+	t.Run("Tender", func(t *testing.T) { testTender(t, c) })
+	t.Run("Lots", func(t *testing.T) { testLots(t, c) })
+	t.Run("Auction", func(t *testing.T) { testAuction(t, c) })
+	t.Run("Sealed", func(t *testing.T) { testSealed(t, c) })
+	t.Run("Invitations", func(t *testing.T) { testInvitations(t, c) })
+	t.Run("Drafts", func(t *testing.T) { testDrafts(t, c) })
+	t.Run("Import", func(t *testing.T) { testImport(t, c) })
+
+	if t.Failed() {
+		return
+	}
+	for _, route := range openapi.Routes() {
+		if !c.hit[route] {
+			t.Errorf("%s is not exercised", route)
+		}
+	}
+}
+
+func testSystem(t *testing.T, c *client) {
+	if _, body := c.send(t, http.MethodGet, "/api/ping", "", nil, http.StatusOK); string(body) != "ok" {
+		t.Errorf("ping = %q, want ok", body)
+	}
+
+	var spec map[string]any
+	c.json(t, http.MethodGet, "/api/openapi.json", nil, http.StatusOK, &spec)
+	if spec["openapi"] == nil {
+		t.Error("specification has no openapi version")
+	}
+
+	c.send(t, http.MethodGet, "/api/docs", "", nil, http.StatusOK)
+}
+
+func testServiceTypes(t *testing.T, c *client) {
+	var types []domain.ServiceType
+	c.json(t, http.MethodGet, "/api/service-types?all=true", nil, http.StatusOK, &types)
+	if len(types) == 0 {
+		t.Fatal("no service types")
+	}
+
+	var construction domain.ServiceType
+	c.json(t, http.MethodGet, "/api/service-types/Construction?locale=en", nil, http.StatusOK, &construction)
+	if construction.Code != "Construction" {
+		t.Errorf("code = %q, want Construction", construction.Code)
+	}
+
+	// The scheme is left to its default, an empty one is not a valid value.
+	survey := map[string]any{"code": "Survey", "names": map[string]string{"en": "Survey", "ru": "Изыскания"}}
+	c.json(t, http.MethodPost, "/api/service-types?"+as(owner), survey, http.StatusForbidden, nil)
+	var created domain.ServiceType
+	c.json(t, http.MethodPost, "/api/service-types?"+as(admin), survey, http.StatusCreated, &created)
+	if created.Scheme != domain.ServiceTypeSchemeCustom {
+		t.Errorf("scheme = %q, want %q", created.Scheme, domain.ServiceTypeSchemeCustom)
+	}
+
+	var updated domain.ServiceType
+	update := domain.ServiceTypeUpdate{Names: map[string]string{"en": "Land survey"}}
+	c.json(t, http.MethodPatch, "/api/service-types/Survey?"+as(admin), update, http.StatusOK, &updated)
+	if updated.Names["en"] != "Land survey" {
+		t.Errorf("names = %v, want the updated en name", updated.Names)
+	}
+
+	c.send(t, http.MethodDelete, "/api/service-types/Survey?"+as(admin), "", nil, http.StatusNoContent)
+	c.send(t, http.MethodGet, "/api/service-types/Survey", "", nil, http.StatusNotFound)
+}
+
+// testDirectory adds an organization and an employee, lets the employee act
+// for it and removes both again.
+func testDirectory(t *testing.T, c *client) {
+	create := domain.CreateOrganizationRequest{Name: "Survey LLC", Type: domain.OrganizationTypeLLC}
+	c.json(t, http.MethodPost, "/api/organizations?"+as(owner), create, http.StatusForbidden, nil)
+	var organization domain.Organization
+	c.json(t, http.MethodPost, "/api/organizations?"+as(admin), create, http.StatusCreated, &organization)
+
+	description := "Land surveys"
+	var updated domain.Organization
+	c.json(t, http.MethodPatch, "/api/organizations/"+organization.ID+"?"+as(admin), domain.OrganizationUpdate{Description: &description}, http.StatusOK, &updated)
+	if updated.Name != create.Name || updated.Description != description {
+		t.Errorf("updated organization = %+v", updated)
+	}
+
+	employee := domain.CreateEmployeeRequest{Username: "contract_surveyor", FirstName: "Sergey"}
+	c.json(t, http.MethodPost, "/api/employees?"+as(admin), employee, http.StatusCreated, nil)
+	c.json(t, http.MethodPost, "/api/employees?"+as(admin), employee, http.StatusConflict, nil)
+
+	responsible := "/api/organizations/" + organization.ID + "/responsibles/" + employee.Username + "?" + as(admin)
+	c.send(t, http.MethodPut, responsible, "", nil, http.StatusNoContent)
+	c.send(t, http.MethodPut, responsible, "", nil, http.StatusNoContent)
+
+	var tender domain.TenderResponse
+	c.json(t, http.MethodPost, "/api/tender/new", domain.CreateTenderRequest{
+		Name: "Съёмка участка", ServiceType: "Construction", OrganizationId: organization.ID, CreatorUsername: employee.Username,
+	}, http.StatusCreated, &tender)
+
+	c.send(t, http.MethodDelete, "/api/organizations/"+organization.ID+"?"+as(admin), "", nil, http.StatusConflict)
+	c.send(t, http.MethodDelete, "/api/employees/"+employee.Username+"?"+as(admin), "", nil, http.StatusConflict)
+
+	c.send(t, http.MethodDelete, responsible, "", nil, http.StatusNoContent)
+	c.send(t, http.MethodDelete, responsible, "", nil, http.StatusNotFound)
+	c.send(t, http.MethodPut, "/api/tenders/"+tender.ID+"/status?status=PUBLISHED&"+as(employee.Username), "", nil, http.StatusForbidden)
+
+	var employees []domain.Employee
+	c.json(t, http.MethodGet, "/api/employees?limit=100&"+as(admin), nil, http.StatusOK, &employees)
+	if !slices.ContainsFunc(employees, func(e domain.Employee) bool { return e.Username == employee.Username && e.FirstName == "Sergey" }) {
+		t.Errorf("employees = %+v, want %s among them", employees, employee.Username)
+	}
+}
+
+// testTender follows a standard tender from its creation to the contract.
+func testTender(t *testing.T, c *client) {
+	var field domain.CustomField
+	c.json(t, http.MethodPost, "/api/organizations/"+ownerOrg+"/custom-fields?"+as(owner),
+		domain.CreateCustomFieldRequest{Key: "region", Name: "Region", Type: domain.CustomFieldTypeString}, http.StatusCreated, &field)
+	var fields []domain.CustomField
+	c.json(t, http.MethodGet, "/api/organizations/"+ownerOrg+"/custom-fields?"+as(owner), nil, http.StatusOK, &fields)
+	if len(fields) != 1 || fields[0].Key != "region" {
+		t.Fatalf("custom fields = %+v, want region", fields)
+	}
+
+	var search domain.SavedSearch
+	c.json(t, http.MethodPost, "/api/searches?"+as(outsider),
+		domain.CreateSavedSearchRequest{Name: "Pipes", Keywords: []string{"pipes"}}, http.StatusCreated, &search)
+
+	settings := domain.NotificationSettings{Email: "owner@example.com", Locale: "en", NotifyOnBid: true}
+	c.json(t, http.MethodPut, "/api/notifications/settings?"+as(owner), settings, http.StatusOK, nil)
+	var saved domain.NotificationSettings
+	c.json(t, http.MethodGet, "/api/notifications/settings?"+as(owner), nil, http.StatusOK, &saved)
+	if saved != settings {
+		t.Errorf("settings = %+v, want %+v", saved, settings)
+	}
+
+	var tender domain.TenderResponse
+	c.json(t, http.MethodPost, "/api/tender/new", domain.CreateTenderRequest{
+		Name:            "Water pipes",
+		Description:     "Replacement of the water pipes",
+		ServiceType:     "Construction",
+		OrganizationId:  ownerOrg,
+		CreatorUsername: owner,
+		Budget:          price(1000),
+		Criteria:        []domain.Criterion{{Name: "Price", Weight: 0.7}, {Name: "Experience", Weight: 0.3}},
+		Tags:            []string{"Water"},
+		CustomFields:    map[string]any{"region": "north"},
+	}, http.StatusCreated, &tender)
+	if tender.Status != "CREATED" || len(tender.Criteria) != 2 {
+		t.Fatalf("created tender = %+v", tender)
+	}
+	tenders := "/api/tenders/" + tender.ID
+
+	drawing := c.upload(t, tenders+"/attachments?"+as(owner), "drawing.txt", "the pipes run north")
+	var attachments []domain.Attachment
+	c.json(t, http.MethodGet, tenders+"/attachments?"+as(owner), nil, http.StatusOK, &attachments)
+	if len(attachments) != 1 {
+		t.Errorf("attachments = %+v, want the drawing", attachments)
+	}
+	if _, body := c.send(t, http.MethodGet, tenders+"/attachments/"+drawing.ID+"?"+as(owner), "", nil, http.StatusOK); string(body) != "the pipes run north" {
+		t.Errorf("downloaded %q", body)
+	}
+
+	c.json(t, http.MethodPut, tenders+"/status?status=PUBLISHED&"+as(owner), nil, http.StatusOK, &tender)
+	var status domain.TenderStatusUpdate
+	c.json(t, http.MethodGet, tenders+"/status?"+as(owner), nil, http.StatusOK, &status)
+	if status.Status != "PUBLISHED" {
+		t.Errorf("status = %q, want PUBLISHED", status.Status)
+	}
+
+	var listed []domain.TenderResponse
+	c.json(t, http.MethodGet, "/api/tenders?service_type=Construction&tag=water&cf.region=north", nil, http.StatusOK, &listed)
+	if len(listed) != 1 || listed[0].ID != tender.ID {
+		t.Errorf("listed = %+v, want the published tender", listed)
+	}
+	c.json(t, http.MethodGet, "/api/tenders/my?"+as(owner), nil, http.StatusOK, &listed)
+	if len(listed) != 1 || listed[0].ID != tender.ID {
+		t.Errorf("my tenders = %+v, want the published tender", listed)
+	}
+	c.json(t, http.MethodGet, tenders+"?"+as(outsider), nil, http.StatusOK, &tender)
+	c.json(t, http.MethodPatch, tenders+"/edit?"+as(outsider), map[string]any{"name": "Taken over"}, http.StatusForbidden, nil)
+	c.send(t, http.MethodPut, tenders+"/rollback/1?"+as(outsider), "", nil, http.StatusForbidden)
+	c.export(t, "/api/tenders?format=csv", "text/csv")
+	c.export(t, "/api/tenders/my?format=xlsx&"+as(owner), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	// Publishing matched the saved search of the outsider.
+	var notifications []domain.InAppNotification
+	for deadline := time.Now().Add(5 * time.Second); len(notifications) == 0; time.Sleep(50 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("no notification for the saved search")
+		}
+		c.json(t, http.MethodGet, "/api/notifications?unread=true&"+as(outsider), nil, http.StatusOK, &notifications)
+	}
+	if notifications[0].TenderID != tender.ID || notifications[0].SavedSearchID != search.ID {
+		t.Errorf("notification = %+v", notifications[0])
+	}
+	var read domain.InAppNotification
+	c.json(t, http.MethodPut, "/api/notifications/"+notifications[0].ID+"/read?"+as(outsider), nil, http.StatusOK, &read)
+	if !read.Read {
+		t.Error("notification is not read")
+	}
+
+	var searches []domain.SavedSearch
+	c.json(t, http.MethodGet, "/api/searches?"+as(outsider), nil, http.StatusOK, &searches)
+	if len(searches) != 1 {
+		t.Errorf("searches = %+v, want one", searches)
+	}
+	c.json(t, http.MethodPut, "/api/searches/"+search.ID+"/subscription?subscribed=false&"+as(outsider), nil, http.StatusOK, &search)
+	if search.Subscribed {
+		t.Error("search is still subscribed")
+	}
+	c.send(t, http.MethodDelete, "/api/searches/"+search.ID+"?"+as(outsider), "", nil, http.StatusNoContent)
+
+	var question domain.Question
+	c.json(t, http.MethodPost, tenders+"/questions?"+as(outsider),
+		domain.CreateQuestionRequest{Question: "Which diameter?"}, http.StatusCreated, &question)
+	c.json(t, http.MethodPut, tenders+"/questions/"+question.ID+"/answer?"+as(owner),
+		domain.AnswerQuestionRequest{Answer: "100 mm", Public: true}, http.StatusOK, &question)
+	var questions []domain.Question
+	c.json(t, http.MethodGet, tenders+"/questions?"+as(outsider), nil, http.StatusOK, &questions)
+	if len(questions) != 1 || questions[0].Answer == nil || *questions[0].Answer != "100 mm" {
+		t.Errorf("questions = %+v, want the answered one", questions)
+	}
+
+	var bid domain.BidResponse
+	c.json(t, http.MethodPost, "/api/bids/new", domain.CreateBidRequest{
+		Name:            "Pipes by Other",
+		Description:     "Steel pipes",
+		TenderId:        tender.ID,
+		OrganizationId:  bidderOrg,
+		CreatorUsername: outsider,
+		Price:           price(900),
+	}, http.StatusCreated, &bid)
+	bids := "/api/bids/" + bid.ID
+
+	offer := c.upload(t, bids+"/attachments?"+as(outsider), "offer.txt", "steel pipes, 900")
+	c.json(t, http.MethodGet, bids+"/attachments?"+as(owner), nil, http.StatusOK, &attachments)
+	if len(attachments) != 1 {
+		t.Errorf("bid attachments = %+v, want the offer", attachments)
+	}
+	c.send(t, http.MethodGet, bids+"/attachments/"+offer.ID+"?"+as(owner), "", nil, http.StatusOK)
+	c.send(t, http.MethodDelete, bids+"/attachments/"+offer.ID+"?"+as(outsider), "", nil, http.StatusNoContent)
+
+	var mine, listedBids []domain.BidResponse
+	c.json(t, http.MethodGet, "/api/bids/my?"+as(outsider), nil, http.StatusOK, &mine)
+	c.json(t, http.MethodGet, "/api/bids/"+tender.ID+"/list?"+as(owner), nil, http.StatusOK, &listedBids)
+	if len(mine) != 1 || len(listedBids) != 1 {
+		t.Errorf("bids = %+v and %+v, want the bid in both", mine, listedBids)
+	}
+	var count domain.BidCount
+	c.json(t, http.MethodGet, "/api/bids/"+tender.ID+"/count?"+as(owner), nil, http.StatusOK, &count)
+	if count.Count != 1 {
+		t.Errorf("count = %d, want 1", count.Count)
+	}
+	c.export(t, "/api/bids/my?format=csv&"+as(outsider), "text/csv")
+	c.export(t, "/api/bids/"+tender.ID+"/list?format=csv&"+as(owner), "text/csv")
+
+	scores := domain.ScoreBidRequest{Scores: []domain.CriterionScore{
+		{CriterionID: tender.Criteria[0].ID, Score: 8},
+		{CriterionID: tender.Criteria[1].ID, Score: 6},
+	}}
+	c.json(t, http.MethodPut, bids+"/scores?"+as(owner), scores, http.StatusOK, nil)
+	var evaluation domain.Evaluation
+	c.json(t, http.MethodGet, tenders+"/evaluation?"+as(owner), nil, http.StatusOK, &evaluation)
+	if len(evaluation.Bids) != 1 || evaluation.Bids[0].BidID != bid.ID {
+		t.Errorf("evaluation = %+v, want the scored bid", evaluation)
+	}
+
+	graphQL(t, c, tender.ID)
+
+	c.send(t, http.MethodGet, tenders+"/award?"+as(owner), "", nil, http.StatusNotFound)
+	var awards []domain.Award
+	c.json(t, http.MethodPut, tenders+"/award?"+as(owner), domain.AwardTenderRequest{BidID: bid.ID}, http.StatusOK, &awards)
+	c.json(t, http.MethodGet, tenders+"/award?"+as(outsider), nil, http.StatusOK, &awards)
+	if len(awards) != 1 || awards[0].OrganizationID != bidderOrg {
+		t.Errorf("awards = %+v, want the bidder", awards)
+	}
+	header, contract := c.send(t, http.MethodGet, tenders+"/award/contract?"+as(outsider), "", nil, http.StatusOK)
+	if mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type")); mediaType != "text/markdown" || !bytes.Contains(contract, []byte("Water pipes")) {
+		t.Errorf("contract %s: %s", mediaType, contract)
+	}
+
+	var record map[string]any
+	c.json(t, http.MethodGet, tenders+"/ocds?package=record", nil, http.StatusOK, &record)
+	var releases map[string]any
+	c.json(t, http.MethodGet, "/api/ocds/releases", nil, http.StatusOK, &releases)
+	if list, _ := releases["releases"].([]any); len(list) == 0 {
+		t.Error("no OCDS releases")
+	}
+
+	c.send(t, http.MethodDelete, tenders+"/attachments/"+drawing.ID+"?"+as(owner), "", nil, http.StatusNoContent)
+	c.send(t, http.MethodDelete, "/api/organizations/"+ownerOrg+"/custom-fields/region?"+as(owner), "", nil, http.StatusNoContent)
+}
+
+func graphQL(t *testing.T, c *client, tenderID string) {
+	var response struct {
+		Data   map[string]json.RawMessage `json:"data"`
+		Errors []json.RawMessage          `json:"errors"`
+	}
+
+	query := url.Values{"query": {"{ me { username organizations { name } } myBids { id tender { id } } }"}, "username": {outsider}}
+	c.json(t, http.MethodGet, "/api/graphql?"+query.Encode(), nil, http.StatusOK, &response)
+	if len(response.Errors) > 0 || response.Data["me"] == nil {
+		t.Errorf("GET graphql = %+v", response)
+	}
+
+	request := map[string]string{"query": `{ tender(id: "` + tenderID + `") { name organization { name } creator { username } versions { version } bids { id organization { name } } } myTenders { id } }`}
+	response.Data, response.Errors = nil, nil
+	c.json(t, http.MethodPost, "/api/graphql?"+as(owner), request, http.StatusOK, &response)
+	if len(response.Errors) > 0 || response.Data["tender"] == nil {
+		t.Errorf("POST graphql = %+v", response)
+	}
+}
+
+func testLots(t *testing.T, c *client) {
+	var tender domain.TenderResponse
+	c.json(t, http.MethodPost, "/api/tender/new", domain.CreateTenderRequest{
+		Name:            "Fittings",
+		Description:     "Fittings in lots",
+		ServiceType:     "Delivery",
+		OrganizationId:  ownerOrg,
+		CreatorUsername: owner,
+		Lots:            []domain.Lot{{Name: "Valves", Quantity: 20, Unit: "pcs"}},
+	}, http.StatusCreated, &tender)
+	tenders := "/api/tenders/" + tender.ID
+	c.json(t, http.MethodPut, tenders+"/status?status=PUBLISHED&"+as(owner), nil, http.StatusOK, nil)
+
+	var lots []domain.Lot
+	c.json(t, http.MethodGet, tenders+"/lots?"+as(outsider), nil, http.StatusOK, &lots)
+	if len(lots) != 1 {
+		t.Fatalf("lots = %+v, want one", lots)
+	}
+
+	// Version 2 adds a lot, rolling back to version 1 removes it and to
+	// version 2 brings it back under its ID.
+	var edited domain.TenderResponse
+	c.json(t, http.MethodPatch, tenders+"/edit?"+as(owner), map[string]any{
+		"lots": []domain.Lot{lots[0], {Name: "Gaskets", Quantity: 50, Unit: "pcs"}},
+	}, http.StatusOK, &edited)
+	if len(edited.Lots) != 2 {
+		t.Fatalf("edited lots = %+v, want two", edited.Lots)
+	}
+	var rolledBack domain.TenderResponse
+	c.json(t, http.MethodPut, tenders+"/rollback/1?"+as(owner), nil, http.StatusOK, &rolledBack)
+	if len(rolledBack.Lots) != 1 || rolledBack.Lots[0].ID != lots[0].ID {
+		t.Errorf("lots rolled back to version 1 = %+v, want %+v", rolledBack.Lots, lots)
+	}
+	c.json(t, http.MethodPut, tenders+"/rollback/2?"+as(owner), nil, http.StatusOK, &rolledBack)
+	if len(rolledBack.Lots) != 2 || rolledBack.Lots[1].ID != edited.Lots[1].ID {
+		t.Errorf("lots rolled back to version 2 = %+v, want %+v", rolledBack.Lots, edited.Lots)
+	}
+	lots = rolledBack.Lots
+
+	var bid domain.BidResponse
+	c.json(t, http.MethodPost, "/api/bids/new", domain.CreateBidRequest{
+		Name:            "Valves by Other",
+		TenderId:        tender.ID,
+		OrganizationId:  bidderOrg,
+		CreatorUsername: outsider,
+		Lots:            []domain.BidLot{{LotID: lots[0].ID, Price: price(300)}, {LotID: lots[1].ID, Price: price(100)}},
+	}, http.StatusCreated, &bid)
+
+	// The gaskets have a bid now, the version without them can not return.
+	c.send(t, http.MethodPut, tenders+"/rollback/1?"+as(owner), "", nil, http.StatusConflict)
+
+	for _, l := range lots {
+		var lot domain.Lot
+		c.json(t, http.MethodPut, tenders+"/lots/"+l.ID+"/award?"+as(owner), domain.AwardLotRequest{BidID: bid.ID}, http.StatusOK, &lot)
+		if lot.AwardedBidID == nil || *lot.AwardedBidID != bid.ID {
+			t.Errorf("lot = %+v, want it awarded to the bid", lot)
+		}
+	}
+
+	var status domain.TenderStatusUpdate
+	c.json(t, http.MethodGet, tenders+"/status?"+as(owner), nil, http.StatusOK, &status)
+	if status.Status != "CLOSED" {
+		t.Errorf("status = %q, want CLOSED once every lot is awarded", status.Status)
+	}
+}
+
+// testSealed bids on a sealed tender: the owner can not read them before
+// the deadline, the bidder reads their own bid decrypted.
+func testSealed(t *testing.T, c *client) {
+	closesAt := time.Now().Add(time.Hour)
+	var tender domain.TenderResponse
+	c.json(t, http.MethodPost, "/api/tender/new", domain.CreateTenderRequest{
+		Name:            "Sealed pipes",
+		Description:     "Pipes with sealed bids",
+		ServiceType:     "Delivery",
+		OrganizationId:  ownerOrg,
+		CreatorUsername: owner,
+		Sealed:          true,
+		ClosesAt:        &closesAt,
+	}, http.StatusCreated, &tender)
+	if !tender.Sealed {
+		t.Fatalf("tender = %+v, want a sealed one", tender)
+	}
+	c.json(t, http.MethodPut, "/api/tenders/"+tender.ID+"/status?status=PUBLISHED&"+as(owner), nil, http.StatusOK, nil)
+
+	var bid domain.BidResponse
+	c.json(t, http.MethodPost, "/api/bids/new", domain.CreateBidRequest{
+		Name:            "Sealed offer",
+		Description:     "Pipes at a secret price",
+		TenderId:        tender.ID,
+		OrganizationId:  bidderOrg,
+		CreatorUsername: outsider,
+		Price:           price(700),
+	}, http.StatusCreated, &bid)
+	if !bid.Sealed {
+		t.Errorf("bid = %+v, want a sealed one", bid)
+	}
+
+	c.send(t, http.MethodGet, "/api/bids/"+tender.ID+"/list?"+as(owner), "", nil, http.StatusForbidden)
+
+	var mine []domain.BidResponse
+	c.json(t, http.MethodGet, "/api/bids/my?limit=50&"+as(outsider), nil, http.StatusOK, &mine)
+	i := slices.IndexFunc(mine, func(b domain.BidResponse) bool { return b.ID == bid.ID })
+	if i < 0 || mine[i].Name != "Sealed offer" || mine[i].Price == nil || *mine[i].Price != 700 {
+		t.Errorf("bids of the bidder = %+v, want the sealed bid decrypted", mine)
+	}
+}
+
+func testAuction(t *testing.T, c *client) {
+	now := time.Now()
+	var tender domain.TenderResponse
+	c.json(t, http.MethodPost, "/api/tender/new", domain.CreateTenderRequest{
+		Name:            "Gravel",
+		Description:     "Gravel by auction",
+		ServiceType:     "Delivery",
+		OrganizationId:  ownerOrg,
+		CreatorUsername: owner,
+		Type:            domain.TenderTypeAuction,
+		Auction: &domain.Auction{
+			StartsAt:     now.Add(-time.Minute),
+			EndsAt:       now.Add(time.Hour),
+			StartPrice:   1000,
+			MinDecrement: 10,
+		},
+	}, http.StatusCreated, &tender)
+	tenders := "/api/tenders/" + tender.ID
+	c.json(t, http.MethodPut, tenders+"/status?status=PUBLISHED&"+as(owner), nil, http.StatusOK, nil)
+
+	var auction domain.Auction
+	c.json(t, http.MethodGet, tenders+"/auction?"+as(outsider), nil, http.StatusOK, &auction)
+	if !auction.Running {
+		t.Fatalf("auction = %+v, want it running", auction)
+	}
+
+	events := c.live(t, tenders+"/auction/live?"+as(outsider))
+	if event := nextEvent(t, events); event.Type != domain.AuctionEventState {
+		t.Errorf("first event = %+v, want the state", event)
+	}
+
+	// Pages of other sites can not open the feed in the name of a watcher.
+	live := "ws://" + c.server.Listener.Addr().String() + tenders + "/auction/live?" + as(outsider)
+	conn, resp, err := websocket.DefaultDialer.Dial(live, http.Header{"Origin": {"https://elsewhere.example"}})
+	if err == nil {
+		conn.Close()
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("live feed from another origin: response %v, error %v, want %d", resp, err, http.StatusForbidden)
+	}
+
+	var offer domain.AuctionOffer
+	c.json(t, http.MethodPost, tenders+"/auction/offers?"+as(outsider),
+		domain.PlaceOfferRequest{OrganizationID: bidderOrg, Price: 900}, http.StatusCreated, &offer)
+	if event := nextEvent(t, events); event.Type != domain.AuctionEventOffer || event.Offer == nil || event.Offer.Price != 900 {
+		t.Errorf("event = %+v, want the offer", event)
+	}
+
+	var offers []domain.AuctionOffer
+	c.json(t, http.MethodGet, tenders+"/auction/offers?"+as(owner), nil, http.StatusOK, &offers)
+	if len(offers) != 1 || offers[0].Price != 900 {
+		t.Errorf("offers = %+v, want the offer", offers)
+	}
+}
+
+func testInvitations(t *testing.T, c *client) {
+	var tender domain.TenderResponse
+	c.json(t, http.MethodPost, "/api/tender/new", domain.CreateTenderRequest{
+		Name:            "Secret repairs",
+		ServiceType:     "Manufacture",
+		OrganizationId:  ownerOrg,
+		CreatorUsername: owner,
+		Visibility:      domain.TenderVisibilityPrivate,
+	}, http.StatusCreated, &tender)
+	tenders := "/api/tenders/" + tender.ID
+	c.json(t, http.MethodPut, tenders+"/status?status=PUBLISHED&"+as(owner), nil, http.StatusOK, nil)
+
+	// seen checks everything the outsider can reach of the tender.
+	seen := func(want bool) {
+		t.Helper()
+
+		status := map[bool]int{false: http.StatusNotFound, true: http.StatusOK}[want]
+		c.send(t, http.MethodGet, tenders+"?"+as(outsider), "", nil, status)
+		c.send(t, http.MethodGet, tenders+"/questions?"+as(outsider), "", nil, status)
+
+		// Seeing a tender does not make it the outsider's to change.
+		changeStatus := map[bool]int{false: http.StatusNotFound, true: http.StatusForbidden}[want]
+		c.json(t, http.MethodPatch, tenders+"/edit?"+as(outsider), map[string]any{"visibility": "PUBLIC"}, changeStatus, nil)
+		c.send(t, http.MethodPut, tenders+"/rollback/1?"+as(outsider), "", nil, changeStatus)
+
+		var listed []domain.TenderResponse
+		c.json(t, http.MethodGet, "/api/tenders?limit=100&"+as(outsider), nil, http.StatusOK, &listed)
+		if got := slices.ContainsFunc(listed, func(l domain.TenderResponse) bool { return l.ID == tender.ID }); got != want {
+			t.Errorf("tender listed for the outsider: %v, want %v", got, want)
+		}
+
+		var response struct {
+			Data   map[string]json.RawMessage `json:"data"`
+			Errors []json.RawMessage          `json:"errors"`
+		}
+		request := map[string]string{"query": `{ tender(id: "` + tender.ID + `") { id } }`}
+		c.json(t, http.MethodPost, "/api/graphql?"+as(outsider), request, http.StatusOK, &response)
+		if got := len(response.Errors) == 0; got != want {
+			t.Errorf("graphql tender for the outsider = %+v, want visible %v", response, want)
+		}
+	}
+	bid := domain.CreateBidRequest{
+		Name:            "Invited repairs",
+		Description:     "Repairs",
+		TenderId:        tender.ID,
+		OrganizationId:  bidderOrg,
+		CreatorUsername: outsider,
+		Price:           price(500),
+	}
+
+	seen(false)
+	c.json(t, http.MethodPost, "/api/bids/new", bid, http.StatusNotFound, nil)
+
+	c.json(t, http.MethodPost, tenders+"/invitations?"+as(owner), domain.CreateInvitationRequest{OrganizationID: bidderOrg}, http.StatusCreated, nil)
+	var invitations []domain.Invitation
+	c.json(t, http.MethodGet, tenders+"/invitations?"+as(owner), nil, http.StatusOK, &invitations)
+	if len(invitations) != 1 || invitations[0].OrganizationID != bidderOrg {
+		t.Errorf("invitations = %+v, want the bidder", invitations)
+	}
+	seen(true)
+	c.json(t, http.MethodPost, "/api/bids/new", bid, http.StatusCreated, nil)
+
+	// Version 2 records the invitation, rolling back to it restores the
+	// invitation and to version 1 drops it again.
+	c.json(t, http.MethodPatch, tenders+"/edit?"+as(owner), map[string]any{"description": "Roof and walls"}, http.StatusOK, nil)
+	c.send(t, http.MethodDelete, tenders+"/invitations/"+bidderOrg+"?"+as(owner), "", nil, http.StatusNoContent)
+	c.send(t, http.MethodGet, tenders+"?"+as(outsider), "", nil, http.StatusNotFound)
+	c.json(t, http.MethodPut, tenders+"/rollback/2?"+as(owner), nil, http.StatusOK, nil)
+	c.send(t, http.MethodGet, tenders+"?"+as(outsider), "", nil, http.StatusOK)
+	c.json(t, http.MethodPut, tenders+"/rollback/1?"+as(owner), nil, http.StatusOK, nil)
+	c.send(t, http.MethodGet, tenders+"?"+as(outsider), "", nil, http.StatusNotFound)
+
+	var public domain.TenderResponse
+	c.json(t, http.MethodPatch, tenders+"/edit?"+as(owner), map[string]any{"visibility": "PUBLIC"}, http.StatusOK, &public)
+	c.send(t, http.MethodGet, tenders+"?"+as(outsider), "", nil, http.StatusOK)
+	c.json(t, http.MethodPut, tenders+"/rollback/"+strconv.Itoa(public.Version-1)+"?"+as(owner), nil, http.StatusOK, nil)
+	c.send(t, http.MethodGet, tenders+"?"+as(outsider), "", nil, http.StatusNotFound)
+}
+
+// testDrafts covers templates, copies, edits and the version history.
+func testDrafts(t *testing.T, c *client) {
+	var tender domain.TenderResponse
+	c.json(t, http.MethodPost, "/api/tender/new", domain.CreateTenderRequest{
+		Name:            "Paint",
+		Description:     "Paint for the office",
+		ServiceType:     "Delivery",
+		OrganizationId:  ownerOrg,
+		CreatorUsername: owner,
+		Budget:          price(50),
+	}, http.StatusCreated, &tender)
+
+	var template domain.TenderTemplate
+	templates := "/api/organizations/" + ownerOrg + "/templates"
+	c.json(t, http.MethodPost, templates+"?"+as(owner), domain.CreateTemplateRequest{Name: "Paint", TenderID: tender.ID}, http.StatusCreated, &template)
+	var listed []domain.TenderTemplate
+	c.json(t, http.MethodGet, templates+"?"+as(owner), nil, http.StatusOK, &listed)
+	if len(listed) != 1 || listed[0].ID != template.ID {
+		t.Errorf("templates = %+v, want the new one", listed)
+	}
+
+	var fromTemplate domain.TenderResponse
+	// The fields present in the request override the template, so only the
+	// ones it does not carry are sent.
+	c.json(t, http.MethodPost, "/api/tender/new", map[string]string{
+		"organizationId":  ownerOrg,
+		"creatorUsername": owner,
+		"templateId":      template.ID,
+	}, http.StatusCreated, &fromTemplate)
+	if fromTemplate.Name != "Paint" || fromTemplate.Budget == nil || *fromTemplate.Budget != 50 {
+		t.Errorf("tender from template = %+v", fromTemplate)
+	}
+	c.send(t, http.MethodDelete, templates+"/"+template.ID+"?"+as(owner), "", nil, http.StatusNoContent)
+
+	var clone domain.TenderResponse
+	c.json(t, http.MethodPost, "/api/tenders/"+tender.ID+"/clone?"+as(owner), nil, http.StatusCreated, &clone)
+	if clone.ID == tender.ID || clone.Version != 1 {
+		t.Errorf("clone = %+v", clone)
+	}
+
+	var edited domain.TenderResponse
+	c.json(t, http.MethodPatch, "/api/tenders/"+clone.ID+"/edit?"+as(owner), map[string]any{"description": "Paint for the warehouse"}, http.StatusOK, &edited)
+	if edited.Version != 2 || edited.Description != "Paint for the warehouse" {
+		t.Errorf("edited = %+v", edited)
+	}
+	var rolledBack domain.TenderResponse
+	c.json(t, http.MethodPut, "/api/tenders/"+clone.ID+"/rollback/1?"+as(owner), nil, http.StatusOK, &rolledBack)
+	if rolledBack.Version != 3 || rolledBack.Description != "Paint for the office" {
+		t.Errorf("rolled back = %+v", rolledBack)
+	}
+
+	var result domain.BatchStatusResult
+	c.json(t, http.MethodPost, "/api/tenders/status:batch?"+as(owner), domain.BatchStatusRequest{
+		TenderIDs: []string{tender.ID, fromTemplate.ID, clone.ID},
+		Status:    "CLOSED",
+	}, http.StatusOK, &result)
+	if result.Applied != 3 || result.Failed != 0 {
+		t.Errorf("batch = %+v, want every tender closed", result)
+	}
+}
+
+func testImport(t *testing.T, c *client) {
+	var rows strings.Builder
+	for _, name := range []string{"Cement", "Sand"} {
+		line, err := json.Marshal(domain.CreateTenderRequest{Name: name, ServiceType: "Delivery", OrganizationId: ownerOrg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows.Write(line)
+		rows.WriteByte('\n')
+	}
+
+	var report domain.ImportReport
+	for _, dryRun := range []bool{true, false} {
+		target := fmt.Sprintf("/api/tenders/import?format=ndjson&dryRun=%t&%s", dryRun, as(owner))
+		_, body := c.send(t, http.MethodPost, target, "application/x-ndjson", strings.NewReader(rows.String()), http.StatusOK)
+		if err := json.Unmarshal(body, &report); err != nil {
+			t.Fatal(err)
+		}
+		if report.Rows != 2 || report.Valid != 2 || len(report.Errors) != 0 {
+			t.Errorf("report = %+v, want two valid rows", report)
+		}
+	}
+	if report.Imported != 2 {
+		t.Errorf("imported %d, want 2", report.Imported)
+	}
+
+	var mine []domain.TenderResponse
+	c.json(t, http.MethodGet, "/api/tenders/my?limit=50&"+as(owner), nil, http.StatusOK, &mine)
+	imported := 0
+	for _, tender := range mine {
+		if tender.Name == "Cement" || tender.Name == "Sand" {
+			imported++
+		}
+	}
+	if imported != 2 {
+		t.Errorf("found %d imported tenders, want 2", imported)
+	}
+}
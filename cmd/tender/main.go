@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,10 +13,8 @@ import (
 	"time"
 
 	"github.com/Te8va/Tender/internal/tender/config"
-	"github.com/Te8va/Tender/internal/tender/handler"
-	"github.com/Te8va/Tender/internal/tender/middleware"
 	"github.com/Te8va/Tender/internal/tender/repository"
-	"github.com/Te8va/Tender/internal/tender/service"
+	"github.com/Te8va/Tender/internal/tender/rpc"
 	"github.com/Te8va/Tender/pkg/logger"
 	"github.com/caarlos0/env/v6"
 	"github.com/golang-migrate/migrate/v4"
@@ -47,36 +46,20 @@ func main() {
 
 	logger.Logger().Infoln("Postgres connection pool created")
 
-	pg := repository.NewPostgres(pool)
-
 	var wg sync.WaitGroup
 
-	pingRep := repository.NewPingProvider(pg)
-	pingService := service.NewPingProvider(pingRep)
-	pingHandler := handler.NewPingProvider(pingService)
-
-	tenderRep := repository.NewTenderService(pool)
-	tenderService := service.NewTender(tenderRep)
-	tenderHandler := handler.NewTenderHandler(tenderService)
-
-	_, cancelDeleteCtx := context.WithCancel(context.Background())
-
-	mux := http.NewServeMux()
-
-	mux.Handle("/api/ping", middleware.Log(http.HandlerFunc(pingHandler.PingHandler)))
+	backgroundCtx, cancelBackgroundCtx := context.WithCancel(context.Background())
+	reminderCtx, stopReminder := context.WithCancel(backgroundCtx)
 
-	mux.Handle("GET /api/tenders", middleware.Log(http.HandlerFunc(tenderHandler.ListTenderHandler)))
-	mux.Handle("POST /api/tender/new", middleware.Log(http.HandlerFunc(tenderHandler.CreateTenderHandler)))
-	mux.Handle("GET /api/tenders/my", middleware.Log(http.HandlerFunc(tenderHandler.GetUserTendersHandler)))
-	mux.Handle("PATCH /api/tenders/{tenderId}/edit", middleware.Log(http.HandlerFunc(tenderHandler.UpdatePartTenderHandler)))
-	mux.Handle("GET /api/tenders/{tenderId}/status", middleware.Log(http.HandlerFunc(tenderHandler.GetTenderStatusHandler)))
-	mux.Handle("PUT /api/tenders/{tenderId}/status", middleware.Log(http.HandlerFunc(tenderHandler.UpdateTenderStatusHandler)))
-	mux.Handle("PUT /api/tenders/{tenderId}/rollback/{version}", middleware.Log(http.HandlerFunc(tenderHandler.RollbackTenderHandler)))
+	app, err := newApp(backgroundCtx, reminderCtx, &wg, cfg, pool)
+	if err != nil {
+		logger.Logger().Fatalln(zap.Error(err))
+	}
 
 	server := &http.Server{
 		Addr:     fmt.Sprintf("%s:%d", cfg.ServiceHost, cfg.ServicePort),
 		ErrorLog: log.New(logger.Logger(), "", 0),
-		Handler:  mux,
+		Handler:  app.handler,
 	}
 
 	go func() {
@@ -86,6 +69,19 @@ func main() {
 		}
 	}()
 
+	grpcServer := rpc.NewServer(app.tenders, app.bids)
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", cfg.ServiceHost, cfg.GRPCPort))
+	if err != nil {
+		logger.Logger().Fatalln(zap.Error(err))
+	}
+
+	go func() {
+		logger.Logger().Infoln("gRPC server started, listening on port", cfg.GRPCPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.Logger().Fatalln("gRPC Serve failed", zap.Error(err))
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -100,6 +96,10 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		logger.Logger().Fatalln("Server was forced to shutdown:", zap.Error(err))
 	}
+	grpcServer.GracefulStop()
+
+	stopReminder()
+	app.dispatcher.Close()
 
 	waitGroupChan := make(chan struct{})
 	go func() {
@@ -109,10 +109,10 @@ func main() {
 
 	select {
 	case <-waitGroupChan:
-		logger.Logger().Infoln("All delete goroutines successfully finished")
+		logger.Logger().Infoln("All background goroutines successfully finished")
 	case <-time.After(time.Second * 3):
-		cancelDeleteCtx()
-		logger.Logger().Infoln("Some of delete goroutines have not completed their job due to shutdown timeout")
+		cancelBackgroundCtx()
+		logger.Logger().Infoln("Some of background goroutines have not completed their job due to shutdown timeout")
 	}
 
 	logger.Logger().Infoln("Server was shut down")
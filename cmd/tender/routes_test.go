@@ -0,0 +1,75 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/openapi"
+)
+
+// registeredRoutes reads the patterns newApp passes to mux.Handle.
+func registeredRoutes(t *testing.T) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "app.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var patterns []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || selector.Sel.Name != "Handle" {
+			return true
+		}
+		literal, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return true
+		}
+
+		pattern, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// A pattern without a method serves every method, the
+		// specification documents it as GET.
+		if !strings.Contains(pattern, " ") {
+			pattern = http.MethodGet + " " + pattern
+		}
+		patterns = append(patterns, pattern)
+		return true
+	})
+
+	return patterns
+}
+
+func TestRoutesSpecified(t *testing.T) {
+	registered := registeredRoutes(t)
+	if len(registered) == 0 {
+		t.Fatal("no routes found in app.go")
+	}
+
+	specified := map[string]bool{}
+	for _, route := range openapi.Routes() {
+		specified[route] = true
+	}
+
+	for _, route := range registered {
+		if !specified[route] {
+			t.Errorf("%s is not in the OpenAPI specification", route)
+		}
+		delete(specified, route)
+	}
+	for route := range specified {
+		t.Errorf("%s is specified but not registered", route)
+	}
+}
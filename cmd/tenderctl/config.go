@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultURL     = "http://localhost:8080"
+	defaultProfile = "default"
+)
+
+// profile is a named set of connection settings of the profile file.
+type profile struct {
+	URL      string `yaml:"url,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Output   string `yaml:"output,omitempty"`
+}
+
+// config is the profile file, by default ~/.config/tenderctl/config.yaml:
+//
+//	current: prod
+//	profiles:
+//	  prod:
+//	    url: https://tender.example.com
+//	    username: ops
+//	    output: table
+type config struct {
+	Current  string             `yaml:"current,omitempty"`
+	Profiles map[string]profile `yaml:"profiles,omitempty"`
+}
+
+func configPath() (string, error) {
+	if path := os.Getenv("TENDERCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating profile file: %w", err)
+	}
+
+	return filepath.Join(dir, "tenderctl", "config.yaml"), nil
+}
+
+// loadConfig reads the profile file, a missing one is empty.
+func loadConfig(path string) (config, error) {
+	cfg := config{Profiles: map[string]profile{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return config{}, fmt.Errorf("reading profile file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return config{}, fmt.Errorf("parsing profile file %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]profile{}
+	}
+
+	return cfg, nil
+}
+
+func (c config) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("writing profile file: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing profile file: %w", err)
+	}
+
+	return nil
+}
+
+// profile returns the named profile, the current one when name is empty.
+// Only an explicitly named profile has to exist.
+func (c config) profile(name string) (profile, error) {
+	if name == "" {
+		name = c.Current
+		if name == "" {
+			name = defaultProfile
+		}
+		return c.Profiles[name], nil
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("profile %q is not in the profile file", name)
+	}
+
+	return p, nil
+}
+
+func (c config) names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// settings are the connection settings of a run: the flags override the
+// environment, which overrides the profile.
+type settings struct {
+	configPath string
+	profile    string
+	url        string
+	username   string
+	output     string
+}
+
+func (s *settings) resolve() (config, error) {
+	if s.configPath == "" {
+		path, err := configPath()
+		if err != nil {
+			return config{}, err
+		}
+		s.configPath = path
+	}
+
+	cfg, err := loadConfig(s.configPath)
+	if err != nil {
+		return config{}, err
+	}
+
+	p, err := cfg.profile(s.profile)
+	if err != nil {
+		return config{}, err
+	}
+
+	s.url = firstSet(s.url, os.Getenv("TENDERCTL_URL"), p.URL, defaultURL)
+	s.username = firstSet(s.username, os.Getenv("TENDERCTL_USERNAME"), p.Username)
+	s.output = firstSet(s.output, os.Getenv("TENDERCTL_OUTPUT"), p.Output, outputTable)
+
+	return cfg, nil
+}
+
+func firstSet(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
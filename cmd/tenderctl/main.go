@@ -0,0 +1,235 @@
+// Command tenderctl operates the tender service through its HTTP API.
+//
+//	tenderctl [-profile name] [-url url] [-username name] [-o table|json|yaml] <command> [args]
+//
+// Connection settings come from the flags, the TENDERCTL_URL,
+// TENDERCTL_USERNAME and TENDERCTL_OUTPUT variables and the profile file,
+// in that order. Run tenderctl help for the commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/Te8va/Tender/pkg/client"
+)
+
+const usage = `Usage: tenderctl [flags] <command> [args]
+
+Tenders:
+  tenders list [-limit n] [-offset n] [-all] [filters]   list visible tenders
+  tenders search <text> [-limit n] [-offset n] [-all] [filters]
+                                                          tenders containing the text in the name or description
+  tenders my [-limit n] [-offset n] [-all]                tenders created by the employee
+  tenders get <id>                                        show a tender
+  tenders versions <id>                                   show the version history
+  tenders diff <id> <version> [<version>]                 compare two versions, the latest by default
+  tenders status <id> [<status>]                          show or change the status
+  tenders rollback <id> <version>                         restore an earlier version
+  tenders import <file> [-format csv|ndjson] [-dry-run]   create tenders from a file
+  tenders export [-out file] [-format csv|xlsx] [filters] download tenders as a file
+
+  filters: -service-type code, -tag tag, -cf key=value (each may be repeated)
+
+Organizations and employees:
+  orgs mine                                               organizations the employee is responsible for
+  orgs list [-limit n] [-offset n] [-all]                 list all organizations
+  orgs create <name> [-type IE|LLC|JSC] [-description text]
+                                                          create an organization, an LLC by default
+  orgs update <id> [-name name] [-type type] [-description text]
+                                                          change an organization
+  orgs delete <id>                                        delete an organization without tenders or bids
+  orgs add-responsible <id> <username>                    let an employee act for an organization
+  orgs remove-responsible <id> <username>                 withdraw that again
+  employees me                                            the employee of the profile
+  employees list [-limit n] [-offset n] [-all]            list all employees
+  employees create <username> [-first-name name] [-last-name name]
+                                                          create an employee
+  employees update <username> [-first-name name] [-last-name name]
+                                                          change the name of an employee
+  employees delete <username>                             delete an employee who authored nothing
+
+  Only administrators may list, create, change or delete organizations and
+  employees.
+
+Profiles:
+  profiles list                                           list the profiles of the profile file
+  profiles use <name>                                     make a profile the current one
+  profiles set <name> [-url url] [-username name] [-o format]
+
+Flags, accepted before the command and among its arguments:
+  -config path      profile file (TENDERCTL_CONFIG, ~/.config/tenderctl/config.yaml)
+  -profile name     profile to use instead of the current one
+  -url url          service URL
+  -username name    employee the requests are made on behalf of
+  -o format         output format: table, json or yaml
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "tenderctl:", err)
+		os.Exit(1)
+	}
+}
+
+// command runs a command once its flags are parsed.
+type command struct {
+	run   func(ctx context.Context, env *env, args []string) error
+	flags func(fs *flag.FlagSet)
+	// minArgs and maxArgs bound the number of positional arguments.
+	minArgs, maxArgs int
+	// local commands do not talk to the service.
+	local bool
+}
+
+// env is what commands run with.
+type env struct {
+	settings settings
+	config   config
+	client   *client.Client
+	out      io.Writer
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	e := &env{out: out}
+
+	// The global flags may come before the command, any flag may come
+	// among its arguments.
+	fs := newFlagSet("tenderctl", &e.settings, nil)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
+	if len(args) == 0 || args[0] == "help" {
+		fmt.Fprint(out, usage)
+		return nil
+	}
+
+	group, ok := newCommands()[args[0]]
+	if !ok || len(args) < 2 {
+		return fmt.Errorf("unknown command %q, run tenderctl help", strings.Join(args, " "))
+	}
+	cmd, ok := group[args[1]]
+	if !ok {
+		return fmt.Errorf("unknown command %q, run tenderctl help", args[0]+" "+args[1])
+	}
+
+	fs = newFlagSet("tenderctl "+args[0]+" "+args[1], &e.settings, cmd.flags)
+	positional, err := parseInterspersed(fs, args[2:])
+	if err != nil {
+		return err
+	}
+	if len(positional) < cmd.minArgs || len(positional) > cmd.maxArgs {
+		return fmt.Errorf("%s: wrong number of arguments, run tenderctl help", fs.Name())
+	}
+
+	if e.config, err = e.settings.resolve(); err != nil {
+		return err
+	}
+
+	if !cmd.local {
+		e.client, err = client.New(e.settings.url, client.WithUsername(e.settings.username))
+		if err != nil {
+			return err
+		}
+	}
+
+	return cmd.run(ctx, e, positional)
+}
+
+// newCommands returns the commands by group, each with its own flag values.
+func newCommands() map[string]map[string]command {
+	return map[string]map[string]command{
+		"tenders":   tenderCommands(),
+		"orgs":      organizationCommands(),
+		"employees": employeeCommands(),
+		"profiles":  profileCommands(),
+	}
+}
+
+// newFlagSet returns a flag set with the flags of a command and the global
+// flags it does not shadow, which keep the values parsed so far.
+func newFlagSet(name string, s *settings, flags func(fs *flag.FlagSet)) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if flags != nil {
+		flags(fs)
+	}
+
+	globals := []struct {
+		value       *string
+		name, usage string
+	}{
+		{&s.configPath, "config", "profile file"},
+		{&s.profile, "profile", "profile"},
+		{&s.url, "url", "service URL"},
+		{&s.username, "username", "employee"},
+		{&s.output, "o", "output format"},
+	}
+	for _, global := range globals {
+		if fs.Lookup(global.name) == nil {
+			fs.StringVar(global.value, global.name, *global.value, global.usage)
+		}
+	}
+
+	return fs
+}
+
+// parseInterspersed parses flags placed anywhere among the positional
+// arguments, which it returns.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s: %w", fs.Name(), err)
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// listFlag collects the values of a repeated flag.
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// mapFlag collects key=value pairs of a repeated flag.
+type mapFlag map[string]string
+
+func (m mapFlag) String() string { return "" }
+
+func (m mapFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("%q is not key=value", value)
+	}
+	m[key] = val
+
+	return nil
+}
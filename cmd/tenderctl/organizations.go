@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/Te8va/Tender/pkg/client"
+)
+
+var organizationColumns = []column[client.Organization]{
+	{"ID", func(o client.Organization) string { return o.ID }},
+	{"NAME", func(o client.Organization) string { return o.Name }},
+	{"TYPE", func(o client.Organization) string { return o.Type }},
+	{"DESCRIPTION", func(o client.Organization) string { return o.Description }},
+}
+
+var employeeColumns = []column[client.Employee]{
+	{"ID", func(e client.Employee) string { return e.ID }},
+	{"USERNAME", func(e client.Employee) string { return e.Username }},
+	{"FIRST NAME", func(e client.Employee) string { return e.FirstName }},
+	{"LAST NAME", func(e client.Employee) string { return e.LastName }},
+	{"ORGANIZATIONS", func(e client.Employee) string { return formatValue(organizationNames(e.Organizations)) }},
+}
+
+// Listing and changing the directory is up to administrators, orgs mine and
+// employees me work for every employee.
+func organizationCommands() map[string]command {
+	return map[string]command{
+		"list":               listOrganizationsCommand(),
+		"mine":               {run: listMyOrganizations},
+		"create":             createOrganizationCommand(),
+		"update":             updateOrganizationCommand(),
+		"delete":             {run: deleteOrganization, minArgs: 1, maxArgs: 1},
+		"add-responsible":    {run: addResponsible, minArgs: 2, maxArgs: 2},
+		"remove-responsible": {run: removeResponsible, minArgs: 2, maxArgs: 2},
+	}
+}
+
+func employeeCommands() map[string]command {
+	return map[string]command{
+		"me":     {run: showMe},
+		"list":   listEmployeesCommand(),
+		"create": createEmployeeCommand(),
+		"update": updateEmployeeCommand(),
+		"delete": {run: deleteEmployee, minArgs: 1, maxArgs: 1},
+	}
+}
+
+func listOrganizationsCommand() command {
+	var page pageFlags
+
+	return command{
+		flags: page.register,
+		run: func(ctx context.Context, e *env, _ []string) error {
+			var (
+				organizations []client.Organization
+				err           error
+			)
+			if page.all {
+				organizations, err = e.client.IterateOrganizations(page.limit).All(ctx)
+			} else {
+				organizations, err = e.client.ListOrganizations(ctx, page.limit, page.offset)
+			}
+			if err != nil {
+				return err
+			}
+
+			return printList(e.out, e.settings.output, organizations, organizationColumns)
+		},
+	}
+}
+
+func listMyOrganizations(ctx context.Context, e *env, _ []string) error {
+	me, err := e.client.Me(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printList(e.out, e.settings.output, me.Organizations, organizationColumns)
+}
+
+// createOrganizationCommand creates the organization named by the argument.
+func createOrganizationCommand() command {
+	req := client.CreateOrganizationRequest{}
+
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&req.Type, "type", client.OrganizationLLC, "IE, LLC or JSC")
+			fs.StringVar(&req.Description, "description", "", "description")
+		},
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(ctx context.Context, e *env, args []string) error {
+			req.Name = args[0]
+			organization, err := e.client.CreateOrganization(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			return printOne(e.out, e.settings.output, organization, organizationColumns)
+		},
+	}
+}
+
+// updateOrganizationCommand changes the fields given by its flags.
+func updateOrganizationCommand() command {
+	update := client.OrganizationUpdate{}
+
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.Func("name", "new name", func(v string) error { update.Name = &v; return nil })
+			fs.Func("type", "IE, LLC or JSC", func(v string) error { update.Type = &v; return nil })
+			fs.Func("description", "description", func(v string) error { update.Description = &v; return nil })
+		},
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(ctx context.Context, e *env, args []string) error {
+			organization, err := e.client.UpdateOrganization(ctx, args[0], update)
+			if err != nil {
+				return err
+			}
+
+			return printOne(e.out, e.settings.output, organization, organizationColumns)
+		},
+	}
+}
+
+func deleteOrganization(ctx context.Context, e *env, args []string) error {
+	return e.client.DeleteOrganization(ctx, args[0])
+}
+
+func addResponsible(ctx context.Context, e *env, args []string) error {
+	return e.client.AddResponsible(ctx, args[0], args[1])
+}
+
+func removeResponsible(ctx context.Context, e *env, args []string) error {
+	return e.client.RemoveResponsible(ctx, args[0], args[1])
+}
+
+func showMe(ctx context.Context, e *env, _ []string) error {
+	me, err := e.client.Me(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printOne(e.out, e.settings.output, me, employeeColumns)
+}
+
+func listEmployeesCommand() command {
+	var page pageFlags
+
+	return command{
+		flags: page.register,
+		run: func(ctx context.Context, e *env, _ []string) error {
+			var (
+				employees []client.Employee
+				err       error
+			)
+			if page.all {
+				employees, err = e.client.IterateEmployees(page.limit).All(ctx)
+			} else {
+				employees, err = e.client.ListEmployees(ctx, page.limit, page.offset)
+			}
+			if err != nil {
+				return err
+			}
+
+			return printList(e.out, e.settings.output, employees, employeeColumns)
+		},
+	}
+}
+
+// createEmployeeCommand creates the employee with the username given as the
+// argument.
+func createEmployeeCommand() command {
+	req := client.CreateEmployeeRequest{}
+
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&req.FirstName, "first-name", "", "first name")
+			fs.StringVar(&req.LastName, "last-name", "", "last name")
+		},
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(ctx context.Context, e *env, args []string) error {
+			req.Username = args[0]
+			employee, err := e.client.CreateEmployee(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			return printOne(e.out, e.settings.output, employee, employeeColumns)
+		},
+	}
+}
+
+func updateEmployeeCommand() command {
+	update := client.EmployeeUpdate{}
+
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.Func("first-name", "first name", func(v string) error { update.FirstName = &v; return nil })
+			fs.Func("last-name", "last name", func(v string) error { update.LastName = &v; return nil })
+		},
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(ctx context.Context, e *env, args []string) error {
+			employee, err := e.client.UpdateEmployee(ctx, args[0], update)
+			if err != nil {
+				return err
+			}
+
+			return printOne(e.out, e.settings.output, employee, employeeColumns)
+		},
+	}
+}
+
+func deleteEmployee(ctx context.Context, e *env, args []string) error {
+	return e.client.DeleteEmployee(ctx, args[0])
+}
+
+func organizationNames(organizations []client.Organization) []string {
+	names := make([]string, len(organizations))
+	for i, organization := range organizations {
+		names[i] = organization.Name
+	}
+
+	return names
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// column is a column of the table output.
+type column[T any] struct {
+	header string
+	value  func(T) string
+}
+
+// printList writes the items as a table or as a JSON or YAML document with
+// the field names of the API.
+func printList[T any](w io.Writer, format string, items []T, columns []column[T]) error {
+	if items == nil {
+		items = []T{}
+	}
+
+	switch format {
+	case outputJSON, outputYAML:
+		return printDocument(w, format, items)
+	case outputTable:
+	default:
+		return fmt.Errorf("unknown output format %q, want table, json or yaml", format)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.header
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, item := range items {
+		values := make([]string, len(columns))
+		for i, c := range columns {
+			values[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(c.value(item))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func printOne[T any](w io.Writer, format string, item T, columns []column[T]) error {
+	if format == outputTable {
+		return printList(w, format, []T{item}, columns)
+	}
+
+	return printDocument(w, format, item)
+}
+
+func printDocument(w io.Writer, format string, v any) error {
+	if format == outputJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	// The JSON form keeps the field names of the API in YAML too.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
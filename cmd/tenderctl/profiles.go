@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+// profileRow is a profile as listed.
+type profileRow struct {
+	Name     string `json:"name"`
+	Current  bool   `json:"current"`
+	URL      string `json:"url,omitempty"`
+	Username string `json:"username,omitempty"`
+	Output   string `json:"output,omitempty"`
+}
+
+var profileColumns = []column[profileRow]{
+	{"CURRENT", func(p profileRow) string {
+		if p.Current {
+			return "*"
+		}
+		return ""
+	}},
+	{"NAME", func(p profileRow) string { return p.Name }},
+	{"URL", func(p profileRow) string { return p.URL }},
+	{"USERNAME", func(p profileRow) string { return p.Username }},
+	{"OUTPUT", func(p profileRow) string { return p.Output }},
+}
+
+func profileCommands() map[string]command {
+	return map[string]command{
+		"list": {run: listProfiles, local: true},
+		"use":  {run: useProfile, minArgs: 1, maxArgs: 1, local: true},
+		"set":  setProfileCommand(),
+	}
+}
+
+func listProfiles(_ context.Context, e *env, _ []string) error {
+	current := e.config.Current
+	if current == "" {
+		current = defaultProfile
+	}
+
+	rows := []profileRow{}
+	for _, name := range e.config.names() {
+		p := e.config.Profiles[name]
+		rows = append(rows, profileRow{Name: name, Current: name == current, URL: p.URL, Username: p.Username, Output: p.Output})
+	}
+
+	return printList(e.out, e.settings.output, rows, profileColumns)
+}
+
+func useProfile(_ context.Context, e *env, args []string) error {
+	if _, ok := e.config.Profiles[args[0]]; !ok {
+		return fmt.Errorf("profile %q is not in the profile file", args[0])
+	}
+
+	e.config.Current = args[0]
+
+	return e.config.save(e.settings.configPath)
+}
+
+// setProfileCommand creates or changes a profile. It takes the values from
+// its own flags, which shadow the global ones.
+func setProfileCommand() command {
+	var (
+		values profile
+		set    = map[string]bool{}
+	)
+
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.Func("url", "service URL", func(v string) error { values.URL, set["url"] = v, true; return nil })
+			fs.Func("username", "employee", func(v string) error { values.Username, set["username"] = v, true; return nil })
+			fs.Func("o", "output format", func(v string) error { values.Output, set["o"] = v, true; return nil })
+		},
+		minArgs: 1,
+		maxArgs: 1,
+		local:   true,
+		run: func(_ context.Context, e *env, args []string) error {
+			switch values.Output {
+			case "", outputTable, outputJSON, outputYAML:
+			default:
+				return fmt.Errorf("unknown output format %q, want table, json or yaml", values.Output)
+			}
+
+			p := e.config.Profiles[args[0]]
+			if set["url"] {
+				p.URL = values.URL
+			}
+			if set["username"] {
+				p.Username = values.Username
+			}
+			if set["o"] {
+				p.Output = values.Output
+			}
+			e.config.Profiles[args[0]] = p
+
+			if e.config.Current == "" {
+				e.config.Current = args[0]
+			}
+
+			return e.config.save(e.settings.configPath)
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Te8va/Tender/pkg/client"
+)
+
+func TestRun(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": "t1", "name": "Поставка серверов", "status": "PUBLISHED", "serviceType": "Delivery", "version": 2, "tags": ["it"]}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("TENDERCTL_URL", "")
+	t.Setenv("TENDERCTL_USERNAME", "")
+	t.Setenv("TENDERCTL_OUTPUT", "")
+	t.Setenv("TENDERCTL_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+
+	tenderctl := func(args ...string) string {
+		t.Helper()
+
+		var out bytes.Buffer
+		if err := run(context.Background(), args, &out); err != nil {
+			t.Fatalf("tenderctl %s: %v", strings.Join(args, " "), err)
+		}
+
+		return out.String()
+	}
+
+	tenderctl("profiles", "set", "prod", "-url", server.URL, "-username", "ops")
+	tenderctl("profiles", "set", "local", "-url", "http://localhost:8080", "-o", "json")
+	if out := tenderctl("profiles", "list"); !regexp.MustCompile(`(?m)^\*\s+prod\s`).MatchString(out) || !strings.Contains(out, "local") {
+		t.Errorf("profiles list =\n%s", out)
+	}
+
+	out := tenderctl("tenders", "list", "-tag", "it", "-cf", "region=77", "-limit", "5")
+	if !strings.Contains(query, "username=ops") || !strings.Contains(query, "tag=it") || !strings.Contains(query, "cf.region=77") || !strings.Contains(query, "limit=5") {
+		t.Errorf("query = %s", query)
+	}
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "Поставка серверов") {
+		t.Errorf("table output =\n%s", out)
+	}
+
+	tenderctl("tenders", "search", "серверов", "-service-type", "Delivery", "-cf", "region=77", "-all")
+	if !strings.Contains(query, "q=%D1%81%D0%B5%D1%80%D0%B2%D0%B5%D1%80%D0%BE%D0%B2") || !strings.Contains(query, "service_type=Delivery") || !strings.Contains(query, "cf.region=77") {
+		t.Errorf("search query = %s, want the text and the filters passed to the service", query)
+	}
+
+	if out := tenderctl("-o", "yaml", "tenders", "list"); !strings.Contains(out, "serviceType: Delivery") {
+		t.Errorf("yaml output =\n%s", out)
+	}
+
+	tenderctl("profiles", "use", "local")
+	if out := tenderctl("tenders", "list", "-url", server.URL); !strings.Contains(out, `"name": "Поставка серверов"`) {
+		t.Errorf("json output of the local profile =\n%s", out)
+	}
+
+	var discard bytes.Buffer
+	if err := run(context.Background(), []string{"tenders", "list", "-profile", "staging"}, &discard); err == nil {
+		t.Error("unknown profile accepted")
+	}
+}
+
+func TestDirectoryCommands(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+
+		switch {
+		case r.Method == http.MethodDelete || r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/api/employees/ghost":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"reason": "employee \"ghost\" does not exist"}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "o1", "name": "Contract LLC", "type": "LLC", "username": "carol"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("TENDERCTL_URL", server.URL)
+	t.Setenv("TENDERCTL_USERNAME", "root")
+	t.Setenv("TENDERCTL_OUTPUT", "")
+	t.Setenv("TENDERCTL_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+
+	commands := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"orgs", "create", "Contract LLC", "-type", "JSC"}, `POST /api/organizations {"name":"Contract LLC","type":"JSC"}`},
+		{[]string{"orgs", "update", "o1", "-description", ""}, `PATCH /api/organizations/o1 {"description":""}`},
+		{[]string{"orgs", "add-responsible", "o1", "carol"}, `PUT /api/organizations/o1/responsibles/carol`},
+		{[]string{"orgs", "remove-responsible", "o1", "carol"}, `DELETE /api/organizations/o1/responsibles/carol`},
+		{[]string{"orgs", "delete", "o1"}, `DELETE /api/organizations/o1`},
+		{[]string{"employees", "create", "carol", "-first-name", "Carol"}, `POST /api/employees {"username":"carol","firstName":"Carol"}`},
+		{[]string{"employees", "update", "carol", "-last-name", "Smith"}, `PATCH /api/employees/carol {"lastName":"Smith"}`},
+		{[]string{"employees", "delete", "carol"}, `DELETE /api/employees/carol`},
+	}
+
+	for _, c := range commands {
+		requests = nil
+		var out bytes.Buffer
+		if err := run(context.Background(), c.args, &out); err != nil {
+			t.Errorf("tenderctl %s: %v", strings.Join(c.args, " "), err)
+			continue
+		}
+		if len(requests) != 1 || requests[0] != c.want {
+			t.Errorf("tenderctl %s sent %q, want %q", strings.Join(c.args, " "), requests, c.want)
+		}
+	}
+
+	var discard bytes.Buffer
+	if err := run(context.Background(), []string{"employees", "update", "ghost", "-first-name", "Casper"}, &discard); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("updating a missing employee = %v, want %v", err, client.ErrNotFound)
+	}
+}
+
+func TestDiffVersions(t *testing.T) {
+	budget, raised := 100.0, 150.0
+	versions := []client.TenderVersion{
+		{Version: 1, Name: "Уборка", Status: "CREATED", Budget: &budget},
+		{Version: 2, Name: "Уборка офиса", Status: "CREATED", Budget: &budget, Tags: []string{}},
+		{Version: 3, Name: "Уборка офиса", Status: "PUBLISHED", Budget: &raised, Tags: []string{"office"}},
+	}
+
+	changes, err := diffVersions(versions, 1, 2)
+	if err != nil || len(changes) != 1 || changes[0].Field != "name" {
+		t.Errorf("diff 1..2 = %+v, %v", changes, err)
+	}
+
+	changes, err = diffVersions(versions, 2, 3)
+	if err != nil || len(changes) != 3 || changes[0].Field != "status" || changes[1].Field != "budget" || changes[2].Field != "tags" {
+		t.Errorf("diff 2..3 = %+v, %v", changes, err)
+	}
+
+	if _, err := diffVersions(versions, 1, 4); err == nil {
+		t.Error("diff with a missing version succeeded")
+	}
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Te8va/Tender/pkg/client"
+)
+
+var tenderColumns = []column[client.Tender]{
+	{"ID", func(t client.Tender) string { return t.ID }},
+	{"NAME", func(t client.Tender) string { return t.Name }},
+	{"STATUS", func(t client.Tender) string { return t.Status }},
+	{"SERVICE TYPE", func(t client.Tender) string { return t.ServiceType }},
+	{"VERSION", func(t client.Tender) string { return strconv.Itoa(t.Version) }},
+	{"VISIBILITY", func(t client.Tender) string { return t.Visibility }},
+	{"BUDGET", func(t client.Tender) string { return formatBudget(t.Budget) }},
+	{"TAGS", func(t client.Tender) string { return strings.Join(t.Tags, ",") }},
+	{"CREATED", func(t client.Tender) string { return formatTime(t.CreatedAt) }},
+}
+
+var versionColumns = []column[client.TenderVersion]{
+	{"VERSION", func(v client.TenderVersion) string { return strconv.Itoa(v.Version) }},
+	{"NAME", func(v client.TenderVersion) string { return v.Name }},
+	{"STATUS", func(v client.TenderVersion) string { return v.Status }},
+	{"SERVICE TYPE", func(v client.TenderVersion) string { return v.ServiceType }},
+	{"BUDGET", func(v client.TenderVersion) string { return formatBudget(v.Budget) }},
+	{"TAGS", func(v client.TenderVersion) string { return strings.Join(v.Tags, ",") }},
+	{"CREATED", func(v client.TenderVersion) string { return formatTime(v.CreatedAt) }},
+}
+
+func tenderCommands() map[string]command {
+	return map[string]command{
+		"list":     listTendersCommand(),
+		"search":   searchTendersCommand(),
+		"my":       myTendersCommand(),
+		"get":      {run: getTender, minArgs: 1, maxArgs: 1},
+		"versions": {run: tenderVersions, minArgs: 1, maxArgs: 1},
+		"diff":     {run: diffTenderVersions, minArgs: 2, maxArgs: 3},
+		"status":   {run: tenderStatus, minArgs: 1, maxArgs: 2},
+		"rollback": {run: rollbackTender, minArgs: 2, maxArgs: 2},
+		"import":   importTendersCommand(),
+		"export":   exportTendersCommand(),
+	}
+}
+
+// tenderFilters are the filter flags of the tender list.
+type tenderFilters struct {
+	serviceTypes listFlag
+	tags         listFlag
+	customFields mapFlag
+}
+
+func (f *tenderFilters) register(fs *flag.FlagSet) {
+	f.customFields = mapFlag{}
+	fs.Var(&f.serviceTypes, "service-type", "service type, with its subcategories")
+	fs.Var(&f.tags, "tag", "tag")
+	fs.Var(f.customFields, "cf", "custom field value as key=value")
+}
+
+func (f *tenderFilters) options() client.ListTendersOptions {
+	return client.ListTendersOptions{ServiceTypes: f.serviceTypes, Tags: f.tags, CustomFields: f.customFields}
+}
+
+// pageFlags select a page of a listing, or all of it.
+type pageFlags struct {
+	limit, offset int
+	all           bool
+}
+
+func (p *pageFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&p.limit, "limit", client.DefaultPageSize, "page size")
+	fs.IntVar(&p.offset, "offset", 0, "items to skip")
+	fs.BoolVar(&p.all, "all", false, "list all pages")
+}
+
+// listTendersCommand lists the tenders passing the filters. Its search
+// variant takes the text to look for as the argument.
+func listTendersCommand() command {
+	var (
+		page    pageFlags
+		filters tenderFilters
+	)
+
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			page.register(fs)
+			filters.register(fs)
+		},
+		run: func(ctx context.Context, e *env, args []string) error {
+			opts := filters.options()
+			opts.Limit, opts.Offset = page.limit, page.offset
+			if len(args) > 0 {
+				opts.Text = args[0]
+			}
+
+			var (
+				tenders []client.Tender
+				err     error
+			)
+			if page.all {
+				tenders, err = e.client.IterateTenders(opts).All(ctx)
+			} else {
+				tenders, err = e.client.ListTenders(ctx, opts)
+			}
+			if err != nil {
+				return err
+			}
+
+			return printList(e.out, e.settings.output, tenders, tenderColumns)
+		},
+	}
+}
+
+// searchTendersCommand lists the tenders containing the text in their name
+// or description, the service filters and pages them.
+func searchTendersCommand() command {
+	search := listTendersCommand()
+	search.minArgs, search.maxArgs = 1, 1
+
+	return search
+}
+
+func myTendersCommand() command {
+	var page pageFlags
+
+	return command{
+		flags: page.register,
+		run: func(ctx context.Context, e *env, _ []string) error {
+			var (
+				tenders []client.Tender
+				err     error
+			)
+			if page.all {
+				tenders, err = e.client.IterateMyTenders(page.limit).All(ctx)
+			} else {
+				tenders, err = e.client.MyTenders(ctx, page.limit, page.offset)
+			}
+			if err != nil {
+				return err
+			}
+
+			return printList(e.out, e.settings.output, tenders, tenderColumns)
+		},
+	}
+}
+
+func getTender(ctx context.Context, e *env, args []string) error {
+	tender, err := e.client.GetTender(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return printOne(e.out, e.settings.output, tender, tenderColumns)
+}
+
+func tenderVersions(ctx context.Context, e *env, args []string) error {
+	versions, err := e.client.TenderVersions(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return printList(e.out, e.settings.output, versions, versionColumns)
+}
+
+// fieldChange is a field that differs between two versions.
+type fieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+var changeColumns = []column[fieldChange]{
+	{"FIELD", func(c fieldChange) string { return c.Field }},
+	{"FROM", func(c fieldChange) string { return formatValue(c.From) }},
+	{"TO", func(c fieldChange) string { return formatValue(c.To) }},
+}
+
+func diffTenderVersions(ctx context.Context, e *env, args []string) error {
+	from, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("version %q is not a number", args[1])
+	}
+	to := 0
+	if len(args) == 3 {
+		if to, err = strconv.Atoi(args[2]); err != nil {
+			return fmt.Errorf("version %q is not a number", args[2])
+		}
+	}
+
+	versions, err := e.client.TenderVersions(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if to == 0 && len(versions) > 0 {
+		to = versions[len(versions)-1].Version
+	}
+
+	changes, err := diffVersions(versions, from, to)
+	if err != nil {
+		return err
+	}
+
+	return printList(e.out, e.settings.output, changes, changeColumns)
+}
+
+func diffVersions(versions []client.TenderVersion, from, to int) ([]fieldChange, error) {
+	find := func(version int) (client.TenderVersion, error) {
+		for _, v := range versions {
+			if v.Version == version {
+				return v, nil
+			}
+		}
+		return client.TenderVersion{}, fmt.Errorf("tender has no version %d", version)
+	}
+
+	a, err := find(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := find(to)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []struct {
+		name     string
+		from, to any
+	}{
+		{"name", a.Name, b.Name},
+		{"description", a.Description, b.Description},
+		{"serviceType", a.ServiceType, b.ServiceType},
+		{"status", a.Status, b.Status},
+		{"budget", a.Budget, b.Budget},
+		{"tags", a.Tags, b.Tags},
+		{"customFields", a.CustomFields, b.CustomFields},
+	}
+
+	changes := []fieldChange{}
+	for _, field := range fields {
+		// Compared in their printed form, so that an empty list is the
+		// same as no list.
+		if formatValue(field.from) != formatValue(field.to) {
+			changes = append(changes, fieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+
+	return changes, nil
+}
+
+func tenderStatus(ctx context.Context, e *env, args []string) error {
+	if len(args) == 1 {
+		status, err := e.client.TenderStatus(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		return printOne(e.out, e.settings.output, map[string]string{"status": status}, []column[map[string]string]{
+			{"STATUS", func(m map[string]string) string { return m["status"] }},
+		})
+	}
+
+	tender, err := e.client.SetTenderStatus(ctx, args[0], strings.ToUpper(args[1]))
+	if err != nil {
+		return err
+	}
+
+	return printOne(e.out, e.settings.output, tender, tenderColumns)
+}
+
+func rollbackTender(ctx context.Context, e *env, args []string) error {
+	version, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("version %q is not a number", args[1])
+	}
+
+	tender, err := e.client.RollbackTender(ctx, args[0], version)
+	if err != nil {
+		return err
+	}
+
+	return printOne(e.out, e.settings.output, tender, tenderColumns)
+}
+
+var importErrorColumns = []column[client.ImportRowError]{
+	{"LINE", func(r client.ImportRowError) string { return strconv.Itoa(r.Line) }},
+	{"ERROR", func(r client.ImportRowError) string { return r.Error }},
+}
+
+func importTendersCommand() command {
+	var (
+		format string
+		dryRun bool
+	)
+
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&format, "format", "", "csv or ndjson, by default taken from the file extension")
+			fs.BoolVar(&dryRun, "dry-run", false, "only validate the rows")
+		},
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(ctx context.Context, e *env, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			if format == "" {
+				format = client.FormatCSV
+				if ext := strings.ToLower(filepath.Ext(args[0])); ext == ".ndjson" || ext == ".jsonl" {
+					format = client.FormatNDJSON
+				}
+			}
+
+			report, err := e.client.ImportTenders(ctx, file, format, dryRun)
+			if err != nil {
+				return err
+			}
+
+			if e.settings.output != outputTable {
+				return printDocument(e.out, e.settings.output, report)
+			}
+
+			fmt.Fprintf(e.out, "%d rows, %d valid, %d imported\n", report.Rows, report.Valid, report.Imported)
+			if report.DryRun {
+				fmt.Fprintln(e.out, "dry run, nothing was imported")
+			}
+			if len(report.Errors) == 0 {
+				return nil
+			}
+
+			fmt.Fprintln(e.out)
+			return printList(e.out, outputTable, report.Errors, importErrorColumns)
+		},
+	}
+}
+
+func exportTendersCommand() command {
+	var (
+		filters tenderFilters
+		format  string
+		out     string
+		limit   int
+	)
+
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			filters.register(fs)
+			fs.StringVar(&format, "format", client.FormatCSV, "csv or xlsx")
+			fs.StringVar(&out, "out", "", "file to write, standard output by default")
+			fs.IntVar(&limit, "limit", 0, "number of tenders, all by default")
+		},
+		run: func(ctx context.Context, e *env, _ []string) error {
+			opts := filters.options()
+			opts.Limit = limit
+
+			body, err := e.client.ExportTenders(ctx, opts, format)
+			if err != nil {
+				return err
+			}
+			defer body.Close()
+
+			if out == "" {
+				_, err = io.Copy(e.out, body)
+				return err
+			}
+
+			file, err := os.Create(out)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(file, body); err != nil {
+				file.Close()
+				return fmt.Errorf("downloading export: %w", err)
+			}
+
+			return file.Close()
+		},
+	}
+}
+
+func formatBudget(budget *float64) string {
+	if budget == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*budget, 'f', -1, 64)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case *float64:
+		return formatBudget(v)
+	case []string:
+		return strings.Join(v, ",")
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil || string(data) == "null" || string(data) == "{}" {
+			return ""
+		}
+		return string(data)
+	}
+}
@@ -12,3 +12,9 @@ func RespondWithError(w http.ResponseWriter, statusCode int, errMessage string)
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(domain.JSONError{Err: errMessage})
 }
+
+func RespondWithDetails(w http.ResponseWriter, statusCode int, errMessage string, details []domain.FieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(domain.JSONError{Err: errMessage, Details: details})
+}
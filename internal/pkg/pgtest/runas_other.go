@@ -0,0 +1,11 @@
+//go:build !unix
+
+package pgtest
+
+import "syscall"
+
+// runAs starts initdb and postgres as the current user, there is no root
+// to drop.
+func runAs(dir string) (*syscall.SysProcAttr, error) {
+	return nil, nil
+}
@@ -0,0 +1,55 @@
+//go:build unix
+
+package pgtest
+
+import (
+	"fmt"
+	"os"
+	osuser "os/user"
+	"strconv"
+	"syscall"
+)
+
+// runAs returns the attributes initdb and postgres are started with. Root
+// hands the directory of the cluster over to an unprivileged user and drops
+// to that user, other users run them as themselves.
+func runAs(dir string) (*syscall.SysProcAttr, error) {
+	if os.Geteuid() != 0 {
+		return nil, nil
+	}
+
+	account, err := unprivilegedUser()
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := strconv.ParseUint(account.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("pgtest.Start: uid of %s: %w", account.Username, err)
+	}
+	gid, err := strconv.ParseUint(account.Gid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("pgtest.Start: gid of %s: %w", account.Username, err)
+	}
+
+	if err := os.Chown(dir, int(uid), int(gid)); err != nil {
+		return nil, fmt.Errorf("pgtest.Start: %w", err)
+	}
+
+	return &syscall.SysProcAttr{Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}}, nil
+}
+
+func unprivilegedUser() (*osuser.User, error) {
+	names := []string{"postgres", "nobody"}
+	if name := os.Getenv("PGTEST_USER"); name != "" {
+		names = []string{name}
+	}
+
+	for _, name := range names {
+		if account, err := osuser.Lookup(name); err == nil && account.Uid != "0" {
+			return account, nil
+		}
+	}
+
+	return nil, ErrRoot
+}
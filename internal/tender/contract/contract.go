@@ -0,0 +1,131 @@
+// Package contract renders the contract document of an awarded tender from a
+// text/template producing Markdown, and typesets it as PDF.
+package contract
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+//go:embed templates/contract.md.tmpl
+var defaultTemplate string
+
+var _ domain.ContractRenderer = (*Renderer)(nil)
+
+type Renderer struct {
+	tmpl *template.Template
+	font *trueType
+}
+
+// NewRenderer loads the contract template from templatePath, or uses the
+// built-in one if it is empty. fontPath names a TrueType font to embed into
+// PDF documents; without it PDFs are typeset in Courier, which lacks
+// Cyrillic.
+func NewRenderer(templatePath string, fontPath string) (*Renderer, error) {
+	source := defaultTemplate
+	if templatePath != "" {
+		data, err := os.ReadFile(templatePath)
+		if err != nil {
+			return nil, fmt.Errorf("contract.NewRenderer: %w", err)
+		}
+		source = string(data)
+	}
+
+	tmpl, err := template.New("contract").Funcs(funcs).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("contract.NewRenderer: %w", err)
+	}
+
+	r := &Renderer{tmpl: tmpl}
+	if fontPath != "" {
+		data, err := os.ReadFile(fontPath)
+		if err != nil {
+			return nil, fmt.Errorf("contract.NewRenderer: %w", err)
+		}
+		r.font, err = parseTrueType(data)
+		if err != nil {
+			return nil, fmt.Errorf("contract.NewRenderer: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+var funcs = template.FuncMap{
+	"money": money,
+	"date": func(t time.Time) string {
+		return t.Format("02.01.2006")
+	},
+	"number": func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	},
+	"join": strings.Join,
+	"inc": func(i int) int {
+		return i + 1
+	},
+}
+
+// money formats an amount with two decimals and thousands separated by
+// spaces. Unknown amounts are shown as a dash.
+func money(v any) string {
+	var amount float64
+	switch v := v.(type) {
+	case float64:
+		amount = v
+	case *float64:
+		if v == nil {
+			return "—"
+		}
+		amount = *v
+	default:
+		return fmt.Sprint(v)
+	}
+
+	s := strconv.FormatFloat(amount, 'f', 2, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(s, ".")
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte(' ')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return sign + grouped.String() + "." + fraction
+}
+
+func (r *Renderer) Markdown(data domain.ContractData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("contract.Markdown: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (r *Renderer) PDF(data domain.ContractData) ([]byte, error) {
+	source, err := r.Markdown(data)
+	if err != nil {
+		return nil, fmt.Errorf("contract.PDF: %w", err)
+	}
+
+	var font pdfFont = courierFont{}
+	if r.font != nil {
+		font = newEmbeddedFont(r.font)
+	}
+
+	return renderPDF(string(source), font), nil
+}
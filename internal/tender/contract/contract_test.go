@@ -0,0 +1,227 @@
+package contract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const dejaVuSans = "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf"
+
+func testData() domain.ContractData {
+	lotID, bidID := "lot-1", "bid-1"
+	first, second := 1250000.5, 99.99
+	decided := time.Date(2024, 9, 14, 10, 0, 0, 0, time.UTC)
+
+	return domain.ContractData{
+		Tender: domain.Tender{ID: "tender-1", Name: "Поставка серверов", Description: "Серверы и монтаж", ServiceType: "Delivery", Version: 3},
+		Buyer:  domain.Organization{ID: "org-1", Name: "ООО Заказчик", Type: domain.OrganizationTypeLLC},
+		Awards: []domain.ContractAward{
+			{
+				Award:    domain.Award{LotID: &lotID, BidID: &bidID, Amount: &first, DecidedAt: decided, DecisionMakers: []string{"ivanov", "petrov"}},
+				Supplier: domain.Organization{ID: "org-2", Name: "ИП Поставщик"},
+				Lot:      &domain.Lot{Name: "Серверы", Quantity: 4, Unit: "шт"},
+				BidName:  "Предложение по серверам",
+			},
+			{
+				Award:    domain.Award{Amount: &second, DecidedAt: decided},
+				Supplier: domain.Organization{ID: "org-2", Name: "ИП Поставщик"},
+			},
+		},
+		GeneratedAt: decided,
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	r, err := NewRenderer("", "")
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	md, err := r.Markdown(testData())
+	if err != nil {
+		t.Fatalf("Markdown: %v", err)
+	}
+
+	for _, want := range []string{
+		"# Договор по итогам тендера «Поставка серверов»",
+		"### 2.1. Лот «Серверы»",
+		"- Количество: 4 шт",
+		"- Цена: 1 250 000.50",
+		"- Решение приняли: ivanov, petrov",
+		"Общая цена договора: 1 250 100.49.",
+	} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("contract does not contain %q:\n%s", want, md)
+		}
+	}
+
+	if n := strings.Count(string(md), "\nПоставщик: ИП Поставщик"); n != 1 {
+		t.Errorf("supplier signs %d times, want once", n)
+	}
+}
+
+func TestCustomTemplate(t *testing.T) {
+	path := t.TempDir() + "/contract.md.tmpl"
+	if err := os.WriteFile(path, []byte("Contract {{.Tender.ID}}: {{money .Total}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewRenderer(path, "")
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	md, err := r.Markdown(testData())
+	if err != nil {
+		t.Fatalf("Markdown: %v", err)
+	}
+	if got, want := string(md), "Contract tender-1: 1 250 100.49"; got != want {
+		t.Fatalf("Markdown = %q, want %q", got, want)
+	}
+}
+
+// checkPDF verifies the cross-reference table points at the objects and
+// returns the decompressed content of all streams.
+func checkPDF(t *testing.T, pdf []byte) string {
+	t.Helper()
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.7")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("not a PDF document")
+	}
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if m == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatal("startxref does not point at the xref table")
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := strconv.Itoa(i+1) + " 0 obj"; !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Fatalf("xref entry %d does not point at %q", i+1, want)
+		}
+	}
+
+	var content strings.Builder
+	streams := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(pdf, -1)
+	for _, stream := range streams {
+		zr, err := zlib.NewReader(bytes.NewReader(stream[1]))
+		if err != nil {
+			t.Fatalf("stream: %v", err)
+		}
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatalf("stream: %v", err)
+		}
+		content.Write(data)
+	}
+
+	return content.String()
+}
+
+func TestPDFCourier(t *testing.T) {
+	r, err := NewRenderer("", "")
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	pdf, err := r.PDF(testData())
+	if err != nil {
+		t.Fatalf("PDF: %v", err)
+	}
+
+	content := checkPDF(t, pdf)
+	if !bytes.Contains(pdf, []byte("/BaseFont /Courier")) {
+		t.Error("PDF does not use Courier")
+	}
+	// Courier has no Cyrillic, so only the Latin part of the text survives.
+	if !strings.Contains(content, courierFont{}.encode("2.1.")) {
+		t.Error("heading text is missing")
+	}
+}
+
+func TestPDFEmbeddedFont(t *testing.T) {
+	if _, err := os.Stat(dejaVuSans); err != nil {
+		t.Skip("DejaVu Sans is not installed")
+	}
+
+	r, err := NewRenderer("", dejaVuSans)
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	data := testData()
+	for i := 0; i < 40; i++ {
+		data.Tender.Description += " Очень длинное описание предмета закупки, которое не помещается на одну страницу."
+	}
+
+	pdf, err := r.PDF(data)
+	if err != nil {
+		t.Fatalf("PDF: %v", err)
+	}
+
+	content := checkPDF(t, pdf)
+	if !bytes.Contains(pdf, []byte("/FontFile2")) {
+		t.Error("font is not embedded")
+	}
+
+	font := newEmbeddedFont(r.font)
+	if !strings.Contains(content, font.encode("Договор")) {
+		t.Error("Cyrillic text is missing")
+	}
+	if !strings.Contains(content, "<0000> <FFFF>") {
+		t.Error("ToUnicode map is missing")
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		if !strings.HasPrefix(line, "BT ") {
+			continue
+		}
+		fields := strings.Fields(line)
+		x, _ := strconv.ParseFloat(fields[4], 64)
+		y, _ := strconv.ParseFloat(fields[5], 64)
+		if x < margin || y < margin || y > pageHeight-margin {
+			t.Fatalf("text outside the margins: %s", line)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	lines := wrap(courierFont{}, "aaaa bbbb cccc dddddddddddd", 10, 30)
+	// Courier at 10pt is 6pt per character, so five characters per line.
+	want := []string{"aaaa", "bbbb", "cccc", "ddddd", "ddddd", "dd"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Fatalf("wrap = %q, want %q", lines, want)
+	}
+}
+
+func TestMoney(t *testing.T) {
+	amount := -1234567.891
+	for _, tt := range []struct {
+		in   any
+		want string
+	}{
+		{0.0, "0.00"},
+		{999.5, "999.50"},
+		{1000.0, "1 000.00"},
+		{&amount, "-1 234 567.89"},
+		{(*float64)(nil), "—"},
+	} {
+		if got := money(tt.in); got != tt.want {
+			t.Errorf("money(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
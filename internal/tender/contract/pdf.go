@@ -0,0 +1,396 @@
+package contract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A4 in points with 2 cm margins.
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 56.69
+)
+
+// pdfFont is the single font a contract is typeset in.
+type pdfFont interface {
+	// encode returns s as a hex string for the Tj operator.
+	encode(s string) string
+	// width returns the width of s in thousandths of the font size.
+	width(s string) int
+	// write adds the font objects to the document and returns the number of
+	// the font dictionary. It is called after all text has been encoded.
+	write(w *pdfWriter) int
+}
+
+// courierFont is the built-in fallback. It needs no embedding but only covers
+// the Windows-1252 repertoire; other characters come out as '?'.
+type courierFont struct{}
+
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+func winAnsi(r rune) byte {
+	switch {
+	case r >= 0x20 && r <= 0x7E, r >= 0xA0 && r <= 0xFF:
+		return byte(r)
+	}
+	if b, ok := winAnsiSpecials[r]; ok {
+		return b
+	}
+
+	return '?'
+}
+
+func (courierFont) encode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		fmt.Fprintf(&b, "%02X", winAnsi(r))
+	}
+
+	return b.String()
+}
+
+func (courierFont) width(s string) int {
+	return 600 * utf8.RuneCountInString(s)
+}
+
+func (courierFont) write(w *pdfWriter) int {
+	id := w.reserve()
+	w.object(id, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	return id
+}
+
+// embeddedFont typesets with a TrueType font embedded as a CID font, which
+// covers whatever the font covers, Cyrillic included.
+type embeddedFont struct {
+	font *trueType
+	used map[uint16]rune
+}
+
+func newEmbeddedFont(font *trueType) *embeddedFont {
+	return &embeddedFont{font: font, used: map[uint16]rune{}}
+}
+
+func (f *embeddedFont) glyph(r rune) uint16 {
+	glyph := f.font.glyphs[r]
+	if _, ok := f.used[glyph]; !ok {
+		f.used[glyph] = r
+	}
+
+	return glyph
+}
+
+func (f *embeddedFont) encode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		fmt.Fprintf(&b, "%04X", f.glyph(r))
+	}
+
+	return b.String()
+}
+
+func (f *embeddedFont) width(s string) int {
+	var width int
+	for _, r := range s {
+		width += f.font.scale(f.font.advances[f.font.glyphs[r]])
+	}
+
+	return width
+}
+
+func (f *embeddedFont) write(w *pdfWriter) int {
+	glyphs := make([]int, 0, len(f.used))
+	for glyph := range f.used {
+		glyphs = append(glyphs, int(glyph))
+	}
+	sort.Ints(glyphs)
+
+	var widths, toUnicode strings.Builder
+	for _, glyph := range glyphs {
+		fmt.Fprintf(&widths, "%d [%d] ", glyph, f.font.scale(f.font.advances[glyph]))
+	}
+
+	toUnicode.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for start := 0; start < len(glyphs); start += 100 {
+		chunk := glyphs[start:min(start+100, len(glyphs))]
+		fmt.Fprintf(&toUnicode, "%d beginbfchar\n", len(chunk))
+		for _, glyph := range chunk {
+			fmt.Fprintf(&toUnicode, "<%04X> <", glyph)
+			for _, unit := range utf16Units(f.used[uint16(glyph)]) {
+				fmt.Fprintf(&toUnicode, "%04X", unit)
+			}
+			toUnicode.WriteString(">\n")
+		}
+		toUnicode.WriteString("endbfchar\n")
+	}
+	toUnicode.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+
+	fontFile, descriptor, cidFont, cmap, font := w.reserve(), w.reserve(), w.reserve(), w.reserve(), w.reserve()
+	w.stream(fontFile, fmt.Sprintf("/Length1 %d", len(f.font.data)), f.font.data)
+	w.object(descriptor, fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /ContractFont /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.font.scale(f.font.bbox[0]), f.font.scale(f.font.bbox[1]), f.font.scale(f.font.bbox[2]), f.font.scale(f.font.bbox[3]),
+		f.font.scale(f.font.ascent), f.font.scale(f.font.descent), f.font.scale(f.font.capHeight), fontFile,
+	))
+	w.object(cidFont, fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /ContractFont /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /W [%s] /CIDToGIDMap /Identity >>",
+		descriptor, widths.String(),
+	))
+	w.stream(cmap, "", []byte(toUnicode.String()))
+	w.object(font, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /ContractFont /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		cidFont, cmap,
+	))
+
+	return font
+}
+
+func utf16Units(r rune) []uint16 {
+	if r < 0x10000 {
+		return []uint16{uint16(r)}
+	}
+	r -= 0x10000
+
+	return []uint16{uint16(0xD800 + (r >> 10)), uint16(0xDC00 + (r & 0x3FF))}
+}
+
+// pdfWriter assembles numbered objects and the cross-reference table.
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.buf.WriteString("%PDF-1.7\n%\xE2\xE3\xCF\xD3\n")
+
+	return w
+}
+
+// reserve allocates an object number to be written later.
+func (w *pdfWriter) reserve() int {
+	w.offsets = append(w.offsets, 0)
+	return len(w.offsets)
+}
+
+func (w *pdfWriter) object(id int, body string) {
+	w.offsets[id-1] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+// stream writes a Flate compressed stream object; dict holds extra entries.
+func (w *pdfWriter) stream(id int, dict string, data []byte) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(data)
+	zw.Close()
+
+	w.offsets[id-1] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< /Length %d /Filter /FlateDecode %s>>\nstream\n", id, compressed.Len(), dict)
+	w.buf.Write(compressed.Bytes())
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (w *pdfWriter) finish(root int) []byte {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, root, xref)
+
+	return w.buf.Bytes()
+}
+
+// block is a typeset unit of the Markdown source.
+type block struct {
+	text    string
+	size    float64
+	bullet  string
+	spacing float64
+	rule    bool
+}
+
+// parseMarkdown understands the subset of Markdown contract templates are
+// expected to use: headings, bullet lists, horizontal rules, paragraphs and
+// bold text, whose markers are dropped.
+func parseMarkdown(source string) []block {
+	inline := strings.NewReplacer("**", "", "`", "")
+
+	var blocks []block
+	for _, line := range strings.Split(source, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+
+		switch {
+		case trimmed == "":
+			blocks = append(blocks, block{spacing: 6})
+		case isRule(trimmed):
+			blocks = append(blocks, block{rule: true})
+		case strings.HasPrefix(trimmed, "#"):
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			text := strings.TrimSpace(trimmed[level:])
+			size := 12.0
+			switch level {
+			case 1:
+				size = 18
+			case 2:
+				size = 14
+			}
+			blocks = append(blocks, block{spacing: size / 2}, block{text: inline.Replace(text), size: size})
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "), strings.HasPrefix(trimmed, "+ "):
+			blocks = append(blocks, block{text: inline.Replace(trimmed[2:]), size: 11, bullet: "• "})
+		default:
+			blocks = append(blocks, block{text: inline.Replace(line), size: 11})
+		}
+	}
+
+	return blocks
+}
+
+func isRule(line string) bool {
+	if len(line) < 3 {
+		return false
+	}
+
+	compact := strings.ReplaceAll(line, " ", "")
+	for _, c := range []string{"-", "*", "_"} {
+		if strings.Trim(compact, c) == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wrap breaks text into lines no wider than maxWidth points.
+func wrap(font pdfFont, text string, size, maxWidth float64) []string {
+	fits := func(s string) bool { return float64(font.width(s))*size/1000 <= maxWidth }
+
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if fits(candidate) {
+			current = candidate
+			continue
+		}
+
+		if current != "" {
+			lines = append(lines, current)
+		}
+		current = word
+		for !fits(current) {
+			cut := nextRune(current, 0)
+			for cut < len(current) && fits(current[:nextRune(current, cut)]) {
+				cut = nextRune(current, cut)
+			}
+			lines = append(lines, current[:cut])
+			current = current[cut:]
+		}
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
+
+// nextRune returns the byte offset of the rune following offset i.
+func nextRune(s string, i int) int {
+	_, n := utf8.DecodeRuneInString(s[i:])
+	return i + n
+}
+
+func formatPoints(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// renderPDF typesets Markdown source on A4 pages.
+func renderPDF(source string, font pdfFont) []byte {
+	var (
+		pages   []*bytes.Buffer
+		content *bytes.Buffer
+		y       float64
+	)
+	newPage := func() {
+		content = &bytes.Buffer{}
+		pages = append(pages, content)
+		y = pageHeight - margin
+	}
+	newPage()
+
+	textWidth := pageWidth - 2*margin
+	for _, b := range parseMarkdown(source) {
+		switch {
+		case b.rule:
+			if y-12 < margin {
+				newPage()
+			}
+			y -= 6
+			fmt.Fprintf(content, "0.5 w %s %s m %s %s l S\n", formatPoints(margin), formatPoints(y), formatPoints(pageWidth-margin), formatPoints(y))
+			y -= 6
+		case b.text == "" && b.size == 0:
+			if y != pageHeight-margin {
+				y -= b.spacing
+			}
+		default:
+			indent := float64(font.width(b.bullet)) * b.size / 1000
+			leading := b.size * 1.35
+			for i, line := range wrap(font, b.text, b.size, textWidth-indent) {
+				if y-leading < margin {
+					newPage()
+				}
+				y -= leading
+
+				x := margin + indent
+				if i == 0 && b.bullet != "" {
+					line = b.bullet + line
+					x = margin
+				}
+				fmt.Fprintf(content, "BT /F1 %s Tf %s %s Td <%s> Tj ET\n", formatPoints(b.size), formatPoints(x), formatPoints(y), font.encode(line))
+			}
+		}
+	}
+
+	w := newPDFWriter()
+	catalog, pageTree := w.reserve(), w.reserve()
+
+	pageIDs := make([]int, len(pages))
+	contentIDs := make([]int, len(pages))
+	for i := range pages {
+		pageIDs[i], contentIDs[i] = w.reserve(), w.reserve()
+	}
+
+	fontID := font.write(w)
+
+	kids := make([]string, len(pages))
+	for i, page := range pages {
+		w.stream(contentIDs[i], "", page.Bytes())
+		w.object(pageIDs[i], fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+			pageTree, formatPoints(pageWidth), formatPoints(pageHeight), fontID, contentIDs[i],
+		))
+		kids[i] = fmt.Sprintf("%d 0 R", pageIDs[i])
+	}
+
+	w.object(pageTree, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	w.object(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pageTree))
+
+	return w.finish(catalog)
+}
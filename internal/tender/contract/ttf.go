@@ -0,0 +1,218 @@
+package contract
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var errBadFont = errors.New("contract: unsupported or malformed TrueType font")
+
+// trueType holds what the PDF writer needs from a TrueType font: the glyph
+// for each character, the glyph widths and the global metrics. The font file
+// itself is embedded into the document as is.
+type trueType struct {
+	data       []byte
+	unitsPerEm int
+	bbox       [4]int
+	ascent     int
+	descent    int
+	capHeight  int
+	advances   []int
+	glyphs     map[rune]uint16
+}
+
+func parseTrueType(data []byte) (*trueType, error) {
+	if len(data) < 12 {
+		return nil, errBadFont
+	}
+
+	switch binary.BigEndian.Uint32(data) {
+	case 0x00010000, 0x74727565: // TrueType outlines
+	default:
+		return nil, fmt.Errorf("%w: only TrueType outlines can be embedded", errBadFont)
+	}
+
+	tables := map[string][]byte{}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		record := 12 + 16*i
+		if record+16 > len(data) {
+			return nil, errBadFont
+		}
+		offset := int(binary.BigEndian.Uint32(data[record+8:]))
+		length := int(binary.BigEndian.Uint32(data[record+12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, errBadFont
+		}
+		tables[string(data[record:record+4])] = data[offset : offset+length]
+	}
+
+	head, hhea, maxp, hmtx, cmap := tables["head"], tables["hhea"], tables["maxp"], tables["hmtx"], tables["cmap"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 || cmap == nil {
+		return nil, errBadFont
+	}
+
+	font := &trueType{
+		data:       data,
+		unitsPerEm: int(binary.BigEndian.Uint16(head[18:])),
+		bbox: [4]int{
+			int(int16(binary.BigEndian.Uint16(head[36:]))),
+			int(int16(binary.BigEndian.Uint16(head[38:]))),
+			int(int16(binary.BigEndian.Uint16(head[40:]))),
+			int(int16(binary.BigEndian.Uint16(head[42:]))),
+		},
+		ascent:  int(int16(binary.BigEndian.Uint16(hhea[4:]))),
+		descent: int(int16(binary.BigEndian.Uint16(hhea[6:]))),
+	}
+	if font.unitsPerEm == 0 {
+		return nil, errBadFont
+	}
+
+	font.capHeight = font.ascent
+	if os2 := tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2) >= 2 {
+		font.capHeight = int(int16(binary.BigEndian.Uint16(os2[88:])))
+	}
+
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if numMetrics == 0 || numMetrics > numGlyphs || len(hmtx) < 4*numMetrics {
+		return nil, errBadFont
+	}
+	font.advances = make([]int, numGlyphs)
+	for i := range font.advances {
+		if i < numMetrics {
+			font.advances[i] = int(binary.BigEndian.Uint16(hmtx[4*i:]))
+		} else {
+			font.advances[i] = font.advances[numMetrics-1]
+		}
+	}
+
+	glyphs, err := parseCmap(cmap)
+	if err != nil {
+		return nil, err
+	}
+	font.glyphs = glyphs
+
+	return font, nil
+}
+
+// parseCmap reads the Unicode character map, preferring the full repertoire
+// (format 12) over the Basic Multilingual Plane one (format 4).
+func parseCmap(cmap []byte) (map[rune]uint16, error) {
+	if len(cmap) < 4 {
+		return nil, errBadFont
+	}
+
+	var format4, format12 []byte
+	numTables := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < numTables; i++ {
+		record := 4 + 8*i
+		if record+8 > len(cmap) {
+			return nil, errBadFont
+		}
+		platform := binary.BigEndian.Uint16(cmap[record:])
+		encoding := binary.BigEndian.Uint16(cmap[record+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[record+4:]))
+		if offset+2 > len(cmap) {
+			return nil, errBadFont
+		}
+		if platform != 0 && !(platform == 3 && (encoding == 1 || encoding == 10)) {
+			continue
+		}
+
+		subtable := cmap[offset:]
+		switch binary.BigEndian.Uint16(subtable) {
+		case 4:
+			format4 = subtable
+		case 12:
+			format12 = subtable
+		}
+	}
+
+	switch {
+	case format12 != nil:
+		return parseCmap12(format12)
+	case format4 != nil:
+		return parseCmap4(format4)
+	default:
+		return nil, fmt.Errorf("%w: no Unicode character map", errBadFont)
+	}
+}
+
+func parseCmap4(table []byte) (map[rune]uint16, error) {
+	if len(table) < 14 {
+		return nil, errBadFont
+	}
+
+	segCount := int(binary.BigEndian.Uint16(table[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + 2*segCount + 2
+	idDeltas := startCodes + 2*segCount
+	idRangeOffsets := idDeltas + 2*segCount
+	if idRangeOffsets+2*segCount > len(table) {
+		return nil, errBadFont
+	}
+
+	glyphs := map[rune]uint16{}
+	for seg := 0; seg < segCount; seg++ {
+		end := int(binary.BigEndian.Uint16(table[endCodes+2*seg:]))
+		start := int(binary.BigEndian.Uint16(table[startCodes+2*seg:]))
+		delta := binary.BigEndian.Uint16(table[idDeltas+2*seg:])
+		rangeOffsetAt := idRangeOffsets + 2*seg
+		rangeOffset := int(binary.BigEndian.Uint16(table[rangeOffsetAt:]))
+
+		for c := start; c <= end && c != 0xFFFF; c++ {
+			var glyph uint16
+			if rangeOffset == 0 {
+				glyph = uint16(c) + delta
+			} else {
+				at := rangeOffsetAt + rangeOffset + 2*(c-start)
+				if at+2 > len(table) {
+					return nil, errBadFont
+				}
+				glyph = binary.BigEndian.Uint16(table[at:])
+				if glyph != 0 {
+					glyph += delta
+				}
+			}
+			if glyph != 0 {
+				glyphs[rune(c)] = glyph
+			}
+		}
+	}
+
+	return glyphs, nil
+}
+
+func parseCmap12(table []byte) (map[rune]uint16, error) {
+	if len(table) < 16 {
+		return nil, errBadFont
+	}
+
+	numGroups := int(binary.BigEndian.Uint32(table[12:]))
+	if 16+12*numGroups > len(table) {
+		return nil, errBadFont
+	}
+
+	glyphs := map[rune]uint16{}
+	for i := 0; i < numGroups; i++ {
+		group := table[16+12*i:]
+		start := binary.BigEndian.Uint32(group)
+		end := binary.BigEndian.Uint32(group[4:])
+		glyph := binary.BigEndian.Uint32(group[8:])
+		if end < start || end > 0x10FFFF {
+			return nil, errBadFont
+		}
+		for c := start; c <= end; c++ {
+			glyphs[rune(c)] = uint16(glyph + c - start)
+		}
+	}
+
+	return glyphs, nil
+}
+
+// scale converts font units to the 1000 unit text space of PDF.
+func (f *trueType) scale(v int) int {
+	return v * 1000 / f.unitsPerEm
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"io"
+	"time"
 )
 
 type TenderServicePingProvider interface {
@@ -13,22 +15,357 @@ type TenderRepositoryPingProvider interface {
 }
 
 type TenderService interface {
-	ListTender(ctx context.Context, limit int, offset int, serviceTypes []string) ([]Tender, error)
+	ListTender(ctx context.Context, filter TenderListFilter) ([]Tender, error)
 	CreateTender(ctx context.Context, tender Tender) (Tender, error)
 	GetUserTenders(ctx context.Context, limit int, offset int, username string) ([]Tender, error)
 	UpdateTenderStatus(ctx context.Context, tenderID string, status string, username string) (Tender, error)
+	UpdateTenderStatuses(ctx context.Context, req BatchStatusRequest, username string) (BatchStatusResult, error)
 	GetTenderStatus(ctx context.Context, tenderID string, username string) (string, error)
 	UpdatePartTender(ctx context.Context, id string, updates map[string]interface{}, username string) (Tender, error)
 	RollbackTenderVersion(ctx context.Context, tenderID string, version int, username string) (Tender, error)
+	GetTender(ctx context.Context, tenderID string, username string) (Tender, error)
+	CloneTender(ctx context.Context, tenderID string, username string) (Tender, error)
 }
 
 //go:generate mockgen -destination=mocks/repo_mock.gen.go -package=mocks . TenderRepositoryGetter
 type TenderRepository interface {
-	ListTender(ctx context.Context, limit int, offset int, serviceTypes []string) ([]Tender, error)
+	ListTender(ctx context.Context, filter TenderListFilter) ([]Tender, error)
 	CreateTender(ctx context.Context, tender Tender) (Tender, error)
 	GetUserTenders(ctx context.Context, limit int, offset int, username string) ([]Tender, error)
-	UpdateTenderStatus(ctx context.Context, tenderID string, status string, username string) (Tender, error)
+	// UpdateTenderStatus moves the tender from one of the from statuses to
+	// status and reports whether it changed, so that concurrent updates to
+	// the same status see a single change.
+	UpdateTenderStatus(ctx context.Context, tenderID string, status string, from []string, username string) (Tender, bool, error)
+	// UpdateTenderStatuses moves the tenders from one of the from statuses
+	// to status, failed checks are reported per tender. An atomic batch is
+	// rolled back when any tender fails.
+	UpdateTenderStatuses(ctx context.Context, tenderIDs []string, status string, from []string, username string, atomic bool) ([]TenderStatusChange, error)
 	GetTenderStatus(ctx context.Context, tenderID string, username string) (string, error)
 	UpdatePartTender(ctx context.Context, id string, updates map[string]interface{}, username string) (Tender, error)
 	RollbackTenderVersion(ctx context.Context, tenderID string, version int, username string) (Tender, error)
+	GetTender(ctx context.Context, tenderID string, username string) (Tender, error)
+}
+
+type ServiceTypeService interface {
+	ListServiceTypes(ctx context.Context, filter ServiceTypeFilter) ([]ServiceType, error)
+	GetServiceType(ctx context.Context, code string, locale string) (ServiceType, error)
+	CreateServiceType(ctx context.Context, serviceType ServiceType, username string) (ServiceType, error)
+	UpdateServiceType(ctx context.Context, code string, update ServiceTypeUpdate, username string) (ServiceType, error)
+	DeleteServiceType(ctx context.Context, code string, username string) error
+}
+
+type ServiceTypeRepository interface {
+	ListServiceTypes(ctx context.Context, filter ServiceTypeFilter) ([]ServiceType, error)
+	GetServiceType(ctx context.Context, code string) (ServiceType, error)
+	CreateServiceType(ctx context.Context, serviceType ServiceType, username string) (ServiceType, error)
+	UpdateServiceType(ctx context.Context, code string, update ServiceTypeUpdate, username string) (ServiceType, error)
+	DeleteServiceType(ctx context.Context, code string, username string) error
+}
+
+// ServiceTypeResolver maps a service type given by a client to its catalog
+// code. Unknown and inactive service types are rejected.
+type ServiceTypeResolver interface {
+	ResolveServiceType(ctx context.Context, code string) (string, error)
+}
+
+// DirectoryService manages the organizations and employees the tenders and
+// bids belong to, and who is responsible for which organization. Only
+// administrators may use it.
+type DirectoryService interface {
+	ListOrganizations(ctx context.Context, limit int, offset int, username string) ([]Organization, error)
+	CreateOrganization(ctx context.Context, req CreateOrganizationRequest, username string) (Organization, error)
+	UpdateOrganization(ctx context.Context, organizationID string, update OrganizationUpdate, username string) (Organization, error)
+	DeleteOrganization(ctx context.Context, organizationID string, username string) error
+	ListEmployees(ctx context.Context, limit int, offset int, username string) ([]Employee, error)
+	CreateEmployee(ctx context.Context, req CreateEmployeeRequest, username string) (Employee, error)
+	UpdateEmployee(ctx context.Context, employeeUsername string, update EmployeeUpdate, username string) (Employee, error)
+	DeleteEmployee(ctx context.Context, employeeUsername string, username string) error
+	AddOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error
+	RemoveOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error
+}
+
+type DirectoryRepository interface {
+	ListOrganizations(ctx context.Context, limit int, offset int, username string) ([]Organization, error)
+	CreateOrganization(ctx context.Context, req CreateOrganizationRequest, username string) (Organization, error)
+	UpdateOrganization(ctx context.Context, organizationID string, update OrganizationUpdate, username string) (Organization, error)
+	// DeleteOrganization and DeleteEmployee refuse to remove what tenders,
+	// bids or their history still refer to.
+	DeleteOrganization(ctx context.Context, organizationID string, username string) error
+	ListEmployees(ctx context.Context, limit int, offset int, username string) ([]Employee, error)
+	CreateEmployee(ctx context.Context, req CreateEmployeeRequest, username string) (Employee, error)
+	UpdateEmployee(ctx context.Context, employeeUsername string, update EmployeeUpdate, username string) (Employee, error)
+	DeleteEmployee(ctx context.Context, employeeUsername string, username string) error
+	AddOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error
+	RemoveOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error
+}
+
+type CustomFieldService interface {
+	CreateCustomField(ctx context.Context, field CustomField, username string) (CustomField, error)
+	ListCustomFields(ctx context.Context, organizationID string, username string) ([]CustomField, error)
+	DeleteCustomField(ctx context.Context, organizationID string, key string, username string) error
+}
+
+type CustomFieldRepository interface {
+	CreateCustomField(ctx context.Context, field CustomField, username string) (CustomField, error)
+	ListCustomFields(ctx context.Context, organizationID string, username string) ([]CustomField, error)
+	DeleteCustomField(ctx context.Context, organizationID string, key string, username string) error
+	GetOrganizationFields(ctx context.Context, organizationID string) ([]CustomField, error)
+}
+
+// CustomFieldSchema provides the custom fields the tenders of an
+// organization are validated against.
+type CustomFieldSchema interface {
+	GetOrganizationFields(ctx context.Context, organizationID string) ([]CustomField, error)
+}
+
+type TemplateService interface {
+	CreateTemplate(ctx context.Context, template TenderTemplate, sourceTenderID string) (TenderTemplate, error)
+	ListTemplates(ctx context.Context, organizationID string, username string) ([]TenderTemplate, error)
+	GetTemplate(ctx context.Context, templateID string, username string) (TenderTemplate, error)
+	DeleteTemplate(ctx context.Context, organizationID string, templateID string, username string) error
+}
+
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, template TenderTemplate) (TenderTemplate, error)
+	ListTemplates(ctx context.Context, organizationID string, username string) ([]TenderTemplate, error)
+	GetTemplate(ctx context.Context, templateID string, username string) (TenderTemplate, error)
+	DeleteTemplate(ctx context.Context, organizationID string, templateID string, username string) error
+}
+
+type InvitationService interface {
+	CreateInvitation(ctx context.Context, tenderID string, organizationID string, username string) (Invitation, error)
+	ListInvitations(ctx context.Context, tenderID string, username string) ([]Invitation, error)
+	DeleteInvitation(ctx context.Context, tenderID string, organizationID string, username string) error
+}
+
+type InvitationRepository interface {
+	CreateInvitation(ctx context.Context, tenderID string, organizationID string, username string) (Invitation, error)
+	ListInvitations(ctx context.Context, tenderID string, username string) ([]Invitation, error)
+	DeleteInvitation(ctx context.Context, tenderID string, organizationID string, username string) error
+}
+
+type LotService interface {
+	ListLots(ctx context.Context, tenderID string, username string) ([]Lot, error)
+	AwardLot(ctx context.Context, tenderID string, lotID string, bidID string, username string) (Lot, error)
+}
+
+type LotRepository interface {
+	ListLots(ctx context.Context, tenderID string, username string) ([]Lot, error)
+	AwardLot(ctx context.Context, tenderID string, lotID string, bidID string, username string) (Lot, error)
+}
+
+type EvaluationService interface {
+	ScoreBid(ctx context.Context, bidID string, scores []CriterionScore, username string) ([]BidScore, error)
+	GetEvaluation(ctx context.Context, tenderID string, username string) (Evaluation, error)
+}
+
+type EvaluationRepository interface {
+	ScoreBid(ctx context.Context, bidID string, scores []CriterionScore, username string) ([]BidScore, error)
+	GetEvaluationSheet(ctx context.Context, tenderID string, username string) (EvaluationSheet, error)
+}
+
+type AwardService interface {
+	AwardTender(ctx context.Context, tenderID string, bidID string, username string) ([]Award, error)
+	GetAwards(ctx context.Context, tenderID string, username string) ([]Award, error)
+	GenerateContract(ctx context.Context, tenderID string, format ContractFormat, username string) ([]byte, error)
+}
+
+type AwardRepository interface {
+	AwardTender(ctx context.Context, tenderID string, bidID string, username string) ([]Award, error)
+	ListAwards(ctx context.Context, tenderID string, username string) ([]Award, error)
+	GetContractData(ctx context.Context, tenderID string, username string) (ContractData, error)
+}
+
+// ContractRenderer renders the contract document of an awarded tender.
+type ContractRenderer interface {
+	Markdown(data ContractData) ([]byte, error)
+	PDF(data ContractData) ([]byte, error)
+}
+
+// ExportService writes tender and bid listings to w as they are read from
+// the database. A zero limit exports everything.
+type ExportService interface {
+	ExportTenders(ctx context.Context, filter TenderListFilter, format ExportFormat, w io.Writer) error
+	ExportUserTenders(ctx context.Context, limit int, offset int, username string, format ExportFormat, w io.Writer) error
+	ExportUserBids(ctx context.Context, limit int, offset int, username string, format ExportFormat, w io.Writer) error
+	ExportTenderBids(ctx context.Context, tenderID string, limit int, offset int, username string, format ExportFormat, w io.Writer) error
+}
+
+// ExportRepository calls fn for every row while the query is still being
+// read. Access is checked before the first call.
+type ExportRepository interface {
+	StreamTenders(ctx context.Context, filter TenderListFilter, fn func(Tender) error) error
+	StreamUserTenders(ctx context.Context, limit int, offset int, username string, fn func(Tender) error) error
+	StreamUserBids(ctx context.Context, limit int, offset int, username string, fn func(Bid) error) error
+	StreamTenderBids(ctx context.Context, tenderID string, limit int, offset int, username string, fn func(Bid) error) error
+}
+
+// ImportService creates tenders from a CSV or NDJSON file. Valid rows are
+// committed together unless dryRun is set, invalid ones are reported.
+type ImportService interface {
+	ImportTenders(ctx context.Context, r io.Reader, format ImportFormat, username string, dryRun bool) (ImportReport, error)
+}
+
+type ImportRepository interface {
+	IsUserAuthorizedForOrganization(ctx context.Context, username, organizationID string) (bool, error)
+	ImportTenders(ctx context.Context, tenders []Tender) error
+}
+
+type OCDSService interface {
+	GetTenderPackage(ctx context.Context, tenderID string, packageType OCDSPackageType, uri string) ([]byte, error)
+	ListReleases(ctx context.Context, filter OCDSFilter, uri string) ([]byte, error)
+}
+
+// OCDSRepository only hands out public tenders that have been published.
+type OCDSRepository interface {
+	GetOCDSTender(ctx context.Context, tenderID string) (OCDSTender, error)
+	ListOCDSTenders(ctx context.Context, filter OCDSFilter) ([]OCDSTender, error)
+}
+
+// OCDSEncoder builds OCDS packages published at uri.
+type OCDSEncoder interface {
+	ReleasePackage(uri string, tenders []OCDSTender, since *time.Time) ([]byte, error)
+	RecordPackage(uri string, tenders []OCDSTender) ([]byte, error)
+}
+
+type AuctionService interface {
+	GetAuction(ctx context.Context, tenderID string, username string) (Auction, error)
+	ListOffers(ctx context.Context, tenderID string, limit int, offset int, username string) ([]AuctionOffer, error)
+	PlaceOffer(ctx context.Context, tenderID string, offer AuctionOffer) (AuctionOffer, error)
+	Subscribe(ctx context.Context, tenderID string, username string) (Auction, <-chan AuctionEvent, func(), error)
+}
+
+type AuctionRepository interface {
+	GetAuction(ctx context.Context, tenderID string, username string) (Auction, error)
+	ListOffers(ctx context.Context, tenderID string, limit int, offset int, username string) ([]AuctionOffer, error)
+	PlaceOffer(ctx context.Context, tenderID string, offer AuctionOffer) (AuctionOffer, Auction, error)
+}
+
+type BidService interface {
+	CreateBid(ctx context.Context, bid Bid) (Bid, error)
+	GetUserBids(ctx context.Context, limit int, offset int, username string) ([]Bid, error)
+	ListTenderBids(ctx context.Context, tenderID string, limit int, offset int, username string) ([]Bid, error)
+	CountTenderBids(ctx context.Context, tenderID string, username string) (BidCount, error)
+}
+
+type BidRepository interface {
+	CreateBid(ctx context.Context, bid Bid) (Bid, error)
+	GetUserBids(ctx context.Context, limit int, offset int, username string) ([]Bid, error)
+	ListTenderBids(ctx context.Context, tenderID string, limit int, offset int, username string) ([]Bid, error)
+	CountTenderBids(ctx context.Context, tenderID string, username string) (BidCount, error)
+}
+
+// BidSealer encrypts bid contents with per-tender keys. Keys are handed out
+// wrapped and are only usable through the sealer.
+type BidSealer interface {
+	NewKey() ([]byte, error)
+	Seal(wrappedKey []byte, plaintext []byte) ([]byte, error)
+	Open(wrappedKey []byte, ciphertext []byte) ([]byte, error)
+	// SealWriter and OpenReader encrypt and decrypt files segment by
+	// segment without holding them in memory.
+	SealWriter(wrappedKey []byte, w io.Writer) (io.WriteCloser, error)
+	OpenReader(wrappedKey []byte, r io.Reader) (io.Reader, error)
+	SealedSize(size int64) int64
+}
+
+type TenderGetter interface {
+	GetTenderByID(ctx context.Context, tenderID string) (Tender, error)
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+type MailSender interface {
+	Send(ctx context.Context, mail Mail) error
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, webhook Webhook, payload any) error
+}
+
+type NotificationService interface {
+	GetSettings(ctx context.Context, username string) (NotificationSettings, error)
+	UpdateSettings(ctx context.Context, username string, settings NotificationSettings) (NotificationSettings, error)
+	ListNotifications(ctx context.Context, username string, unreadOnly bool, limit int, offset int) ([]InAppNotification, error)
+	MarkNotificationRead(ctx context.Context, notificationID string, username string) (InAppNotification, error)
+}
+
+type NotificationRepository interface {
+	GetSettings(ctx context.Context, username string) (NotificationSettings, error)
+	UpdateSettings(ctx context.Context, username string, settings NotificationSettings) (NotificationSettings, error)
+	ListRecipients(ctx context.Context, organizationID string, event NotificationEvent) ([]Recipient, error)
+	ListClosingTenders(ctx context.Context, before time.Time) ([]Tender, error)
+	MarkClosingNotified(ctx context.Context, tenderID string) error
+	CreateNotification(ctx context.Context, notification InAppNotification) (InAppNotification, error)
+	ListNotifications(ctx context.Context, username string, unreadOnly bool, limit int, offset int) ([]InAppNotification, error)
+	MarkNotificationRead(ctx context.Context, notificationID string, username string) (InAppNotification, error)
+}
+
+type SavedSearchService interface {
+	CreateSavedSearch(ctx context.Context, search SavedSearch) (SavedSearch, error)
+	ListSavedSearches(ctx context.Context, username string) ([]SavedSearch, error)
+	SetSubscription(ctx context.Context, searchID string, subscribed bool, username string) (SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, searchID string, username string) error
+}
+
+type SavedSearchRepository interface {
+	CreateSavedSearch(ctx context.Context, search SavedSearch) (SavedSearch, error)
+	ListSavedSearches(ctx context.Context, username string) ([]SavedSearch, error)
+	SetSubscription(ctx context.Context, searchID string, subscribed bool, username string) (SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, searchID string, username string) error
+	ListMatchingSearches(ctx context.Context, tender Tender) ([]SearchMatch, error)
+}
+
+type QuestionService interface {
+	CreateQuestion(ctx context.Context, tenderID string, text string, username string) (Question, error)
+	ListQuestions(ctx context.Context, tenderID string, username string) ([]Question, error)
+	AnswerQuestion(ctx context.Context, tenderID string, questionID string, answer string, visibility QuestionVisibility, username string) (Question, error)
+}
+
+type QuestionRepository interface {
+	CreateQuestion(ctx context.Context, tenderID string, text string, username string) (Question, error)
+	ListQuestions(ctx context.Context, tenderID string, username string) ([]Question, error)
+	AnswerQuestion(ctx context.Context, tenderID string, questionID string, answer string, visibility QuestionVisibility, username string) (Question, error)
+}
+
+type FileStorage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, owner AttachmentOwner, fileName string, contentType string, r io.Reader, username string) (Attachment, error)
+	ListAttachments(ctx context.Context, owner AttachmentOwner, username string) ([]Attachment, error)
+	DownloadAttachment(ctx context.Context, owner AttachmentOwner, attachmentID string, username string) (Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, owner AttachmentOwner, attachmentID string, username string) error
+}
+
+type AttachmentRepository interface {
+	GetUploadTarget(ctx context.Context, owner AttachmentOwner, username string) (AttachmentTarget, error)
+	CreateAttachment(ctx context.Context, owner AttachmentOwner, attachment Attachment) (Attachment, error)
+	ListAttachments(ctx context.Context, owner AttachmentOwner, username string) ([]Attachment, error)
+	GetAttachment(ctx context.Context, owner AttachmentOwner, attachmentID string, username string) (Attachment, error)
+	DeleteAttachment(ctx context.Context, owner AttachmentOwner, attachmentID string, username string) (Attachment, error)
+}
+
+// GraphService loads what GraphQL queries ask for in batches keyed by ID.
+// Keys without a result are left out of the maps.
+type GraphService interface {
+	GetTenders(ctx context.Context, tenderIDs []string, username string) (map[string]Tender, error)
+	GetTenderVersions(ctx context.Context, tenderIDs []string) (map[string][]TenderVersion, error)
+	GetTenderBids(ctx context.Context, tenderIDs []string, username string) (map[string][]Bid, error)
+	GetOrganizations(ctx context.Context, organizationIDs []string) (map[string]Organization, error)
+	GetEmployees(ctx context.Context, usernames []string) (map[string]Employee, error)
+	GetResponsibleOrganizations(ctx context.Context, usernames []string) (map[string][]Organization, error)
+}
+
+type GraphRepository interface {
+	GetTenders(ctx context.Context, tenderIDs []string, username string) (map[string]Tender, error)
+	GetTenderVersions(ctx context.Context, tenderIDs []string) (map[string][]TenderVersion, error)
+	GetTenderBids(ctx context.Context, tenderIDs []string) (map[string][]Bid, error)
+	GetOrganizations(ctx context.Context, organizationIDs []string) (map[string]Organization, error)
+	GetEmployees(ctx context.Context, usernames []string) (map[string]Employee, error)
+	GetResponsibleOrganizations(ctx context.Context, usernames []string) (map[string][]Organization, error)
 }
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+type AttachmentOwnerType string
+
+const (
+	AttachmentOwnerTender AttachmentOwnerType = "tender"
+	AttachmentOwnerBid    AttachmentOwnerType = "bid"
+)
+
+type AttachmentOwner struct {
+	Type AttachmentOwnerType
+	ID   string
+}
+
+// AttachmentTarget is what an upload needs to know about the owner. SealKey is
+// set for bids on sealed tenders, whose files are encrypted under it.
+type AttachmentTarget struct {
+	Version int
+	SealKey []byte
+}
+
+// Attachment is a file uploaded to a tender or a bid. Version is the version
+// of the owner at the moment the file was added. Size and SHA256 describe the
+// file as uploaded, also when it is stored sealed.
+type Attachment struct {
+	ID          string    `json:"id"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	Version     int       `json:"version"`
+	StorageKey  string    `json:"-"`
+	Owner       string    `json:"-"`
+	OwnerType   string    `json:"-"`
+	UploadedBy  string    `json:"uploadedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Sealed      bool      `json:"-"`
+	SealKey     []byte    `json:"-"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+type TenderType string
+
+const (
+	TenderTypeStandard TenderType = "STANDARD"
+	TenderTypeAuction  TenderType = "AUCTION"
+)
+
+// Auction holds the rules and the current state of a reverse auction. Every
+// offer must undercut the best price by at least MinDecrement; an offer
+// placed within ExtensionSeconds of the end pushes the end out to
+// ExtensionSeconds from the offer.
+type Auction struct {
+	TenderID         string    `json:"tenderId"`
+	StartsAt         time.Time `json:"startsAt"`
+	EndsAt           time.Time `json:"endsAt"`
+	StartPrice       float64   `json:"startPrice"`
+	MinDecrement     float64   `json:"minDecrement"`
+	ExtensionSeconds int       `json:"extensionSeconds"`
+	BestPrice        *float64  `json:"bestPrice,omitempty"`
+	OfferCount       int       `json:"offerCount"`
+	Running          bool      `json:"running"`
+}
+
+// AuctionOffer is a price offer in an auction. Seq orders the offers of a
+// tender in the order they were accepted.
+type AuctionOffer struct {
+	ID              string    `json:"id,omitempty"`
+	TenderID        string    `json:"tenderId"`
+	Seq             int       `json:"seq"`
+	OrganizationID  string    `json:"organizationId,omitempty"`
+	CreatorUsername string    `json:"creatorUsername,omitempty"`
+	Price           float64   `json:"price"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+type PlaceOfferRequest struct {
+	OrganizationID string  `json:"organizationId"`
+	Price          float64 `json:"price"`
+}
+
+type AuctionEventType string
+
+const (
+	AuctionEventState AuctionEventType = "state"
+	AuctionEventOffer AuctionEventType = "offer"
+)
+
+// AuctionEvent is pushed to auction watchers. Offers in events do not
+// identify the bidder.
+type AuctionEvent struct {
+	Type    AuctionEventType `json:"type"`
+	Auction Auction          `json:"auction"`
+	Offer   *AuctionOffer    `json:"offer,omitempty"`
+}
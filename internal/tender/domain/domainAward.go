@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// Award records the outcome of a tender: one award per lot, or a single one
+// for a tender without lots. Awards are created when the decision closes the
+// tender.
+type Award struct {
+	ID             string    `json:"id"`
+	TenderID       string    `json:"tenderId"`
+	LotID          *string   `json:"lotId,omitempty"`
+	BidID          *string   `json:"bidId,omitempty"`
+	OfferID        *string   `json:"offerId,omitempty"`
+	OrganizationID string    `json:"organizationId"`
+	Amount         *float64  `json:"amount,omitempty"`
+	DecidedAt      time.Time `json:"decidedAt"`
+	DecisionMakers []string  `json:"decisionMakers"`
+
+	// Bid is the winning bid, loaded so the service can fill in the amount of
+	// sealed bids.
+	Bid *Bid `json:"-"`
+}
+
+type AwardTenderRequest struct {
+	BidID string `json:"bidId"`
+}
+
+type ContractFormat string
+
+const (
+	ContractFormatMarkdown ContractFormat = "markdown"
+	ContractFormatPDF      ContractFormat = "pdf"
+)
+
+// ContractData is what contract templates are executed with.
+type ContractData struct {
+	Tender      Tender
+	Buyer       Organization
+	Awards      []ContractAward
+	GeneratedAt time.Time
+}
+
+type ContractAward struct {
+	Award
+	Supplier Organization
+	Lot      *Lot
+	BidName  string
+}
+
+// Total sums the awarded amounts. It is nil if any amount is unknown.
+func (d ContractData) Total() *float64 {
+	var total float64
+	for _, award := range d.Awards {
+		if award.Amount == nil {
+			return nil
+		}
+		total += *award.Amount
+	}
+
+	return &total
+}
+
+// Suppliers returns the winning organizations, each once.
+func (d ContractData) Suppliers() []Organization {
+	seen := map[string]bool{}
+	suppliers := []Organization{}
+	for _, award := range d.Awards {
+		if seen[award.Supplier.ID] {
+			continue
+		}
+		seen[award.Supplier.ID] = true
+		suppliers = append(suppliers, award.Supplier)
+	}
+
+	return suppliers
+}
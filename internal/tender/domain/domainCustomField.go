@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeString CustomFieldType = "STRING"
+	CustomFieldTypeNumber CustomFieldType = "NUMBER"
+	CustomFieldTypeDate   CustomFieldType = "DATE"
+	CustomFieldTypeEnum   CustomFieldType = "ENUM"
+)
+
+// CustomFieldDateLayout is the format of DATE custom field values.
+const CustomFieldDateLayout = "2006-01-02"
+
+// CustomField defines a field the tenders of an organization carry in their
+// customFields under Key. ENUM values are limited to Options.
+type CustomField struct {
+	ID             string          `json:"id"`
+	OrganizationID string          `json:"organizationId"`
+	Key            string          `json:"key"`
+	Name           string          `json:"name"`
+	Type           CustomFieldType `json:"type"`
+	Options        []string        `json:"options,omitempty"`
+	Required       bool            `json:"required"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+type CreateCustomFieldRequest struct {
+	Key      string          `json:"key"`
+	Name     string          `json:"name"`
+	Type     CustomFieldType `json:"type"`
+	Options  []string        `json:"options,omitempty"`
+	Required bool            `json:"required"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+type CreateOrganizationRequest struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Type        OrganizationType `json:"type"`
+}
+
+// OrganizationUpdate changes the fields that are set.
+type OrganizationUpdate struct {
+	Name        *string           `json:"name,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	Type        *OrganizationType `json:"type,omitempty"`
+}
+
+// CreateEmployeeRequest adds an employee. The username can not be changed
+// later, tenders and bids refer to their authors by it.
+type CreateEmployeeRequest struct {
+	Username  string `json:"username"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// EmployeeUpdate changes the fields that are set.
+type EmployeeUpdate struct {
+	FirstName *string `json:"firstName,omitempty"`
+	LastName  *string `json:"lastName,omitempty"`
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// MaxScore is the upper bound of a score given to a bid per criterion.
+const MaxScore = 10
+
+// Criterion is a weighted evaluation criterion of a tender, e.g. price or
+// delivery time.
+type Criterion struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+}
+
+type CriterionScore struct {
+	CriterionID string  `json:"criterionId"`
+	Score       float64 `json:"score"`
+}
+
+// BidScore is the score one evaluator gave a bid for one criterion.
+type BidScore struct {
+	BidID       string    `json:"bidId"`
+	CriterionID string    `json:"criterionId"`
+	Evaluator   string    `json:"evaluator"`
+	Score       float64   `json:"score"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type ScoreBidRequest struct {
+	Scores []CriterionScore `json:"scores"`
+}
+
+// EvaluationSheet is the raw material of an evaluation: the criteria, the
+// bids and every score given so far.
+type EvaluationSheet struct {
+	TenderID     string
+	TenderStatus string
+	Criteria     []Criterion
+	Bids         []Bid
+	Scores       []BidScore
+}
+
+// Evaluation is the ranked scoring matrix of a tender. Scores are averaged
+// over evaluators per criterion and weighted into a total on the MaxScore
+// scale.
+type Evaluation struct {
+	TenderID string          `json:"tenderId"`
+	Locked   bool            `json:"locked"`
+	Criteria []Criterion     `json:"criteria"`
+	Bids     []BidEvaluation `json:"bids"`
+}
+
+type BidEvaluation struct {
+	Rank           int                  `json:"rank"`
+	BidID          string               `json:"bidId"`
+	BidName        string               `json:"bidName"`
+	OrganizationID string               `json:"organizationId"`
+	Scores         map[string]float64   `json:"scores"`
+	WeightedTotal  float64              `json:"weightedTotal"`
+	Evaluators     []EvaluatorBreakdown `json:"evaluators"`
+}
+
+type EvaluatorBreakdown struct {
+	Evaluator     string             `json:"evaluator"`
+	Scores        map[string]float64 `json:"scores"`
+	WeightedTotal float64            `json:"weightedTotal"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Invitation allows the responsibles of an organization to see a private
+// tender and to bid on it.
+type Invitation struct {
+	TenderID       string    `json:"tenderId"`
+	OrganizationID string    `json:"organizationId"`
+	InvitedBy      string    `json:"invitedBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type CreateInvitationRequest struct {
+	OrganizationID string `json:"organizationId"`
+}
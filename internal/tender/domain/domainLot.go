@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Lot is a separately awarded part of a tender. Lots are versioned together
+// with the tender, the award decision is not.
+type Lot struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Quantity     float64    `json:"quantity"`
+	Unit         string     `json:"unit"`
+	Budget       *float64   `json:"budget,omitempty"`
+	AwardedBidID *string    `json:"awardedBidId,omitempty"`
+	AwardedAt    *time.Time `json:"awardedAt,omitempty"`
+}
+
+// SameLots reports whether a and b describe the same lots in the same order.
+// The award decision is left out, it is not versioned.
+func SameLots(a, b []Lot) bool {
+	return slices.EqualFunc(a, b, func(x, y Lot) bool {
+		return x.ID == y.ID && x.Name == y.Name && x.Description == y.Description &&
+			x.Quantity == y.Quantity && x.Unit == y.Unit &&
+			(x.Budget == nil) == (y.Budget == nil) && (x.Budget == nil || *x.Budget == *y.Budget)
+	})
+}
+
+// BidLot is a lot targeted by a bid with the price offered for it.
+type BidLot struct {
+	LotID string   `json:"lotId"`
+	Price *float64 `json:"price,omitempty"`
+}
+
+type AwardLotRequest struct {
+	BidID string `json:"bidId"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+type NotificationEvent string
+
+const (
+	NotificationBidCreated      NotificationEvent = "bid_created"
+	NotificationTenderClosing   NotificationEvent = "tender_closing"
+	NotificationTenderPublished NotificationEvent = "tender_published"
+)
+
+const (
+	LocaleRU = "ru"
+	LocaleEN = "en"
+)
+
+type Notification struct {
+	Event  NotificationEvent
+	Tender Tender
+	Bid    *Bid
+}
+
+type NotificationSettings struct {
+	Email           string `json:"email"`
+	Locale          string `json:"locale"`
+	NotifyOnBid     bool   `json:"notifyOnBid"`
+	NotifyOnClosing bool   `json:"notifyOnClosing"`
+}
+
+type Recipient struct {
+	Username  string
+	FirstName string
+	LastName  string
+	Email     string
+	Locale    string
+}
+
+type Mail struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// InAppNotification is a notification stored for an employee and shown by
+// GET /api/notifications until it is marked as read.
+type InAppNotification struct {
+	ID            string     `json:"id"`
+	Username      string     `json:"-"`
+	Event         string     `json:"event"`
+	TenderID      string     `json:"tenderId,omitempty"`
+	SavedSearchID string     `json:"savedSearchId,omitempty"`
+	Title         string     `json:"title"`
+	Message       string     `json:"message"`
+	Read          bool       `json:"read"`
+	ReadAt        *time.Time `json:"readAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+type Webhook struct {
+	URL    string
+	Secret string
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type OCDSPackageType string
+
+const (
+	OCDSPackageRelease OCDSPackageType = "release"
+	OCDSPackageRecord  OCDSPackageType = "record"
+)
+
+// OCDSTender is everything published about a tender in OCDS: its current
+// state, the stored versions, the bids once the tender is closed and the
+// award decisions. Status changes do not create versions, StatusChangedAt
+// dates the current status. Organizations holds the buyer and every bidding
+// or winning organization by ID.
+type OCDSTender struct {
+	Tender            Tender
+	StatusChangedAt   time.Time
+	ServiceTypeScheme ServiceTypeScheme
+	Versions          []TenderVersion
+	Bids              []Bid
+	Awards            []Award
+	Organizations     map[string]Organization
+}
+
+// OCDSFilter pages through the published tenders. With Since set only
+// tenders changed at or after it are returned.
+type OCDSFilter struct {
+	Limit  int
+	Offset int
+	Since  *time.Time
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+type QuestionVisibility string
+
+const (
+	QuestionVisibilityPublic  QuestionVisibility = "PUBLIC"
+	QuestionVisibilityPrivate QuestionVisibility = "PRIVATE"
+)
+
+// Question is a clarification request on a published tender. The answer is
+// visible either to every employee (PUBLIC) or only to the author of the
+// question and the responsibles of the tender organization (PRIVATE).
+type Question struct {
+	ID             string             `json:"id"`
+	TenderID       string             `json:"tenderId"`
+	AuthorUsername string             `json:"authorUsername"`
+	Text           string             `json:"question"`
+	Answer         *string            `json:"answer,omitempty"`
+	AnsweredBy     *string            `json:"answeredBy,omitempty"`
+	AnsweredAt     *time.Time         `json:"answeredAt,omitempty"`
+	Visibility     QuestionVisibility `json:"visibility"`
+	CreatedAt      time.Time          `json:"createdAt"`
+}
+
+type CreateQuestionRequest struct {
+	Question string `json:"question"`
+}
+
+type AnswerQuestionRequest struct {
+	Answer string `json:"answer"`
+	Public bool   `json:"public"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+type SavedSearch struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Username       string    `json:"username"`
+	ServiceTypes   []string  `json:"serviceTypes"`
+	Keywords       []string  `json:"keywords"`
+	BudgetMin      *float64  `json:"budgetMin,omitempty"`
+	BudgetMax      *float64  `json:"budgetMax,omitempty"`
+	OrganizationId *string   `json:"organizationId,omitempty"`
+	Subscribed     bool      `json:"subscribed"`
+	WebhookURL     string    `json:"webhookUrl,omitempty"`
+	WebhookSecret  string    `json:"-"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type CreateSavedSearchRequest struct {
+	Name           string   `json:"name"`
+	ServiceTypes   []string `json:"serviceTypes"`
+	Keywords       []string `json:"keywords"`
+	BudgetMin      *float64 `json:"budgetMin,omitempty"`
+	BudgetMax      *float64 `json:"budgetMax,omitempty"`
+	OrganizationId *string  `json:"organizationId,omitempty"`
+	Subscribed     *bool    `json:"subscribed,omitempty"`
+	WebhookURL     string   `json:"webhookUrl,omitempty"`
+	WebhookSecret  string   `json:"webhookSecret,omitempty"`
+}
+
+// SearchMatch is a subscribed saved search that matched a newly published
+// tender, together with the data needed to notify its owner.
+type SearchMatch struct {
+	Search SavedSearch
+	Locale string
+}
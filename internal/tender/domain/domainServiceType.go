@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+type ServiceTypeScheme string
+
+const (
+	ServiceTypeSchemeCustom ServiceTypeScheme = "CUSTOM"
+	ServiceTypeSchemeCPV    ServiceTypeScheme = "CPV"
+	ServiceTypeSchemeOKPD2  ServiceTypeScheme = "OKPD2"
+)
+
+// ServiceType is an entry of the service type catalog. Entries form a tree
+// through ParentCode, the code is what tenders store as their service type.
+// Inactive entries stay valid for existing tenders but not for new ones.
+type ServiceType struct {
+	Code       string            `json:"code"`
+	ParentCode *string           `json:"parentCode,omitempty"`
+	Scheme     ServiceTypeScheme `json:"scheme"`
+	Names      map[string]string `json:"names"`
+	Name       string            `json:"name"`
+	Active     bool              `json:"active"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+}
+
+// Localize sets Name to the name in the locale, falling back to Russian and
+// then to the code.
+func (t *ServiceType) Localize(locale string) {
+	switch {
+	case t.Names[locale] != "":
+		t.Name = t.Names[locale]
+	case t.Names["ru"] != "":
+		t.Name = t.Names["ru"]
+	default:
+		t.Name = t.Code
+	}
+}
+
+// ServiceTypeFilter narrows the catalog to the children of Parent, or to the
+// top level when Parent is empty and All is not set.
+type ServiceTypeFilter struct {
+	Parent          string
+	All             bool
+	IncludeInactive bool
+	Locale          string
+}
+
+// ServiceTypeUpdate changes the fields that are set. An empty ParentCode
+// moves the entry to the top level, a new Code renames it everywhere it is
+// used.
+type ServiceTypeUpdate struct {
+	Code       *string           `json:"code,omitempty"`
+	ParentCode *string           `json:"parentCode,omitempty"`
+	Names      map[string]string `json:"names,omitempty"`
+	Active     *bool             `json:"active,omitempty"`
+}
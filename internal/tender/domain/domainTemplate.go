@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// TenderTemplate is a set of tender fields an organization saved for reuse.
+// A tender is created from it by passing templateId on creation, the fields
+// given in the request override the ones of the template.
+type TenderTemplate struct {
+	ID              string         `json:"id"`
+	OrganizationID  string         `json:"organizationId"`
+	Name            string         `json:"name"`
+	Tender          TemplateTender `json:"tender"`
+	CreatorUsername string         `json:"creatorUsername"`
+	CreatedAt       time.Time      `json:"createdAt"`
+}
+
+// TemplateTender holds the tender fields a template presets. Deadlines and
+// auction schedules are left out, they never carry over to the next tender.
+type TemplateTender struct {
+	Name         string           `json:"name,omitempty"`
+	Description  string           `json:"description,omitempty"`
+	ServiceType  string           `json:"serviceType,omitempty"`
+	Visibility   TenderVisibility `json:"visibility,omitempty"`
+	Sealed       bool             `json:"sealed,omitempty"`
+	Budget       *float64         `json:"budget,omitempty"`
+	Lots         []Lot            `json:"lots,omitempty"`
+	Criteria     []Criterion      `json:"criteria,omitempty"`
+	Tags         []string         `json:"tags,omitempty"`
+	CustomFields map[string]any   `json:"customFields,omitempty"`
+}
+
+// CreateTemplateRequest saves either the given fields or, with TenderID, the
+// fields of an existing tender of the organization.
+type CreateTemplateRequest struct {
+	Name     string         `json:"name"`
+	TenderID string         `json:"tenderId,omitempty"`
+	Tender   TemplateTender `json:"tender"`
+}
+
+// TemplateFromTender takes the reusable fields of a tender. Lot and
+// criterion identities and award decisions stay with the original.
+func TemplateFromTender(tender Tender) TemplateTender {
+	template := TemplateTender{
+		Name:         tender.Name,
+		Description:  tender.Description,
+		ServiceType:  tender.ServiceType,
+		Visibility:   tender.Visibility,
+		Budget:       tender.Budget,
+		Tags:         tender.Tags,
+		CustomFields: tender.CustomFields,
+	}
+
+	for _, lot := range tender.Lots {
+		lot.ID, lot.AwardedBidID, lot.AwardedAt = "", nil, nil
+		template.Lots = append(template.Lots, lot)
+	}
+
+	for _, criterion := range tender.Criteria {
+		criterion.ID = ""
+		template.Criteria = append(template.Criteria, criterion)
+	}
+
+	return template
+}
+
+// Request turns the template into a creation request of the organization,
+// to be overlaid with the fields of the actual request. The request gets
+// copies of the lists, maps and pointers, decoding over it leaves the
+// template as it was.
+func (t TemplateTender) Request(organizationID string) CreateTenderRequest {
+	req := CreateTenderRequest{
+		Name:           t.Name,
+		Description:    t.Description,
+		ServiceType:    t.ServiceType,
+		OrganizationId: organizationID,
+		Visibility:     t.Visibility,
+		Sealed:         t.Sealed,
+		Criteria:       slices.Clone(t.Criteria),
+		Tags:           slices.Clone(t.Tags),
+		CustomFields:   maps.Clone(t.CustomFields),
+	}
+
+	if t.Budget != nil {
+		budget := *t.Budget
+		req.Budget = &budget
+	}
+
+	for _, lot := range t.Lots {
+		if lot.Budget != nil {
+			budget := *lot.Budget
+			lot.Budget = &budget
+		}
+		req.Lots = append(req.Lots, lot)
+	}
+
+	return req
+}
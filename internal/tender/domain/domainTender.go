@@ -7,10 +7,10 @@ import (
 type Employee struct {
 	ID        string    `json:"id" db:"id"`
 	Username  string    `json:"username" db:"username"`
-	FirstName string    `json:"first_name" db:"first_name"`
-	LastName  string    `json:"last_name" db:"last_name"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	FirstName string    `json:"firstName" db:"first_name"`
+	LastName  string    `json:"lastName" db:"last_name"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
 }
 
 type OrganizationType string
@@ -26,8 +26,8 @@ type Organization struct {
 	Name        string           `json:"name" db:"name"`
 	Description string           `json:"description" db:"description"`
 	Type        OrganizationType `json:"type" db:"type"`
-	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time        `json:"updated_at" db:"updated_at"`
+	CreatedAt   time.Time        `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time        `json:"updatedAt" db:"updated_at"`
 }
 
 type OrganizationResponsible struct {
@@ -36,54 +36,168 @@ type OrganizationResponsible struct {
 	UserID         string `json:"user_id" db:"user_id"`
 }
 
+type TenderVisibility string
+
+const (
+	TenderVisibilityPublic  TenderVisibility = "PUBLIC"
+	TenderVisibilityPrivate TenderVisibility = "PRIVATE"
+)
+
 type Tender struct {
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	Status          string           `json:"status"`
+	ServiceType     string           `json:"serviceType"`
+	OrganizationId  string           `json:"organizationId"`
+	CreatorUsername string           `json:"creatorUsername"`
+	Version         int              `json:"version"`
+	Type            TenderType       `json:"type"`
+	Visibility      TenderVisibility `json:"visibility"`
+	Sealed          bool             `json:"sealed"`
+	SealKey         []byte           `json:"-"`
+	Auction         *Auction         `json:"auction,omitempty"`
+	Budget          *float64         `json:"budget,omitempty"`
+	ClosesAt        *time.Time       `json:"closesAt,omitempty"`
+	CreatedAt       time.Time        `json:"createdAt"`
+	Lots            []Lot            `json:"lots,omitempty"`
+	Criteria        []Criterion      `json:"criteria,omitempty"`
+	Tags            []string         `json:"tags,omitempty"`
+	CustomFields    map[string]any   `json:"customFields,omitempty"`
+	Attachments     []Attachment     `json:"attachments,omitempty"`
+}
+
+// BidsRevealed reports whether bid contents may be read. Bids on a sealed
+// tender stay encrypted until its deadline passes or it is closed.
+func (t Tender) BidsRevealed(now time.Time) bool {
+	return !t.Sealed || t.Status == "CLOSED" || (t.ClosesAt != nil && !now.Before(*t.ClosesAt))
+}
+
+type CreateTenderRequest struct {
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	ServiceType     string           `json:"serviceType"`
+	OrganizationId  string           `json:"organizationId"`
+	CreatorUsername string           `json:"creatorUsername"`
+	Type            TenderType       `json:"type,omitempty"`
+	Visibility      TenderVisibility `json:"visibility,omitempty"`
+	Sealed          bool             `json:"sealed,omitempty"`
+	Auction         *Auction         `json:"auction,omitempty"`
+	Budget          *float64         `json:"budget,omitempty"`
+	ClosesAt        *time.Time       `json:"closesAt,omitempty"`
+	Lots            []Lot            `json:"lots,omitempty"`
+	Criteria        []Criterion      `json:"criteria,omitempty"`
+	Tags            []string         `json:"tags,omitempty"`
+	CustomFields    map[string]any   `json:"customFields,omitempty"`
+	TemplateID      string           `json:"templateId,omitempty"`
+}
+
+type TenderResponse struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	Status       string           `json:"status"`
+	ServiceType  string           `json:"serviceType"`
+	Version      int              `json:"version"`
+	Type         TenderType       `json:"type"`
+	Visibility   TenderVisibility `json:"visibility"`
+	Sealed       bool             `json:"sealed"`
+	Auction      *Auction         `json:"auction,omitempty"`
+	Budget       *float64         `json:"budget,omitempty"`
+	ClosesAt     *time.Time       `json:"closesAt,omitempty"`
+	CreatedAt    time.Time        `json:"createdAt"`
+	Lots         []Lot            `json:"lots,omitempty"`
+	Criteria     []Criterion      `json:"criteria,omitempty"`
+	Tags         []string         `json:"tags,omitempty"`
+	CustomFields map[string]any   `json:"customFields,omitempty"`
+	Attachments  []Attachment     `json:"attachments,omitempty"`
+}
+
+// TenderListFilter narrows the tender list. ServiceTypes match their
+// subcategories too, a tender has to carry all Tags and CustomFields values
+// in their text form and contain Text in its name or description, ignoring
+// case. Private tenders are listed only when Username is responsible for the
+// owning or an invited organization.
+type TenderListFilter struct {
+	Limit        int
+	Offset       int
+	ServiceTypes []string
+	Tags         []string
+	CustomFields map[string]string
+	Text         string
+	Username     string
+}
+
+type TenderVersion struct {
+	ID              int            `json:"id"`
+	TenderID        string         `json:"tender_id"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description"`
+	ServiceType     string         `json:"serviceType"`
+	Status          string         `json:"status"`
+	OrganizationId  string         `json:"organizationId"`
+	CreatorUsername string         `json:"creatorUsername"`
+	Version         int            `json:"version"`
+	Budget          *float64       `json:"budget,omitempty"`
+	Lots            []Lot          `json:"lots"`
+	Tags            []string       `json:"tags"`
+	CustomFields    map[string]any `json:"customFields"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	// Visibility, InvitedOrganizations and Criteria are empty for versions
+	// saved before they were recorded.
+	Visibility           TenderVisibility `json:"visibility,omitempty"`
+	InvitedOrganizations []string         `json:"-"`
+	Criteria             []Criterion      `json:"criteria,omitempty"`
+}
+
+type Bid struct {
 	ID              string    `json:"id"`
 	Name            string    `json:"name"`
 	Description     string    `json:"description"`
 	Status          string    `json:"status"`
-	ServiceType     string    `json:"serviceType"`
+	TenderId        string    `json:"tenderId"`
 	OrganizationId  string    `json:"organizationId"`
 	CreatorUsername string    `json:"creatorUsername"`
 	Version         int       `json:"version"`
+	Price           *float64  `json:"price,omitempty"`
+	Sealed          bool      `json:"sealed,omitempty"`
+	SealedPayload   []byte    `json:"-"`
 	CreatedAt       time.Time `json:"createdAt"`
-}
-type CreateTenderRequest struct {
-	Name            string `json:"name"`
-	Description     string `json:"description"`
-	ServiceType     string `json:"serviceType"`
-	OrganizationId  string `json:"organizationId"`
-	CreatorUsername string `json:"creatorUsername"`
+	Lots            []BidLot  `json:"lots,omitempty"`
 }
 
-type TenderResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	ServiceType string    `json:"serviceType"`
-	Version     int       `json:"version"`
-	CreatedAt   time.Time `json:"createdAt"`
+type CreateBidRequest struct {
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	TenderId        string   `json:"tenderId"`
+	OrganizationId  string   `json:"organizationId"`
+	CreatorUsername string   `json:"creatorUsername"`
+	Price           *float64 `json:"price,omitempty"`
+	Lots            []BidLot `json:"lots,omitempty"`
 }
 
-type TenderVersion struct {
-	ID              int    `json:"id"`
-	TenderID        string `json:"tender_id"`
-	Name            string `json:"name"`
-	Description     string `json:"description"`
-	ServiceType     string `json:"serviceType"`
-	Status          string `json:"status"`
-	OrganizationId  string `json:"organizationId"`
-	CreatorUsername string `json:"creatorUsername"`
-	Version         int    `json:"version"`
+type BidResponse struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Status         string    `json:"status"`
+	TenderId       string    `json:"tenderId"`
+	OrganizationId string    `json:"organizationId"`
+	Version        int       `json:"version"`
+	Price          *float64  `json:"price,omitempty"`
+	Sealed         bool      `json:"sealed,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	Lots           []BidLot  `json:"lots,omitempty"`
 }
 
-type Bid struct {
-	Name            string `json:"name"`
-	Description     string `json:"description"`
-	Status          string `json:"status"`
-	TenderId        int    `json:"tenderId"`
-	OrganizationId  int    `json:"organizationId"`
-	CreatorUsername string `json:"creatorUsername"`
+// BidCount is all the tender owner learns about the bids on a sealed tender
+// before they are revealed.
+type BidCount struct {
+	TenderID string     `json:"tenderId"`
+	Count    int        `json:"count"`
+	Sealed   bool       `json:"sealed"`
+	Revealed bool       `json:"revealed"`
+	RevealAt *time.Time `json:"revealAt,omitempty"`
 }
 
 type TenderStatusUpdate struct {
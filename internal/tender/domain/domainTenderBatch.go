@@ -0,0 +1,36 @@
+package domain
+
+// BatchStatusMode decides what happens to a batch of status changes when
+// some of them fail: atomic batches apply all changes or none, best effort
+// ones apply every change that passes its checks.
+type BatchStatusMode string
+
+const (
+	BatchStatusAtomic     BatchStatusMode = "atomic"
+	BatchStatusBestEffort BatchStatusMode = "bestEffort"
+)
+
+type BatchStatusRequest struct {
+	TenderIDs []string        `json:"tenderIds"`
+	Status    string          `json:"status"`
+	Mode      BatchStatusMode `json:"mode,omitempty"`
+}
+
+// TenderStatusChange is the outcome for one tender of a batch. Applied is
+// false for tenders that already had the status.
+type TenderStatusChange struct {
+	TenderID       string `json:"tenderId"`
+	PreviousStatus string `json:"previousStatus,omitempty"`
+	Status         string `json:"status,omitempty"`
+	Applied        bool   `json:"applied"`
+	Error          string `json:"error,omitempty"`
+	Err            error  `json:"-"`
+}
+
+type BatchStatusResult struct {
+	Status  string               `json:"status"`
+	Mode    BatchStatusMode      `json:"mode"`
+	Applied int                  `json:"applied"`
+	Failed  int                  `json:"failed"`
+	Results []TenderStatusChange `json:"results"`
+}
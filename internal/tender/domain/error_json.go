@@ -1,5 +1,14 @@
 package domain
 
 type JSONError struct {
-	Err string `json:"error"`
-}
\ No newline at end of file
+	Err     string       `json:"error"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError points at the parameter or body field a request was rejected
+// for. Field is a parameter name or a path such as lots[0].name.
+type FieldError struct {
+	In    string `json:"in"`
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
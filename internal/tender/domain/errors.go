@@ -0,0 +1,20 @@
+package domain
+
+import "errors"
+
+var (
+	ErrUserNotFound      = errors.New("user does not exist")
+	ErrUserNotAuthorized = errors.New("user is not authorized for this organization")
+	ErrTenderNotFound    = errors.New("tender not found")
+	ErrTenderNotOpen     = errors.New("tender is not published")
+	ErrTenderClosed      = errors.New("tender is closed")
+	ErrBidNotFound       = errors.New("bid not found")
+	ErrNotFound          = errors.New("not found")
+	ErrInvalidInput      = errors.New("invalid input")
+	ErrConflict          = errors.New("conflict")
+	ErrTooLarge          = errors.New("payload is too large")
+	ErrUnsupportedType   = errors.New("unsupported media type")
+	ErrBidsSealed        = errors.New("bids are sealed until the tender deadline")
+	ErrAuctionNotRunning = errors.New("auction is not running")
+	ErrOfferTooHigh      = errors.New("offer does not undercut the best price by the minimum decrement")
+)
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+func writeTable(t *testing.T, format domain.ExportFormat) []byte {
+	t.Helper()
+
+	budget := 1500.5
+	created := time.Date(2024, 9, 1, 9, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf, "Tenders & bids")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][]any{
+		{"id", "name", "budget", "closesAt", "createdAt", "version"},
+		{"tender-1", `Серверы, "монтаж" <срочно>`, &budget, (*time.Time)(nil), created, 2},
+	} {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	records, err := csv.NewReader(bytes.NewReader(writeTable(t, domain.ExportFormatCSV))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"tender-1", `Серверы, "монтаж" <срочно>`, "1500.5", "", "2024-09-01T06:00:00Z", "2"}
+	if len(records) != 2 {
+		t.Fatalf("%d records, want 2", len(records))
+	}
+	for i := range want {
+		if records[1][i] != want[i] {
+			t.Errorf("cell %d = %q, want %q", i, records[1][i], want[i])
+		}
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	data := writeTable(t, domain.ExportFormatXLSX)
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := map[string][]byte{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[f.Name], _ = io.ReadAll(r)
+		r.Close()
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/workbook.xml", "xl/worksheets/sheet1.xml"} {
+		if parts[name] == nil {
+			t.Fatalf("missing part %s", name)
+		}
+		if err := xml.Unmarshal(parts[name], new(struct{})); err != nil {
+			t.Fatalf("%s is not well-formed: %v", name, err)
+		}
+	}
+
+	var sheet struct {
+		Rows []struct {
+			Cells []struct {
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				String string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sheet.Rows) != 2 || len(sheet.Rows[1].Cells) != 6 {
+		t.Fatalf("sheet = %+v, want 2 rows of 6 cells", sheet.Rows)
+	}
+	cells := sheet.Rows[1].Cells
+	if cells[1].Type != "inlineStr" || cells[1].String != `Серверы, "монтаж" <срочно>` {
+		t.Errorf("name cell = %+v", cells[1])
+	}
+	if cells[2].Type != "" || cells[2].Value != "1500.5" {
+		t.Errorf("budget cell = %+v, want a number", cells[2])
+	}
+	if cells[3].Value != "" || cells[3].String != "" {
+		t.Errorf("closesAt cell = %+v, want empty", cells[3])
+	}
+}
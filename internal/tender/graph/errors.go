@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// presentError adds the code of service errors to the error extensions,
+// the way statusFromError turns them into HTTP status codes.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) && gqlErr.Err == nil {
+		return presented
+	}
+
+	code := codeFromError(err)
+	if code == "INTERNAL_SERVER_ERROR" {
+		logger.Logger().Errorln("GraphQL error at", presented.Path.String()+":", err.Error())
+	}
+
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]any{}
+	}
+	presented.Extensions["code"] = code
+
+	return presented
+}
+
+func codeFromError(err error) string {
+	switch {
+	case errors.Is(err, errMissingUsername), errors.Is(err, domain.ErrUserNotFound):
+		return "UNAUTHENTICATED"
+	case errors.Is(err, domain.ErrUserNotAuthorized), errors.Is(err, domain.ErrBidsSealed):
+		return "FORBIDDEN"
+	case errors.Is(err, domain.ErrTenderNotFound), errors.Is(err, domain.ErrBidNotFound), errors.Is(err, domain.ErrNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, domain.ErrInvalidInput), errors.Is(err, domain.ErrTenderNotOpen):
+		return "BAD_USER_INPUT"
+	case errors.Is(err, domain.ErrTenderClosed), errors.Is(err, domain.ErrConflict):
+		return "CONFLICT"
+	default:
+		return "INTERNAL_SERVER_ERROR"
+	}
+}
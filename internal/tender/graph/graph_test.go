@@ -0,0 +1,215 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type stubTenders struct {
+	domain.TenderService
+	tenders []domain.Tender
+}
+
+func (s stubTenders) ListTender(ctx context.Context, filter domain.TenderListFilter) ([]domain.Tender, error) {
+	return s.tenders[:min(filter.Limit, len(s.tenders))], nil
+}
+
+// stubGraph serves a fixed data set and records the batches it is asked for.
+type stubGraph struct {
+	mu      sync.Mutex
+	batches map[string][][]string
+
+	tenders []domain.Tender
+}
+
+func (s *stubGraph) record(name string, keys []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches[name] = append(s.batches[name], keys)
+}
+
+func (s *stubGraph) GetTenders(ctx context.Context, tenderIDs []string, username string) (map[string]domain.Tender, error) {
+	s.record("tenders", tenderIDs)
+	tenders := map[string]domain.Tender{}
+	for _, tender := range s.tenders {
+		tenders[tender.ID] = tender
+	}
+	return tenders, nil
+}
+
+func (s *stubGraph) GetTenderVersions(ctx context.Context, tenderIDs []string) (map[string][]domain.TenderVersion, error) {
+	s.record("versions", tenderIDs)
+	versions := map[string][]domain.TenderVersion{}
+	for _, id := range tenderIDs {
+		versions[id] = []domain.TenderVersion{{TenderID: id, Version: 1}}
+	}
+	return versions, nil
+}
+
+func (s *stubGraph) GetTenderBids(ctx context.Context, tenderIDs []string, username string) (map[string][]domain.Bid, error) {
+	s.record("bids", tenderIDs)
+	bids := map[string][]domain.Bid{}
+	for _, id := range tenderIDs {
+		bids[id] = []domain.Bid{{ID: "bid-" + id, TenderId: id, OrganizationId: "org-2"}}
+	}
+	return bids, nil
+}
+
+func (s *stubGraph) GetOrganizations(ctx context.Context, organizationIDs []string) (map[string]domain.Organization, error) {
+	s.record("organizations", organizationIDs)
+	organizations := map[string]domain.Organization{}
+	for _, id := range organizationIDs {
+		organizations[id] = domain.Organization{ID: id, Name: "Организация " + id, Type: domain.OrganizationTypeLLC}
+	}
+	return organizations, nil
+}
+
+func (s *stubGraph) GetEmployees(ctx context.Context, usernames []string) (map[string]domain.Employee, error) {
+	s.record("employees", usernames)
+	employees := map[string]domain.Employee{}
+	for _, username := range usernames {
+		if username != "ghost" {
+			employees[username] = domain.Employee{ID: username, Username: username}
+		}
+	}
+	return employees, nil
+}
+
+func (s *stubGraph) GetResponsibleOrganizations(ctx context.Context, usernames []string) (map[string][]domain.Organization, error) {
+	s.record("responsible", usernames)
+	return map[string][]domain.Organization{"user1": {{ID: "org-1"}}}, nil
+}
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Path       []any          `json:"path"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func run(t *testing.T, h http.Handler, username, query string) response {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql?username="+url.QueryEscape(username), strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding %q: %v", rec.Body.String(), err)
+	}
+
+	return resp
+}
+
+func TestHandler(t *testing.T) {
+	tenders := []domain.Tender{
+		{ID: "t1", OrganizationId: "org-1", CreatorUsername: "user1", Type: domain.TenderTypeStandard, Visibility: domain.TenderVisibilityPublic},
+		{ID: "t2", OrganizationId: "org-1", CreatorUsername: "user1", Type: domain.TenderTypeStandard, Visibility: domain.TenderVisibilityPublic},
+		{ID: "t3", OrganizationId: "org-3", CreatorUsername: "user3", Type: domain.TenderTypeStandard, Visibility: domain.TenderVisibilityPublic},
+	}
+
+	t.Run("batches", func(t *testing.T) {
+		graph := &stubGraph{batches: map[string][][]string{}, tenders: tenders}
+		h := NewHandler(stubTenders{tenders: tenders}, nil, graph)
+
+		resp := run(t, h, "user1", `{ tenders { id organization { name } versions { version } bids { id organization { id } tender { id } } } }`)
+		if len(resp.Errors) != 2 {
+			t.Fatalf("errors = %+v, want the versions and bids of t3 refused", resp.Errors)
+		}
+		for _, err := range resp.Errors {
+			if err.Extensions["code"] != "FORBIDDEN" || err.Path[1] != 2.0 {
+				t.Errorf("error %+v, want FORBIDDEN for t3", err)
+			}
+		}
+
+		for name, want := range map[string]int{"organizations": 2, "versions": 1, "bids": 1, "tenders": 1, "employees": 1, "responsible": 1} {
+			if got := len(graph.batches[name]); got != want {
+				t.Errorf("%s fetched in %d batches %v, want %d", name, got, graph.batches[name], want)
+			}
+		}
+		if got := graph.batches["versions"]; len(got) == 1 && len(got[0]) != 2 {
+			t.Errorf("versions fetched for %v, want the two tenders of org-1", got[0])
+		}
+	})
+
+	t.Run("authorization", func(t *testing.T) {
+		h := NewHandler(stubTenders{tenders: tenders}, nil, &stubGraph{batches: map[string][][]string{}, tenders: tenders})
+
+		for _, tc := range []struct {
+			username, query, code string
+		}{
+			{"", `{ me { username } }`, "UNAUTHENTICATED"},
+			{"ghost", `{ tenders { creator { username } } }`, "UNAUTHENTICATED"},
+			{"user3", `{ tenders { bids { id } } }`, "FORBIDDEN"},
+			{"user3", `{ tenders { creator { organizations { id } } } }`, "FORBIDDEN"},
+			{"user1", `{ me { organizations { id } } tenders(limit: 2) { creator { username } versions { version } } }`, ""},
+		} {
+			resp := run(t, h, tc.username, tc.query)
+			var code any
+			if len(resp.Errors) > 0 {
+				code = resp.Errors[0].Extensions["code"]
+			}
+			if tc.code == "" && len(resp.Errors) > 0 || tc.code != "" && code != tc.code {
+				t.Errorf("%q as %q: errors %+v, want code %q", tc.query, tc.username, resp.Errors, tc.code)
+			}
+		}
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		h := NewHandler(stubTenders{tenders: tenders}, nil, &stubGraph{batches: map[string][][]string{}, tenders: tenders})
+
+		for _, tc := range []struct {
+			query, code string
+		}{
+			{`{ tenders { bids { tender { bids { tender { bids { id } } } } } } }`, "DEPTH_LIMIT_EXCEEDED"},
+			{`{ tenders(limit: 100) { versions { version name status } bids { id } } }`, "COMPLEXITY_LIMIT_EXCEEDED"},
+		} {
+			resp := run(t, h, "user1", tc.query)
+			if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != tc.code {
+				t.Errorf("%q: errors %+v, want %s", tc.query, resp.Errors, tc.code)
+			}
+			if resp.Data != nil {
+				t.Errorf("%q: ran despite the limit", tc.query)
+			}
+		}
+
+		resp := run(t, h, "user1", `{ __schema { types { name fields { name type { name ofType { name ofType { name } } } } } } }`)
+		if len(resp.Errors) > 0 {
+			t.Errorf("introspection: %+v", resp.Errors)
+		}
+	})
+}
+
+func TestCodeFromError(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("repository.GetTenderStatus: %w", domain.ErrUserNotFound), "UNAUTHENTICATED"},
+		{fmt.Errorf("service.GetTender: %w", domain.ErrUserNotAuthorized), "FORBIDDEN"},
+		{fmt.Errorf("service.GetTender: %w", domain.ErrTenderNotFound), "NOT_FOUND"},
+		{fmt.Errorf("service.CreateBid: %w", domain.ErrTenderNotOpen), "BAD_USER_INPUT"},
+		{fmt.Errorf("service.CreateBid: %w", domain.ErrTenderClosed), "CONFLICT"},
+		{fmt.Errorf("%w: tender can not move from CLOSED to PUBLISHED", domain.ErrConflict), "CONFLICT"},
+		{errors.New("connection refused"), "INTERNAL_SERVER_ERROR"},
+	} {
+		if got := codeFromError(tt.err); got != tt.want {
+			t.Errorf("codeFromError(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// MaxDepth bounds how deep selections nest, introspection fields aside.
+	MaxDepth = 6
+	// MaxComplexity bounds the cost of an operation. Every field costs one,
+	// lists multiply the cost of their elements by their expected length.
+	MaxComplexity = 2000
+
+	// unpagedLength is the expected length of lists without a limit.
+	unpagedLength = 10
+)
+
+func complexity() ComplexityRoot {
+	var c ComplexityRoot
+
+	paged := func(child int, limit *int) int {
+		length, _ := pagination(limit, nil)
+		return 1 + length*child
+	}
+	unpaged := func(child int) int {
+		return 1 + unpagedLength*child
+	}
+
+	c.Query.Tenders = func(child int, limit, _ *int, _, _ []string) int { return paged(child, limit) }
+	c.Query.MyTenders = func(child int, limit, _ *int) int { return paged(child, limit) }
+	c.Query.MyBids = func(child int, limit, _ *int) int { return paged(child, limit) }
+	c.Tender.Versions = unpaged
+	c.Tender.Bids = unpaged
+	c.Employee.Organizations = unpaged
+
+	return c
+}
+
+// depthLimit rejects operations nesting selections deeper than max, the
+// complexity limit alone lets narrow but deep queries through.
+type depthLimit struct {
+	max int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = depthLimit{}
+
+func (d depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d depthLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d depthLimit) MutateOperationContext(_ context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	op := rc.Doc.Operations.ForName(rc.OperationName)
+	if op == nil {
+		return nil
+	}
+
+	if depth := selectionDepth(op.SelectionSet); depth > d.max {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.max)
+		errcode.Set(err, "DEPTH_LIMIT_EXCEEDED")
+		return err
+	}
+
+	return nil
+}
+
+func selectionDepth(set ast.SelectionSet) int {
+	depth := 0
+	for _, selection := range set {
+		var d int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			d = 1 + selectionDepth(s.SelectionSet)
+		case *ast.InlineFragment:
+			d = selectionDepth(s.SelectionSet)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				d = selectionDepth(s.Definition.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+	}
+
+	return depth
+}
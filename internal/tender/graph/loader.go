@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// batchWait is how long a batch collects keys before it is fetched.
+	// Resolvers of sibling fields run concurrently, so a few milliseconds
+	// are enough to gather all keys of a list.
+	batchWait = 2 * time.Millisecond
+	maxBatch  = 500
+)
+
+// loader collects the keys asked for by concurrent resolvers into batches
+// and fetches each batch with a single call. Results are cached for the life
+// of the loader, which lives as long as a request.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending *batch[K, V]
+	cache   map[K]*batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys   []K
+	once   sync.Once
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, cache: map[K]*batch[K, V]{}}
+}
+
+// Load returns the value of the key, ok is false when the fetch left the key
+// out.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (value V, ok bool, err error) {
+	l.mu.Lock()
+	b, cached := l.cache[key]
+	if !cached {
+		if l.pending == nil {
+			l.pending = &batch[K, V]{done: make(chan struct{})}
+			pending := l.pending
+			time.AfterFunc(batchWait, func() { l.dispatch(ctx, pending) })
+		}
+
+		b = l.pending
+		b.keys = append(b.keys, key)
+		l.cache[key] = b
+
+		if len(b.keys) >= maxBatch {
+			l.pending = nil
+			go l.dispatch(ctx, b)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return value, false, ctx.Err()
+	}
+
+	if b.err != nil {
+		return value, false, b.err
+	}
+
+	value, ok = b.values[key]
+	return value, ok, nil
+}
+
+func (l *loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if l.pending == b {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	b.once.Do(func() {
+		b.values, b.err = l.fetch(ctx, b.keys)
+		close(b.done)
+	})
+}
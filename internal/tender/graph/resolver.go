@@ -0,0 +1,156 @@
+// Package graph serves tenders, their versions and bids, organizations and
+// employees over GraphQL, on top of the same service layer as the HTTP API.
+// The schema lives in schema.graphqls, generated.go is derived from it.
+package graph
+
+//go:generate go run github.com/99designs/gqlgen generate --config gqlgen.yml
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+const defaultLimit = 10
+
+var errMissingUsername = errors.New("missing username")
+
+type Resolver struct {
+	tenders domain.TenderService
+	bids    domain.BidService
+}
+
+// NewHandler returns the GraphQL endpoint. The employee is given by the
+// username query parameter, as for the HTTP API, and every request gets its
+// own loaders.
+func NewHandler(tenders domain.TenderService, bids domain.BidService, graph domain.GraphService) http.Handler {
+	srv := handler.New(NewExecutableSchema(Config{
+		Resolvers:  &Resolver{tenders: tenders, bids: bids},
+		Complexity: complexity(),
+	}))
+
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.SetQueryCache(lru.New(1000))
+	srv.Use(extension.Introspection{})
+	srv.Use(depthLimit{max: MaxDepth})
+	srv.Use(extension.FixedComplexityLimit(MaxComplexity))
+	srv.SetErrorPresenter(presentError)
+	srv.SetRecoverFunc(func(ctx context.Context, err any) error {
+		logger.Logger().Errorln("GraphQL resolver panicked:", err)
+		return errors.New("internal server error")
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), loadersKey{}, newLoaders(graph, r.URL.Query().Get("username")))
+		srv.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type loadersKey struct{}
+
+// loaders batch the lookups of a request, on behalf of its employee.
+type loaders struct {
+	username      string
+	tenders       *loader[string, domain.Tender]
+	versions      *loader[string, []domain.TenderVersion]
+	bids          *loader[string, []domain.Bid]
+	organizations *loader[string, domain.Organization]
+	employees     *loader[string, domain.Employee]
+	responsible   *loader[string, []domain.Organization]
+}
+
+func newLoaders(graph domain.GraphService, username string) *loaders {
+	return &loaders{
+		username: username,
+		tenders: newLoader(func(ctx context.Context, tenderIDs []string) (map[string]domain.Tender, error) {
+			return graph.GetTenders(ctx, tenderIDs, username)
+		}),
+		versions: newLoader(graph.GetTenderVersions),
+		bids: newLoader(func(ctx context.Context, tenderIDs []string) (map[string][]domain.Bid, error) {
+			return graph.GetTenderBids(ctx, tenderIDs, username)
+		}),
+		organizations: newLoader(graph.GetOrganizations),
+		employees:     newLoader(graph.GetEmployees),
+		responsible:   newLoader(graph.GetResponsibleOrganizations),
+	}
+}
+
+func loadersFor(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// employee returns the employee making the request.
+func (l *loaders) employee(ctx context.Context) (domain.Employee, error) {
+	if l.username == "" {
+		return domain.Employee{}, errMissingUsername
+	}
+
+	employee, ok, err := l.employees.Load(ctx, l.username)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+	if !ok {
+		return domain.Employee{}, domain.ErrUserNotFound
+	}
+
+	return employee, nil
+}
+
+// manage lets through the employees responsible for the organization owning
+// the tender, the ones its versions, bids and creator are shown to.
+func (l *loaders) manage(ctx context.Context, tender *domain.Tender) error {
+	if _, err := l.employee(ctx); err != nil {
+		return err
+	}
+
+	organizations, _, err := l.responsible.Load(ctx, l.username)
+	if err != nil {
+		return err
+	}
+
+	for _, organization := range organizations {
+		if organization.ID == tender.OrganizationId {
+			return nil
+		}
+	}
+
+	return domain.ErrUserNotAuthorized
+}
+
+func pagination(limit, offset *int) (int, int) {
+	l, o := defaultLimit, 0
+	if limit != nil {
+		l = *limit
+	}
+	if offset != nil {
+		o = *offset
+	}
+
+	return l, o
+}
+
+func organization(ctx context.Context, organizationID string) (*domain.Organization, error) {
+	organization, ok, err := loadersFor(ctx).organizations.Load(ctx, organizationID)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &organization, nil
+}
+
+// nonNil keeps lists without elements from being returned as null.
+func nonNil[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+
+	return list
+}
@@ -0,0 +1,189 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.49
+
+import (
+	"context"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// Tender is the resolver for the tender field.
+func (r *bidResolver) Tender(ctx context.Context, obj *domain.Bid) (*domain.Tender, error) {
+	tender, ok, err := loadersFor(ctx).tenders.Load(ctx, obj.TenderId)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &tender, nil
+}
+
+// Organization is the resolver for the organization field.
+func (r *bidResolver) Organization(ctx context.Context, obj *domain.Bid) (*domain.Organization, error) {
+	return organization(ctx, obj.OrganizationId)
+}
+
+// Organizations is the resolver for the organizations field.
+func (r *employeeResolver) Organizations(ctx context.Context, obj *domain.Employee) ([]domain.Organization, error) {
+	l := loadersFor(ctx)
+	if obj.Username != l.username {
+		return nil, domain.ErrUserNotAuthorized
+	}
+
+	organizations, _, err := l.responsible.Load(ctx, obj.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(organizations), nil
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*domain.Employee, error) {
+	employee, err := loadersFor(ctx).employee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &employee, nil
+}
+
+// Tender is the resolver for the tender field.
+func (r *queryResolver) Tender(ctx context.Context, id string) (*domain.Tender, error) {
+	username := loadersFor(ctx).username
+	if username == "" {
+		return nil, errMissingUsername
+	}
+
+	tender, err := r.tenders.GetTender(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tender, nil
+}
+
+// Tenders is the resolver for the tenders field.
+func (r *queryResolver) Tenders(ctx context.Context, limit *int, offset *int, serviceTypes []string, tags []string) ([]domain.Tender, error) {
+	pageLimit, pageOffset := pagination(limit, offset)
+
+	return r.tenders.ListTender(ctx, domain.TenderListFilter{
+		Limit:        pageLimit,
+		Offset:       pageOffset,
+		ServiceTypes: serviceTypes,
+		Tags:         tags,
+		Username:     loadersFor(ctx).username,
+	})
+}
+
+// MyTenders is the resolver for the myTenders field.
+func (r *queryResolver) MyTenders(ctx context.Context, limit *int, offset *int) ([]domain.Tender, error) {
+	username := loadersFor(ctx).username
+	if username == "" {
+		return nil, errMissingUsername
+	}
+
+	pageLimit, pageOffset := pagination(limit, offset)
+
+	return r.tenders.GetUserTenders(ctx, pageLimit, pageOffset, username)
+}
+
+// MyBids is the resolver for the myBids field.
+func (r *queryResolver) MyBids(ctx context.Context, limit *int, offset *int) ([]domain.Bid, error) {
+	username := loadersFor(ctx).username
+	if username == "" {
+		return nil, errMissingUsername
+	}
+
+	pageLimit, pageOffset := pagination(limit, offset)
+
+	return r.bids.GetUserBids(ctx, pageLimit, pageOffset, username)
+}
+
+// CustomFields is the resolver for the customFields field.
+func (r *tenderResolver) CustomFields(ctx context.Context, obj *domain.Tender) (map[string]interface{}, error) {
+	return obj.CustomFields, nil
+}
+
+// Organization is the resolver for the organization field.
+func (r *tenderResolver) Organization(ctx context.Context, obj *domain.Tender) (*domain.Organization, error) {
+	return organization(ctx, obj.OrganizationId)
+}
+
+// Creator is the resolver for the creator field.
+func (r *tenderResolver) Creator(ctx context.Context, obj *domain.Tender) (*domain.Employee, error) {
+	l := loadersFor(ctx)
+	if err := l.manage(ctx, obj); err != nil {
+		return nil, err
+	}
+
+	employee, ok, err := l.employees.Load(ctx, obj.CreatorUsername)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &employee, nil
+}
+
+// Versions is the resolver for the versions field.
+func (r *tenderResolver) Versions(ctx context.Context, obj *domain.Tender) ([]domain.TenderVersion, error) {
+	l := loadersFor(ctx)
+	if err := l.manage(ctx, obj); err != nil {
+		return nil, err
+	}
+
+	versions, _, err := l.versions.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(versions), nil
+}
+
+// Bids is the resolver for the bids field.
+func (r *tenderResolver) Bids(ctx context.Context, obj *domain.Tender) ([]domain.Bid, error) {
+	l := loadersFor(ctx)
+	if err := l.manage(ctx, obj); err != nil {
+		return nil, err
+	}
+
+	if !obj.BidsRevealed(time.Now()) {
+		return nil, domain.ErrBidsSealed
+	}
+
+	bids, _, err := l.bids.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(bids), nil
+}
+
+// CustomFields is the resolver for the customFields field.
+func (r *tenderVersionResolver) CustomFields(ctx context.Context, obj *domain.TenderVersion) (map[string]interface{}, error) {
+	return obj.CustomFields, nil
+}
+
+// Bid returns BidResolver implementation.
+func (r *Resolver) Bid() BidResolver { return &bidResolver{r} }
+
+// Employee returns EmployeeResolver implementation.
+func (r *Resolver) Employee() EmployeeResolver { return &employeeResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Tender returns TenderResolver implementation.
+func (r *Resolver) Tender() TenderResolver { return &tenderResolver{r} }
+
+// TenderVersion returns TenderVersionResolver implementation.
+func (r *Resolver) TenderVersion() TenderVersionResolver { return &tenderVersionResolver{r} }
+
+type bidResolver struct{ *Resolver }
+type employeeResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type tenderResolver struct{ *Resolver }
+type tenderVersionResolver struct{ *Resolver }
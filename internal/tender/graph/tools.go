@@ -0,0 +1,6 @@
+//go:build tools
+
+package graph
+
+// The generator run by go generate, kept in go.mod with its dependencies.
+import _ "github.com/99designs/gqlgen"
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+const attachmentFormField = "file"
+
+type AttachmentHandler struct {
+	srv       domain.AttachmentService
+	ownerType domain.AttachmentOwnerType
+	pathParam string
+}
+
+// NewAttachmentHandler returns handlers for attachments of the given owner
+// type; pathParam is the route wildcard holding the owner ID.
+func NewAttachmentHandler(srv domain.AttachmentService, ownerType domain.AttachmentOwnerType, pathParam string) *AttachmentHandler {
+	return &AttachmentHandler{srv: srv, ownerType: ownerType, pathParam: pathParam}
+}
+
+func (h *AttachmentHandler) owner(w http.ResponseWriter, r *http.Request) (domain.AttachmentOwner, string, bool) {
+	ownerID := r.PathValue(h.pathParam)
+	if ownerID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid "+string(h.ownerType)+" ID")
+		logger.Logger().Errorln("Error: Invalid", h.ownerType, "ID")
+		return domain.AttachmentOwner{}, "", false
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return domain.AttachmentOwner{}, "", false
+	}
+
+	return domain.AttachmentOwner{Type: h.ownerType, ID: ownerID}, username, true
+}
+
+func (h *AttachmentHandler) UploadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	owner, username, ok := h.owner(w, r)
+	if !ok {
+		return
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Expected multipart/form-data payload")
+		logger.Logger().Errorln("Error reading multipart payload:", err.Error())
+		return
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			errwriter.RespondWithError(w, http.StatusBadRequest, "Missing "+attachmentFormField+" field")
+			logger.Logger().Errorln("Error: Missing file in multipart payload")
+			return
+		}
+		if err != nil {
+			errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid multipart payload")
+			logger.Logger().Errorln("Error reading multipart payload:", err.Error())
+			return
+		}
+
+		if part.FormName() != attachmentFormField {
+			part.Close()
+			continue
+		}
+
+		attachment, err := h.srv.UploadAttachment(r.Context(), owner, part.FileName(), part.Header.Get("Content-Type"), part, username)
+		part.Close()
+		if err != nil {
+			errwriter.RespondWithError(w, statusFromError(err), err.Error())
+			logger.Logger().Errorln("Error uploading attachment:", err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, attachment)
+		return
+	}
+}
+
+func (h *AttachmentHandler) ListAttachmentsHandler(w http.ResponseWriter, r *http.Request) {
+	owner, username, ok := h.owner(w, r)
+	if !ok {
+		return
+	}
+
+	attachments, err := h.srv.ListAttachments(r.Context(), owner, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching attachments:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, attachments)
+}
+
+func (h *AttachmentHandler) DownloadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	owner, username, ok := h.owner(w, r)
+	if !ok {
+		return
+	}
+
+	attachment, rc, err := h.srv.DownloadAttachment(r.Context(), owner, r.PathValue("attachmentId"), username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error downloading attachment:", err.Error())
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Checksum-SHA256", attachment.SHA256)
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, rc); err != nil {
+		logger.Logger().Errorln("Error writing attachment:", err.Error())
+	}
+}
+
+func (h *AttachmentHandler) DeleteAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	owner, username, ok := h.owner(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.srv.DeleteAttachment(r.Context(), owner, r.PathValue("attachmentId"), username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error deleting attachment:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
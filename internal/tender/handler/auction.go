@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// auctionPingInterval keeps idle auction connections alive through proxies.
+	auctionPingInterval = 30 * time.Second
+	// auctionWriteTimeout bounds every write to a watcher.
+	auctionWriteTimeout = 10 * time.Second
+	// auctionMaxMessageSize limits messages read from watchers, who only
+	// send control frames.
+	auctionMaxMessageSize = 64 << 10
+)
+
+type AuctionHandler struct {
+	srv      domain.AuctionService
+	upgrader websocket.Upgrader
+}
+
+// NewAuctionHandler accepts live connections from pages of the service itself
+// and of allowedOrigins. Clients that send no Origin, which browsers always do,
+// are not checked.
+func NewAuctionHandler(srv domain.AuctionService, allowedOrigins []string) *AuctionHandler {
+	h := &AuctionHandler{srv: srv}
+	h.upgrader = websocket.Upgrader{
+		HandshakeTimeout: auctionWriteTimeout,
+		CheckOrigin: func(r *http.Request) bool {
+			return originAllowed(r, allowedOrigins)
+		},
+	}
+
+	return h
+}
+
+func originAllowed(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	return slices.ContainsFunc(allowedOrigins, func(allowed string) bool {
+		return strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin)
+	})
+}
+
+func (h *AuctionHandler) GetAuctionHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	auction, err := h.srv.GetAuction(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching auction:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, auction)
+}
+
+func (h *AuctionHandler) ListOffersHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	limit, offset := parsePagination(r)
+
+	offers, err := h.srv.ListOffers(r.Context(), tenderID, limit, offset, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching auction offers:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, offers)
+}
+
+func (h *AuctionHandler) PlaceOfferHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.PlaceOfferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	offer, err := h.srv.PlaceOffer(r.Context(), tenderID, domain.AuctionOffer{
+		TenderID:        tenderID,
+		OrganizationID:  req.OrganizationID,
+		CreatorUsername: username,
+		Price:           req.Price,
+	})
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error placing auction offer:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, offer)
+}
+
+// LiveAuctionHandler streams the auction over a WebSocket: the current state
+// first, then an event for every accepted offer.
+func (h *AuctionHandler) LiveAuctionHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	auction, events, cancel, err := h.srv.Subscribe(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error subscribing to auction:", err.Error())
+		return
+	}
+	defer cancel()
+
+	// The upgrader answers failed handshakes itself.
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Logger().Errorln("Error upgrading auction connection:", err.Error())
+		return
+	}
+	defer conn.Close()
+	conn.SetReadLimit(auctionMaxMessageSize)
+
+	closeWith := func(code int, reason string) {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(auctionWriteTimeout))
+	}
+	defer closeWith(websocket.CloseGoingAway, "")
+
+	// Watchers only listen; reading is needed to notice the client leaving
+	// and to answer its pings.
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	conn.SetWriteDeadline(time.Now().Add(auctionWriteTimeout))
+	if err := conn.WriteJSON(domain.AuctionEvent{Type: domain.AuctionEventState, Auction: auction}); err != nil {
+		logger.Logger().Errorln("Error writing auction state:", err.Error())
+		return
+	}
+
+	ping := time.NewTicker(auctionPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-gone:
+			return
+		case event, ok := <-events:
+			if !ok {
+				closeWith(websocket.CloseTryAgainLater, "watcher fell behind")
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(auctionWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				logger.Logger().Errorln("Error writing auction event:", err.Error())
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(auctionWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type AwardHandler struct {
+	srv domain.AwardService
+}
+
+func NewAwardHandler(srv domain.AwardService) *AwardHandler {
+	return &AwardHandler{srv: srv}
+}
+
+func (h *AwardHandler) AwardTenderHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.AwardTenderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	awards, err := h.srv.AwardTender(r.Context(), tenderID, req.BidID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error awarding tender:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, awards)
+}
+
+func (h *AwardHandler) GetAwardsHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	awards, err := h.srv.GetAwards(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching awards:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, awards)
+}
+
+func (h *AwardHandler) ContractHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	format := domain.ContractFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = domain.ContractFormatMarkdown
+	}
+
+	document, err := h.srv.GenerateContract(r.Context(), tenderID, format, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error generating contract:", err.Error())
+		return
+	}
+
+	contentType, fileName := "text/markdown; charset=utf-8", "contract-"+tenderID+".md"
+	if format == domain.ContractFormatPDF {
+		contentType, fileName = "application/pdf", "contract-"+tenderID+".pdf"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(document)))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(document); err != nil {
+		logger.Logger().Errorln("Error writing contract:", err.Error())
+	}
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type BidHandler struct {
+	srv     domain.BidService
+	exports domain.ExportService
+}
+
+func NewBidHandler(srv domain.BidService, exports domain.ExportService) *BidHandler {
+	return &BidHandler{srv: srv, exports: exports}
+}
+
+func (h *BidHandler) CreateBidHandler(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateBidRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	if req.Name == "" || req.TenderId == "" || req.OrganizationId == "" || req.CreatorUsername == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Missing required fields")
+		logger.Logger().Errorln("Error: Missing required fields in request")
+		return
+	}
+
+	createdBid, err := h.srv.CreateBid(r.Context(), domain.Bid{
+		Name:            req.Name,
+		Description:     req.Description,
+		Status:          "CREATED",
+		TenderId:        req.TenderId,
+		OrganizationId:  req.OrganizationId,
+		CreatorUsername: req.CreatorUsername,
+		Version:         1,
+		Price:           req.Price,
+		Lots:            req.Lots,
+	})
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error creating bid:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toBidResponse(createdBid))
+}
+
+func (h *BidHandler) GetUserBidsHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	limit, offset := parsePagination(r)
+
+	format, export, err := exportFormat(r)
+	if err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, err.Error())
+		logger.Logger().Errorln("Error fetching user bids:", err.Error())
+		return
+	}
+
+	if export {
+		writeExport(w, format, "bids", func(out io.Writer) error {
+			return h.exports.ExportUserBids(r.Context(), exportLimit(r, limit), offset, username, format, out)
+		})
+		return
+	}
+
+	bids, err := h.srv.GetUserBids(r.Context(), limit, offset, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching user bids:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toBidResponses(bids))
+}
+
+func (h *BidHandler) ListTenderBidsHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	limit, offset := parsePagination(r)
+
+	format, export, err := exportFormat(r)
+	if err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, err.Error())
+		logger.Logger().Errorln("Error fetching tender bids:", err.Error())
+		return
+	}
+
+	if export {
+		writeExport(w, format, "bids", func(out io.Writer) error {
+			return h.exports.ExportTenderBids(r.Context(), tenderID, exportLimit(r, limit), offset, username, format, out)
+		})
+		return
+	}
+
+	bids, err := h.srv.ListTenderBids(r.Context(), tenderID, limit, offset, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching tender bids:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toBidResponses(bids))
+}
+
+func (h *BidHandler) CountTenderBidsHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	count, err := h.srv.CountTenderBids(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error counting tender bids:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, count)
+}
+
+func toBidResponse(bid domain.Bid) domain.BidResponse {
+	return domain.BidResponse{
+		ID:             bid.ID,
+		Name:           bid.Name,
+		Description:    bid.Description,
+		Status:         bid.Status,
+		TenderId:       bid.TenderId,
+		OrganizationId: bid.OrganizationId,
+		Version:        bid.Version,
+		Price:          bid.Price,
+		Sealed:         bid.Sealed,
+		CreatedAt:      bid.CreatedAt,
+		Lots:           bid.Lots,
+	}
+}
+
+func toBidResponses(bids []domain.Bid) []domain.BidResponse {
+	responseBids := make([]domain.BidResponse, 0, len(bids))
+	for _, bid := range bids {
+		responseBids = append(responseBids, toBidResponse(bid))
+	}
+
+	return responseBids
+}
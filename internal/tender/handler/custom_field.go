@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type CustomFieldHandler struct {
+	srv domain.CustomFieldService
+}
+
+func NewCustomFieldHandler(srv domain.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{srv: srv}
+}
+
+func (h *CustomFieldHandler) CreateCustomFieldHandler(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.PathValue("organizationId")
+	if organizationID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		logger.Logger().Errorln("Error: Invalid organization ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.CreateCustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	if req.Key == "" || req.Type == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Missing required fields")
+		logger.Logger().Errorln("Error: Missing required fields in request")
+		return
+	}
+
+	created, err := h.srv.CreateCustomField(r.Context(), domain.CustomField{
+		OrganizationID: organizationID,
+		Key:            req.Key,
+		Name:           req.Name,
+		Type:           req.Type,
+		Options:        req.Options,
+		Required:       req.Required,
+	}, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error creating custom field:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (h *CustomFieldHandler) ListCustomFieldsHandler(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.PathValue("organizationId")
+	if organizationID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		logger.Logger().Errorln("Error: Invalid organization ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	fields, err := h.srv.ListCustomFields(r.Context(), organizationID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching custom fields:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, fields)
+}
+
+func (h *CustomFieldHandler) DeleteCustomFieldHandler(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.PathValue("organizationId")
+	key := r.PathValue("key")
+	if organizationID == "" || key == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID or custom field key")
+		logger.Logger().Errorln("Error: Invalid organization ID or custom field key")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	if err := h.srv.DeleteCustomField(r.Context(), organizationID, key, username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error deleting custom field:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type DirectoryHandler struct {
+	srv domain.DirectoryService
+}
+
+func NewDirectoryHandler(srv domain.DirectoryService) *DirectoryHandler {
+	return &DirectoryHandler{srv: srv}
+}
+
+func (h *DirectoryHandler) ListOrganizationsHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	limit, offset := parsePagination(r)
+	organizations, err := h.srv.ListOrganizations(r.Context(), limit, offset, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching organizations:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, organizations)
+}
+
+func (h *DirectoryHandler) CreateOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	created, err := h.srv.CreateOrganization(r.Context(), req, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error creating organization:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (h *DirectoryHandler) UpdateOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var update domain.OrganizationUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	updated, err := h.srv.UpdateOrganization(r.Context(), r.PathValue("organizationId"), update, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error updating organization:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (h *DirectoryHandler) DeleteOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	if err := h.srv.DeleteOrganization(r.Context(), r.PathValue("organizationId"), username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error deleting organization:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *DirectoryHandler) AddOrganizationResponsibleHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	if err := h.srv.AddOrganizationResponsible(r.Context(), r.PathValue("organizationId"), r.PathValue("employee"), username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error adding responsible employee:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *DirectoryHandler) RemoveOrganizationResponsibleHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	if err := h.srv.RemoveOrganizationResponsible(r.Context(), r.PathValue("organizationId"), r.PathValue("employee"), username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error removing responsible employee:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *DirectoryHandler) ListEmployeesHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	limit, offset := parsePagination(r)
+	employees, err := h.srv.ListEmployees(r.Context(), limit, offset, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching employees:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, employees)
+}
+
+func (h *DirectoryHandler) CreateEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.CreateEmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	created, err := h.srv.CreateEmployee(r.Context(), req, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error creating employee:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (h *DirectoryHandler) UpdateEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var update domain.EmployeeUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	updated, err := h.srv.UpdateEmployee(r.Context(), r.PathValue("employee"), update, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error updating employee:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (h *DirectoryHandler) DeleteEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	if err := h.srv.DeleteEmployee(r.Context(), r.PathValue("employee"), username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error deleting employee:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
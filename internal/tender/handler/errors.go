@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// statusFromError maps domain errors returned by the service layer to HTTP
+// status codes.
+func statusFromError(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrUserNotAuthorized):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrTenderNotFound), errors.Is(err, domain.ErrBidNotFound), errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrTenderNotOpen), errors.Is(err, domain.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTenderClosed), errors.Is(err, domain.ErrConflict),
+		errors.Is(err, domain.ErrAuctionNotRunning), errors.Is(err, domain.ErrOfferTooHigh):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrBidsSealed):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, domain.ErrUnsupportedType):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type EvaluationHandler struct {
+	srv domain.EvaluationService
+}
+
+func NewEvaluationHandler(srv domain.EvaluationService) *EvaluationHandler {
+	return &EvaluationHandler{srv: srv}
+}
+
+func (h *EvaluationHandler) ScoreBidHandler(w http.ResponseWriter, r *http.Request) {
+	bidID := r.PathValue("bidId")
+	if bidID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid bid ID")
+		logger.Logger().Errorln("Error: Invalid bid ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.ScoreBidRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	scores, err := h.srv.ScoreBid(r.Context(), bidID, req.Scores, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error scoring bid:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, scores)
+}
+
+func (h *EvaluationHandler) GetEvaluationHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	evaluation, err := h.srv.GetEvaluation(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching evaluation:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, evaluation)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/export"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+// exportFormat returns the format a listing is exported in, taken from the
+// format parameter or else from the Accept header. ok is false when the
+// listing is returned as JSON.
+func exportFormat(r *http.Request) (format domain.ExportFormat, ok bool, err error) {
+	switch value := r.URL.Query().Get("format"); value {
+	case "":
+	case "json":
+		return "", false, nil
+	case string(domain.ExportFormatCSV), string(domain.ExportFormatXLSX):
+		return domain.ExportFormat(value), true, nil
+	default:
+		return "", false, fmt.Errorf("%w: format must be json, csv or xlsx", domain.ErrInvalidInput)
+	}
+
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaType := range strings.Split(accept, ",") {
+			mediaType, _, _ = strings.Cut(mediaType, ";")
+			switch strings.TrimSpace(mediaType) {
+			case "text/csv":
+				return domain.ExportFormatCSV, true, nil
+			case export.ContentType(domain.ExportFormatXLSX):
+				return domain.ExportFormatXLSX, true, nil
+			}
+		}
+	}
+
+	return "", false, nil
+}
+
+// exportLimit is the page size of an export, which covers the whole listing
+// unless a limit is given.
+func exportLimit(r *http.Request, limit int) int {
+	if r.URL.Query().Get("limit") == "" {
+		return 0
+	}
+
+	return limit
+}
+
+// exportResponse sends the headers along with the first bytes of the file,
+// so that an error found before that still gets a JSON error response.
+type exportResponse struct {
+	w        http.ResponseWriter
+	format   domain.ExportFormat
+	filename string
+	started  bool
+}
+
+func (e *exportResponse) Write(p []byte) (int, error) {
+	if !e.started {
+		e.started = true
+		e.w.Header().Set("Content-Type", export.ContentType(e.format))
+		e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", e.filename+"."+string(e.format)))
+		e.w.WriteHeader(http.StatusOK)
+	}
+
+	return e.w.Write(p)
+}
+
+func writeExport(w http.ResponseWriter, format domain.ExportFormat, filename string, write func(io.Writer) error) {
+	response := &exportResponse{w: w, format: format, filename: filename}
+
+	if err := write(response); err != nil {
+		if !response.started {
+			errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		}
+		logger.Logger().Errorln("Error exporting "+filename+":", err.Error())
+	}
+}
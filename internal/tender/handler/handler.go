@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -38,11 +39,13 @@ func (h *PingHandler) PingHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 type TenderHandler struct {
-	srv domain.TenderService
+	srv       domain.TenderService
+	templates domain.TemplateService
+	exports   domain.ExportService
 }
 
-func NewTenderHandler(srv domain.TenderService) *TenderHandler {
-	return &TenderHandler{srv: srv}
+func NewTenderHandler(srv domain.TenderService, templates domain.TemplateService, exports domain.ExportService) *TenderHandler {
+	return &TenderHandler{srv: srv, templates: templates, exports: exports}
 }
 
 func (h *TenderHandler) ListTenderHandler(w http.ResponseWriter, r *http.Request) {
@@ -50,6 +53,13 @@ func (h *TenderHandler) ListTenderHandler(w http.ResponseWriter, r *http.Request
 	offsetStr := r.URL.Query().Get("offset")
 	serviceTypes := r.URL.Query()["service_type"]
 
+	customFields := map[string]string{}
+	for key, values := range r.URL.Query() {
+		if field, ok := strings.CutPrefix(key, "cf."); ok && field != "" && len(values) > 0 {
+			customFields[field] = values[0]
+		}
+	}
+
 	limit := 10
 	offset := 0
 
@@ -64,7 +74,32 @@ func (h *TenderHandler) ListTenderHandler(w http.ResponseWriter, r *http.Request
 		}
 	}
 
-	tenders, err := h.srv.ListTender(r.Context(), limit, offset, serviceTypes)
+	format, export, err := exportFormat(r)
+	if err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, err.Error())
+		logger.Logger().Errorln("Error fetching tender list:", err.Error())
+		return
+	}
+
+	filter := domain.TenderListFilter{
+		Limit:        limit,
+		Offset:       offset,
+		ServiceTypes: serviceTypes,
+		Tags:         r.URL.Query()["tag"],
+		CustomFields: customFields,
+		Text:         r.URL.Query().Get("q"),
+		Username:     r.URL.Query().Get("username"),
+	}
+
+	if export {
+		filter.Limit = exportLimit(r, limit)
+		writeExport(w, format, "tenders", func(out io.Writer) error {
+			return h.exports.ExportTenders(r.Context(), filter, format, out)
+		})
+		return
+	}
+
+	tenders, err := h.srv.ListTender(r.Context(), filter)
 	if err != nil {
 		errwriter.RespondWithError(w, http.StatusBadRequest, err.Error())
 		logger.Logger().Errorln("Error fetching tender list:", err.Error())
@@ -73,15 +108,7 @@ func (h *TenderHandler) ListTenderHandler(w http.ResponseWriter, r *http.Request
 
 	var responseTenders []domain.TenderResponse
 	for _, tender := range tenders {
-		responseTenders = append(responseTenders, domain.TenderResponse{
-			ID:          tender.ID,
-			Name:        tender.Name,
-			Description: tender.Description,
-			Status:      tender.Status,
-			ServiceType: tender.ServiceType,
-			Version:     tender.Version,
-			CreatedAt:   tender.CreatedAt,
-		})
+		responseTenders = append(responseTenders, tenderResponse(tender))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -113,34 +140,34 @@ func (h *TenderHandler) GetUserTendersHandler(w http.ResponseWriter, r *http.Req
 
 	username := r.URL.Query().Get("username")
 	if username == "" {
-		errwriter.RespondWithError(w, http.StatusBadRequest, "Missing username")
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
 		logger.Logger().Errorln("Error: Missing username in query parameters")
 		return
 	}
 
+	format, export, err := exportFormat(r)
+	if err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, err.Error())
+		logger.Logger().Errorln("Error fetching user tenders:", err.Error())
+		return
+	}
+
+	if export {
+		writeExport(w, format, "tenders", func(out io.Writer) error {
+			return h.exports.ExportUserTenders(r.Context(), exportLimit(r, limit), offset, username, format, out)
+		})
+		return
+	}
+
 	tenders, err := h.srv.GetUserTenders(r.Context(), limit, offset, username)
 	if err != nil {
-		var statusCode int
-		if err.Error() == "service.CreateTender: user does not exist" {
-			statusCode = http.StatusBadRequest
-		} else {
-			statusCode = http.StatusInternalServerError
-		}
-		errwriter.RespondWithError(w, statusCode, err.Error())
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
 		return
 	}
 
 	var responseTenders []domain.TenderResponse
 	for _, tender := range tenders {
-		responseTenders = append(responseTenders, domain.TenderResponse{
-			ID:          tender.ID,
-			Name:        tender.Name,
-			Description: tender.Description,
-			Status:      tender.Status,
-			ServiceType: tender.ServiceType,
-			Version:     tender.Version,
-			CreatedAt:   tender.CreatedAt,
-		})
+		responseTenders = append(responseTenders, tenderResponse(tender))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -153,14 +180,45 @@ func (h *TenderHandler) GetUserTendersHandler(w http.ResponseWriter, r *http.Req
 }
 
 func (h *TenderHandler) CreateTenderHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error reading request payload:", err.Error())
+		return
+	}
+
 	var req domain.CreateTenderRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
+	err = json.Unmarshal(body, &req)
 	if err != nil {
 		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
 		logger.Logger().Errorln("Error decoding request payload:", err.Error())
 		return
 	}
 
+	// A template supplies the fields, the ones present in the request are
+	// decoded over them.
+	if req.TemplateID != "" {
+		template, err := h.templates.GetTemplate(r.Context(), req.TemplateID, req.CreatorUsername)
+		if err != nil {
+			errwriter.RespondWithError(w, statusFromError(err), err.Error())
+			logger.Logger().Errorln("Error fetching tender template:", err.Error())
+			return
+		}
+
+		req = template.Tender.Request(template.OrganizationID)
+		if err := json.Unmarshal(body, &req); err != nil {
+			errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			logger.Logger().Errorln("Error decoding request payload:", err.Error())
+			return
+		}
+
+		if req.OrganizationId != template.OrganizationID {
+			errwriter.RespondWithError(w, http.StatusBadRequest, "Template belongs to another organization")
+			logger.Logger().Errorln("Error: Template belongs to another organization")
+			return
+		}
+	}
+
 	if req.Name == "" || req.ServiceType == "" || req.OrganizationId == "" || req.CreatorUsername == "" {
 		errwriter.RespondWithError(w, http.StatusBadRequest, "Missing required fields")
 		logger.Logger().Errorln("Error: Missing required fields in request")
@@ -175,33 +233,26 @@ func (h *TenderHandler) CreateTenderHandler(w http.ResponseWriter, r *http.Reque
 		OrganizationId:  req.OrganizationId,
 		CreatorUsername: req.CreatorUsername,
 		Version:         1,
+		Type:            req.Type,
+		Visibility:      req.Visibility,
+		Sealed:          req.Sealed,
+		Auction:         req.Auction,
+		Lots:            req.Lots,
+		Criteria:        req.Criteria,
+		Tags:            req.Tags,
+		CustomFields:    req.CustomFields,
+		Budget:          req.Budget,
+		ClosesAt:        req.ClosesAt,
 		CreatedAt:       time.Now(),
 	}
 
 	createdTender, err := h.srv.CreateTender(r.Context(), newTender)
 	if err != nil {
-		var statusCode int
-		switch err.Error() {
-		case "service.CreateTender: user does not exist":
-			statusCode = http.StatusUnauthorized
-		case "service.CreateTender: user is not authorized to create tender for this organization":
-			statusCode = http.StatusForbidden
-		default:
-			statusCode = http.StatusInternalServerError
-		}
-		errwriter.RespondWithError(w, statusCode, err.Error())
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
 		return
 	}
 
-	response := domain.TenderResponse{
-		ID:          createdTender.ID,
-		Name:        createdTender.Name,
-		Description: createdTender.Description,
-		Status:      createdTender.Status,
-		ServiceType: createdTender.ServiceType,
-		Version:     createdTender.Version,
-		CreatedAt:   createdTender.CreatedAt,
-	}
+	response := tenderResponse(createdTender)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -212,6 +263,55 @@ func (h *TenderHandler) CreateTenderHandler(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+func (h *TenderHandler) CloneTenderHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	clone, err := h.srv.CloneTender(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error cloning tender:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tenderResponse(clone))
+}
+
+// tenderResponse is the tender as the API returns it.
+func tenderResponse(tender domain.Tender) domain.TenderResponse {
+	return domain.TenderResponse{
+		ID:           tender.ID,
+		Name:         tender.Name,
+		Description:  tender.Description,
+		Status:       tender.Status,
+		ServiceType:  tender.ServiceType,
+		Version:      tender.Version,
+		Type:         tender.Type,
+		Visibility:   tender.Visibility,
+		Sealed:       tender.Sealed,
+		Auction:      tender.Auction,
+		Lots:         tender.Lots,
+		Criteria:     tender.Criteria,
+		Tags:         tender.Tags,
+		CustomFields: tender.CustomFields,
+		Budget:       tender.Budget,
+		ClosesAt:     tender.ClosesAt,
+		CreatedAt:    tender.CreatedAt,
+		Attachments:  tender.Attachments,
+	}
+}
+
 func (h *TenderHandler) GetTenderStatusHandler(w http.ResponseWriter, r *http.Request) {
 	tenderID := r.PathValue("tenderId")
 	if tenderID == "" {
@@ -229,16 +329,7 @@ func (h *TenderHandler) GetTenderStatusHandler(w http.ResponseWriter, r *http.Re
 
 	status, err := h.srv.GetTenderStatus(r.Context(), tenderID, username)
 	if err != nil {
-		var statusCode int
-		switch err.Error() {
-		case "service.GetTenderByID: repository.GetTenderStatus: %!w(<nil>)":
-			statusCode = http.StatusForbidden
-		case "service.GetTenderByID: repository.GetTenderStatus: no rows in result set":
-			statusCode = http.StatusNotFound
-		default:
-			statusCode = http.StatusInternalServerError
-		}
-		errwriter.RespondWithError(w, statusCode, err.Error())
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
 		return
 	}
 
@@ -276,30 +367,11 @@ func (h *TenderHandler) UpdateTenderStatusHandler(w http.ResponseWriter, r *http
 
 	updatedTender, err := h.srv.UpdateTenderStatus(r.Context(), tenderID, status, username)
 	if err != nil {
-		var statusCode int
-		switch err.Error() {
-		case "service.UpdateTenderStatus: repository.UpdateTenderStatus: %!w(<nil>)":
-			statusCode = http.StatusForbidden
-		case "service.UpdateTenderStatus: repository.UpdateTenderStatus: ERROR: new row for relation \"tender\" violates check constraint \"tender_status_check\" (SQLSTATE 23514)":
-			statusCode = http.StatusBadRequest
-		case "service.UpdateTenderStatus: no rows updated; check the ID":
-			statusCode = http.StatusNotFound
-		default:
-			statusCode = http.StatusInternalServerError
-		}
-		errwriter.RespondWithError(w, statusCode, err.Error())
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
 		return
 	}
 
-	response := domain.TenderResponse{
-		ID:          updatedTender.ID,
-		Name:        updatedTender.Name,
-		Description: updatedTender.Description,
-		Status:      updatedTender.Status,
-		ServiceType: updatedTender.ServiceType,
-		Version:     updatedTender.Version,
-		CreatedAt:   updatedTender.CreatedAt,
-	}
+	response := tenderResponse(updatedTender)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -334,30 +406,11 @@ func (h *TenderHandler) UpdatePartTenderHandler(w http.ResponseWriter, r *http.R
 
 	updatedTender, err := h.srv.UpdatePartTender(r.Context(), tenderID, updates, username)
 	if err != nil {
-		var statusCode int
-		switch err.Error() {
-		case "failed to update tender in repository: repository.UpdatePartTender: %!w(<nil>)":
-			statusCode = http.StatusForbidden
-		case "service.UpdateTenderStatus: repository.UpdatePartTender: ERROR: new row for relation \"tender\" violates check constraint \"tender_status_check\" (SQLSTATE 23514)":
-			statusCode = http.StatusBadRequest
-		case "failed to update tender in repository: error fetching current version: no rows in result set":
-			statusCode = http.StatusNotFound
-		default:
-			statusCode = http.StatusInternalServerError
-		}
-		errwriter.RespondWithError(w, statusCode, err.Error())
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
 		return
 	}
 
-	response := domain.TenderResponse{
-		ID:          updatedTender.ID,
-		Name:        updatedTender.Name,
-		Description: updatedTender.Description,
-		Status:      updatedTender.Status,
-		ServiceType: updatedTender.ServiceType,
-		Version:     updatedTender.Version,
-		CreatedAt:   updatedTender.CreatedAt,
-	}
+	response := tenderResponse(updatedTender)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -404,28 +457,11 @@ func (h *TenderHandler) RollbackTenderHandler(w http.ResponseWriter, r *http.Req
 
 	updatedTender, err := h.srv.RollbackTenderVersion(r.Context(), tenderID, version, username)
 	if err != nil {
-		var statusCode int
-		switch err.Error() {
-		case "service.RollbackTenderVersion: repository.UpdatePartTender: %!w(<nil>)":
-			statusCode = http.StatusForbidden
-		case "service.RollbackTenderVersion: error fetching target version: no rows in result set":
-			statusCode = http.StatusNotFound
-		default:
-			statusCode = http.StatusInternalServerError
-		}
-		errwriter.RespondWithError(w, statusCode, err.Error())
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
 		return
 	}
 
-	response := domain.TenderResponse{
-		ID:          updatedTender.ID,
-		Name:        updatedTender.Name,
-		Description: updatedTender.Description,
-		Status:      updatedTender.Status,
-		ServiceType: updatedTender.ServiceType,
-		Version:     updatedTender.Version,
-		CreatedAt:   updatedTender.CreatedAt,
-	}
+	response := tenderResponse(updatedTender)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -435,3 +471,28 @@ func (h *TenderHandler) RollbackTenderHandler(w http.ResponseWriter, r *http.Req
 		errwriter.RespondWithError(w, http.StatusInternalServerError, "Failed to encode response")
 	}
 }
+
+func (h *TenderHandler) GetTenderHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	tender, err := h.srv.GetTender(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching tender:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tenderResponse(tender))
+}
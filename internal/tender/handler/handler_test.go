@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// TestTenderResponse keeps the one place tender responses are built in step
+// with domain.TenderResponse: every field has to be filled from the tender.
+func TestTenderResponse(t *testing.T) {
+	budget := 1000.0
+	closesAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	response := tenderResponse(domain.Tender{
+		ID:           "4c6d2f1e-8b3a-4e5f-9a7c-1d2e3f4a5b6c",
+		Name:         "Pipes",
+		Description:  "Steel pipes",
+		Status:       "PUBLISHED",
+		ServiceType:  "Delivery",
+		Version:      2,
+		Type:         domain.TenderTypeAuction,
+		Visibility:   domain.TenderVisibilityPublic,
+		Sealed:       true,
+		Auction:      &domain.Auction{StartPrice: 1000},
+		Budget:       &budget,
+		ClosesAt:     &closesAt,
+		CreatedAt:    closesAt.Add(-time.Hour),
+		Lots:         []domain.Lot{{Name: "Pipes"}},
+		Criteria:     []domain.Criterion{{Name: "Price", Weight: 1}},
+		Tags:         []string{"steel"},
+		CustomFields: map[string]any{"region": "north"},
+		Attachments:  []domain.Attachment{{FileName: "spec.pdf"}},
+	})
+
+	value := reflect.ValueOf(response)
+	for i := 0; i < value.NumField(); i++ {
+		if value.Field(i).IsZero() {
+			t.Errorf("%s is not filled in from the tender", value.Type().Field(i).Name)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type ImportHandler struct {
+	srv     domain.ImportService
+	maxSize int64
+}
+
+func NewImportHandler(srv domain.ImportService, maxSize int64) *ImportHandler {
+	return &ImportHandler{srv: srv, maxSize: maxSize}
+}
+
+// importFormat takes the format from the format parameter or else from the
+// Content-Type of the body.
+func importFormat(r *http.Request) (domain.ImportFormat, bool) {
+	switch format := domain.ImportFormat(r.URL.Query().Get("format")); format {
+	case domain.ImportFormatCSV, domain.ImportFormatNDJSON:
+		return format, true
+	case "":
+	default:
+		return "", false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		return domain.ImportFormatCSV, true
+	case "application/x-ndjson", "application/jsonl":
+		return domain.ImportFormatNDJSON, true
+	default:
+		return "", false
+	}
+}
+
+func (h *ImportHandler) ImportTendersHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	format, ok := importFormat(r)
+	if !ok {
+		errwriter.RespondWithError(w, http.StatusUnsupportedMediaType, "Expected text/csv or application/x-ndjson payload")
+		logger.Logger().Errorln("Error: Unsupported import format")
+		return
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dryRun"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid dryRun")
+			logger.Logger().Errorln("Error parsing dryRun:", err.Error())
+			return
+		}
+	}
+
+	body := http.MaxBytesReader(w, r.Body, h.maxSize)
+	report, err := h.srv.ImportTenders(r.Context(), body, format, username, dryRun)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			errwriter.RespondWithError(w, http.StatusRequestEntityTooLarge, "Import file is too large")
+		} else {
+			errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		}
+		logger.Logger().Errorln("Error importing tenders:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type InvitationHandler struct {
+	srv domain.InvitationService
+}
+
+func NewInvitationHandler(srv domain.InvitationService) *InvitationHandler {
+	return &InvitationHandler{srv: srv}
+}
+
+func (h *InvitationHandler) CreateInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.CreateInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	invitation, err := h.srv.CreateInvitation(r.Context(), tenderID, req.OrganizationID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error creating invitation:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, invitation)
+}
+
+func (h *InvitationHandler) ListInvitationsHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	invitations, err := h.srv.ListInvitations(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching invitations:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, invitations)
+}
+
+func (h *InvitationHandler) DeleteInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	organizationID := r.PathValue("organizationId")
+	if tenderID == "" || organizationID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender or organization ID")
+		logger.Logger().Errorln("Error: Invalid tender or organization ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	if err := h.srv.DeleteInvitation(r.Context(), tenderID, organizationID, username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error deleting invitation:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
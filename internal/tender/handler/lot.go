@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type LotHandler struct {
+	srv domain.LotService
+}
+
+func NewLotHandler(srv domain.LotService) *LotHandler {
+	return &LotHandler{srv: srv}
+}
+
+func (h *LotHandler) ListLotsHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	lots, err := h.srv.ListLots(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching lots:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, lots)
+}
+
+func (h *LotHandler) AwardLotHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	lotID := r.PathValue("lotId")
+	if tenderID == "" || lotID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender or lot ID")
+		logger.Logger().Errorln("Error: Invalid tender or lot ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.AwardLotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	lot, err := h.srv.AwardLot(r.Context(), tenderID, lotID, req.BidID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error awarding lot:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, lot)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type NotificationHandler struct {
+	srv domain.NotificationService
+}
+
+func NewNotificationHandler(srv domain.NotificationService) *NotificationHandler {
+	return &NotificationHandler{srv: srv}
+}
+
+func (h *NotificationHandler) GetSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	settings, err := h.srv.GetSettings(r.Context(), username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching notification settings:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, settings)
+}
+
+func (h *NotificationHandler) UpdateSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var settings domain.NotificationSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	updated, err := h.srv.UpdateSettings(r.Context(), username, settings)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error updating notification settings:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (h *NotificationHandler) ListNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+	limit, offset := parsePagination(r)
+
+	notifications, err := h.srv.ListNotifications(r.Context(), username, unreadOnly, limit, offset)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching notifications:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, notifications)
+}
+
+func (h *NotificationHandler) MarkNotificationReadHandler(w http.ResponseWriter, r *http.Request) {
+	notificationID := r.PathValue("notificationId")
+	if notificationID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid notification ID")
+		logger.Logger().Errorln("Error: Invalid notification ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	notification, err := h.srv.MarkNotificationRead(r.Context(), notificationID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error marking notification as read:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, notification)
+}
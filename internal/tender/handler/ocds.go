@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type OCDSHandler struct {
+	srv domain.OCDSService
+}
+
+func NewOCDSHandler(srv domain.OCDSService) *OCDSHandler {
+	return &OCDSHandler{srv: srv}
+}
+
+// packageURI is the address the package was requested at, which identifies
+// it in OCDS.
+func packageURI(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+func writeOCDS(w http.ResponseWriter, pkg []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(pkg)))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(pkg); err != nil {
+		logger.Logger().Errorln("Error writing OCDS package:", err.Error())
+	}
+}
+
+func (h *OCDSHandler) TenderPackageHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	packageType := domain.OCDSPackageType(r.URL.Query().Get("package"))
+	if packageType == "" {
+		packageType = domain.OCDSPackageRecord
+	}
+
+	pkg, err := h.srv.GetTenderPackage(r.Context(), tenderID, packageType, packageURI(r))
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error exporting tender to OCDS:", err.Error())
+		return
+	}
+
+	writeOCDS(w, pkg)
+}
+
+func (h *OCDSHandler) ListReleasesHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+	filter := domain.OCDSFilter{Limit: limit, Offset: offset}
+
+	if value := r.URL.Query().Get("since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid since, expected an RFC 3339 date")
+			logger.Logger().Errorln("Error parsing since:", err.Error())
+			return
+		}
+		filter.Since = &since
+	}
+
+	pkg, err := h.srv.ListReleases(r.Context(), filter, packageURI(r))
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error exporting OCDS releases:", err.Error())
+		return
+	}
+
+	writeOCDS(w, pkg)
+}
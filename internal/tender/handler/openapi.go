@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+// swaggerUI is the page of the documentation. The server hosts only the
+// page, the scripts and styles of Swagger UI are loaded from the base URL of
+// a swagger-ui-dist package.
+var swaggerUI = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Tender API</title>
+<link rel="stylesheet" href="{{.}}/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="{{.}}/swagger-ui-bundle.js" crossorigin></script>
+<script>
+window.ui = SwaggerUIBundle({url: "/api/openapi.json", dom_id: "#swagger-ui"});
+</script>
+</body>
+</html>
+`))
+
+type OpenAPIHandler struct {
+	spec []byte
+	page []byte
+}
+
+// NewOpenAPIHandler serves the specification and the Swagger UI page loading
+// its assets from swaggerUIURL.
+func NewOpenAPIHandler(spec []byte, swaggerUIURL string) (*OpenAPIHandler, error) {
+	var page bytes.Buffer
+	if err := swaggerUI.Execute(&page, strings.TrimSuffix(swaggerUIURL, "/")); err != nil {
+		return nil, err
+	}
+
+	return &OpenAPIHandler{spec: spec, page: page.Bytes()}, nil
+}
+
+func (h *OpenAPIHandler) SpecHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(h.spec)))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(h.spec); err != nil {
+		logger.Logger().Errorln("Error writing OpenAPI specification:", err.Error())
+	}
+}
+
+func (h *OpenAPIHandler) SwaggerUIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(h.page); err != nil {
+		logger.Logger().Errorln("Error writing Swagger UI:", err.Error())
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSwaggerUIHandler(t *testing.T) {
+	h, err := NewOpenAPIHandler([]byte(`{}`), "https://mirror.example.com/swagger-ui/")
+	if err != nil {
+		t.Fatalf("NewOpenAPIHandler: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.SwaggerUIHandler(rec, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+
+	page := rec.Body.String()
+	for _, asset := range []string{"https://mirror.example.com/swagger-ui/swagger-ui.css", "https://mirror.example.com/swagger-ui/swagger-ui-bundle.js"} {
+		if !strings.Contains(page, asset) {
+			t.Errorf("page does not load %s:\n%s", asset, page)
+		}
+	}
+	if strings.Contains(page, "unpkg.com") {
+		t.Errorf("page loads assets from the default CDN:\n%s", page)
+	}
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type QuestionHandler struct {
+	srv domain.QuestionService
+}
+
+func NewQuestionHandler(srv domain.QuestionService) *QuestionHandler {
+	return &QuestionHandler{srv: srv}
+}
+
+func (h *QuestionHandler) CreateQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.CreateQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	question, err := h.srv.CreateQuestion(r.Context(), tenderID, req.Question, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error creating question:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, question)
+}
+
+func (h *QuestionHandler) ListQuestionsHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	if tenderID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender ID")
+		logger.Logger().Errorln("Error: Invalid tender ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	questions, err := h.srv.ListQuestions(r.Context(), tenderID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching questions:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, questions)
+}
+
+func (h *QuestionHandler) AnswerQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID := r.PathValue("tenderId")
+	questionID := r.PathValue("questionId")
+	if tenderID == "" || questionID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid tender or question ID")
+		logger.Logger().Errorln("Error: Invalid tender or question ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.AnswerQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	visibility := domain.QuestionVisibilityPrivate
+	if req.Public {
+		visibility = domain.QuestionVisibilityPublic
+	}
+
+	question, err := h.srv.AnswerQuestion(r.Context(), tenderID, questionID, req.Answer, visibility, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error answering question:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, question)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Logger().Errorln("Error encoding JSON response:", err.Error())
+	}
+}
+
+func parsePagination(r *http.Request) (int, int) {
+	limit := 10
+	offset := 0
+
+	if parsedLimit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		limit = parsedLimit
+	}
+	if parsedOffset, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil {
+		offset = parsedOffset
+	}
+
+	return limit, offset
+}
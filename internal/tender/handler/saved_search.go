@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type SavedSearchHandler struct {
+	srv domain.SavedSearchService
+}
+
+func NewSavedSearchHandler(srv domain.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{srv: srv}
+}
+
+func (h *SavedSearchHandler) CreateSavedSearchHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.CreateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	if req.Name == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Missing required fields")
+		logger.Logger().Errorln("Error: Missing required fields in request")
+		return
+	}
+
+	subscribed := true
+	if req.Subscribed != nil {
+		subscribed = *req.Subscribed
+	}
+
+	created, err := h.srv.CreateSavedSearch(r.Context(), domain.SavedSearch{
+		Name:           req.Name,
+		Username:       username,
+		ServiceTypes:   req.ServiceTypes,
+		Keywords:       req.Keywords,
+		BudgetMin:      req.BudgetMin,
+		BudgetMax:      req.BudgetMax,
+		OrganizationId: req.OrganizationId,
+		Subscribed:     subscribed,
+		WebhookURL:     req.WebhookURL,
+		WebhookSecret:  req.WebhookSecret,
+	})
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error creating saved search:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (h *SavedSearchHandler) ListSavedSearchesHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	searches, err := h.srv.ListSavedSearches(r.Context(), username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching saved searches:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, searches)
+}
+
+func (h *SavedSearchHandler) SetSubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	searchID := r.PathValue("searchId")
+	if searchID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid saved search ID")
+		logger.Logger().Errorln("Error: Invalid saved search ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	subscribed, err := strconv.ParseBool(r.URL.Query().Get("subscribed"))
+	if err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid subscribed value")
+		logger.Logger().Errorln("Error: Invalid subscribed value")
+		return
+	}
+
+	search, err := h.srv.SetSubscription(r.Context(), searchID, subscribed, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error updating subscription:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, search)
+}
+
+func (h *SavedSearchHandler) DeleteSavedSearchHandler(w http.ResponseWriter, r *http.Request) {
+	searchID := r.PathValue("searchId")
+	if searchID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid saved search ID")
+		logger.Logger().Errorln("Error: Invalid saved search ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	if err := h.srv.DeleteSavedSearch(r.Context(), searchID, username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error deleting saved search:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
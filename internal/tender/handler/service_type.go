@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type ServiceTypeHandler struct {
+	srv domain.ServiceTypeService
+}
+
+func NewServiceTypeHandler(srv domain.ServiceTypeService) *ServiceTypeHandler {
+	return &ServiceTypeHandler{srv: srv}
+}
+
+func (h *ServiceTypeHandler) ListServiceTypesHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	serviceTypes, err := h.srv.ListServiceTypes(r.Context(), domain.ServiceTypeFilter{
+		Parent:          query.Get("parent"),
+		All:             query.Get("all") == "true",
+		IncludeInactive: query.Get("includeInactive") == "true",
+		Locale:          query.Get("locale"),
+	})
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching service types:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, serviceTypes)
+}
+
+func (h *ServiceTypeHandler) GetServiceTypeHandler(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	if code == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid service type code")
+		logger.Logger().Errorln("Error: Invalid service type code")
+		return
+	}
+
+	serviceType, err := h.srv.GetServiceType(r.Context(), code, r.URL.Query().Get("locale"))
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching service type:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, serviceType)
+}
+
+func (h *ServiceTypeHandler) CreateServiceTypeHandler(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.ServiceType
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	if req.Code == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Missing required fields")
+		logger.Logger().Errorln("Error: Missing required fields in request")
+		return
+	}
+
+	created, err := h.srv.CreateServiceType(r.Context(), domain.ServiceType{
+		Code:       req.Code,
+		ParentCode: req.ParentCode,
+		Scheme:     req.Scheme,
+		Names:      req.Names,
+	}, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error creating service type:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (h *ServiceTypeHandler) UpdateServiceTypeHandler(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	if code == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid service type code")
+		logger.Logger().Errorln("Error: Invalid service type code")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var update domain.ServiceTypeUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	updated, err := h.srv.UpdateServiceType(r.Context(), code, update, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error updating service type:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (h *ServiceTypeHandler) DeleteServiceTypeHandler(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	if code == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid service type code")
+		logger.Logger().Errorln("Error: Invalid service type code")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	if err := h.srv.DeleteServiceType(r.Context(), code, username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error deleting service type:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type TemplateHandler struct {
+	srv domain.TemplateService
+}
+
+func NewTemplateHandler(srv domain.TemplateService) *TemplateHandler {
+	return &TemplateHandler{srv: srv}
+}
+
+func (h *TemplateHandler) CreateTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.PathValue("organizationId")
+	if organizationID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		logger.Logger().Errorln("Error: Invalid organization ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	var req domain.CreateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		logger.Logger().Errorln("Error decoding request payload:", err.Error())
+		return
+	}
+
+	created, err := h.srv.CreateTemplate(r.Context(), domain.TenderTemplate{
+		OrganizationID:  organizationID,
+		Name:            req.Name,
+		Tender:          req.Tender,
+		CreatorUsername: username,
+	}, req.TenderID)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error creating tender template:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (h *TemplateHandler) ListTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.PathValue("organizationId")
+	if organizationID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		logger.Logger().Errorln("Error: Invalid organization ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	templates, err := h.srv.ListTemplates(r.Context(), organizationID, username)
+	if err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error fetching tender templates:", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, templates)
+}
+
+func (h *TemplateHandler) DeleteTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.PathValue("organizationId")
+	templateID := r.PathValue("templateId")
+	if organizationID == "" || templateID == "" {
+		errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid organization or template ID")
+		logger.Logger().Errorln("Error: Invalid organization or template ID")
+		return
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		errwriter.RespondWithError(w, http.StatusUnauthorized, "Missing username")
+		logger.Logger().Errorln("Error: Missing username in query parameters")
+		return
+	}
+
+	if err := h.srv.DeleteTemplate(r.Context(), organizationID, templateID, username); err != nil {
+		errwriter.RespondWithError(w, statusFromError(err), err.Error())
+		logger.Logger().Errorln("Error deleting tender template:", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
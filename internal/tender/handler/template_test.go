@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const (
+	templateOrg      = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	templateOtherOrg = "16fd2706-8baf-433b-82eb-8c7fada847da"
+	templateID       = "0b6e1f0a-2c44-4d7e-9d1c-6b3f7a9e1c01"
+)
+
+type tenderServiceStub struct {
+	domain.TenderService
+	created []domain.Tender
+}
+
+func (s *tenderServiceStub) CreateTender(_ context.Context, tender domain.Tender) (domain.Tender, error) {
+	s.created = append(s.created, tender)
+	return tender, nil
+}
+
+type templateServiceStub struct {
+	domain.TemplateService
+	template domain.TenderTemplate
+}
+
+func (s templateServiceStub) GetTemplate(_ context.Context, id string, _ string) (domain.TenderTemplate, error) {
+	if id != s.template.ID {
+		return domain.TenderTemplate{}, domain.ErrNotFound
+	}
+	return s.template, nil
+}
+
+func TestCreateTenderFromTemplate(t *testing.T) {
+	budget := 900.0
+	template := domain.TenderTemplate{
+		ID:             templateID,
+		OrganizationID: templateOrg,
+		Name:           "Уборка",
+		Tender: domain.TemplateTender{
+			Name:         "Уборка офиса",
+			Description:  "Ежедневная уборка",
+			ServiceType:  "Delivery",
+			Visibility:   domain.TenderVisibilityPrivate,
+			Budget:       &budget,
+			Lots:         []domain.Lot{{Name: "Этаж 1", Quantity: 1, Unit: "шт"}},
+			Criteria:     []domain.Criterion{{Name: "Цена", Weight: 1}},
+			Tags:         []string{"уборка"},
+			CustomFields: map[string]any{"region": "Москва", "floors": 3.0},
+		},
+	}
+
+	cases := []struct {
+		name  string
+		body  string
+		check func(t *testing.T, tender domain.Tender)
+	}{
+		{
+			name: "template fields",
+			body: `{"templateId": "` + templateID + `", "creatorUsername": "ivan"}`,
+			check: func(t *testing.T, tender domain.Tender) {
+				if tender.Name != "Уборка офиса" || tender.Description != "Ежедневная уборка" || tender.ServiceType != "Delivery" ||
+					tender.OrganizationId != templateOrg || tender.Visibility != domain.TenderVisibilityPrivate ||
+					tender.Budget == nil || *tender.Budget != budget {
+					t.Errorf("tender = %+v, want the fields of the template", tender)
+				}
+				if len(tender.Lots) != 1 || len(tender.Criteria) != 1 || !slices.Equal(tender.Tags, []string{"уборка"}) ||
+					tender.CustomFields["region"] != "Москва" {
+					t.Errorf("tender = %+v, want the lots, criteria, tags and fields of the template", tender)
+				}
+			},
+		},
+		{
+			name: "request fields win",
+			body: `{"templateId": "` + templateID + `", "creatorUsername": "ivan", "name": "Уборка склада",
+				"visibility": "PUBLIC", "budget": 1500, "tags": ["склад"], "customFields": {"region": "Казань"}}`,
+			check: func(t *testing.T, tender domain.Tender) {
+				if tender.Name != "Уборка склада" || tender.Visibility != domain.TenderVisibilityPublic || tender.Budget == nil || *tender.Budget != 1500 {
+					t.Errorf("tender = %+v, want the name, visibility and budget of the request", tender)
+				}
+				if tender.Description != "Ежедневная уборка" || len(tender.Lots) != 1 {
+					t.Errorf("tender = %+v, want the description and lots of the template", tender)
+				}
+				if !slices.Equal(tender.Tags, []string{"склад"}) {
+					t.Errorf("tags = %v, the list of the request replaces the one of the template", tender.Tags)
+				}
+				// JSON objects are merged key by key into the map of the template.
+				if !maps.Equal(tender.CustomFields, map[string]any{"region": "Казань", "floors": 3.0}) {
+					t.Errorf("custom fields = %v, want the region of the request and the floors of the template", tender.CustomFields)
+				}
+			},
+		},
+		{
+			name: "empty lists clear the template",
+			body: `{"templateId": "` + templateID + `", "creatorUsername": "ivan", "lots": [], "tags": []}`,
+			check: func(t *testing.T, tender domain.Tender) {
+				if len(tender.Lots) != 0 || len(tender.Tags) != 0 {
+					t.Errorf("lots = %+v, tags = %v, want none", tender.Lots, tender.Tags)
+				}
+				if len(tender.Criteria) != 1 {
+					t.Errorf("criteria = %+v, want those of the template", tender.Criteria)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			srv := &tenderServiceStub{}
+			h := NewTenderHandler(srv, templateServiceStub{template: template}, nil)
+
+			rec := httptest.NewRecorder()
+			h.CreateTenderHandler(rec, httptest.NewRequest(http.MethodPost, "/api/tender/new", strings.NewReader(c.body)))
+			if rec.Code != http.StatusCreated || len(srv.created) != 1 {
+				t.Fatalf("status %d: %s", rec.Code, rec.Body)
+			}
+			c.check(t, srv.created[0])
+		})
+	}
+
+	if !slices.Equal(template.Tender.Tags, []string{"уборка"}) || template.Tender.CustomFields["region"] != "Москва" ||
+		len(template.Tender.Lots) != 1 || *template.Tender.Budget != 900 {
+		t.Errorf("template = %+v, requests must not change it", template.Tender)
+	}
+
+	for _, c := range []struct {
+		name string
+		body string
+		want int
+	}{
+		{"another organization", `{"templateId": "` + templateID + `", "creatorUsername": "ivan", "organizationId": "` + templateOtherOrg + `"}`, http.StatusBadRequest},
+		{"missing template", `{"templateId": "` + templateOtherOrg + `", "creatorUsername": "ivan"}`, http.StatusNotFound},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			srv := &tenderServiceStub{}
+			h := NewTenderHandler(srv, templateServiceStub{template: template}, nil)
+
+			rec := httptest.NewRecorder()
+			h.CreateTenderHandler(rec, httptest.NewRequest(http.MethodPost, "/api/tender/new", strings.NewReader(c.body)))
+			if rec.Code != c.want || len(srv.created) != 0 {
+				t.Errorf("status %d, %d tenders created, want %d and none: %s", rec.Code, len(srv.created), c.want, rec.Body)
+			}
+		})
+	}
+}
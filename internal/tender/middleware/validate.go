@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	errwriter "github.com/Te8va/Tender/internal/pkg/errWriter"
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/openapi"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+// Validate rejects requests that do not match the specification before they
+// reach next. Requests of unspecified routes are passed through, the mux
+// answers them.
+func Validate(validator *openapi.Validator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operation, pathValues := validator.Operation(r)
+		if operation == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		details, err := validator.Validate(r, operation, pathValues)
+		switch {
+		case errors.Is(err, openapi.ErrBodyTooLarge):
+			errwriter.RespondWithError(w, http.StatusRequestEntityTooLarge, err.Error())
+			logger.Logger().Errorln("Error validating request:", err.Error())
+			return
+		case err != nil:
+			errwriter.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			logger.Logger().Errorln("Error reading request body:", err.Error())
+			return
+		case len(details) > 0:
+			errwriter.RespondWithDetails(w, validationStatus(details), "Invalid request", details)
+			logger.Logger().Errorln("Request", r.Method, r.URL.String(), "failed validation:", details)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validationStatus keeps the 401 handlers answer a request without the
+// username with.
+func validationStatus(details []domain.FieldError) int {
+	for _, detail := range details {
+		if detail.In == "query" && detail.Field == "username" && detail.Error == openapi.Missing {
+			return http.StatusUnauthorized
+		}
+	}
+
+	return http.StatusBadRequest
+}
@@ -0,0 +1,215 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+var (
+	_ domain.Notifier = (*Dispatcher)(nil)
+)
+
+var (
+	ErrQueueFull   = errors.New("notification queue is full")
+	ErrQueueClosed = errors.New("notification queue is closed")
+)
+
+const sendTimeout = 30 * time.Second
+
+// Dispatcher queues notifications and delivers them from a pool of
+// background workers, so callers never wait for recipients lookup, SMTP or
+// webhooks. Bid and closing events are mailed to the organization
+// responsibles; published tenders are matched against saved searches and
+// reported in-app and to the search webhook.
+type Dispatcher struct {
+	repo      domain.NotificationRepository
+	searches  domain.SavedSearchRepository
+	sender    domain.MailSender
+	webhooks  domain.WebhookSender
+	templates *templates
+	workers   int
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan domain.Notification
+}
+
+func NewDispatcher(repo domain.NotificationRepository, searches domain.SavedSearchRepository, sender domain.MailSender,
+	webhooks domain.WebhookSender, queueSize, workers int) (*Dispatcher, error) {
+	tmpl, err := loadTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("notification.NewDispatcher: %w", err)
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &Dispatcher{
+		repo:      repo,
+		searches:  searches,
+		sender:    sender,
+		webhooks:  webhooks,
+		templates: tmpl,
+		workers:   workers,
+		queue:     make(chan domain.Notification, queueSize),
+	}, nil
+}
+
+// Notify enqueues the notification without blocking. ErrQueueFull is returned
+// when the workers cannot keep up; the notification is dropped in that case.
+func (d *Dispatcher) Notify(_ context.Context, notification domain.Notification) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case d.queue <- notification:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Run starts the workers. They exit once Close has been called and the queue
+// is drained, or as soon as ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context, wg *sync.WaitGroup) {
+	for i := 0; i < d.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case notification, ok := <-d.queue:
+					if !ok {
+						return
+					}
+					d.deliver(ctx, notification)
+				}
+			}
+		}()
+	}
+}
+
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, notification domain.Notification) {
+	if notification.Event == domain.NotificationTenderPublished {
+		d.deliverSearchMatches(ctx, notification)
+		return
+	}
+
+	d.deliverMail(ctx, notification)
+}
+
+func (d *Dispatcher) deliverMail(ctx context.Context, notification domain.Notification) {
+	recipients, err := d.repo.ListRecipients(ctx, notification.Tender.OrganizationId, notification.Event)
+	if err != nil {
+		logger.Logger().Errorln("Error listing notification recipients:", err.Error())
+		return
+	}
+
+	for _, recipient := range recipients {
+		subject, body, err := d.templates.render(notification.Event, templateData{
+			Recipient: recipient,
+			Tender:    notification.Tender,
+			Bid:       notification.Bid,
+		})
+		if err != nil {
+			logger.Logger().Errorln("Error rendering notification:", err.Error())
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		err = d.sender.Send(sendCtx, domain.Mail{To: []string{recipient.Email}, Subject: subject, Body: body})
+		cancel()
+		if err != nil {
+			logger.Logger().Errorln("Error sending notification to", recipient.Username+":", err.Error())
+		}
+	}
+}
+
+type webhookPayload struct {
+	Event         domain.NotificationEvent `json:"event"`
+	SavedSearchID string                   `json:"savedSearchId"`
+	Tender        domain.TenderResponse    `json:"tender"`
+}
+
+func (d *Dispatcher) deliverSearchMatches(ctx context.Context, notification domain.Notification) {
+	matches, err := d.searches.ListMatchingSearches(ctx, notification.Tender)
+	if err != nil {
+		logger.Logger().Errorln("Error matching saved searches:", err.Error())
+		return
+	}
+
+	tender := notification.Tender
+	for _, match := range matches {
+		search := match.Search
+
+		title, message, err := d.templates.render(notification.Event, templateData{
+			Recipient: domain.Recipient{Username: search.Username, Locale: match.Locale},
+			Tender:    tender,
+			Search:    &search,
+		})
+		if err != nil {
+			logger.Logger().Errorln("Error rendering notification:", err.Error())
+			continue
+		}
+
+		_, err = d.repo.CreateNotification(ctx, domain.InAppNotification{
+			Username:      search.Username,
+			Event:         string(notification.Event),
+			TenderID:      tender.ID,
+			SavedSearchID: search.ID,
+			Title:         title,
+			Message:       message,
+		})
+		if err != nil {
+			logger.Logger().Errorln("Error saving notification for", search.Username+":", err.Error())
+		}
+
+		if search.WebhookURL == "" {
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		err = d.webhooks.Send(sendCtx, domain.Webhook{URL: search.WebhookURL, Secret: search.WebhookSecret}, webhookPayload{
+			Event:         notification.Event,
+			SavedSearchID: search.ID,
+			Tender: domain.TenderResponse{
+				ID:          tender.ID,
+				Name:        tender.Name,
+				Description: tender.Description,
+				Status:      tender.Status,
+				ServiceType: tender.ServiceType,
+				Version:     tender.Version,
+				Budget:      tender.Budget,
+				ClosesAt:    tender.ClosesAt,
+				CreatedAt:   tender.CreatedAt,
+			},
+		})
+		cancel()
+		if err != nil {
+			logger.Logger().Errorln("Error calling webhook of saved search", search.ID+":", err.Error())
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// fakeSMTPServer is a minimal SMTP stand-in that accepts every message and
+// hands the raw DATA section to the test.
+type fakeSMTPServer struct {
+	ln       net.Listener
+	messages chan string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	s := &fakeSMTPServer{ln: ln, messages: make(chan string, 10)}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeSMTPServer) addr() (string, int) {
+	addr := s.ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"), strings.HasPrefix(cmd, "RSET"):
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.messages <- data.String()
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+type fakeNotificationRepo struct {
+	domain.NotificationRepository
+	recipients []domain.Recipient
+
+	mu            sync.Mutex
+	notifications []domain.InAppNotification
+}
+
+func (r *fakeNotificationRepo) ListRecipients(context.Context, string, domain.NotificationEvent) ([]domain.Recipient, error) {
+	return r.recipients, nil
+}
+
+func (r *fakeNotificationRepo) CreateNotification(_ context.Context, notification domain.InAppNotification) (domain.InAppNotification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.notifications = append(r.notifications, notification)
+	return notification, nil
+}
+
+type fakeSearchRepo struct {
+	domain.SavedSearchRepository
+	matches []domain.SearchMatch
+}
+
+func (r *fakeSearchRepo) ListMatchingSearches(context.Context, domain.Tender) ([]domain.SearchMatch, error) {
+	return r.matches, nil
+}
+
+type fakeWebhookSender struct {
+	mu       sync.Mutex
+	webhooks []domain.Webhook
+}
+
+func (s *fakeWebhookSender) Send(_ context.Context, webhook domain.Webhook, _ any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhooks = append(s.webhooks, webhook)
+	return nil
+}
+
+func TestDispatcherDeliversLocalizedMail(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	host, port := server.addr()
+
+	repo := &fakeNotificationRepo{recipients: []domain.Recipient{
+		{Username: "ivan", FirstName: "Иван", Email: "ivan@example.com", Locale: domain.LocaleRU},
+		{Username: "john", FirstName: "John", Email: "john@example.com", Locale: domain.LocaleEN},
+	}}
+
+	dispatcher, err := NewDispatcher(repo, nil, NewSMTPSender(host, port, "", "", "tender@example.com"), nil, 10, 1)
+	if err != nil {
+		t.Fatalf("NewDispatcher: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	dispatcher.Run(ctx, &wg)
+
+	err = dispatcher.Notify(ctx, domain.Notification{
+		Event:  domain.NotificationBidCreated,
+		Tender: domain.Tender{ID: "t-1", Name: "Серверы", OrganizationId: "org-1"},
+		Bid:    &domain.Bid{ID: "b-1", Name: "Поставка", CreatedAt: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	dispatcher.Close()
+	wg.Wait()
+
+	subjects := map[string]string{}
+	for i := 0; i < len(repo.recipients); i++ {
+		select {
+		case raw := <-server.messages:
+			msg, err := mail.ReadMessage(strings.NewReader(raw))
+			if err != nil {
+				t.Fatalf("ReadMessage: %v", err)
+			}
+
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			if err != nil {
+				t.Fatalf("DecodeHeader: %v", err)
+			}
+
+			body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			if !strings.Contains(string(body), "Поставка") {
+				t.Errorf("body for %s does not mention the bid: %q", msg.Header.Get("To"), body)
+			}
+
+			subjects[msg.Header.Get("To")] = subject
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for message")
+		}
+	}
+
+	if want := "Новое предложение по тендеру «Серверы»"; subjects["ivan@example.com"] != want {
+		t.Errorf("russian subject = %q, want %q", subjects["ivan@example.com"], want)
+	}
+
+	if want := `New bid on tender "Серверы"`; subjects["john@example.com"] != want {
+		t.Errorf("english subject = %q, want %q", subjects["john@example.com"], want)
+	}
+}
+
+func TestDispatcherNotifyDoesNotBlock(t *testing.T) {
+	dispatcher, err := NewDispatcher(&fakeNotificationRepo{}, nil, NewLogSender(), nil, 1, 1)
+	if err != nil {
+		t.Fatalf("NewDispatcher: %v", err)
+	}
+
+	notification := domain.Notification{Event: domain.NotificationTenderClosing}
+	if err := dispatcher.Notify(context.Background(), notification); err != nil {
+		t.Fatalf("first Notify: %v", err)
+	}
+
+	if err := dispatcher.Notify(context.Background(), notification); err != ErrQueueFull {
+		t.Fatalf("second Notify error = %v, want %v", err, ErrQueueFull)
+	}
+
+	dispatcher.Close()
+	if err := dispatcher.Notify(context.Background(), notification); err != ErrQueueClosed {
+		t.Fatalf("Notify after Close error = %v, want %v", err, ErrQueueClosed)
+	}
+}
+
+func TestDispatcherDeliversSearchMatches(t *testing.T) {
+	repo := &fakeNotificationRepo{}
+	searches := &fakeSearchRepo{matches: []domain.SearchMatch{
+		{Search: domain.SavedSearch{ID: "s-1", Name: "Серверы", Username: "ivan"}, Locale: domain.LocaleRU},
+		{Search: domain.SavedSearch{ID: "s-2", Name: "Servers", Username: "john", WebhookURL: "https://example.com/hook", WebhookSecret: "s3cret"}, Locale: domain.LocaleEN},
+	}}
+	webhooks := &fakeWebhookSender{}
+
+	dispatcher, err := NewDispatcher(repo, searches, NewLogSender(), webhooks, 10, 1)
+	if err != nil {
+		t.Fatalf("NewDispatcher: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	dispatcher.Run(context.Background(), &wg)
+
+	err = dispatcher.Notify(context.Background(), domain.Notification{
+		Event:  domain.NotificationTenderPublished,
+		Tender: domain.Tender{ID: "t-1", Name: "Серверы для ЦОД", OrganizationId: "org-1"},
+	})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	dispatcher.Close()
+	wg.Wait()
+
+	if len(repo.notifications) != 2 {
+		t.Fatalf("in-app notifications = %+v, want one per matching search", repo.notifications)
+	}
+	for i, notification := range repo.notifications {
+		search := searches.matches[i].Search
+		if notification.Username != search.Username || notification.SavedSearchID != search.ID || notification.TenderID != "t-1" || notification.Read {
+			t.Errorf("notification %d = %+v, want an unread one for search %s", i, notification, search.ID)
+		}
+	}
+
+	want := []domain.Webhook{{URL: "https://example.com/hook", Secret: "s3cret"}}
+	if len(webhooks.webhooks) != 1 || webhooks.webhooks[0] != want[0] {
+		t.Errorf("webhooks = %+v, want %+v", webhooks.webhooks, want)
+	}
+}
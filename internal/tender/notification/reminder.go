@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+// ClosingReminder periodically looks for published tenders whose closing time
+// falls within the reminder window and notifies their organization once.
+type ClosingReminder struct {
+	repo     domain.NotificationRepository
+	notifier domain.Notifier
+	before   time.Duration
+	interval time.Duration
+}
+
+func NewClosingReminder(repo domain.NotificationRepository, notifier domain.Notifier, before, interval time.Duration) *ClosingReminder {
+	return &ClosingReminder{repo: repo, notifier: notifier, before: before, interval: interval}
+}
+
+func (r *ClosingReminder) Run(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			r.check(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *ClosingReminder) check(ctx context.Context) {
+	tenders, err := r.repo.ListClosingTenders(ctx, time.Now().Add(r.before))
+	if err != nil {
+		logger.Logger().Errorln("Error listing closing tenders:", err.Error())
+		return
+	}
+
+	for _, tender := range tenders {
+		err := r.notifier.Notify(ctx, domain.Notification{Event: domain.NotificationTenderClosing, Tender: tender})
+		if err != nil {
+			logger.Logger().Errorln("Error queueing closing notification:", err.Error())
+			continue
+		}
+
+		if err := r.repo.MarkClosingNotified(ctx, tender.ID); err != nil {
+			logger.Logger().Errorln("Error marking tender as notified:", err.Error())
+		}
+	}
+}
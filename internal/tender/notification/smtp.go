@@ -0,0 +1,140 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+var (
+	_ domain.MailSender = (*SMTPSender)(nil)
+	_ domain.MailSender = (*LogSender)(nil)
+)
+
+type SMTPSender struct {
+	host string
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPSender{
+		host: host,
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+		auth: auth,
+	}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, mail domain.Mail) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+		}
+	}
+
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+	}
+
+	for _, to := range mail.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+	}
+
+	if _, err := w.Write(buildMessage(s.from, mail)); err != nil {
+		return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+	}
+
+	if err := client.Quit(); err != nil {
+		return fmt.Errorf("notification.SMTPSender.Send: %w", err)
+	}
+
+	return nil
+}
+
+func buildMessage(from string, mail domain.Mail) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + strings.Join(mail.To, ", ") + "\r\n")
+	buf.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", mail.Subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	body := strings.ReplaceAll(mail.Body, "\r\n", "\n")
+	qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	qp.Close()
+
+	return buf.Bytes()
+}
+
+// LogSender is used when no SMTP server is configured: messages are written
+// to the service log instead of being delivered.
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(_ context.Context, mail domain.Mail) error {
+	logger.Logger().Infoln("Mail to", strings.Join(mail.To, ", "), "not sent, SMTP is not configured:", mail.Subject)
+	return nil
+}
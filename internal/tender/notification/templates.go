@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+type templateData struct {
+	Recipient domain.Recipient
+	Tender    domain.Tender
+	Bid       *domain.Bid
+	Search    *domain.SavedSearch
+}
+
+type templates struct {
+	byName map[string]*template.Template
+}
+
+func loadTemplates() (*templates, error) {
+	paths, err := templateFS.ReadDir("templates")
+	if err != nil {
+		return nil, fmt.Errorf("notification.loadTemplates: %w", err)
+	}
+
+	t := &templates{byName: make(map[string]*template.Template, len(paths))}
+	for _, entry := range paths {
+		tmpl, err := template.ParseFS(templateFS, "templates/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("notification.loadTemplates: %w", err)
+		}
+		t.byName[strings.TrimSuffix(entry.Name(), ".tmpl")] = tmpl
+	}
+
+	return t, nil
+}
+
+// render returns subject and body of the message for the event in the
+// recipient's locale, falling back to Russian when the locale is unknown.
+func (t *templates) render(event domain.NotificationEvent, data templateData) (string, string, error) {
+	tmpl, ok := t.byName[string(event)+"."+data.Recipient.Locale]
+	if !ok {
+		tmpl, ok = t.byName[string(event)+"."+domain.LocaleRU]
+	}
+	if !ok {
+		return "", "", fmt.Errorf("notification.render: no template for event %q", event)
+	}
+
+	var subject, body strings.Builder
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", fmt.Errorf("notification.render: %w", err)
+	}
+
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", fmt.Errorf("notification.render: %w", err)
+	}
+
+	return strings.TrimSpace(subject.String()), strings.TrimLeft(body.String(), "\n"), nil
+}
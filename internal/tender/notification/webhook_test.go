@@ -0,0 +1,122 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type webhookRequest struct {
+	body      []byte
+	signature string
+}
+
+func newWebhookServer(t *testing.T) (*httptest.Server, chan webhookRequest) {
+	t.Helper()
+
+	requests := make(chan webhookRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- webhookRequest{body: body, signature: r.Header.Get(SignatureHeader)}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func TestWebhookSignature(t *testing.T) {
+	server, requests := newWebhookServer(t)
+
+	// The test server listens on loopback, which NewHTTPWebhookSender refuses.
+	sender := &HTTPWebhookSender{client: server.Client()}
+
+	err := sender.Send(context.Background(), domain.Webhook{URL: server.URL, Secret: "s3cret"}, map[string]string{"tenderId": "t-1"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	req := <-requests
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(req.body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); req.signature != want {
+		t.Errorf("signature = %q, want %q", req.signature, want)
+	}
+
+	if err := sender.Send(context.Background(), domain.Webhook{URL: server.URL}, map[string]string{}); err != nil {
+		t.Fatalf("Send without secret: %v", err)
+	}
+	if req := <-requests; req.signature != "" {
+		t.Errorf("signature without secret = %q, want none", req.signature)
+	}
+}
+
+func TestWebhookRefusesInternalAddresses(t *testing.T) {
+	server, requests := newWebhookServer(t)
+
+	err := NewHTTPWebhookSender(time.Second).Send(context.Background(), domain.Webhook{URL: server.URL}, map[string]string{})
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("Send to %s: error = %v, want %v", server.URL, err, ErrBlockedAddress)
+	}
+	if len(requests) != 0 {
+		t.Error("the webhook was called")
+	}
+
+}
+
+func TestDialPublic(t *testing.T) {
+	tests := []struct {
+		address string
+		blocked bool
+	}{
+		{"93.184.216.34:443", false},
+		{"[2606:2800:220:1::1]:443", false},
+		{"1.1.1.1:443", false},
+		{"100.63.255.255:443", false},
+		{"100.128.0.1:443", false},
+		{"198.20.0.1:443", false},
+		{"127.0.0.1:80", true},
+		{"[::1]:80", true},
+		{"10.1.2.3:443", true},
+		{"172.16.0.1:443", true},
+		{"192.168.1.1:80", true},
+		{"169.254.169.254:80", true},
+		{"0.0.0.0:80", true},
+		{"0.1.2.3:80", true},
+		{"100.64.0.1:80", true},
+		{"100.127.255.254:80", true},
+		{"192.0.0.8:80", true},
+		{"192.0.2.1:80", true},
+		{"198.18.0.1:80", true},
+		{"198.19.255.254:80", true},
+		{"198.51.100.1:80", true},
+		{"203.0.113.1:80", true},
+		{"224.0.0.1:80", true},
+		{"240.0.0.1:80", true},
+		{"255.255.255.255:80", true},
+		{"[::]:80", true},
+		{"[::ffff:127.0.0.1]:80", true},
+		{"[::ffff:100.64.0.1]:80", true},
+		{"[::10.0.0.1]:80", true},
+		{"[64:ff9b::a00:1]:80", true},
+		{"[2002:a00:1::1]:80", true},
+		{"[2001:db8::1]:80", true},
+		{"[fd00::1]:80", true},
+		{"[fe80::1%eth0]:80", true},
+		{"[ff02::1]:80", true},
+	}
+	for _, test := range tests {
+		err := dialPublic("tcp", test.address, nil)
+		if blocked := errors.Is(err, ErrBlockedAddress); blocked != test.blocked || !blocked && err != nil {
+			t.Errorf("dialPublic(%s) = %v, want blocked %t", test.address, err, test.blocked)
+		}
+	}
+}
@@ -0,0 +1,552 @@
+// Package ocds maps tenders to release and record packages of the Open
+// Contracting Data Standard 1.1. Every stored version of a tender becomes a
+// release, status changes and award decisions get releases of their own, and
+// records carry all releases of a tender together with a compiled release of
+// its current state.
+package ocds
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const Version = "1.1"
+
+// Extensions used by the packages: bids and lots are not part of the core
+// schema.
+var Extensions = []string{
+	"https://raw.githubusercontent.com/open-contracting-extensions/ocds_bid_extension/v1.1.5/extension.json",
+	"https://raw.githubusercontent.com/open-contracting-extensions/ocds_lots_extension/v1.1.5/extension.json",
+}
+
+var _ domain.OCDSEncoder = (*Encoder)(nil)
+
+type Encoder struct {
+	prefix    string
+	publisher Publisher
+	currency  string
+	now       func() time.Time
+}
+
+// NewEncoder returns an encoder building OCIDs from the registered prefix
+// (ocds-xxxxxx) and stating all amounts in currency, an ISO 4217 code.
+func NewEncoder(prefix string, publisher string, currency string) *Encoder {
+	return &Encoder{
+		prefix:    prefix,
+		publisher: Publisher{Name: publisher},
+		currency:  currency,
+		now:       time.Now,
+	}
+}
+
+type Publisher struct {
+	Name string `json:"name"`
+}
+
+type ReleasePackage struct {
+	URI           string    `json:"uri"`
+	Version       string    `json:"version"`
+	Extensions    []string  `json:"extensions"`
+	PublishedDate time.Time `json:"publishedDate"`
+	Publisher     Publisher `json:"publisher"`
+	Releases      []Release `json:"releases"`
+}
+
+type RecordPackage struct {
+	URI           string    `json:"uri"`
+	Version       string    `json:"version"`
+	Extensions    []string  `json:"extensions"`
+	PublishedDate time.Time `json:"publishedDate"`
+	Publisher     Publisher `json:"publisher"`
+	Records       []Record  `json:"records"`
+}
+
+type Record struct {
+	OCID            string    `json:"ocid"`
+	Releases        []Release `json:"releases"`
+	CompiledRelease Release   `json:"compiledRelease"`
+}
+
+type Release struct {
+	OCID           string                `json:"ocid"`
+	ID             string                `json:"id"`
+	Date           time.Time             `json:"date"`
+	Tag            []string              `json:"tag"`
+	InitiationType string                `json:"initiationType"`
+	Parties        []Party               `json:"parties"`
+	Buyer          OrganizationReference `json:"buyer"`
+	Tender         Tender                `json:"tender"`
+	Bids           *Bids                 `json:"bids,omitempty"`
+	Awards         []Award               `json:"awards,omitempty"`
+}
+
+type OrganizationReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type Party struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name,omitempty"`
+	Roles []string `json:"roles"`
+}
+
+type Value struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+type Period struct {
+	EndDate *time.Time `json:"endDate,omitempty"`
+}
+
+type Classification struct {
+	Scheme string `json:"scheme"`
+	ID     string `json:"id"`
+}
+
+type Unit struct {
+	Name string `json:"name"`
+}
+
+type Item struct {
+	ID             string          `json:"id"`
+	Description    string          `json:"description,omitempty"`
+	Classification *Classification `json:"classification,omitempty"`
+	Quantity       *float64        `json:"quantity,omitempty"`
+	Unit           *Unit           `json:"unit,omitempty"`
+	RelatedLot     string          `json:"relatedLot,omitempty"`
+}
+
+type Lot struct {
+	ID          string `json:"id"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Value       *Value `json:"value,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+type Tender struct {
+	ID                       string                  `json:"id"`
+	Title                    string                  `json:"title,omitempty"`
+	Description              string                  `json:"description,omitempty"`
+	Status                   string                  `json:"status"`
+	Value                    *Value                  `json:"value,omitempty"`
+	ProcurementMethod        string                  `json:"procurementMethod"`
+	ProcurementMethodDetails string                  `json:"procurementMethodDetails,omitempty"`
+	SubmissionMethod         []string                `json:"submissionMethod"`
+	TenderPeriod             *Period                 `json:"tenderPeriod,omitempty"`
+	Items                    []Item                  `json:"items"`
+	Lots                     []Lot                   `json:"lots,omitempty"`
+	NumberOfTenderers        *int                    `json:"numberOfTenderers,omitempty"`
+	Tenderers                []OrganizationReference `json:"tenderers,omitempty"`
+}
+
+type Bids struct {
+	Details []Bid `json:"details"`
+}
+
+type Bid struct {
+	ID          string                  `json:"id"`
+	Date        time.Time               `json:"date"`
+	Status      string                  `json:"status"`
+	Tenderers   []OrganizationReference `json:"tenderers"`
+	Value       *Value                  `json:"value,omitempty"`
+	RelatedLots []string                `json:"relatedLots,omitempty"`
+}
+
+type Award struct {
+	ID          string                  `json:"id"`
+	Status      string                  `json:"status"`
+	Date        time.Time               `json:"date"`
+	Value       *Value                  `json:"value,omitempty"`
+	Suppliers   []OrganizationReference `json:"suppliers"`
+	RelatedLots []string                `json:"relatedLots,omitempty"`
+	RelatedBid  string                  `json:"relatedBid,omitempty"`
+}
+
+// ReleasePackage packages the releases of the tenders. With since set,
+// releases dated before it are left out.
+func (e *Encoder) ReleasePackage(uri string, tenders []domain.OCDSTender, since *time.Time) ([]byte, error) {
+	pkg := ReleasePackage{
+		URI:           uri,
+		Version:       Version,
+		Extensions:    Extensions,
+		PublishedDate: e.now().UTC(),
+		Publisher:     e.publisher,
+		Releases:      []Release{},
+	}
+
+	for _, tender := range tenders {
+		for _, release := range e.Releases(tender) {
+			if since == nil || !release.Date.Before(*since) {
+				pkg.Releases = append(pkg.Releases, release)
+			}
+		}
+	}
+
+	data, err := json.Marshal(pkg)
+	if err != nil {
+		return nil, fmt.Errorf("ocds.ReleasePackage: %w", err)
+	}
+
+	return data, nil
+}
+
+func (e *Encoder) RecordPackage(uri string, tenders []domain.OCDSTender) ([]byte, error) {
+	pkg := RecordPackage{
+		URI:           uri,
+		Version:       Version,
+		Extensions:    Extensions,
+		PublishedDate: e.now().UTC(),
+		Publisher:     e.publisher,
+		Records:       []Record{},
+	}
+
+	for _, tender := range tenders {
+		pkg.Records = append(pkg.Records, e.Record(tender))
+	}
+
+	data, err := json.Marshal(pkg)
+	if err != nil {
+		return nil, fmt.Errorf("ocds.RecordPackage: %w", err)
+	}
+
+	return data, nil
+}
+
+func (e *Encoder) OCID(tenderID string) string {
+	return e.prefix + "-" + tenderID
+}
+
+// Releases returns the releases of the tender in date order: one per stored
+// version, one for a status the tender reached without a new version and one
+// for the award decisions.
+func (e *Encoder) Releases(data domain.OCDSTender) []Release {
+	tender := data.Tender
+	releases := []Release{}
+
+	lastStatus := ""
+	for i, version := range data.Versions {
+		tag := "tenderUpdate"
+		if i == 0 {
+			tag = "tender"
+		}
+
+		release := e.release(data, tender.ID+"-"+strconv.Itoa(version.Version), version.CreatedAt, tag)
+		release.Tender = e.tender(data, domain.Tender{
+			ID:          tender.ID,
+			Name:        version.Name,
+			Description: version.Description,
+			ServiceType: version.ServiceType,
+			Status:      version.Status,
+			Type:        tender.Type,
+			Budget:      version.Budget,
+			ClosesAt:    tender.ClosesAt,
+			Lots:        version.Lots,
+		})
+		releases = append(releases, release)
+		lastStatus = version.Status
+	}
+
+	if tender.Status != lastStatus {
+		id := tender.ID + "-" + strconv.Itoa(tender.Version) + "-" + strings.ToLower(tender.Status)
+		tag := "tenderUpdate"
+		if len(releases) == 0 {
+			tag = "tender"
+		}
+
+		release := e.release(data, id, data.StatusChangedAt, tag)
+		release.Tender = e.tender(data, tender)
+		releases = append(releases, release)
+	}
+
+	if len(data.Awards) > 0 {
+		release := e.release(data, tender.ID+"-award", awardDate(data.Awards), "award")
+		release.Parties = e.parties(data)
+		release.Tender = e.tender(data, tender)
+		release.Bids = e.bids(data)
+		release.Awards = e.awards(data)
+		releases = append(releases, release)
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].Date.Before(releases[j].Date)
+	})
+
+	return releases
+}
+
+// Record returns the releases of the tender and a compiled release of its
+// current state.
+func (e *Encoder) Record(data domain.OCDSTender) Record {
+	releases := e.Releases(data)
+
+	date := data.StatusChangedAt
+	for _, release := range releases {
+		if release.Date.After(date) {
+			date = release.Date
+		}
+	}
+
+	compiled := e.release(data, data.Tender.ID+"-compiled", date, "compiled")
+	compiled.Parties = e.parties(data)
+	compiled.Tender = e.tender(data, data.Tender)
+	compiled.Bids = e.bids(data)
+	compiled.Awards = e.awards(data)
+
+	return Record{
+		OCID:            e.OCID(data.Tender.ID),
+		Releases:        releases,
+		CompiledRelease: compiled,
+	}
+}
+
+func (e *Encoder) release(data domain.OCDSTender, id string, date time.Time, tag string) Release {
+	buyer := e.organization(data, data.Tender.OrganizationId)
+
+	return Release{
+		OCID:           e.OCID(data.Tender.ID),
+		ID:             id,
+		Date:           date.UTC(),
+		Tag:            []string{tag},
+		InitiationType: "tender",
+		Parties:        []Party{{ID: buyer.ID, Name: buyer.Name, Roles: []string{"buyer", "procuringEntity"}}},
+		Buyer:          buyer,
+	}
+}
+
+func (e *Encoder) organization(data domain.OCDSTender, id string) OrganizationReference {
+	return OrganizationReference{ID: id, Name: data.Organizations[id].Name}
+}
+
+// parties lists the buyer, the bidding organizations and the winners with
+// their roles.
+func (e *Encoder) parties(data domain.OCDSTender) []Party {
+	buyer := e.organization(data, data.Tender.OrganizationId)
+	parties := []Party{{ID: buyer.ID, Name: buyer.Name, Roles: []string{"buyer", "procuringEntity"}}}
+	index := map[string]int{buyer.ID: 0}
+
+	addRole := func(id string, role string) {
+		i, ok := index[id]
+		if !ok {
+			organization := e.organization(data, id)
+			parties = append(parties, Party{ID: organization.ID, Name: organization.Name, Roles: []string{}})
+			i = len(parties) - 1
+			index[id] = i
+		}
+		for _, existing := range parties[i].Roles {
+			if existing == role {
+				return
+			}
+		}
+		parties[i].Roles = append(parties[i].Roles, role)
+	}
+
+	for _, bid := range data.Bids {
+		addRole(bid.OrganizationId, "tenderer")
+	}
+	for _, award := range data.Awards {
+		addRole(award.OrganizationID, "supplier")
+	}
+
+	return parties
+}
+
+func (e *Encoder) value(amount *float64) *Value {
+	if amount == nil {
+		return nil
+	}
+
+	return &Value{Amount: *amount, Currency: e.currency}
+}
+
+func (e *Encoder) tender(data domain.OCDSTender, tender domain.Tender) Tender {
+	result := Tender{
+		ID:                tender.ID,
+		Title:             tender.Name,
+		Description:       tender.Description,
+		Status:            tenderStatus(tender.Status, len(data.Awards) > 0),
+		Value:             e.value(tender.Budget),
+		ProcurementMethod: "open",
+		SubmissionMethod:  []string{"electronicSubmission"},
+		Items:             []Item{},
+	}
+
+	if tender.Type == domain.TenderTypeAuction {
+		result.ProcurementMethodDetails = "Reverse auction"
+	}
+
+	if tender.ClosesAt != nil {
+		endDate := tender.ClosesAt.UTC()
+		result.TenderPeriod = &Period{EndDate: &endDate}
+	}
+
+	classification := &Classification{Scheme: classificationScheme(data.ServiceTypeScheme), ID: tender.ServiceType}
+	if len(tender.Lots) == 0 {
+		result.Items = append(result.Items, Item{ID: "1", Description: tender.Name, Classification: classification})
+	}
+
+	awarded := map[string]bool{}
+	for _, award := range data.Awards {
+		if award.LotID != nil {
+			awarded[*award.LotID] = true
+		}
+	}
+
+	for _, lot := range tender.Lots {
+		quantity := lot.Quantity
+		item := Item{ID: lot.ID, Description: lot.Name, Classification: classification, Quantity: &quantity, RelatedLot: lot.ID}
+		if lot.Unit != "" {
+			item.Unit = &Unit{Name: lot.Unit}
+		}
+		result.Items = append(result.Items, item)
+
+		status := result.Status
+		if awarded[lot.ID] {
+			status = "complete"
+		}
+		result.Lots = append(result.Lots, Lot{
+			ID:          lot.ID,
+			Title:       lot.Name,
+			Description: lot.Description,
+			Value:       e.value(lot.Budget),
+			Status:      status,
+		})
+	}
+
+	if tender.Status == "CLOSED" {
+		tenderers := []OrganizationReference{}
+		seen := map[string]bool{}
+		for _, bid := range data.Bids {
+			if !seen[bid.OrganizationId] {
+				seen[bid.OrganizationId] = true
+				tenderers = append(tenderers, e.organization(data, bid.OrganizationId))
+			}
+		}
+
+		count := len(tenderers)
+		result.NumberOfTenderers = &count
+		if count > 0 {
+			result.Tenderers = tenderers
+		}
+	}
+
+	return result
+}
+
+// bids lists the bids of a closed tender. A bid on several lots is valued at
+// the sum of its lot prices when all of them are known.
+func (e *Encoder) bids(data domain.OCDSTender) *Bids {
+	if data.Tender.Status != "CLOSED" {
+		return nil
+	}
+
+	bids := &Bids{Details: []Bid{}}
+	for _, bid := range data.Bids {
+		detail := Bid{
+			ID:        bid.ID,
+			Date:      bid.CreatedAt.UTC(),
+			Status:    bidStatus(bid.Status),
+			Tenderers: []OrganizationReference{e.organization(data, bid.OrganizationId)},
+			Value:     e.value(bid.Price),
+		}
+
+		if len(bid.Lots) > 0 {
+			var total float64
+			known := true
+			for _, lot := range bid.Lots {
+				detail.RelatedLots = append(detail.RelatedLots, lot.LotID)
+				if lot.Price == nil {
+					known = false
+					continue
+				}
+				total += *lot.Price
+			}
+			if known && bid.Price == nil {
+				detail.Value = e.value(&total)
+			}
+		}
+
+		bids.Details = append(bids.Details, detail)
+	}
+
+	return bids
+}
+
+func (e *Encoder) awards(data domain.OCDSTender) []Award {
+	awards := []Award{}
+	for _, award := range data.Awards {
+		result := Award{
+			ID:        award.ID,
+			Status:    "active",
+			Date:      award.DecidedAt.UTC(),
+			Value:     e.value(award.Amount),
+			Suppliers: []OrganizationReference{e.organization(data, award.OrganizationID)},
+		}
+		if award.LotID != nil {
+			result.RelatedLots = []string{*award.LotID}
+		}
+		if award.BidID != nil {
+			result.RelatedBid = *award.BidID
+		}
+		awards = append(awards, result)
+	}
+
+	return awards
+}
+
+func awardDate(awards []domain.Award) time.Time {
+	var date time.Time
+	for _, award := range awards {
+		if award.DecidedAt.After(date) {
+			date = award.DecidedAt
+		}
+	}
+
+	return date
+}
+
+// tenderStatus maps the tender status to the OCDS tender status codelist.
+// A closed tender is complete once a winner is known.
+func tenderStatus(status string, awarded bool) string {
+	switch status {
+	case "CREATED":
+		return "planned"
+	case "CLOSED":
+		if awarded {
+			return "complete"
+		}
+		return "unsuccessful"
+	default:
+		return "active"
+	}
+}
+
+func bidStatus(status string) string {
+	switch status {
+	case "CREATED":
+		return "pending"
+	case "CANCELED":
+		return "withdrawn"
+	default:
+		return "valid"
+	}
+}
+
+// classificationScheme names the scheme of a service type code. Codes of the
+// own catalog get a scheme of their own.
+func classificationScheme(scheme domain.ServiceTypeScheme) string {
+	switch scheme {
+	case domain.ServiceTypeSchemeCPV, domain.ServiceTypeSchemeOKPD2:
+		return string(scheme)
+	default:
+		return "X_CATALOG"
+	}
+}
@@ -0,0 +1,215 @@
+package ocds
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const schemaURL = "https://standard.open-contracting.org/schema/1__1__5/"
+
+// compileSchema compiles a bundled package schema, resolving the release
+// schema it refers to from the schema directory as well.
+func compileSchema(t *testing.T, name string) *jsonschema.Schema {
+	t.Helper()
+
+	compiler := jsonschema.NewCompiler()
+	for _, file := range []string{"release-schema.json", "release-package-schema.json", "record-package-schema.json"} {
+		data, err := os.ReadFile("schema/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := compiler.AddResource(schemaURL+file, bytes.NewReader(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	schema, err := compiler.Compile(schemaURL + name)
+	if err != nil {
+		t.Fatalf("compile %s: %v", name, err)
+	}
+
+	return schema
+}
+
+func validate(t *testing.T, schema *jsonschema.Schema, data []byte) map[string]any {
+	t.Helper()
+
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if err := schema.Validate(document); err != nil {
+		t.Fatalf("package does not match the OCDS schema: %#v\n%s", err, data)
+	}
+
+	return document
+}
+
+func testTender() domain.OCDSTender {
+	lotID, bidID := "lot-1", "bid-1"
+	budget, lotBudget, price, lotPrice := 1500000.0, 1000000.0, 1200000.0, 950000.0
+	created := time.Date(2024, 9, 1, 9, 0, 0, 0, time.UTC)
+	closes := created.AddDate(0, 0, 14)
+
+	return domain.OCDSTender{
+		Tender: domain.Tender{
+			ID:             "tender-1",
+			Name:           "Поставка серверов",
+			Description:    "Серверы и монтаж",
+			Status:         "CLOSED",
+			ServiceType:    "30211000-1",
+			OrganizationId: "org-1",
+			Version:        2,
+			Budget:         &budget,
+			ClosesAt:       &closes,
+			CreatedAt:      created,
+			Lots: []domain.Lot{
+				{ID: lotID, Name: "Серверы", Quantity: 4, Unit: "шт", Budget: &lotBudget, AwardedBidID: &bidID},
+				{ID: "lot-2", Name: "Монтаж", Quantity: 1},
+			},
+		},
+		StatusChangedAt:   closes,
+		ServiceTypeScheme: domain.ServiceTypeSchemeCPV,
+		Versions: []domain.TenderVersion{
+			{Version: 1, Name: "Поставка серверов", Status: "CREATED", Budget: &budget, CreatedAt: created},
+			{Version: 2, Name: "Поставка серверов", Description: "Серверы и монтаж", Status: "PUBLISHED", Budget: &budget, CreatedAt: created.Add(time.Hour)},
+		},
+		Bids: []domain.Bid{
+			{ID: bidID, Status: "PUBLISHED", OrganizationId: "org-2", CreatedAt: created.AddDate(0, 0, 3), Lots: []domain.BidLot{{LotID: lotID, Price: &lotPrice}}},
+			{ID: "bid-2", Status: "CANCELED", OrganizationId: "org-3", Price: &price, CreatedAt: created.AddDate(0, 0, 4)},
+		},
+		Awards: []domain.Award{
+			{ID: "award-1", TenderID: "tender-1", LotID: &lotID, BidID: &bidID, OrganizationID: "org-2", Amount: &lotPrice, DecidedAt: closes.Add(time.Hour)},
+		},
+		Organizations: map[string]domain.Organization{
+			"org-1": {ID: "org-1", Name: "ООО Заказчик"},
+			"org-2": {ID: "org-2", Name: "ИП Поставщик"},
+			"org-3": {ID: "org-3", Name: "АО Участник"},
+		},
+	}
+}
+
+func TestRecordPackage(t *testing.T) {
+	encoder := NewEncoder("ocds-abc123", "Tender", "RUB")
+
+	data, err := encoder.RecordPackage("https://tender.example/api/tenders/tender-1/ocds", []domain.OCDSTender{testTender()})
+	if err != nil {
+		t.Fatalf("RecordPackage: %v", err)
+	}
+
+	validate(t, compileSchema(t, "record-package-schema.json"), data)
+
+	var pkg RecordPackage
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		t.Fatal(err)
+	}
+
+	record := pkg.Records[0]
+	if record.OCID != "ocds-abc123-tender-1" {
+		t.Errorf("ocid = %q", record.OCID)
+	}
+
+	ids := []string{}
+	for _, release := range record.Releases {
+		ids = append(ids, release.ID+":"+release.Tag[0])
+	}
+	want := []string{"tender-1-1:tender", "tender-1-2:tenderUpdate", "tender-1-2-closed:tenderUpdate", "tender-1-award:award"}
+	if len(ids) != len(want) {
+		t.Fatalf("releases = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("releases = %v, want %v", ids, want)
+		}
+	}
+
+	if status := record.Releases[0].Tender.Status; status != "planned" {
+		t.Errorf("first release tender status = %q, want planned", status)
+	}
+
+	compiled := record.CompiledRelease
+	if compiled.Tender.Status != "complete" {
+		t.Errorf("compiled tender status = %q, want complete", compiled.Tender.Status)
+	}
+	if compiled.Tender.NumberOfTenderers == nil || *compiled.Tender.NumberOfTenderers != 2 {
+		t.Errorf("numberOfTenderers = %v, want 2", compiled.Tender.NumberOfTenderers)
+	}
+	if compiled.Bids == nil || len(compiled.Bids.Details) != 2 {
+		t.Fatalf("bids = %+v, want 2", compiled.Bids)
+	}
+	if value := compiled.Bids.Details[0].Value; value == nil || value.Amount != 950000 {
+		t.Errorf("lot bid value = %+v, want the sum of its lot prices", value)
+	}
+	if compiled.Bids.Details[1].Status != "withdrawn" {
+		t.Errorf("canceled bid status = %q, want withdrawn", compiled.Bids.Details[1].Status)
+	}
+	if lots := compiled.Tender.Lots; len(lots) != 2 || lots[0].Status != "complete" {
+		t.Errorf("lots = %+v", lots)
+	}
+
+	roles := map[string][]string{}
+	for _, party := range compiled.Parties {
+		roles[party.ID] = party.Roles
+	}
+	if len(roles["org-2"]) != 2 || roles["org-2"][1] != "supplier" {
+		t.Errorf("winner roles = %v, want tenderer and supplier", roles["org-2"])
+	}
+}
+
+func TestReleasePackage(t *testing.T) {
+	encoder := NewEncoder("ocds-abc123", "Tender", "RUB")
+	schema := compileSchema(t, "release-package-schema.json")
+
+	published := testTender()
+	published.Tender.ID = "tender-2"
+	published.Tender.Status = "PUBLISHED"
+	published.Tender.Type = domain.TenderTypeAuction
+	published.Versions = published.Versions[1:]
+	published.Bids, published.Awards = nil, nil
+
+	data, err := encoder.ReleasePackage("https://tender.example/api/ocds/releases", []domain.OCDSTender{testTender(), published}, nil)
+	if err != nil {
+		t.Fatalf("ReleasePackage: %v", err)
+	}
+
+	var pkg ReleasePackage
+	validate(t, schema, data)
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pkg.Releases) != 5 {
+		t.Fatalf("%d releases, want 5", len(pkg.Releases))
+	}
+
+	last := pkg.Releases[4]
+	if last.OCID != "ocds-abc123-tender-2" || last.Tender.Status != "active" || last.Bids != nil {
+		t.Errorf("published tender release = %+v, want an active tender without bids", last)
+	}
+	if last.Tender.ProcurementMethodDetails != "Reverse auction" {
+		t.Errorf("procurementMethodDetails = %q", last.Tender.ProcurementMethodDetails)
+	}
+
+	since := testTender().StatusChangedAt
+	data, err = encoder.ReleasePackage("https://tender.example/api/ocds/releases", []domain.OCDSTender{testTender()}, &since)
+	if err != nil {
+		t.Fatalf("ReleasePackage: %v", err)
+	}
+
+	pkg = ReleasePackage{}
+	validate(t, schema, data)
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pkg.Releases) != 2 {
+		t.Errorf("%d releases since the tender closed, want 2", len(pkg.Releases))
+	}
+}
@@ -0,0 +1,222 @@
+// Package openapi describes the HTTP API as an OpenAPI 3.0 document. The
+// schemas are generated from the domain types the handlers encode and
+// decode, the operations are listed in routes.go next to each other in the
+// order cmd/tender registers them.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem maps lower case HTTP methods to the operations of a path.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Resolve follows a component reference.
+func (d *Document) Resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+
+	return schema
+}
+
+// route is an operation in a compact form, Build expands it.
+type route struct {
+	method, path string
+	tag, summary string
+	description  string
+	query        []Parameter
+	// request is a value of the JSON request body type, body lists other
+	// request media types.
+	request any
+	body    map[string]*Schema
+	status  int
+	// response is a value of the JSON response type, content lists other
+	// response media types.
+	response any
+	content  map[string]*Schema
+}
+
+// oneOf is a response or request value that is one of several types.
+type oneOf []any
+
+func (s *schemas) value(v any) *Schema {
+	alternatives, ok := v.(oneOf)
+	if !ok {
+		return s.of(reflect.TypeOf(v))
+	}
+
+	schema := &Schema{}
+	for _, alternative := range alternatives {
+		schema.OneOf = append(schema.OneOf, s.of(reflect.TypeOf(alternative)))
+	}
+
+	return schema
+}
+
+var (
+	pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+	pathWord  = regexp.MustCompile(`[A-Za-z0-9]+`)
+)
+
+// Build returns the specification of every route.
+func Build() *Document {
+	s := &schemas{components: map[string]*Schema{}, enums: enums, required: required, constraints: constraints}
+	errorSchema := s.of(reflect.TypeOf(domain.JSONError{}))
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "Tender API",
+			Description: "Tenders, bids and everything around them. Most operations identify the employee by the username query parameter.",
+			Version:     "1.0",
+		},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: s.components},
+	}
+
+	for _, r := range routes {
+		operation := &Operation{
+			OperationID: operationID(r.method, r.path),
+			Summary:     r.summary,
+			Description: r.description,
+			Tags:        []string{r.tag},
+			Responses: map[string]Response{
+				"default": {Description: "Error", Content: map[string]MediaType{"application/json": {Schema: errorSchema}}},
+			},
+		}
+
+		for _, match := range pathParam.FindAllStringSubmatch(r.path, -1) {
+			operation.Parameters = append(operation.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: pathParameter(match[1])})
+		}
+		operation.Parameters = append(operation.Parameters, r.query...)
+		for _, parameter := range r.query {
+			if parameter.In == "query" && parameter.Name == "username" && parameter.Required {
+				operation.Responses[strconv.Itoa(http.StatusUnauthorized)] = Response{
+					Description: "Missing or unknown username",
+					Content:     map[string]MediaType{"application/json": {Schema: errorSchema}},
+				}
+			}
+		}
+
+		if r.request != nil || r.body != nil {
+			body := &RequestBody{Required: true, Content: map[string]MediaType{}}
+			if r.request != nil {
+				body.Content["application/json"] = MediaType{Schema: s.value(r.request)}
+			}
+			for mediaType, schema := range r.body {
+				body.Content[mediaType] = MediaType{Schema: schema}
+			}
+			operation.RequestBody = body
+		}
+
+		status := r.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		response := Response{Description: http.StatusText(status)}
+		if r.response != nil || r.content != nil {
+			response.Content = map[string]MediaType{}
+			if r.response != nil {
+				response.Content["application/json"] = MediaType{Schema: s.value(r.response)}
+			}
+			for mediaType, schema := range r.content {
+				response.Content[mediaType] = MediaType{Schema: schema}
+			}
+		}
+		operation.Responses[strconv.Itoa(status)] = response
+
+		if doc.Paths[r.path] == nil {
+			doc.Paths[r.path] = PathItem{}
+		}
+		doc.Paths[r.path][strings.ToLower(r.method)] = operation
+	}
+
+	return doc
+}
+
+// JSON returns the specification encoded for serving.
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// Routes lists the specified operations as ServeMux patterns.
+func Routes() []string {
+	patterns := make([]string, 0, len(routes))
+	for _, r := range routes {
+		patterns = append(patterns, r.method+" "+r.path)
+	}
+
+	return patterns
+}
+
+// operationID derives a camel case ID such as getTendersTenderIdStatus.
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, word := range pathWord.FindAllString(path, -1) {
+		if word == "api" {
+			continue
+		}
+		id += strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return id
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// refs collects the component references of a decoded document.
+func refs(value any, found map[string]bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				found[ref] = true
+			}
+			refs(item, found)
+		}
+	case []any:
+		for _, item := range v {
+			refs(item, found)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	data, err := Build().JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	found := map[string]bool{}
+	refs(document, found)
+	components := Build().Components.Schemas
+	for ref := range found {
+		if _, ok := components[strings.TrimPrefix(ref, "#/components/schemas/")]; !ok {
+			t.Errorf("%s does not resolve", ref)
+		}
+	}
+	for _, name := range []string{"JSONError", "TenderResponse", "OCDSRecordPackage"} {
+		if _, ok := components[name]; !ok {
+			t.Errorf("component %s is missing", name)
+		}
+	}
+
+	ids := map[string]string{}
+	for path, item := range Build().Paths {
+		for method, operation := range item {
+			if other, ok := ids[operation.OperationID]; ok {
+				t.Errorf("%s %s and %s share the operation ID %s", method, path, other, operation.OperationID)
+			}
+			ids[operation.OperationID] = method + " " + path
+
+			if _, ok := operation.Responses["default"]; !ok {
+				t.Errorf("%s %s has no error response", method, path)
+			}
+			for _, parameter := range operation.Parameters {
+				if _, ok := operation.Responses["401"]; parameter.Name == "username" && parameter.Required && !ok {
+					t.Errorf("%s %s requires the username but documents no 401", method, path)
+				}
+			}
+			for _, match := range pathParam.FindAllStringSubmatch(path, -1) {
+				declared := false
+				for _, parameter := range operation.Parameters {
+					declared = declared || (parameter.In == "path" && parameter.Name == match[1])
+				}
+				if !declared {
+					t.Errorf("%s %s does not declare the path parameter %s", method, path, match[1])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,460 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/ocds"
+)
+
+// enums lists the values of the domain string types.
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(domain.TenderType("")):          {string(domain.TenderTypeStandard), string(domain.TenderTypeAuction)},
+	reflect.TypeOf(domain.TenderVisibility("")):    {string(domain.TenderVisibilityPublic), string(domain.TenderVisibilityPrivate)},
+	reflect.TypeOf(domain.OrganizationType("")):    {string(domain.OrganizationTypeIE), string(domain.OrganizationTypeLLC), string(domain.OrganizationTypeJSC)},
+	reflect.TypeOf(domain.CustomFieldType("")):     {string(domain.CustomFieldTypeString), string(domain.CustomFieldTypeNumber), string(domain.CustomFieldTypeDate), string(domain.CustomFieldTypeEnum)},
+	reflect.TypeOf(domain.QuestionVisibility("")):  {string(domain.QuestionVisibilityPublic), string(domain.QuestionVisibilityPrivate)},
+	reflect.TypeOf(domain.ServiceTypeScheme("")):   {string(domain.ServiceTypeSchemeCustom), string(domain.ServiceTypeSchemeCPV), string(domain.ServiceTypeSchemeOKPD2)},
+	reflect.TypeOf(domain.BatchStatusMode("")):     {string(domain.BatchStatusAtomic), string(domain.BatchStatusBestEffort)},
+	reflect.TypeOf(domain.AttachmentOwnerType("")): {string(domain.AttachmentOwnerTender), string(domain.AttachmentOwnerBid)},
+	reflect.TypeOf(domain.AuctionEventType("")):    {string(domain.AuctionEventState), string(domain.AuctionEventOffer)},
+	reflect.TypeOf(domain.ContractFormat("")):      {string(domain.ContractFormatMarkdown), string(domain.ContractFormatPDF)},
+	reflect.TypeOf(domain.NotificationEvent("")):   {string(domain.NotificationBidCreated), string(domain.NotificationTenderClosing), string(domain.NotificationTenderPublished)},
+	reflect.TypeOf(domain.OCDSPackageType("")):     {string(domain.OCDSPackageRelease), string(domain.OCDSPackageRecord)},
+}
+
+// required lists the properties a type has to carry.
+var required = map[reflect.Type][]string{
+	reflect.TypeOf(domain.CreateBidRequest{}):          {"name", "tenderId", "organizationId", "creatorUsername"},
+	reflect.TypeOf(domain.BidLot{}):                    {"lotId"},
+	reflect.TypeOf(domain.Lot{}):                       {"name", "quantity"},
+	reflect.TypeOf(domain.Criterion{}):                 {"name", "weight"},
+	reflect.TypeOf(domain.BatchStatusRequest{}):        {"tenderIds", "status"},
+	reflect.TypeOf(domain.ServiceType{}):               {"code"},
+	reflect.TypeOf(domain.CreateOrganizationRequest{}): {"name", "type"},
+	reflect.TypeOf(domain.CreateEmployeeRequest{}):     {"username"},
+	reflect.TypeOf(domain.CreateTemplateRequest{}):     {"name"},
+	reflect.TypeOf(domain.CreateCustomFieldRequest{}):  {"key", "name", "type"},
+	reflect.TypeOf(domain.AwardLotRequest{}):           {"bidId"},
+	reflect.TypeOf(domain.AwardTenderRequest{}):        {"bidId"},
+	reflect.TypeOf(domain.ScoreBidRequest{}):           {"scores"},
+	reflect.TypeOf(domain.CriterionScore{}):            {"criterionId", "score"},
+	reflect.TypeOf(domain.PlaceOfferRequest{}):         {"organizationId", "price"},
+	reflect.TypeOf(domain.CreateInvitationRequest{}):   {"organizationId"},
+	reflect.TypeOf(domain.CreateQuestionRequest{}):     {"question"},
+	reflect.TypeOf(domain.AnswerQuestionRequest{}):     {"answer"},
+	reflect.TypeOf(domain.CreateSavedSearchRequest{}):  {"name"},
+	reflect.TypeOf(graphQLRequest{}):                   {"query"},
+}
+
+var tenderStatuses = []string{"CREATED", "PUBLISHED", "CLOSED", "OPEN"}
+
+func limit(n int) *Schema { return &Schema{MaxLength: n} }
+
+func minimum(n float64, exclusive bool) *Schema {
+	return &Schema{Minimum: &n, ExclusiveMinimum: exclusive}
+}
+
+func uuid() *Schema { return &Schema{Format: "uuid"} }
+
+// constraints narrow the properties of request types down to what the
+// database columns hold.
+var constraints = map[reflect.Type]map[string]*Schema{
+	reflect.TypeOf(domain.CreateTenderRequest{}): {
+		"name":            limit(255),
+		"serviceType":     limit(50),
+		"organizationId":  uuid(),
+		"creatorUsername": limit(255),
+		"budget":          minimum(0, false),
+		"tags":            {Items: limit(50)},
+		"templateId":      uuid(),
+	},
+	reflect.TypeOf(tenderUpdate{}): {
+		"name":        limit(255),
+		"serviceType": limit(50),
+		"budget":      minimum(0, false),
+		"tags":        {Items: limit(50)},
+	},
+	reflect.TypeOf(domain.TemplateTender{}): {
+		"name":        limit(255),
+		"serviceType": limit(50),
+		"budget":      minimum(0, false),
+		"tags":        {Items: limit(50)},
+	},
+	reflect.TypeOf(domain.Auction{}): {
+		"startPrice":       minimum(0, true),
+		"minDecrement":     minimum(0, true),
+		"extensionSeconds": minimum(0, false),
+	},
+	reflect.TypeOf(domain.Lot{}): {
+		"name":     limit(255),
+		"quantity": minimum(0, true),
+		"unit":     limit(50),
+		"budget":   minimum(0, false),
+	},
+	reflect.TypeOf(domain.Criterion{}): {
+		"name":   limit(255),
+		"weight": minimum(0, true),
+	},
+	reflect.TypeOf(domain.CreateBidRequest{}): {
+		"name":            limit(255),
+		"tenderId":        uuid(),
+		"organizationId":  uuid(),
+		"creatorUsername": limit(255),
+		"price":           minimum(0, false),
+	},
+	reflect.TypeOf(domain.BidLot{}): {
+		"lotId": uuid(),
+		"price": minimum(0, false),
+	},
+	reflect.TypeOf(domain.BatchStatusRequest{}): {
+		"tenderIds": {MinItems: 1, Items: uuid()},
+		"status":    {Enum: tenderStatuses},
+	},
+	reflect.TypeOf(domain.ServiceType{}): {
+		"code":       limit(50),
+		"parentCode": limit(50),
+	},
+	reflect.TypeOf(domain.ServiceTypeUpdate{}): {
+		"code":       limit(50),
+		"parentCode": limit(50),
+	},
+	reflect.TypeOf(domain.CreateOrganizationRequest{}): {"name": limit(100)},
+	reflect.TypeOf(domain.OrganizationUpdate{}):        {"name": limit(100)},
+	reflect.TypeOf(domain.CreateEmployeeRequest{}): {
+		"username":  limit(50),
+		"firstName": limit(50),
+		"lastName":  limit(50),
+	},
+	reflect.TypeOf(domain.EmployeeUpdate{}): {
+		"firstName": limit(50),
+		"lastName":  limit(50),
+	},
+	reflect.TypeOf(domain.CreateTemplateRequest{}): {
+		"name":     limit(100),
+		"tenderId": uuid(),
+	},
+	reflect.TypeOf(domain.CreateCustomFieldRequest{}): {
+		"key":     limit(50),
+		"name":    limit(100),
+		"options": {Items: limit(100)},
+	},
+	reflect.TypeOf(domain.AwardLotRequest{}):    {"bidId": uuid()},
+	reflect.TypeOf(domain.AwardTenderRequest{}): {"bidId": uuid()},
+	reflect.TypeOf(domain.CriterionScore{}): {
+		"criterionId": uuid(),
+		"score":       {Minimum: &zero, Maximum: &maxScore},
+	},
+	reflect.TypeOf(domain.PlaceOfferRequest{}): {
+		"organizationId": uuid(),
+		"price":          minimum(0, true),
+	},
+	reflect.TypeOf(domain.CreateInvitationRequest{}): {"organizationId": uuid()},
+	reflect.TypeOf(domain.NotificationSettings{}): {
+		"email":  limit(255),
+		"locale": limit(2),
+	},
+	reflect.TypeOf(domain.CreateSavedSearchRequest{}): {
+		"name":           limit(100),
+		"serviceTypes":   {Items: limit(50)},
+		"budgetMin":      minimum(0, false),
+		"budgetMax":      minimum(0, false),
+		"organizationId": uuid(),
+	},
+}
+
+// pathParameter describes a path parameter by its name, IDs are UUIDs.
+func pathParameter(name string) *Schema {
+	switch {
+	case name == "version":
+		return &Schema{Type: "integer", Format: "int32", Minimum: &one}
+	case strings.HasSuffix(name, "Id"):
+		return &Schema{Type: "string", Format: "uuid"}
+	default:
+		return &Schema{Type: "string", MaxLength: 50}
+	}
+}
+
+// tenderUpdate documents the partial update of a tender, the handler
+// decodes it into a map and applies only the keys present.
+type tenderUpdate struct {
+	Name         string                  `json:"name,omitempty"`
+	Description  string                  `json:"description,omitempty"`
+	ServiceType  string                  `json:"serviceType,omitempty"`
+	Visibility   domain.TenderVisibility `json:"visibility,omitempty"`
+	Budget       *float64                `json:"budget,omitempty"`
+	ClosesAt     *time.Time              `json:"closesAt,omitempty"`
+	Tags         []string                `json:"tags,omitempty"`
+	CustomFields map[string]any          `json:"customFields,omitempty"`
+	Lots         []domain.Lot            `json:"lots,omitempty"`
+	Criteria     []domain.Criterion      `json:"criteria,omitempty"`
+}
+
+// graphQLRequest documents a GraphQL operation, the schema itself is
+// published through introspection.
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	Extensions    map[string]any `json:"extensions,omitempty"`
+}
+
+// graphQLResponse carries the errors of a GraphQL operation next to the data,
+// with the status 200 as long as the operation could be run.
+type graphQLResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []graphQLError `json:"errors,omitempty"`
+}
+
+type graphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+func query(name, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func requiredQuery(name, description string, schema *Schema) Parameter {
+	parameter := query(name, description, schema)
+	parameter.Required = true
+	return parameter
+}
+
+func stringSchema() *Schema  { return &Schema{Type: "string"} }
+func integerSchema() *Schema { return &Schema{Type: "integer", Format: "int32"} }
+func booleanSchema() *Schema { return &Schema{Type: "boolean"} }
+func binarySchema() *Schema  { return &Schema{Type: "string", Format: "binary"} }
+
+func enumSchema(values ...string) *Schema { return &Schema{Type: "string", Enum: values} }
+
+var (
+	username     = requiredQuery("username", "Employee making the request. A missing or unknown employee is answered with 401.", &Schema{Type: "string", MaxLength: 255})
+	pageLimit    = query("limit", "Maximum number of items, 5 by default.", &Schema{Type: "integer", Format: "int32", Minimum: &zero})
+	pageOffset   = query("offset", "Number of items to skip.", &Schema{Type: "integer", Format: "int32", Minimum: &zero})
+	exportFormat = query("format", "Return the list as a file instead of JSON, the Accept header may be used as well.", enumSchema("json", "csv", "xlsx"))
+	locale       = query("locale", "Language of the service type names.", stringSchema())
+
+	graphQLUsername = query("username", "Employee making the request, anonymous queries see public tenders only.", &Schema{Type: "string", MaxLength: 255})
+
+	zero, one, maxScore = 0.0, 1.0, 10.0
+)
+
+// exportContent lists the file formats of exportable lists.
+var exportContent = map[string]*Schema{
+	"text/csv": stringSchema(),
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": binarySchema(),
+}
+
+// upload is a multipart form carrying a single file.
+var upload = map[string]*Schema{
+	"multipart/form-data": {
+		Type:       "object",
+		Properties: map[string]*Schema{"file": binarySchema()},
+		Required:   []string{"file"},
+	},
+}
+
+// routes lists every operation in the order cmd/tender registers them.
+var routes = []route{
+	{method: http.MethodGet, path: "/api/ping", tag: "system", summary: "Check that the server and the database are available",
+		content: map[string]*Schema{"text/plain": stringSchema()}},
+	{method: http.MethodGet, path: "/api/openapi.json", tag: "system", summary: "This specification",
+		content: map[string]*Schema{"application/json": {Type: "object"}}},
+	{method: http.MethodGet, path: "/api/docs", tag: "system", summary: "Swagger UI for this specification",
+		content: map[string]*Schema{"text/html": stringSchema()}},
+
+	{method: http.MethodGet, path: "/api/tenders", tag: "tenders", summary: "List published and visible tenders",
+		description: "Custom fields are filtered with cf.<key>=<value> parameters.",
+		query: []Parameter{pageLimit, pageOffset,
+			query("service_type", "Service type codes, subcategories included.", &Schema{Type: "array", Items: &Schema{Type: "string", MaxLength: 50}}),
+			query("tag", "Tags a tender has to carry.", &Schema{Type: "array", Items: &Schema{Type: "string", MaxLength: 50}}),
+			query("q", "Text the name or the description has to contain, ignoring case.", &Schema{Type: "string", MaxLength: 100}),
+			query("username", "Employee whose private tenders are listed as well.", &Schema{Type: "string", MaxLength: 255}),
+			exportFormat},
+		response: []domain.TenderResponse{}, content: exportContent},
+	{method: http.MethodPost, path: "/api/tender/new", tag: "tenders", summary: "Create a tender",
+		request: domain.CreateTenderRequest{}, status: http.StatusCreated, response: domain.TenderResponse{}},
+	{method: http.MethodGet, path: "/api/tenders/my", tag: "tenders", summary: "List the tenders of the employee",
+		query: []Parameter{pageLimit, pageOffset, username, exportFormat}, response: []domain.TenderResponse{}, content: exportContent},
+	{method: http.MethodPatch, path: "/api/tenders/{tenderId}/edit", tag: "tenders", summary: "Edit a tender",
+		query: []Parameter{username}, request: tenderUpdate{}, response: domain.TenderResponse{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/status", tag: "tenders", summary: "Get the status of a tender",
+		query: []Parameter{username}, response: domain.TenderStatusUpdate{}},
+	{method: http.MethodPut, path: "/api/tenders/{tenderId}/status", tag: "tenders", summary: "Change the status of a tender",
+		query: []Parameter{requiredQuery("status", "New status.", enumSchema(tenderStatuses...)), username}, response: domain.TenderResponse{}},
+	{method: http.MethodPut, path: "/api/tenders/{tenderId}/rollback/{version}", tag: "tenders", summary: "Roll a tender back to a version",
+		query: []Parameter{username}, response: domain.TenderResponse{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}", tag: "tenders", summary: "Get a tender",
+		query: []Parameter{username}, response: domain.TenderResponse{}},
+	{method: http.MethodPost, path: "/api/tenders/status:batch", tag: "tenders", summary: "Change the status of many tenders",
+		query: []Parameter{username}, request: domain.BatchStatusRequest{}, response: domain.BatchStatusResult{}},
+	{method: http.MethodPost, path: "/api/tenders/import", tag: "tenders", summary: "Import tenders from CSV or NDJSON",
+		query: []Parameter{username,
+			query("format", "Format of the body, taken from Content-Type when omitted.", enumSchema(string(domain.ImportFormatCSV), string(domain.ImportFormatNDJSON))),
+			query("dryRun", "Only validate the rows.", booleanSchema())},
+		body:     map[string]*Schema{"text/csv": stringSchema(), "application/x-ndjson": stringSchema()},
+		response: domain.ImportReport{}},
+	{method: http.MethodPost, path: "/api/tenders/{tenderId}/clone", tag: "tenders", summary: "Copy a tender into a new draft",
+		query: []Parameter{username}, status: http.StatusCreated, response: domain.TenderResponse{}},
+
+	{method: http.MethodGet, path: "/api/service-types", tag: "service types", summary: "List service types",
+		query: []Parameter{
+			query("parent", "Code of the parent, top level types when omitted.", stringSchema()),
+			query("all", "List the whole tree.", booleanSchema()),
+			query("includeInactive", "List deactivated types as well.", booleanSchema()),
+			locale},
+		response: []domain.ServiceType{}},
+	{method: http.MethodPost, path: "/api/service-types", tag: "service types", summary: "Create a service type",
+		query: []Parameter{username}, request: domain.ServiceType{}, status: http.StatusCreated, response: domain.ServiceType{}},
+	{method: http.MethodGet, path: "/api/service-types/{code}", tag: "service types", summary: "Get a service type",
+		query: []Parameter{locale}, response: domain.ServiceType{}},
+	{method: http.MethodPatch, path: "/api/service-types/{code}", tag: "service types", summary: "Update a service type",
+		query: []Parameter{username}, request: domain.ServiceTypeUpdate{}, response: domain.ServiceType{}},
+	{method: http.MethodDelete, path: "/api/service-types/{code}", tag: "service types", summary: "Delete a service type",
+		query: []Parameter{username}, status: http.StatusNoContent},
+
+	{method: http.MethodGet, path: "/api/organizations", tag: "organizations", summary: "List organizations",
+		query: []Parameter{pageLimit, pageOffset, username}, response: []domain.Organization{}},
+	{method: http.MethodPost, path: "/api/organizations", tag: "organizations", summary: "Create an organization",
+		query: []Parameter{username}, request: domain.CreateOrganizationRequest{}, status: http.StatusCreated, response: domain.Organization{}},
+	{method: http.MethodPatch, path: "/api/organizations/{organizationId}", tag: "organizations", summary: "Update an organization",
+		query: []Parameter{username}, request: domain.OrganizationUpdate{}, response: domain.Organization{}},
+	{method: http.MethodDelete, path: "/api/organizations/{organizationId}", tag: "organizations", summary: "Delete an organization without tenders or bids",
+		query: []Parameter{username}, status: http.StatusNoContent},
+	{method: http.MethodPut, path: "/api/organizations/{organizationId}/responsibles/{employee}", tag: "organizations", summary: "Make an employee responsible for an organization",
+		query: []Parameter{username}, status: http.StatusNoContent},
+	{method: http.MethodDelete, path: "/api/organizations/{organizationId}/responsibles/{employee}", tag: "organizations", summary: "Withdraw the responsibility of an employee",
+		query: []Parameter{username}, status: http.StatusNoContent},
+	{method: http.MethodGet, path: "/api/employees", tag: "employees", summary: "List employees",
+		query: []Parameter{pageLimit, pageOffset, username}, response: []domain.Employee{}},
+	{method: http.MethodPost, path: "/api/employees", tag: "employees", summary: "Create an employee",
+		query: []Parameter{username}, request: domain.CreateEmployeeRequest{}, status: http.StatusCreated, response: domain.Employee{}},
+	{method: http.MethodPatch, path: "/api/employees/{employee}", tag: "employees", summary: "Update an employee",
+		query: []Parameter{username}, request: domain.EmployeeUpdate{}, response: domain.Employee{}},
+	{method: http.MethodDelete, path: "/api/employees/{employee}", tag: "employees", summary: "Delete an employee who authored nothing",
+		query: []Parameter{username}, status: http.StatusNoContent},
+
+	{method: http.MethodPost, path: "/api/organizations/{organizationId}/templates", tag: "templates", summary: "Create a tender template",
+		query: []Parameter{username}, request: domain.CreateTemplateRequest{}, status: http.StatusCreated, response: domain.TenderTemplate{}},
+	{method: http.MethodGet, path: "/api/organizations/{organizationId}/templates", tag: "templates", summary: "List the templates of an organization",
+		query: []Parameter{username}, response: []domain.TenderTemplate{}},
+	{method: http.MethodDelete, path: "/api/organizations/{organizationId}/templates/{templateId}", tag: "templates", summary: "Delete a template",
+		query: []Parameter{username}, status: http.StatusNoContent},
+
+	{method: http.MethodPost, path: "/api/organizations/{organizationId}/custom-fields", tag: "custom fields", summary: "Define a custom tender field",
+		query: []Parameter{username}, request: domain.CreateCustomFieldRequest{}, status: http.StatusCreated, response: domain.CustomField{}},
+	{method: http.MethodGet, path: "/api/organizations/{organizationId}/custom-fields", tag: "custom fields", summary: "List the custom fields of an organization",
+		query: []Parameter{username}, response: []domain.CustomField{}},
+	{method: http.MethodDelete, path: "/api/organizations/{organizationId}/custom-fields/{key}", tag: "custom fields", summary: "Delete a custom field",
+		query: []Parameter{username}, status: http.StatusNoContent},
+
+	{method: http.MethodPost, path: "/api/tenders/{tenderId}/attachments", tag: "attachments", summary: "Attach a file to a tender",
+		query: []Parameter{username}, body: upload, status: http.StatusCreated, response: domain.Attachment{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/attachments", tag: "attachments", summary: "List the files of a tender",
+		query: []Parameter{username}, response: []domain.Attachment{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/attachments/{attachmentId}", tag: "attachments", summary: "Download a tender file",
+		query: []Parameter{username}, content: map[string]*Schema{"application/octet-stream": binarySchema()}},
+	{method: http.MethodDelete, path: "/api/tenders/{tenderId}/attachments/{attachmentId}", tag: "attachments", summary: "Delete a tender file",
+		query: []Parameter{username}, status: http.StatusNoContent},
+
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/lots", tag: "awards", summary: "List the lots of a tender",
+		query: []Parameter{username}, response: []domain.Lot{}},
+	{method: http.MethodPut, path: "/api/tenders/{tenderId}/lots/{lotId}/award", tag: "awards", summary: "Award a lot to a bid",
+		query: []Parameter{username}, request: domain.AwardLotRequest{}, response: domain.Lot{}},
+	{method: http.MethodPut, path: "/api/tenders/{tenderId}/award", tag: "awards", summary: "Award a tender",
+		query: []Parameter{username}, request: domain.AwardTenderRequest{}, response: []domain.Award{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/award", tag: "awards", summary: "Get the awards of a tender",
+		query: []Parameter{username}, response: []domain.Award{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/award/contract", tag: "awards", summary: "Generate the contract of an awarded tender",
+		query:   []Parameter{username, query("format", "Document format, markdown by default.", enumSchema(string(domain.ContractFormatMarkdown), string(domain.ContractFormatPDF)))},
+		content: map[string]*Schema{"text/markdown": stringSchema(), "application/pdf": binarySchema()}},
+
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/ocds", tag: "ocds", summary: "Export a tender in the Open Contracting Data Standard",
+		query:    []Parameter{query("package", "Package type, record by default.", enumSchema(string(domain.OCDSPackageRecord), string(domain.OCDSPackageRelease)))},
+		response: oneOf{ocds.RecordPackage{}, ocds.ReleasePackage{}}},
+	{method: http.MethodGet, path: "/api/ocds/releases", tag: "ocds", summary: "List releases of all public tenders",
+		query:    []Parameter{pageLimit, pageOffset, query("since", "Only releases published after this time.", &Schema{Type: "string", Format: "date-time"})},
+		response: ocds.ReleasePackage{}},
+
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/evaluation", tag: "evaluation", summary: "Get the evaluation of the bids",
+		query: []Parameter{username}, response: domain.Evaluation{}},
+	{method: http.MethodPut, path: "/api/bids/{bidId}/scores", tag: "evaluation", summary: "Score a bid",
+		query: []Parameter{username}, request: domain.ScoreBidRequest{}, response: []domain.BidScore{}},
+
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/auction", tag: "auction", summary: "Get the state of an auction",
+		query: []Parameter{username}, response: domain.Auction{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/auction/offers", tag: "auction", summary: "List the offers of an auction",
+		query: []Parameter{username}, response: []domain.AuctionOffer{}},
+	{method: http.MethodPost, path: "/api/tenders/{tenderId}/auction/offers", tag: "auction", summary: "Place an offer",
+		query: []Parameter{username}, request: domain.PlaceOfferRequest{}, status: http.StatusCreated, response: domain.AuctionOffer{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/auction/live", tag: "auction", summary: "Watch an auction over a WebSocket",
+		description: "Sends AuctionEvent messages, the current state first and then every accepted offer.",
+		query:       []Parameter{username}, status: http.StatusSwitchingProtocols},
+
+	{method: http.MethodPost, path: "/api/tenders/{tenderId}/invitations", tag: "invitations", summary: "Invite an organization to a private tender",
+		query: []Parameter{username}, request: domain.CreateInvitationRequest{}, status: http.StatusCreated, response: domain.Invitation{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/invitations", tag: "invitations", summary: "List the invitations of a tender",
+		query: []Parameter{username}, response: []domain.Invitation{}},
+	{method: http.MethodDelete, path: "/api/tenders/{tenderId}/invitations/{organizationId}", tag: "invitations", summary: "Withdraw an invitation",
+		query: []Parameter{username}, status: http.StatusNoContent},
+
+	{method: http.MethodPost, path: "/api/tenders/{tenderId}/questions", tag: "questions", summary: "Ask a question about a tender",
+		query: []Parameter{username}, request: domain.CreateQuestionRequest{}, status: http.StatusCreated, response: domain.Question{}},
+	{method: http.MethodGet, path: "/api/tenders/{tenderId}/questions", tag: "questions", summary: "List the questions of a tender",
+		query: []Parameter{username}, response: []domain.Question{}},
+	{method: http.MethodPut, path: "/api/tenders/{tenderId}/questions/{questionId}/answer", tag: "questions", summary: "Answer a question",
+		query: []Parameter{username}, request: domain.AnswerQuestionRequest{}, response: domain.Question{}},
+
+	{method: http.MethodPost, path: "/api/bids/new", tag: "bids", summary: "Create a bid",
+		request: domain.CreateBidRequest{}, status: http.StatusCreated, response: domain.BidResponse{}},
+	{method: http.MethodGet, path: "/api/bids/my", tag: "bids", summary: "List the bids of the employee",
+		query: []Parameter{pageLimit, pageOffset, username, exportFormat}, response: []domain.BidResponse{}, content: exportContent},
+	{method: http.MethodGet, path: "/api/bids/{tenderId}/list", tag: "bids", summary: "List the bids on a tender",
+		query: []Parameter{pageLimit, pageOffset, username, exportFormat}, response: []domain.BidResponse{}, content: exportContent},
+	{method: http.MethodGet, path: "/api/bids/{tenderId}/count", tag: "bids", summary: "Count the bids on a tender",
+		query: []Parameter{username}, response: domain.BidCount{}},
+
+	{method: http.MethodPost, path: "/api/bids/{bidId}/attachments", tag: "attachments", summary: "Attach a file to a bid",
+		query: []Parameter{username}, body: upload, status: http.StatusCreated, response: domain.Attachment{}},
+	{method: http.MethodGet, path: "/api/bids/{bidId}/attachments", tag: "attachments", summary: "List the files of a bid",
+		query: []Parameter{username}, response: []domain.Attachment{}},
+	{method: http.MethodGet, path: "/api/bids/{bidId}/attachments/{attachmentId}", tag: "attachments", summary: "Download a bid file",
+		query: []Parameter{username}, content: map[string]*Schema{"application/octet-stream": binarySchema()}},
+	{method: http.MethodDelete, path: "/api/bids/{bidId}/attachments/{attachmentId}", tag: "attachments", summary: "Delete a bid file",
+		query: []Parameter{username}, status: http.StatusNoContent},
+
+	{method: http.MethodGet, path: "/api/notifications/settings", tag: "notifications", summary: "Get the notification settings of the employee",
+		query: []Parameter{username}, response: domain.NotificationSettings{}},
+	{method: http.MethodPut, path: "/api/notifications/settings", tag: "notifications", summary: "Update the notification settings",
+		query: []Parameter{username}, request: domain.NotificationSettings{}, response: domain.NotificationSettings{}},
+	{method: http.MethodGet, path: "/api/notifications", tag: "notifications", summary: "List in-app notifications",
+		query:    []Parameter{username, query("unread", "Only unread notifications.", booleanSchema()), pageLimit, pageOffset},
+		response: []domain.InAppNotification{}},
+	{method: http.MethodPut, path: "/api/notifications/{notificationId}/read", tag: "notifications", summary: "Mark a notification read",
+		query: []Parameter{username}, response: domain.InAppNotification{}},
+
+	{method: http.MethodPost, path: "/api/searches", tag: "searches", summary: "Save a tender search",
+		query: []Parameter{username}, request: domain.CreateSavedSearchRequest{}, status: http.StatusCreated, response: domain.SavedSearch{}},
+	{method: http.MethodGet, path: "/api/searches", tag: "searches", summary: "List saved searches",
+		query: []Parameter{username}, response: []domain.SavedSearch{}},
+	{method: http.MethodPut, path: "/api/searches/{searchId}/subscription", tag: "searches", summary: "Subscribe to or unsubscribe from a search",
+		query: []Parameter{username, requiredQuery("subscribed", "Whether new matches are notified.", booleanSchema())}, response: domain.SavedSearch{}},
+	{method: http.MethodDelete, path: "/api/searches/{searchId}", tag: "searches", summary: "Delete a saved search",
+		query: []Parameter{username}, status: http.StatusNoContent},
+
+	{method: http.MethodGet, path: "/api/graphql", tag: "graphql", summary: "Run a GraphQL query",
+		description: "Query depth and complexity are limited, the employee sees what the HTTP API would show them.",
+		query: []Parameter{graphQLUsername,
+			requiredQuery("query", "GraphQL document.", stringSchema()),
+			query("operationName", "Operation to run when the document has several.", stringSchema()),
+			query("variables", "Variables as a JSON object.", stringSchema())},
+		response: graphQLResponse{}},
+	{method: http.MethodPost, path: "/api/graphql", tag: "graphql", summary: "Run a GraphQL query",
+		description: "Query depth and complexity are limited, the employee sees what the HTTP API would show them.",
+		query:       []Parameter{graphQLUsername}, request: graphQLRequest{}, response: graphQLResponse{}},
+}
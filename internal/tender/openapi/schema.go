@@ -0,0 +1,187 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema is the subset of the OpenAPI 3.0 schema object the specification
+// uses.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            int                `json:"minLength,omitempty"`
+	MaxLength            int                `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             int                `json:"minItems,omitempty"`
+	MaxItems             int                `json:"maxItems,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	// Closed objects reject properties they do not list, it is encoded as
+	// additionalProperties: false.
+	Closed bool      `json:"-"`
+	OneOf  []*Schema `json:"oneOf,omitempty"`
+}
+
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	if !s.Closed {
+		return json.Marshal(plain(s))
+	}
+
+	return json.Marshal(struct {
+		plain
+		AdditionalProperties bool `json:"additionalProperties"`
+	}{plain: plain(s)})
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemas turns Go types into schemas the way encoding/json encodes them.
+// Named structs become components referenced by name, closed to properties
+// the struct does not have.
+type schemas struct {
+	components  map[string]*Schema
+	enums       map[reflect.Type][]string
+	required    map[reflect.Type][]string
+	constraints map[reflect.Type]map[string]*Schema
+}
+
+func (s *schemas) of(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		schema := s.of(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string", Enum: s.enums[t]}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		name := componentName(t)
+		if _, ok := s.components[name]; !ok {
+			// Registered before the fields, so that recursive types end.
+			s.components[name] = &Schema{}
+			*s.components[name] = *s.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	default:
+		return &Schema{}
+	}
+}
+
+func (s *schemas) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}, Required: s.required[t], Closed: true}
+	s.fields(t, schema)
+	for name, constraint := range s.constraints[t] {
+		constrain(schema.Properties[name], constraint)
+	}
+	return schema
+}
+
+func (s *schemas) fields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			s.fields(field.Type, schema)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = s.of(field.Type)
+	}
+}
+
+// componentName names domain types as they are, types of other packages
+// get the package name in front.
+func componentName(t reflect.Type) string {
+	name := []rune(t.Name())
+	name[0] = unicode.ToUpper(name[0])
+
+	switch pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]; pkg {
+	case "domain", "openapi":
+		return string(name)
+	default:
+		return strings.ToUpper(pkg) + string(name)
+	}
+}
+
+// constrain adds the limits of constraint to schema.
+func constrain(schema, constraint *Schema) {
+	if schema == nil || constraint == nil {
+		return
+	}
+
+	if constraint.Format != "" {
+		schema.Format = constraint.Format
+	}
+	if constraint.Enum != nil {
+		schema.Enum = constraint.Enum
+	}
+	if constraint.MinLength != 0 {
+		schema.MinLength = constraint.MinLength
+	}
+	if constraint.MaxLength != 0 {
+		schema.MaxLength = constraint.MaxLength
+	}
+	if constraint.Minimum != nil {
+		schema.Minimum = constraint.Minimum
+		schema.ExclusiveMinimum = constraint.ExclusiveMinimum
+	}
+	if constraint.Maximum != nil {
+		schema.Maximum = constraint.Maximum
+	}
+	if constraint.MinItems != 0 {
+		schema.MinItems = constraint.MinItems
+	}
+	if constraint.MaxItems != 0 {
+		schema.MaxItems = constraint.MaxItems
+	}
+	constrain(schema.Items, constraint.Items)
+	constrain(schema.AdditionalProperties, constraint.AdditionalProperties)
+}
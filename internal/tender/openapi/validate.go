@@ -0,0 +1,352 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const (
+	// MaxBodySize bounds the JSON bodies the validator reads.
+	MaxBodySize = 10 << 20
+	// Missing is the error of a required parameter or field left out.
+	Missing = "is required"
+)
+
+var (
+	ErrBodyTooLarge = errors.New("request body is too large")
+	uuidFormat      = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// Validator checks requests against the operations of a document.
+type Validator struct {
+	doc    *Document
+	routes []compiledRoute
+}
+
+type compiledRoute struct {
+	method    string
+	segments  []string
+	operation *Operation
+}
+
+func NewValidator(doc *Document) *Validator {
+	v := &Validator{doc: doc}
+	for path, item := range doc.Paths {
+		for method, operation := range item {
+			v.routes = append(v.routes, compiledRoute{
+				method:    strings.ToUpper(method),
+				segments:  strings.Split(strings.Trim(path, "/"), "/"),
+				operation: operation,
+			})
+		}
+	}
+
+	// Literal segments take precedence over parameters, as in ServeMux.
+	sort.Slice(v.routes, func(i, j int) bool {
+		return specificity(v.routes[i].segments) > specificity(v.routes[j].segments)
+	})
+
+	return v
+}
+
+func specificity(segments []string) string {
+	var key strings.Builder
+	for _, segment := range segments {
+		if isParameter(segment) {
+			key.WriteByte('0')
+		} else {
+			key.WriteByte('1')
+		}
+	}
+
+	return key.String()
+}
+
+func isParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// Operation returns the operation serving the request and its path
+// parameters, or nil when the request is not specified.
+func (v *Validator) Operation(r *http.Request) (*Operation, map[string]string) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	for _, route := range v.routes {
+		if route.method != r.Method || len(route.segments) != len(segments) {
+			continue
+		}
+
+		values := map[string]string{}
+		matched := true
+		for i, segment := range route.segments {
+			if isParameter(segment) && segments[i] != "" {
+				values[segment[1:len(segment)-1]] = segments[i]
+			} else if segment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return route.operation, values
+		}
+	}
+
+	return nil, nil
+}
+
+// Validate checks the parameters and the JSON body of a request. The body is
+// read and replaced, so that the handler can decode it again. The returned
+// error is set when the body could not be read at all.
+func (v *Validator) Validate(r *http.Request, operation *Operation, pathValues map[string]string) ([]domain.FieldError, error) {
+	var details []domain.FieldError
+	query := r.URL.Query()
+
+	for _, parameter := range operation.Parameters {
+		var values []string
+		switch parameter.In {
+		case "path":
+			values = []string{pathValues[parameter.Name]}
+		case "query":
+			values = query[parameter.Name]
+		}
+
+		if len(values) == 0 {
+			if parameter.Required {
+				details = append(details, domain.FieldError{In: parameter.In, Field: parameter.Name, Error: Missing})
+			}
+			continue
+		}
+
+		schema := parameter.Schema
+		if schema.Type != "array" {
+			values = values[:1]
+		} else {
+			schema = schema.Items
+		}
+		for _, value := range values {
+			if message := v.parameter(schema, value); message != "" {
+				details = append(details, domain.FieldError{In: parameter.In, Field: parameter.Name, Error: message})
+			}
+		}
+	}
+
+	bodyDetails, err := v.body(r, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(details, bodyDetails...), nil
+}
+
+// parameter converts a parameter to the type of its schema and validates it.
+func (v *Validator) parameter(schema *Schema, value string) string {
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "must be an integer"
+		}
+		return v.numberError(schema, float64(n))
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "must be a number"
+		}
+		return v.numberError(schema, n)
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be true or false"
+		}
+		return ""
+	default:
+		return v.stringError(schema, value)
+	}
+}
+
+func (v *Validator) body(r *http.Request, operation *Operation) ([]domain.FieldError, error) {
+	if operation.RequestBody == nil || r.Body == nil {
+		return nil, nil
+	}
+	media, ok := operation.RequestBody.Content["application/json"]
+	if !ok {
+		return nil, nil
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "application/json" {
+			return nil, nil
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	if len(data) > MaxBodySize {
+		return nil, ErrBodyTooLarge
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if operation.RequestBody.Required {
+			return []domain.FieldError{{In: "body", Error: Missing}}, nil
+		}
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []domain.FieldError{{In: "body", Error: "is not valid JSON"}}, nil
+	}
+
+	var details []domain.FieldError
+	v.value(media.Schema, value, "", &details)
+
+	return details, nil
+}
+
+// value validates a decoded JSON value. Null is accepted anywhere, as
+// encoding/json leaves the field unset.
+func (v *Validator) value(schema *Schema, value any, field string, details *[]domain.FieldError) {
+	schema = v.doc.Resolve(schema)
+	if schema == nil || value == nil {
+		return
+	}
+
+	fail := func(message string) {
+		*details = append(*details, domain.FieldError{In: "body", Field: field, Error: message})
+	}
+
+	switch schema.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+		} else if message := v.stringError(schema, s); message != "" {
+			fail(message)
+		}
+	case "integer", "number":
+		n, ok := value.(json.Number)
+		if !ok {
+			fail("must be a number")
+			return
+		}
+		f, err := n.Float64()
+		if err != nil || (schema.Type == "integer" && f != float64(int64(f))) {
+			fail("must be an integer")
+			return
+		}
+		if message := v.numberError(schema, f); message != "" {
+			fail(message)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be true or false")
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if schema.MinItems != 0 && len(items) < schema.MinItems {
+			fail(fmt.Sprintf("must have at least %d items", schema.MinItems))
+		}
+		if schema.MaxItems != 0 && len(items) > schema.MaxItems {
+			fail(fmt.Sprintf("must have at most %d items", schema.MaxItems))
+		}
+		for i, item := range items {
+			v.value(schema.Items, item, fmt.Sprintf("%s[%d]", field, i), details)
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				*details = append(*details, domain.FieldError{In: "body", Field: join(field, name), Error: Missing})
+			}
+		}
+
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := schema.Properties[name]
+			switch {
+			case ok:
+				v.value(property, object[name], join(field, name), details)
+			case schema.Closed:
+				*details = append(*details, domain.FieldError{In: "body", Field: join(field, name), Error: "is not a known field"})
+			case schema.AdditionalProperties != nil:
+				v.value(schema.AdditionalProperties, object[name], join(field, name), details)
+			}
+		}
+	}
+}
+
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+
+	return field + "." + name
+}
+
+func (v *Validator) stringError(schema *Schema, s string) string {
+	length := utf8.RuneCountInString(s)
+	switch {
+	case schema.Enum != nil && !slices.Contains(schema.Enum, s):
+		return "must be one of " + strings.Join(schema.Enum, ", ")
+	case schema.MinLength != 0 && length < schema.MinLength:
+		return fmt.Sprintf("must be at least %d characters long", schema.MinLength)
+	case schema.MaxLength != 0 && length > schema.MaxLength:
+		return fmt.Sprintf("must be at most %d characters long", schema.MaxLength)
+	}
+
+	switch schema.Format {
+	case "uuid":
+		if !uuidFormat.MatchString(s) {
+			return "must be a UUID"
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return "must be an RFC 3339 date"
+		}
+	}
+
+	return ""
+}
+
+func (v *Validator) numberError(schema *Schema, n float64) string {
+	switch {
+	case schema.Minimum != nil && schema.ExclusiveMinimum && n <= *schema.Minimum:
+		return "must be greater than " + strconv.FormatFloat(*schema.Minimum, 'f', -1, 64)
+	case schema.Minimum != nil && n < *schema.Minimum:
+		return "must be at least " + strconv.FormatFloat(*schema.Minimum, 'f', -1, 64)
+	case schema.Maximum != nil && n > *schema.Maximum:
+		return "must be at most " + strconv.FormatFloat(*schema.Maximum, 'f', -1, 64)
+	}
+
+	return ""
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const tenderID = "550e8400-e29b-41d4-a716-446655440000"
+
+func TestValidate(t *testing.T) {
+	validator := NewValidator(Build())
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   []domain.FieldError
+	}{
+		{
+			name:   "valid edit",
+			method: http.MethodPatch,
+			target: "/api/tenders/" + tenderID + "/edit?username=user1",
+			body:   `{"name": "Серверы", "budget": 1000, "tags": ["it"], "lots": [{"name": "Лот", "quantity": 2}]}`,
+		},
+		{
+			name:   "unknown field and wrong type",
+			method: http.MethodPatch,
+			target: "/api/tenders/" + tenderID + "/edit?username=user1",
+			body:   `{"status": "CLOSED", "budget": "much", "lots": [{"name": "Лот", "quantity": 0}]}`,
+			want: []domain.FieldError{
+				{In: "body", Field: "budget", Error: "must be a number"},
+				{In: "body", Field: "lots[0].quantity", Error: "must be greater than 0"},
+				{In: "body", Field: "status", Error: "is not a known field"},
+			},
+		},
+		{
+			name:   "column lengths",
+			method: http.MethodPost,
+			target: "/api/tender/new",
+			body:   `{"name": "` + strings.Repeat("я", 256) + `", "organizationId": "org-1", "tags": ["` + strings.Repeat("t", 51) + `"]}`,
+			want: []domain.FieldError{
+				{In: "body", Field: "name", Error: "must be at most 255 characters long"},
+				{In: "body", Field: "organizationId", Error: "must be a UUID"},
+				{In: "body", Field: "tags[0]", Error: "must be at most 50 characters long"},
+			},
+		},
+		{
+			name:   "required fields",
+			method: http.MethodPost,
+			target: "/api/bids/new",
+			body:   `{"name": "Предложение", "tenderId": "` + tenderID + `"}`,
+			want: []domain.FieldError{
+				{In: "body", Field: "organizationId", Error: Missing},
+				{In: "body", Field: "creatorUsername", Error: Missing},
+			},
+		},
+		{
+			name:   "parameters",
+			method: http.MethodPut,
+			target: "/api/tenders/42/status?status=DONE",
+			want: []domain.FieldError{
+				{In: "path", Field: "tenderId", Error: "must be a UUID"},
+				{In: "query", Field: "status", Error: "must be one of CREATED, PUBLISHED, CLOSED, OPEN"},
+				{In: "query", Field: "username", Error: Missing},
+			},
+		},
+		{
+			name:   "literal segments first",
+			method: http.MethodGet,
+			target: "/api/tenders/my?username=user1&limit=-1",
+			want: []domain.FieldError{
+				{In: "query", Field: "limit", Error: "must be at least 0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			operation, pathValues := validator.Operation(r)
+			if operation == nil {
+				t.Fatalf("no operation for %s %s", tt.method, tt.target)
+			}
+
+			details, err := validator.Validate(r, operation, pathValues)
+			if err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+			if len(details) != len(tt.want) {
+				t.Fatalf("details = %+v, want %+v", details, tt.want)
+			}
+			for i := range tt.want {
+				if details[i] != tt.want[i] {
+					t.Errorf("details[%d] = %+v, want %+v", i, details[i], tt.want[i])
+				}
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != tt.body {
+				t.Errorf("body left for the handler = %q, want %q", body, tt.body)
+			}
+		})
+	}
+
+	if operation, _ := validator.Operation(httptest.NewRequest(http.MethodGet, "/api/unknown", nil)); operation != nil {
+		t.Errorf("unspecified route matched %s", operation.OperationID)
+	}
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.AttachmentRepository = (*AttachmentService)(nil)
+)
+
+const attachmentColumns = `id, file_name, content_type, size, sha256, version, storage_key, COALESCE(uploaded_by, ''), created_at, sealed`
+
+type AttachmentService struct {
+	pool *pgxpool.Pool
+}
+
+func NewAttachmentService(pool *pgxpool.Pool) *AttachmentService {
+	return &AttachmentService{pool: pool}
+}
+
+func scanAttachment(row rowScanner) (domain.Attachment, error) {
+	var attachment domain.Attachment
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.SHA256,
+		&attachment.Version,
+		&attachment.StorageKey,
+		&attachment.UploadedBy,
+		&attachment.CreatedAt,
+		&attachment.Sealed,
+	)
+
+	return attachment, err
+}
+
+func attachmentOwnerColumn(owner domain.AttachmentOwner) string {
+	if owner.Type == domain.AttachmentOwnerBid {
+		return "bid_id"
+	}
+
+	return "tender_id"
+}
+
+// attachmentOwner is what access checks need to know about the owner of
+// attachments. Tender attachments are readable by every employee the tender is
+// visible to; bid attachments by the bidder and, once the bids are revealed, by
+// the organization of the tender.
+type attachmentOwner struct {
+	version              int
+	organizationID       string
+	tenderOrganizationID string
+	revealed             bool
+	sealKey              []byte
+}
+
+func attachmentOwnerInfo(ctx context.Context, q querier, owner domain.AttachmentOwner) (attachmentOwner, error) {
+	var (
+		info attachmentOwner
+		err  error
+	)
+
+	switch owner.Type {
+	case domain.AttachmentOwnerTender:
+		err = q.QueryRow(ctx, `SELECT version, organization_id FROM tender WHERE id = $1`, owner.ID).
+			Scan(&info.version, &info.organizationID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return attachmentOwner{}, domain.ErrTenderNotFound
+		}
+	case domain.AttachmentOwnerBid:
+		err = q.QueryRow(ctx, `
+			SELECT b.version, COALESCE(b.organization_id::text, ''), t.organization_id, `+bidsRevealedSQL+`,
+				CASE WHEN t.sealed THEN t.seal_key END
+			FROM bid b
+			JOIN tender t ON t.id = b.tender_id
+			WHERE b.id = $1
+		`, owner.ID).Scan(&info.version, &info.organizationID, &info.tenderOrganizationID, &info.revealed, &info.sealKey)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return attachmentOwner{}, domain.ErrBidNotFound
+		}
+	default:
+		return attachmentOwner{}, domain.ErrInvalidInput
+	}
+	if err != nil {
+		return attachmentOwner{}, err
+	}
+
+	return info, nil
+}
+
+func (r *AttachmentService) checkReadAccess(ctx context.Context, owner domain.AttachmentOwner, username string) (attachmentOwner, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return attachmentOwner{}, err
+	}
+
+	info, err := attachmentOwnerInfo(ctx, r.pool, owner)
+	if err != nil {
+		return attachmentOwner{}, err
+	}
+
+	if owner.Type == domain.AttachmentOwnerTender {
+		return info, checkTenderVisible(ctx, r.pool, owner.ID, username)
+	}
+
+	bidder, err := isResponsible(ctx, r.pool, username, info.organizationID)
+	if err != nil {
+		return attachmentOwner{}, err
+	}
+	if bidder {
+		return info, nil
+	}
+
+	if err := checkResponsible(ctx, r.pool, username, info.tenderOrganizationID); err != nil {
+		return attachmentOwner{}, err
+	}
+	if !info.revealed {
+		return attachmentOwner{}, domain.ErrBidsSealed
+	}
+
+	return info, nil
+}
+
+func (r *AttachmentService) GetUploadTarget(ctx context.Context, owner domain.AttachmentOwner, username string) (domain.AttachmentTarget, error) {
+	info, err := attachmentOwnerInfo(ctx, r.pool, owner)
+	if err != nil {
+		return domain.AttachmentTarget{}, fmt.Errorf("repository.GetUploadTarget: %w", err)
+	}
+
+	if err := checkResponsible(ctx, r.pool, username, info.organizationID); err != nil {
+		return domain.AttachmentTarget{}, fmt.Errorf("repository.GetUploadTarget: %w", err)
+	}
+
+	return domain.AttachmentTarget{Version: info.version, SealKey: info.sealKey}, nil
+}
+
+func (r *AttachmentService) CreateAttachment(ctx context.Context, owner domain.AttachmentOwner, attachment domain.Attachment) (domain.Attachment, error) {
+	created, err := scanAttachment(r.pool.QueryRow(ctx, `
+		INSERT INTO attachment (`+attachmentOwnerColumn(owner)+`, version, file_name, content_type, size, sha256, storage_key, uploaded_by, sealed)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+attachmentColumns,
+		owner.ID, attachment.Version, attachment.FileName, attachment.ContentType, attachment.Size,
+		attachment.SHA256, attachment.StorageKey, attachment.UploadedBy, attachment.Sealed,
+	))
+	if err != nil {
+		return domain.Attachment{}, fmt.Errorf("repository.CreateAttachment: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *AttachmentService) ListAttachments(ctx context.Context, owner domain.AttachmentOwner, username string) ([]domain.Attachment, error) {
+	if _, err := r.checkReadAccess(ctx, owner, username); err != nil {
+		return nil, fmt.Errorf("repository.ListAttachments: %w", err)
+	}
+
+	attachments, err := listAttachments(ctx, r.pool, owner)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListAttachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func listAttachments(ctx context.Context, pool *pgxpool.Pool, owner domain.AttachmentOwner) ([]domain.Attachment, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM attachment
+		WHERE `+attachmentOwnerColumn(owner)+` = $1
+		ORDER BY created_at
+	`, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []domain.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+func (r *AttachmentService) GetAttachment(ctx context.Context, owner domain.AttachmentOwner, attachmentID string, username string) (domain.Attachment, error) {
+	info, err := r.checkReadAccess(ctx, owner, username)
+	if err != nil {
+		return domain.Attachment{}, fmt.Errorf("repository.GetAttachment: %w", err)
+	}
+
+	attachment, err := scanAttachment(r.pool.QueryRow(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM attachment
+		WHERE id = $1 AND `+attachmentOwnerColumn(owner)+` = $2
+	`, attachmentID, owner.ID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Attachment{}, fmt.Errorf("repository.GetAttachment: %w", domain.ErrNotFound)
+		}
+		return domain.Attachment{}, fmt.Errorf("repository.GetAttachment: %w", err)
+	}
+
+	if attachment.Sealed {
+		attachment.SealKey = info.sealKey
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentService) DeleteAttachment(ctx context.Context, owner domain.AttachmentOwner, attachmentID string, username string) (domain.Attachment, error) {
+	info, err := attachmentOwnerInfo(ctx, r.pool, owner)
+	if err != nil {
+		return domain.Attachment{}, fmt.Errorf("repository.DeleteAttachment: %w", err)
+	}
+
+	if err := checkResponsible(ctx, r.pool, username, info.organizationID); err != nil {
+		return domain.Attachment{}, fmt.Errorf("repository.DeleteAttachment: %w", err)
+	}
+
+	attachment, err := scanAttachment(r.pool.QueryRow(ctx, `
+		DELETE FROM attachment
+		WHERE id = $1 AND `+attachmentOwnerColumn(owner)+` = $2
+		RETURNING `+attachmentColumns,
+		attachmentID, owner.ID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Attachment{}, fmt.Errorf("repository.DeleteAttachment: %w", domain.ErrNotFound)
+		}
+		return domain.Attachment{}, fmt.Errorf("repository.DeleteAttachment: %w", err)
+	}
+
+	return attachment, nil
+}
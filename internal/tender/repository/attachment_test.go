@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+func TestSealedBidAttachments(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	tenders := repository.NewTenderService(pool)
+	bids := repository.NewBidService(pool)
+	attachments := repository.NewAttachmentService(pool)
+
+	closesAt := time.Now().Add(24 * time.Hour)
+	sealed := newTender("Запечатанный", 1000, domain.TenderVisibilityPublic)
+	sealed.Sealed = true
+	sealed.SealKey = []byte("wrapped tender key")
+	sealed.ClosesAt = &closesAt
+	tender, err := tenders.CreateTender(ctx, sealed)
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+
+	bid, err := bids.CreateBid(ctx, domain.Bid{
+		Status:          "CREATED",
+		TenderId:        tender.ID,
+		OrganizationId:  repotest.OtherOrganization,
+		CreatorUsername: repotest.Outsider,
+		Version:         1,
+		SealedPayload:   []byte("sealed bid"),
+	})
+	if err != nil {
+		t.Fatalf("CreateBid: %v", err)
+	}
+	owner := domain.AttachmentOwner{Type: domain.AttachmentOwnerBid, ID: bid.ID}
+
+	target, err := attachments.GetUploadTarget(ctx, owner, repotest.Outsider)
+	if err != nil {
+		t.Fatalf("GetUploadTarget: %v", err)
+	}
+	if string(target.SealKey) != "wrapped tender key" {
+		t.Errorf("seal key = %q, want the key of the tender", target.SealKey)
+	}
+
+	attachment, err := attachments.CreateAttachment(ctx, owner, domain.Attachment{
+		FileName:    "offer.txt",
+		ContentType: "text/plain",
+		SHA256:      "0000000000000000000000000000000000000000000000000000000000000000",
+		Version:     target.Version,
+		StorageKey:  "bid/" + bid.ID + "/offer",
+		UploadedBy:  repotest.Outsider,
+		Sealed:      true,
+	})
+	if err != nil {
+		t.Fatalf("CreateAttachment: %v", err)
+	}
+
+	got, err := attachments.GetAttachment(ctx, owner, attachment.ID, repotest.Outsider)
+	if err != nil {
+		t.Fatalf("GetAttachment by the bidder: %v", err)
+	}
+	if !got.Sealed || string(got.SealKey) != "wrapped tender key" {
+		t.Errorf("attachment = %+v, want it sealed with the key of the tender", got)
+	}
+
+	// Until the deadline the tender organization sees neither the bid nor
+	// its files.
+	if _, err := attachments.ListAttachments(ctx, owner, repotest.Owner); !errors.Is(err, domain.ErrBidsSealed) {
+		t.Errorf("ListAttachments by the tender owner: error = %v, want %v", err, domain.ErrBidsSealed)
+	}
+	if _, err := attachments.GetAttachment(ctx, owner, attachment.ID, repotest.Owner); !errors.Is(err, domain.ErrBidsSealed) {
+		t.Errorf("GetAttachment by the tender owner: error = %v, want %v", err, domain.ErrBidsSealed)
+	}
+
+	if _, _, err := tenders.UpdateTenderStatus(ctx, tender.ID, "CLOSED", []string{"PUBLISHED"}, repotest.Owner); err != nil {
+		t.Fatalf("UpdateTenderStatus: %v", err)
+	}
+	if _, err := attachments.GetAttachment(ctx, owner, attachment.ID, repotest.Owner); err != nil {
+		t.Errorf("GetAttachment by the tender owner after closing: %v", err)
+	}
+
+	// Closing reveals the bids, so it can not be undone to hide them again.
+	_, _, err = tenders.UpdateTenderStatus(ctx, tender.ID, "PUBLISHED", []string{"CREATED", "OPEN"}, repotest.Owner)
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("publishing the closed tender again: error = %v, want %v", err, domain.ErrConflict)
+	}
+	if _, err := tenders.RollbackTenderVersion(ctx, tender.ID, 1, repotest.Owner); err != nil {
+		t.Fatalf("RollbackTenderVersion: %v", err)
+	}
+	if status, err := tenders.GetTenderStatus(ctx, tender.ID, repotest.Owner); err != nil || status != "CLOSED" {
+		t.Errorf("status after rollback = %q, %v, want CLOSED", status, err)
+	}
+}
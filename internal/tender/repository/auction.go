@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.AuctionRepository = (*AuctionService)(nil)
+)
+
+// auctionColumns selects an auction aliased as a of the tender aliased as t.
+const auctionColumns = `a.tender_id, a.starts_at, a.ends_at, a.start_price, a.min_decrement, a.extension_seconds,
+	a.best_price, a.offer_count, (t.status = 'PUBLISHED' AND a.starts_at <= NOW() AND NOW() < a.ends_at)`
+
+const offerColumns = `id, tender_id, seq, organization_id, created_by_user, price, created_at`
+
+type AuctionService struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuctionService(pool *pgxpool.Pool) *AuctionService {
+	return &AuctionService{pool: pool}
+}
+
+func scanAuction(row rowScanner) (domain.Auction, error) {
+	var auction domain.Auction
+	err := row.Scan(
+		&auction.TenderID,
+		&auction.StartsAt,
+		&auction.EndsAt,
+		&auction.StartPrice,
+		&auction.MinDecrement,
+		&auction.ExtensionSeconds,
+		&auction.BestPrice,
+		&auction.OfferCount,
+		&auction.Running,
+	)
+
+	return auction, err
+}
+
+func scanOffer(row rowScanner) (domain.AuctionOffer, error) {
+	var offer domain.AuctionOffer
+	err := row.Scan(
+		&offer.ID,
+		&offer.TenderID,
+		&offer.Seq,
+		&offer.OrganizationID,
+		&offer.CreatorUsername,
+		&offer.Price,
+		&offer.CreatedAt,
+	)
+
+	return offer, err
+}
+
+func getAuction(ctx context.Context, q querier, tenderID string) (domain.Auction, error) {
+	auction, err := scanAuction(q.QueryRow(ctx, `
+		SELECT `+auctionColumns+`
+		FROM tender_auction a
+		JOIN tender t ON t.id = a.tender_id
+		WHERE a.tender_id = $1
+	`, tenderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Auction{}, fmt.Errorf("%w: tender is not an auction", domain.ErrNotFound)
+		}
+		return domain.Auction{}, err
+	}
+
+	return auction, nil
+}
+
+func createAuction(ctx context.Context, q querier, tenderID string, auction domain.Auction) error {
+	_, err := q.Exec(ctx, `
+		INSERT INTO tender_auction (tender_id, starts_at, ends_at, start_price, min_decrement, extension_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, tenderID, auction.StartsAt, auction.EndsAt, auction.StartPrice, auction.MinDecrement, auction.ExtensionSeconds)
+
+	return err
+}
+
+func (r *AuctionService) GetAuction(ctx context.Context, tenderID string, username string) (domain.Auction, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return domain.Auction{}, fmt.Errorf("repository.GetAuction: %w", err)
+	}
+
+	if err := checkTenderVisible(ctx, r.pool, tenderID, username); err != nil {
+		return domain.Auction{}, fmt.Errorf("repository.GetAuction: %w", err)
+	}
+
+	auction, err := getAuction(ctx, r.pool, tenderID)
+	if err != nil {
+		return domain.Auction{}, fmt.Errorf("repository.GetAuction: %w", err)
+	}
+
+	return auction, nil
+}
+
+// ListOffers returns the full offer history in order. Bidder identities are
+// only shown to the tender owner.
+func (r *AuctionService) ListOffers(ctx context.Context, tenderID string, limit, offset int, username string) ([]domain.AuctionOffer, error) {
+	var organizationID string
+	err := r.pool.QueryRow(ctx, `SELECT organization_id FROM tender WHERE id = $1`, tenderID).Scan(&organizationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("repository.ListOffers: %w", domain.ErrTenderNotFound)
+		}
+		return nil, fmt.Errorf("repository.ListOffers: %w", err)
+	}
+
+	if err := checkResponsible(ctx, r.pool, username, organizationID); err != nil {
+		return nil, fmt.Errorf("repository.ListOffers: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+offerColumns+`
+		FROM auction_offer
+		WHERE tender_id = $1
+		ORDER BY seq
+		LIMIT $2 OFFSET $3
+	`, tenderID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListOffers: %w", err)
+	}
+	defer rows.Close()
+
+	offers := []domain.AuctionOffer{}
+	for rows.Next() {
+		offer, err := scanOffer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListOffers: %w", err)
+		}
+		offers = append(offers, offer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListOffers: %w", err)
+	}
+
+	return offers, nil
+}
+
+// PlaceOffer accepts an offer if it undercuts the current best price by the
+// minimum decrement. The auction row is locked for the duration of the
+// transaction, so simultaneous offers are decided one after another against
+// the best price left by the previous one.
+func (r *AuctionService) PlaceOffer(ctx context.Context, tenderID string, offer domain.AuctionOffer) (domain.AuctionOffer, domain.Auction, error) {
+	if err := checkUser(ctx, r.pool, offer.CreatorUsername); err != nil {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+	}
+
+	if err := checkResponsible(ctx, r.pool, offer.CreatorUsername, offer.OrganizationID); err != nil {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkTenderVisible(ctx, tx, tenderID, offer.CreatorUsername); err != nil {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+	}
+
+	var (
+		status, ownerID       string
+		invited               bool
+		startsAt, endsAt      time.Time
+		now                   time.Time
+		startPrice, decrement float64
+		bestPrice             *float64
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT t.status, t.organization_id, t.visibility = 'PUBLIC' OR EXISTS (
+				SELECT 1 FROM tender_invitation i WHERE i.tender_id = t.id AND i.organization_id = $2
+			),
+			a.starts_at, a.ends_at, NOW()::timestamp, a.start_price, a.min_decrement, a.best_price
+		FROM tender_auction a
+		JOIN tender t ON t.id = a.tender_id
+		WHERE a.tender_id = $1
+		FOR UPDATE OF a
+	`, tenderID, offer.OrganizationID).Scan(&status, &ownerID, &invited, &startsAt, &endsAt, &now, &startPrice, &decrement, &bestPrice)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w: tender is not an auction", domain.ErrNotFound)
+		}
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+	}
+
+	if !invited {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", domain.ErrUserNotAuthorized)
+	}
+
+	if ownerID == offer.OrganizationID {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w: the tender owner cannot bid", domain.ErrInvalidInput)
+	}
+
+	switch status {
+	case "PUBLISHED":
+	case "CLOSED":
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", domain.ErrTenderClosed)
+	default:
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", domain.ErrTenderNotOpen)
+	}
+
+	if now.Before(startsAt) || !now.Before(endsAt) {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", domain.ErrAuctionNotRunning)
+	}
+
+	// The first offer only has to stay within the start price.
+	ceiling := startPrice
+	if bestPrice != nil {
+		ceiling = *bestPrice - decrement
+	}
+	if offer.Price > ceiling+priceEpsilon {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w: offer at most %.2f", domain.ErrOfferTooHigh, ceiling)
+	}
+
+	created, err := scanOffer(tx.QueryRow(ctx, `
+		INSERT INTO auction_offer (tender_id, seq, organization_id, created_by_user, price, created_at)
+		SELECT $1, offer_count + 1, $2, $3, $4, $5
+		FROM tender_auction
+		WHERE tender_id = $1
+		RETURNING `+offerColumns,
+		tenderID, offer.OrganizationID, offer.CreatorUsername, offer.Price, now,
+	))
+	if err != nil {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+	}
+
+	var extendedTo time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE tender_auction
+		SET best_offer_id = $2, best_price = $3, offer_count = offer_count + 1,
+			ends_at = GREATEST(ends_at, $4::timestamp + make_interval(secs => extension_seconds))
+		WHERE tender_id = $1
+		RETURNING ends_at
+	`, tenderID, created.ID, created.Price, now).Scan(&extendedTo)
+	if err != nil {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+	}
+
+	if extendedTo.After(endsAt) {
+		_, err = tx.Exec(ctx, `UPDATE tender SET closes_at = $2 WHERE id = $1`, tenderID, extendedTo)
+		if err != nil {
+			return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+		}
+	}
+
+	auction, err := getAuction(ctx, tx, tenderID)
+	if err != nil {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.AuctionOffer{}, domain.Auction{}, fmt.Errorf("repository.PlaceOffer: %w", err)
+	}
+
+	return created, auction, nil
+}
+
+// priceEpsilon absorbs float rounding when comparing prices that are stored
+// with two decimals.
+const priceEpsilon = 0.005
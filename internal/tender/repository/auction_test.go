@@ -0,0 +1,108 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+func TestConcurrentOffers(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	tenders := repository.NewTenderService(pool)
+	auctions := repository.NewAuctionService(pool)
+
+	now := time.Now()
+	auction := newTender("Аукцион", 1000, domain.TenderVisibilityPublic)
+	auction.Type = domain.TenderTypeAuction
+	auction.Auction = &domain.Auction{
+		StartsAt:     now.Add(-time.Hour),
+		EndsAt:       now.Add(time.Hour),
+		StartPrice:   1000,
+		MinDecrement: 10,
+	}
+	tender, err := tenders.CreateTender(ctx, auction)
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+
+	place := func(price float64) error {
+		_, _, err := auctions.PlaceOffer(ctx, tender.ID, domain.AuctionOffer{
+			TenderID:        tender.ID,
+			OrganizationID:  repotest.OtherOrganization,
+			CreatorUsername: repotest.Outsider,
+			Price:           price,
+		})
+		return err
+	}
+
+	// The same price offered at once: the lock on the auction lets the first
+	// one in and decides the others against its price.
+	const offers = 8
+	errs := make(chan error, offers)
+	var wg sync.WaitGroup
+	for range offers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- place(990)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	accepted := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			accepted++
+		case !errors.Is(err, domain.ErrOfferTooHigh):
+			t.Errorf("PlaceOffer: %v", err)
+		}
+	}
+	if accepted != 1 {
+		t.Errorf("accepted %d of the equal offers, want 1", accepted)
+	}
+
+	// Offers of different prices racing each other: whatever order they are
+	// accepted in, every accepted one undercuts the previous by the minimum.
+	wg = sync.WaitGroup{}
+	for i := range offers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := place(float64(950 - 20*i)); err != nil && !errors.Is(err, domain.ErrOfferTooHigh) {
+				t.Errorf("PlaceOffer: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	history, err := auctions.ListOffers(ctx, tender.ID, 100, 0, repotest.Owner)
+	if err != nil {
+		t.Fatalf("ListOffers: %v", err)
+	}
+	for i, offer := range history {
+		if offer.Seq != i+1 {
+			t.Errorf("offer %d has seq %d", i+1, offer.Seq)
+		}
+		if i > 0 && offer.Price > history[i-1].Price-10 {
+			t.Errorf("offer %d at %.2f does not undercut %.2f by the minimum", offer.Seq, offer.Price, history[i-1].Price)
+		}
+	}
+
+	state, err := auctions.GetAuction(ctx, tender.ID, repotest.Owner)
+	if err != nil {
+		t.Fatalf("GetAuction: %v", err)
+	}
+	last := history[len(history)-1]
+	if state.OfferCount != len(history) || state.BestPrice == nil || *state.BestPrice != last.Price {
+		t.Errorf("auction = %+v, want %d offers and the best price %.2f", state, len(history), last.Price)
+	}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// querier is satisfied by both the pool and a transaction.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func checkUser(ctx context.Context, q querier, username string) error {
+	var exists bool
+	err := q.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM employee WHERE username = $1)`, username).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func isResponsible(ctx context.Context, q querier, username, organizationID string) (bool, error) {
+	var responsible bool
+	err := q.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM organization_responsible r
+			JOIN employee e ON e.id = r.user_id
+			WHERE e.username = $1 AND r.organization_id = $2
+		)
+	`, username, organizationID).Scan(&responsible)
+
+	return responsible, err
+}
+
+func checkResponsible(ctx context.Context, q querier, username, organizationID string) error {
+	if err := checkUser(ctx, q, username); err != nil {
+		return err
+	}
+
+	responsible, err := isResponsible(ctx, q, username, organizationID)
+	if err != nil {
+		return err
+	}
+
+	if !responsible {
+		return domain.ErrUserNotAuthorized
+	}
+
+	return nil
+}
+
+// tenderVisibleTo is an SQL condition over the tender aliased as alias. It
+// holds for public tenders and, for private ones, when the employee given by
+// the username expression is responsible for the owning or an invited
+// organization.
+func tenderVisibleTo(alias, username string) string {
+	return `(` + alias + `.visibility = 'PUBLIC' OR EXISTS (
+		SELECT 1
+		FROM organization_responsible r
+		JOIN employee e ON e.id = r.user_id
+		WHERE e.username = ` + username + `
+			AND (r.organization_id = ` + alias + `.organization_id OR EXISTS (
+				SELECT 1
+				FROM tender_invitation i
+				WHERE i.tender_id = ` + alias + `.id AND i.organization_id = r.organization_id
+			))
+	))`
+}
+
+// checkTenderVisible reports private tenders the employee may not see as not
+// found, so that their existence is not disclosed either.
+func checkTenderVisible(ctx context.Context, q querier, tenderID, username string) error {
+	var visible bool
+	err := q.QueryRow(ctx, `SELECT `+tenderVisibleTo("t", "$2")+` FROM tender t WHERE t.id = $1`, tenderID, username).Scan(&visible)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTenderNotFound
+		}
+		return err
+	}
+
+	if !visible {
+		return domain.ErrTenderNotFound
+	}
+
+	return nil
+}
+
+// checkAdmin lets only administrators through, they manage the shared
+// reference data.
+func checkAdmin(ctx context.Context, q querier, username string) error {
+	var admin bool
+	err := q.QueryRow(ctx, `SELECT is_admin FROM employee WHERE username = $1`, username).Scan(&admin)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrUserNotFound
+		}
+		return err
+	}
+
+	if !admin {
+		return fmt.Errorf("%w: administrators only", domain.ErrUserNotAuthorized)
+	}
+
+	return nil
+}
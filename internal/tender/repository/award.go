@@ -0,0 +1,386 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.AwardRepository = (*AwardService)(nil)
+)
+
+// awardColumns selects an award aliased as aw.
+const awardColumns = `aw.id, aw.tender_id, aw.lot_id, aw.bid_id, aw.offer_id, COALESCE(aw.organization_id::text, ''),
+	aw.amount, aw.decided_at, aw.decision_makers`
+
+// decisionMakersSQL lists the employee given by the username expression
+// together with everyone who scored the bid given by the bid expression.
+func decisionMakersSQL(bid, username string) string {
+	return `ARRAY(
+		SELECT DISTINCT m.username
+		FROM (
+			SELECT ` + username + `::varchar AS username
+			UNION
+			SELECT s.evaluator FROM bid_score s WHERE s.bid_id = ` + bid + `
+		) m
+		WHERE m.username IS NOT NULL
+		ORDER BY m.username
+	)`
+}
+
+type AwardService struct {
+	pool *pgxpool.Pool
+}
+
+func NewAwardService(pool *pgxpool.Pool) *AwardService {
+	return &AwardService{pool: pool}
+}
+
+func scanAward(row rowScanner) (domain.Award, error) {
+	var award domain.Award
+	err := row.Scan(
+		&award.ID,
+		&award.TenderID,
+		&award.LotID,
+		&award.BidID,
+		&award.OfferID,
+		&award.OrganizationID,
+		&award.Amount,
+		&award.DecidedAt,
+		&award.DecisionMakers,
+	)
+
+	return award, err
+}
+
+// listAwards returns the awards of the tender in lot order, each with its
+// winning bid.
+func listAwards(ctx context.Context, q querier, tenderID string) ([]domain.Award, error) {
+	rows, err := q.Query(ctx, `
+		SELECT `+awardColumns+`
+		FROM award aw
+		LEFT JOIN tender_lot l ON l.id = aw.lot_id
+		WHERE aw.tender_id = $1
+		ORDER BY l.position NULLS FIRST, aw.created_at
+	`, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	awards := []domain.Award{}
+	bidIDs := []string{}
+	for rows.Next() {
+		award, err := scanAward(rows)
+		if err != nil {
+			return nil, err
+		}
+		awards = append(awards, award)
+		if award.BidID != nil {
+			bidIDs = append(bidIDs, *award.BidID)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(bidIDs) == 0 {
+		return awards, nil
+	}
+
+	bidRows, err := q.Query(ctx, `SELECT `+bidColumns+` FROM bid b WHERE b.id::text = ANY($1)`, bidIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := scanBids(bidRows)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]domain.Bid, len(bids))
+	for _, bid := range bids {
+		byID[bid.ID] = bid
+	}
+
+	for i := range awards {
+		if awards[i].BidID == nil {
+			continue
+		}
+		if bid, ok := byID[*awards[i].BidID]; ok {
+			awards[i].Bid = &bid
+		}
+	}
+
+	return awards, nil
+}
+
+// createLotAwards records the lot decisions of a tender that has just been
+// closed by its last one.
+func createLotAwards(ctx context.Context, q querier, tenderID string) error {
+	_, err := q.Exec(ctx, `
+		INSERT INTO award (tender_id, lot_id, bid_id, organization_id, amount, decided_at, decision_makers)
+		SELECT l.tender_id, l.id, b.id, b.organization_id, bl.price, l.awarded_at, `+decisionMakersSQL("b.id", "l.awarded_by")+`
+		FROM tender_lot l
+		JOIN bid b ON b.id = l.awarded_bid_id
+		LEFT JOIN bid_lot bl ON bl.bid_id = b.id AND bl.lot_id = l.id
+		WHERE l.tender_id = $1
+	`, tenderID)
+
+	return err
+}
+
+// AwardTender decides a tender without lots and closes it. Auctions are won
+// by their best offer, so no bid is given for them.
+func (r *AwardService) AwardTender(ctx context.Context, tenderID string, bidID string, username string) ([]domain.Award, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.AwardTender: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		status, organizationID string
+		tenderType             domain.TenderType
+		revealed, hasLots      bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT t.status, t.organization_id, t.type, `+bidsRevealedSQL+`,
+			EXISTS (SELECT 1 FROM tender_lot l WHERE l.tender_id = t.id)
+		FROM tender t
+		WHERE t.id = $1
+		FOR UPDATE
+	`, tenderID).Scan(&status, &organizationID, &tenderType, &revealed, &hasLots)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("repository.AwardTender: %w", domain.ErrTenderNotFound)
+		}
+		return nil, fmt.Errorf("repository.AwardTender: %w", err)
+	}
+
+	if err := checkResponsible(ctx, tx, username, organizationID); err != nil {
+		return nil, fmt.Errorf("repository.AwardTender: %w", err)
+	}
+
+	switch status {
+	case "PUBLISHED":
+	case "CLOSED":
+		return nil, fmt.Errorf("repository.AwardTender: %w", domain.ErrTenderClosed)
+	default:
+		return nil, fmt.Errorf("repository.AwardTender: %w", domain.ErrTenderNotOpen)
+	}
+
+	if hasLots {
+		return nil, fmt.Errorf("repository.AwardTender: %w: lots are awarded one by one", domain.ErrConflict)
+	}
+
+	if tenderType == domain.TenderTypeAuction {
+		err = r.awardAuction(ctx, tx, tenderID, bidID, username)
+	} else {
+		err = r.awardBid(ctx, tx, tenderID, bidID, username, revealed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repository.AwardTender: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE tender SET status = 'CLOSED' WHERE id = $1`, tenderID); err != nil {
+		return nil, fmt.Errorf("repository.AwardTender: %w", err)
+	}
+
+	awards, err := listAwards(ctx, tx, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.AwardTender: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("repository.AwardTender: %w", err)
+	}
+
+	return awards, nil
+}
+
+func (r *AwardService) awardBid(ctx context.Context, tx pgx.Tx, tenderID, bidID, username string, revealed bool) error {
+	if bidID == "" {
+		return fmt.Errorf("%w: missing bidId", domain.ErrInvalidInput)
+	}
+
+	if !revealed {
+		return domain.ErrBidsSealed
+	}
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO award (tender_id, bid_id, organization_id, amount, decided_at, decision_makers)
+		SELECT b.tender_id, b.id, b.organization_id, b.price, NOW(), `+decisionMakersSQL("b.id", "$3")+`
+		FROM bid b
+		WHERE b.id = $1 AND b.tender_id = $2
+	`, bidID, tenderID, username)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrBidNotFound
+	}
+
+	return nil
+}
+
+func (r *AwardService) awardAuction(ctx context.Context, tx pgx.Tx, tenderID, bidID, username string) error {
+	if bidID != "" {
+		return fmt.Errorf("%w: an auction is won by its best offer", domain.ErrInvalidInput)
+	}
+
+	var (
+		ended  bool
+		bestID *string
+		endsAt time.Time
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT ends_at <= NOW(), best_offer_id, ends_at
+		FROM tender_auction
+		WHERE tender_id = $1
+		FOR UPDATE
+	`, tenderID).Scan(&ended, &bestID, &endsAt)
+	if err != nil {
+		return err
+	}
+
+	if !ended {
+		return fmt.Errorf("%w: auction runs until %s", domain.ErrConflict, endsAt.Format(time.RFC3339))
+	}
+	if bestID == nil {
+		return fmt.Errorf("%w: auction has no offers", domain.ErrConflict)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO award (tender_id, offer_id, organization_id, amount, decided_at, decision_makers)
+		SELECT o.tender_id, o.id, o.organization_id, o.price, NOW(), ARRAY[$2::varchar]
+		FROM auction_offer o
+		WHERE o.id = $1
+	`, *bestID, username)
+
+	return err
+}
+
+func (r *AwardService) ListAwards(ctx context.Context, tenderID string, username string) ([]domain.Award, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return nil, fmt.Errorf("repository.ListAwards: %w", err)
+	}
+
+	if err := checkTenderVisible(ctx, r.pool, tenderID, username); err != nil {
+		return nil, fmt.Errorf("repository.ListAwards: %w", err)
+	}
+
+	awards, err := listAwards(ctx, r.pool, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListAwards: %w", err)
+	}
+
+	if len(awards) == 0 {
+		return nil, fmt.Errorf("repository.ListAwards: %w: tender has not been awarded", domain.ErrNotFound)
+	}
+
+	return awards, nil
+}
+
+func getOrganization(ctx context.Context, q querier, organizationID string) (domain.Organization, error) {
+	var organization domain.Organization
+	err := q.QueryRow(ctx, `
+		SELECT id, name, COALESCE(description, ''), type::text
+		FROM organization
+		WHERE id = $1
+	`, organizationID).Scan(&organization.ID, &organization.Name, &organization.Description, &organization.Type)
+
+	return organization, err
+}
+
+// GetContractData gathers everything the contract names. It is available to
+// the responsibles of the buyer and of the winning organizations.
+func (r *AwardService) GetContractData(ctx context.Context, tenderID string, username string) (domain.ContractData, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", err)
+	}
+
+	tender, err := scanTender(r.pool.QueryRow(ctx, `SELECT `+tenderColumns+` FROM tender WHERE id = $1`, tenderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", domain.ErrTenderNotFound)
+		}
+		return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", err)
+	}
+
+	awards, err := listAwards(ctx, r.pool, tenderID)
+	if err != nil {
+		return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", err)
+	}
+
+	allowed, err := isResponsible(ctx, r.pool, username, tender.OrganizationId)
+	if err != nil {
+		return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", err)
+	}
+	for _, award := range awards {
+		if allowed {
+			break
+		}
+		allowed, err = isResponsible(ctx, r.pool, username, award.OrganizationID)
+		if err != nil {
+			return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", err)
+		}
+	}
+
+	if !allowed {
+		if err := checkTenderVisible(ctx, r.pool, tenderID, username); err != nil {
+			return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", err)
+		}
+		return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", domain.ErrUserNotAuthorized)
+	}
+
+	if len(awards) == 0 {
+		return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w: tender has not been awarded", domain.ErrNotFound)
+	}
+
+	data := domain.ContractData{Tender: tender}
+	data.Buyer, err = getOrganization(ctx, r.pool, tender.OrganizationId)
+	if err != nil {
+		return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", err)
+	}
+
+	lots, err := listLots(ctx, r.pool, tenderID)
+	if err != nil {
+		return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", err)
+	}
+
+	suppliers := map[string]domain.Organization{}
+	for _, award := range awards {
+		contractAward := domain.ContractAward{Award: award}
+
+		if award.OrganizationID != "" {
+			supplier, ok := suppliers[award.OrganizationID]
+			if !ok {
+				supplier, err = getOrganization(ctx, r.pool, award.OrganizationID)
+				if err != nil {
+					return domain.ContractData{}, fmt.Errorf("repository.GetContractData: %w", err)
+				}
+				suppliers[award.OrganizationID] = supplier
+			}
+			contractAward.Supplier = supplier
+		}
+
+		for i := range lots {
+			if award.LotID != nil && lots[i].ID == *award.LotID {
+				contractAward.Lot = &lots[i]
+			}
+		}
+
+		data.Awards = append(data.Awards, contractAward)
+	}
+
+	return data, nil
+}
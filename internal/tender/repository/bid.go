@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.BidRepository = (*BidService)(nil)
+)
+
+// bidColumns selects a bid aliased as b together with the lots it targets.
+const bidColumns = `b.id, b.name, b.description, b.status, b.tender_id, COALESCE(b.organization_id::text, ''),
+	COALESCE(b.created_by_user, ''), b.version, b.price, b.sealed_payload IS NOT NULL, b.sealed_payload, b.created_at,
+	COALESCE((
+		SELECT json_agg(json_build_object('lotId', bl.lot_id, 'price', bl.price) ORDER BY l.position)
+		FROM bid_lot bl
+		JOIN tender_lot l ON l.id = bl.lot_id
+		WHERE bl.bid_id = b.id
+	), '[]')`
+
+// bidsRevealedSQL holds when the contents of bids on the tender aliased as t
+// may be read.
+const bidsRevealedSQL = `(NOT t.sealed OR t.status = 'CLOSED' OR t.closes_at <= NOW())`
+
+type BidService struct {
+	pool *pgxpool.Pool
+}
+
+func NewBidService(pool *pgxpool.Pool) *BidService {
+	return &BidService{pool: pool}
+}
+
+func (r *BidService) CreateBid(ctx context.Context, bid domain.Bid) (domain.Bid, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkResponsible(ctx, tx, bid.CreatorUsername, bid.OrganizationId); err != nil {
+		return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", err)
+	}
+
+	var (
+		tenderStatus string
+		invited      bool
+		deadlinePast bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT t.status, t.visibility = 'PUBLIC' OR EXISTS (
+			SELECT 1 FROM tender_invitation i WHERE i.tender_id = t.id AND i.organization_id = $2
+		), t.sealed AND COALESCE(t.closes_at <= NOW(), false)
+		FROM tender t
+		WHERE t.id = $1
+		FOR SHARE
+	`, bid.TenderId, bid.OrganizationId).Scan(&tenderStatus, &invited, &deadlinePast)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", domain.ErrTenderNotFound)
+		}
+		return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", err)
+	}
+
+	if !invited {
+		if err := checkTenderVisible(ctx, tx, bid.TenderId, bid.CreatorUsername); err != nil {
+			return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", err)
+		}
+		return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", domain.ErrUserNotAuthorized)
+	}
+
+	if tenderStatus != "PUBLISHED" {
+		return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", domain.ErrTenderNotOpen)
+	}
+
+	// Bids on a sealed tender are revealed at its deadline, a later bid would
+	// be readable by everyone right away.
+	if deadlinePast {
+		return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w: the deadline of the sealed tender has passed", domain.ErrTenderClosed)
+	}
+
+	if err := checkBidLots(ctx, tx, bid.TenderId, bid.Lots); err != nil {
+		return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", err)
+	}
+
+	var createdBid domain.Bid
+	err = tx.QueryRow(ctx, `
+		INSERT INTO bid (id, name, description, status, tender_id, organization_id, created_by_user, version, price, sealed_payload, created_at)
+		VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		RETURNING id, name, description, status, tender_id, organization_id, created_by_user, version, price,
+			sealed_payload IS NOT NULL, sealed_payload, created_at
+	`, bid.Name, bid.Description, bid.Status, bid.TenderId, bid.OrganizationId, bid.CreatorUsername, bid.Version,
+		bid.Price, bid.SealedPayload).Scan(
+		&createdBid.ID,
+		&createdBid.Name,
+		&createdBid.Description,
+		&createdBid.Status,
+		&createdBid.TenderId,
+		&createdBid.OrganizationId,
+		&createdBid.CreatorUsername,
+		&createdBid.Version,
+		&createdBid.Price,
+		&createdBid.Sealed,
+		&createdBid.SealedPayload,
+		&createdBid.CreatedAt,
+	)
+	if err != nil {
+		return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", err)
+	}
+
+	for _, lot := range bid.Lots {
+		_, err := tx.Exec(ctx, `INSERT INTO bid_lot (bid_id, lot_id, price) VALUES ($1, $2, $3)`, createdBid.ID, lot.LotID, lot.Price)
+		if err != nil {
+			return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", err)
+		}
+	}
+	createdBid.Lots = bid.Lots
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Bid{}, fmt.Errorf("repository.CreateBid: %w", err)
+	}
+
+	return createdBid, nil
+}
+
+// checkBidLots makes sure every targeted lot belongs to the tender and that a
+// bid on a tender split into lots targets at least one of them.
+func checkBidLots(ctx context.Context, q querier, tenderID string, lots []domain.BidLot) error {
+	lotIDs := make([]string, 0, len(lots))
+	for _, lot := range lots {
+		lotIDs = append(lotIDs, lot.LotID)
+	}
+
+	var matched, total int
+	err := q.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE id::text = ANY($2::text[])), COUNT(*)
+		FROM tender_lot
+		WHERE tender_id = $1
+	`, tenderID, lotIDs).Scan(&matched, &total)
+	if err != nil {
+		return err
+	}
+
+	if matched != len(lotIDs) {
+		return fmt.Errorf("%w: unknown lot", domain.ErrInvalidInput)
+	}
+
+	if total > 0 && len(lotIDs) == 0 {
+		return fmt.Errorf("%w: bid must target at least one lot", domain.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+func (r *BidService) GetUserBids(ctx context.Context, limit, offset int, username string) ([]domain.Bid, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return nil, fmt.Errorf("repository.GetUserBids: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+bidColumns+`
+		FROM bid b
+		WHERE b.created_by_user = $1
+		ORDER BY b.name
+		LIMIT $2 OFFSET $3
+	`, username, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetUserBids: %w", err)
+	}
+	defer rows.Close()
+
+	bids, err := scanBids(rows)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetUserBids: %w", err)
+	}
+
+	return bids, nil
+}
+
+// ownedTenderBids checks that the employee is responsible for the tender and
+// reports whether the bids on it are revealed.
+func ownedTenderBids(ctx context.Context, q querier, tenderID string, username string) (domain.BidCount, error) {
+	var (
+		organizationID string
+		count          domain.BidCount
+	)
+	err := q.QueryRow(ctx, `
+		SELECT t.organization_id, t.sealed, `+bidsRevealedSQL+`, t.closes_at,
+			(SELECT COUNT(*) FROM bid b WHERE b.tender_id = t.id)
+		FROM tender t
+		WHERE t.id = $1
+	`, tenderID).Scan(&organizationID, &count.Sealed, &count.Revealed, &count.RevealAt, &count.Count)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.BidCount{}, domain.ErrTenderNotFound
+		}
+		return domain.BidCount{}, err
+	}
+
+	if err := checkResponsible(ctx, q, username, organizationID); err != nil {
+		return domain.BidCount{}, err
+	}
+
+	count.TenderID = tenderID
+	if !count.Sealed {
+		count.RevealAt = nil
+	}
+
+	return count, nil
+}
+
+func (r *BidService) CountTenderBids(ctx context.Context, tenderID string, username string) (domain.BidCount, error) {
+	count, err := ownedTenderBids(ctx, r.pool, tenderID, username)
+	if err != nil {
+		return domain.BidCount{}, fmt.Errorf("repository.CountTenderBids: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *BidService) ListTenderBids(ctx context.Context, tenderID string, limit, offset int, username string) ([]domain.Bid, error) {
+	count, err := ownedTenderBids(ctx, r.pool, tenderID, username)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListTenderBids: %w", err)
+	}
+
+	if !count.Revealed {
+		return nil, fmt.Errorf("repository.ListTenderBids: %w", domain.ErrBidsSealed)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+bidColumns+`
+		FROM bid b
+		WHERE b.tender_id = $1
+		ORDER BY b.name
+		LIMIT $2 OFFSET $3
+	`, tenderID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListTenderBids: %w", err)
+	}
+	defer rows.Close()
+
+	bids, err := scanBids(rows)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListTenderBids: %w", err)
+	}
+
+	return bids, nil
+}
+
+func scanBid(row rowScanner) (domain.Bid, error) {
+	var bid domain.Bid
+	err := row.Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId,
+		&bid.OrganizationId, &bid.CreatorUsername, &bid.Version, &bid.Price, &bid.Sealed, &bid.SealedPayload,
+		&bid.CreatedAt, &bid.Lots)
+
+	return bid, err
+}
+
+func scanBids(rows pgx.Rows) ([]domain.Bid, error) {
+	bids := []domain.Bid{}
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, bid)
+	}
+
+	return bids, rows.Err()
+}
@@ -0,0 +1,42 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+func TestBidAfterSealedDeadline(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	tenders := repository.NewTenderService(pool)
+	bids := repository.NewBidService(pool)
+
+	// The tender is still published, nobody closed it at the deadline.
+	closesAt := time.Now().Add(-time.Minute)
+	sealed := newTender("Просроченный", 1000, domain.TenderVisibilityPublic)
+	sealed.Sealed = true
+	sealed.SealKey = []byte("wrapped tender key")
+	sealed.ClosesAt = &closesAt
+	tender, err := tenders.CreateTender(ctx, sealed)
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+
+	_, err = bids.CreateBid(ctx, domain.Bid{
+		Status:          "CREATED",
+		TenderId:        tender.ID,
+		OrganizationId:  repotest.OtherOrganization,
+		CreatorUsername: repotest.Outsider,
+		Version:         1,
+		SealedPayload:   []byte("sealed bid"),
+	})
+	if !errors.Is(err, domain.ErrTenderClosed) {
+		t.Errorf("CreateBid after the deadline: error = %v, want %v", err, domain.ErrTenderClosed)
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.CustomFieldRepository = (*CustomFieldService)(nil)
+)
+
+const customFieldColumns = `id, organization_id, key, name, type, options, required, created_at`
+
+type CustomFieldService struct {
+	pool *pgxpool.Pool
+}
+
+func NewCustomFieldService(pool *pgxpool.Pool) *CustomFieldService {
+	return &CustomFieldService{pool: pool}
+}
+
+func scanCustomField(row rowScanner) (domain.CustomField, error) {
+	var field domain.CustomField
+	err := row.Scan(
+		&field.ID,
+		&field.OrganizationID,
+		&field.Key,
+		&field.Name,
+		&field.Type,
+		&field.Options,
+		&field.Required,
+		&field.CreatedAt,
+	)
+
+	return field, err
+}
+
+func (r *CustomFieldService) CreateCustomField(ctx context.Context, field domain.CustomField, username string) (domain.CustomField, error) {
+	if err := checkResponsible(ctx, r.pool, username, field.OrganizationID); err != nil {
+		return domain.CustomField{}, fmt.Errorf("repository.CreateCustomField: %w", err)
+	}
+
+	created, err := scanCustomField(r.pool.QueryRow(ctx, `
+		INSERT INTO custom_field (organization_id, key, name, type, options, required)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (organization_id, key) DO NOTHING
+		RETURNING `+customFieldColumns,
+		field.OrganizationID, field.Key, field.Name, field.Type, field.Options, field.Required,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.CustomField{}, fmt.Errorf("repository.CreateCustomField: %w: custom field %q already exists", domain.ErrConflict, field.Key)
+		}
+		return domain.CustomField{}, fmt.Errorf("repository.CreateCustomField: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *CustomFieldService) ListCustomFields(ctx context.Context, organizationID string, username string) ([]domain.CustomField, error) {
+	if err := checkResponsible(ctx, r.pool, username, organizationID); err != nil {
+		return nil, fmt.Errorf("repository.ListCustomFields: %w", err)
+	}
+
+	fields, err := r.GetOrganizationFields(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListCustomFields: %w", err)
+	}
+
+	return fields, nil
+}
+
+func (r *CustomFieldService) GetOrganizationFields(ctx context.Context, organizationID string) ([]domain.CustomField, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+customFieldColumns+`
+		FROM custom_field
+		WHERE organization_id = $1
+		ORDER BY key
+	`, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetOrganizationFields: %w", err)
+	}
+	defer rows.Close()
+
+	fields := []domain.CustomField{}
+	for rows.Next() {
+		field, err := scanCustomField(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.GetOrganizationFields: %w", err)
+		}
+		fields = append(fields, field)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.GetOrganizationFields: %w", err)
+	}
+
+	return fields, nil
+}
+
+// DeleteCustomField removes the definition only. Tenders keep their values
+// until their custom fields are edited next.
+func (r *CustomFieldService) DeleteCustomField(ctx context.Context, organizationID string, key string, username string) error {
+	if err := checkResponsible(ctx, r.pool, username, organizationID); err != nil {
+		return fmt.Errorf("repository.DeleteCustomField: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, `DELETE FROM custom_field WHERE organization_id = $1 AND key = $2`, organizationID, key)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteCustomField: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository.DeleteCustomField: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.DirectoryRepository = (*DirectoryService)(nil)
+)
+
+const (
+	organizationColumns = `id, name, COALESCE(description, ''), COALESCE(type::text, ''), created_at, updated_at`
+	employeeColumns     = `id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), created_at, updated_at`
+)
+
+// DirectoryService keeps the organization, employee and
+// organization_responsible tables the service shares with the rest of the
+// platform.
+type DirectoryService struct {
+	pool *pgxpool.Pool
+}
+
+func NewDirectoryService(pool *pgxpool.Pool) *DirectoryService {
+	return &DirectoryService{pool: pool}
+}
+
+func scanOrganization(row rowScanner) (domain.Organization, error) {
+	var organization domain.Organization
+	err := row.Scan(
+		&organization.ID,
+		&organization.Name,
+		&organization.Description,
+		&organization.Type,
+		&organization.CreatedAt,
+		&organization.UpdatedAt,
+	)
+
+	return organization, err
+}
+
+func scanEmployee(row rowScanner) (domain.Employee, error) {
+	var employee domain.Employee
+	err := row.Scan(
+		&employee.ID,
+		&employee.Username,
+		&employee.FirstName,
+		&employee.LastName,
+		&employee.CreatedAt,
+		&employee.UpdatedAt,
+	)
+
+	return employee, err
+}
+
+func (r *DirectoryService) ListOrganizations(ctx context.Context, limit int, offset int, username string) ([]domain.Organization, error) {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return nil, fmt.Errorf("repository.ListOrganizations: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+organizationColumns+`
+		FROM organization
+		ORDER BY name, id
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListOrganizations: %w", err)
+	}
+	defer rows.Close()
+
+	organizations := []domain.Organization{}
+	for rows.Next() {
+		organization, err := scanOrganization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListOrganizations: %w", err)
+		}
+		organizations = append(organizations, organization)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListOrganizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (r *DirectoryService) CreateOrganization(ctx context.Context, req domain.CreateOrganizationRequest, username string) (domain.Organization, error) {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return domain.Organization{}, fmt.Errorf("repository.CreateOrganization: %w", err)
+	}
+
+	created, err := scanOrganization(r.pool.QueryRow(ctx, `
+		INSERT INTO organization (name, description, type)
+		VALUES ($1, $2, $3)
+		RETURNING `+organizationColumns,
+		req.Name, req.Description, string(req.Type),
+	))
+	if err != nil {
+		return domain.Organization{}, fmt.Errorf("repository.CreateOrganization: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *DirectoryService) UpdateOrganization(ctx context.Context, organizationID string, update domain.OrganizationUpdate, username string) (domain.Organization, error) {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return domain.Organization{}, fmt.Errorf("repository.UpdateOrganization: %w", err)
+	}
+
+	var organizationType *string
+	if update.Type != nil {
+		value := string(*update.Type)
+		organizationType = &value
+	}
+
+	updated, err := scanOrganization(r.pool.QueryRow(ctx, `
+		UPDATE organization
+		SET name = COALESCE($2, name), description = COALESCE($3, description), type = COALESCE($4::organization_type, type), updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+organizationColumns,
+		organizationID, update.Name, update.Description, organizationType,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Organization{}, fmt.Errorf("repository.UpdateOrganization: %w: organization %s does not exist", domain.ErrNotFound, organizationID)
+		}
+		return domain.Organization{}, fmt.Errorf("repository.UpdateOrganization: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteOrganization removes an organization without tenders, bids or
+// auction offers. Its templates, custom fields, invitations and the
+// responsibility links go with it.
+func (r *DirectoryService) DeleteOrganization(ctx context.Context, organizationID string, username string) error {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return fmt.Errorf("repository.DeleteOrganization: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteOrganization: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var inUse bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM tender WHERE organization_id = o.id)
+			OR EXISTS (SELECT 1 FROM bid WHERE organization_id = o.id)
+			OR EXISTS (SELECT 1 FROM auction_offer WHERE organization_id = o.id)
+		FROM organization o
+		WHERE o.id = $1
+		FOR UPDATE
+	`, organizationID).Scan(&inUse)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("repository.DeleteOrganization: %w: organization %s does not exist", domain.ErrNotFound, organizationID)
+		}
+		return fmt.Errorf("repository.DeleteOrganization: %w", err)
+	}
+
+	if inUse {
+		return fmt.Errorf("repository.DeleteOrganization: %w: the organization has tenders or bids", domain.ErrConflict)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM organization WHERE id = $1`, organizationID); err != nil {
+		return fmt.Errorf("repository.DeleteOrganization: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("repository.DeleteOrganization: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DirectoryService) ListEmployees(ctx context.Context, limit int, offset int, username string) ([]domain.Employee, error) {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return nil, fmt.Errorf("repository.ListEmployees: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+employeeColumns+`
+		FROM employee
+		ORDER BY username
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListEmployees: %w", err)
+	}
+	defer rows.Close()
+
+	employees := []domain.Employee{}
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListEmployees: %w", err)
+		}
+		employees = append(employees, employee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListEmployees: %w", err)
+	}
+
+	return employees, nil
+}
+
+func (r *DirectoryService) CreateEmployee(ctx context.Context, req domain.CreateEmployeeRequest, username string) (domain.Employee, error) {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return domain.Employee{}, fmt.Errorf("repository.CreateEmployee: %w", err)
+	}
+
+	created, err := scanEmployee(r.pool.QueryRow(ctx, `
+		INSERT INTO employee (username, first_name, last_name)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+		RETURNING `+employeeColumns,
+		req.Username, req.FirstName, req.LastName,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Employee{}, fmt.Errorf("repository.CreateEmployee: %w: employee %q already exists", domain.ErrConflict, req.Username)
+		}
+		return domain.Employee{}, fmt.Errorf("repository.CreateEmployee: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *DirectoryService) UpdateEmployee(ctx context.Context, employeeUsername string, update domain.EmployeeUpdate, username string) (domain.Employee, error) {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return domain.Employee{}, fmt.Errorf("repository.UpdateEmployee: %w", err)
+	}
+
+	updated, err := scanEmployee(r.pool.QueryRow(ctx, `
+		UPDATE employee
+		SET first_name = COALESCE($2, first_name), last_name = COALESCE($3, last_name), updated_at = NOW()
+		WHERE username = $1
+		RETURNING `+employeeColumns,
+		employeeUsername, update.FirstName, update.LastName,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Employee{}, fmt.Errorf("repository.UpdateEmployee: %w: employee %q does not exist", domain.ErrNotFound, employeeUsername)
+		}
+		return domain.Employee{}, fmt.Errorf("repository.UpdateEmployee: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteEmployee removes an employee who has not authored tenders, bids,
+// offers, scores or questions. Their notifications, saved searches and
+// responsibility links go with them.
+func (r *DirectoryService) DeleteEmployee(ctx context.Context, employeeUsername string, username string) error {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return fmt.Errorf("repository.DeleteEmployee: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteEmployee: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var inUse bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM tender WHERE created_by_user = e.username)
+			OR EXISTS (SELECT 1 FROM bid WHERE created_by_user = e.username)
+			OR EXISTS (SELECT 1 FROM auction_offer WHERE created_by_user = e.username)
+			OR EXISTS (SELECT 1 FROM bid_score WHERE evaluator = e.username)
+			OR EXISTS (SELECT 1 FROM tender_question WHERE author_username = e.username)
+		FROM employee e
+		WHERE e.username = $1
+		FOR UPDATE
+	`, employeeUsername).Scan(&inUse)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("repository.DeleteEmployee: %w: employee %q does not exist", domain.ErrNotFound, employeeUsername)
+		}
+		return fmt.Errorf("repository.DeleteEmployee: %w", err)
+	}
+
+	if inUse {
+		return fmt.Errorf("repository.DeleteEmployee: %w: the employee has authored tenders, bids, offers, scores or questions", domain.ErrConflict)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM employee WHERE username = $1`, employeeUsername); err != nil {
+		return fmt.Errorf("repository.DeleteEmployee: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("repository.DeleteEmployee: %w", err)
+	}
+
+	return nil
+}
+
+// AddOrganizationResponsible makes the employee responsible for the
+// organization, doing nothing when they already are.
+func (r *DirectoryService) AddOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return fmt.Errorf("repository.AddOrganizationResponsible: %w", err)
+	}
+
+	var organizationExists, employeeID *string
+	err := r.pool.QueryRow(ctx, `
+		SELECT (SELECT id::text FROM organization WHERE id = $1), (SELECT id::text FROM employee WHERE username = $2)
+	`, organizationID, employeeUsername).Scan(&organizationExists, &employeeID)
+	if err != nil {
+		return fmt.Errorf("repository.AddOrganizationResponsible: %w", err)
+	}
+
+	if organizationExists == nil {
+		return fmt.Errorf("repository.AddOrganizationResponsible: %w: organization %s does not exist", domain.ErrNotFound, organizationID)
+	}
+	if employeeID == nil {
+		return fmt.Errorf("repository.AddOrganizationResponsible: %w: employee %q does not exist", domain.ErrNotFound, employeeUsername)
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO organization_responsible (organization_id, user_id)
+		SELECT $1::uuid, $2::uuid
+		WHERE NOT EXISTS (SELECT 1 FROM organization_responsible WHERE organization_id = $1 AND user_id = $2)
+	`, organizationID, *employeeID)
+	if err != nil {
+		return fmt.Errorf("repository.AddOrganizationResponsible: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DirectoryService) RemoveOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return fmt.Errorf("repository.RemoveOrganizationResponsible: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM organization_responsible r
+		USING employee e
+		WHERE r.user_id = e.id AND r.organization_id = $1 AND e.username = $2
+	`, organizationID, employeeUsername)
+	if err != nil {
+		return fmt.Errorf("repository.RemoveOrganizationResponsible: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository.RemoveOrganizationResponsible: %w: %q is not responsible for organization %s", domain.ErrNotFound, employeeUsername, organizationID)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+func TestDirectory(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	directory := repository.NewDirectoryService(pool)
+
+	create := domain.CreateOrganizationRequest{Name: "Survey LLC", Type: domain.OrganizationTypeLLC}
+	if _, err := directory.CreateOrganization(ctx, create, repotest.Owner); !errors.Is(err, domain.ErrUserNotAuthorized) {
+		t.Errorf("CreateOrganization by an employee: error = %v, want %v", err, domain.ErrUserNotAuthorized)
+	}
+
+	if _, err := pool.Exec(ctx, `UPDATE employee SET is_admin = TRUE WHERE username = $1`, repotest.Owner); err != nil {
+		t.Fatalf("making %s an administrator: %v", repotest.Owner, err)
+	}
+
+	organization, err := directory.CreateOrganization(ctx, create, repotest.Owner)
+	if err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+
+	organizationType := domain.OrganizationTypeJSC
+	updated, err := directory.UpdateOrganization(ctx, organization.ID, domain.OrganizationUpdate{Type: &organizationType}, repotest.Owner)
+	if err != nil || updated.Name != create.Name || updated.Type != organizationType {
+		t.Errorf("UpdateOrganization = %+v, %v", updated, err)
+	}
+
+	if err := directory.AddOrganizationResponsible(ctx, organization.ID, repotest.Outsider, repotest.Owner); err != nil {
+		t.Fatalf("AddOrganizationResponsible: %v", err)
+	}
+	if err := directory.AddOrganizationResponsible(ctx, organization.ID, repotest.Outsider, repotest.Owner); err != nil {
+		t.Fatalf("AddOrganizationResponsible twice: %v", err)
+	}
+
+	var links int
+	err = pool.QueryRow(ctx, `SELECT count(*) FROM organization_responsible WHERE organization_id = $1`, organization.ID).Scan(&links)
+	if err != nil || links != 1 {
+		t.Errorf("responsibility links = %d, %v, want 1", links, err)
+	}
+
+	// The seeded organizations have employees responsible for them but no
+	// tenders, Organization gets one.
+	if _, err := repository.NewTenderService(pool).CreateTender(ctx, newTender("Поставка", 100, domain.TenderVisibilityPublic)); err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+	if err := directory.DeleteOrganization(ctx, repotest.Organization, repotest.Owner); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("DeleteOrganization with a tender: error = %v, want %v", err, domain.ErrConflict)
+	}
+	if err := directory.DeleteEmployee(ctx, repotest.Owner, repotest.Owner); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("DeleteEmployee of the tender author: error = %v, want %v", err, domain.ErrConflict)
+	}
+
+	if err := directory.DeleteOrganization(ctx, organization.ID, repotest.Owner); err != nil {
+		t.Errorf("DeleteOrganization: %v", err)
+	}
+	if err := directory.RemoveOrganizationResponsible(ctx, organization.ID, repotest.Outsider, repotest.Owner); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("RemoveOrganizationResponsible of a deleted organization: error = %v, want %v", err, domain.ErrNotFound)
+	}
+
+	if _, err := directory.CreateEmployee(ctx, domain.CreateEmployeeRequest{Username: repotest.Colleague}, repotest.Owner); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("CreateEmployee with a taken username: error = %v, want %v", err, domain.ErrConflict)
+	}
+	if err := directory.DeleteEmployee(ctx, repotest.Colleague, repotest.Owner); err != nil {
+		t.Errorf("DeleteEmployee: %v", err)
+	}
+
+	employees, err := directory.ListEmployees(ctx, 10, 0, repotest.Owner)
+	if err != nil || len(employees) != 2 || employees[0].Username != repotest.Outsider || employees[1].Username != repotest.Owner {
+		t.Errorf("ListEmployees = %+v, %v", employees, err)
+	}
+}
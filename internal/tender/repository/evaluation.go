@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.EvaluationRepository = (*EvaluationService)(nil)
+)
+
+type EvaluationService struct {
+	pool *pgxpool.Pool
+}
+
+func NewEvaluationService(pool *pgxpool.Pool) *EvaluationService {
+	return &EvaluationService{pool: pool}
+}
+
+func listCriteria(ctx context.Context, q querier, tenderID string) ([]domain.Criterion, error) {
+	rows, err := q.Query(ctx, `
+		SELECT id, name, weight
+		FROM tender_criterion
+		WHERE tender_id = $1
+		ORDER BY position
+	`, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	criteria := []domain.Criterion{}
+	for rows.Next() {
+		var criterion domain.Criterion
+		if err := rows.Scan(&criterion.ID, &criterion.Name, &criterion.Weight); err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	return criteria, rows.Err()
+}
+
+// replaceCriteria makes criteria the complete criteria list of the tender in
+// the same way replaceLots does for lots. Criteria that were already used for
+// scoring cannot be removed.
+func replaceCriteria(ctx context.Context, q querier, tenderID string, criteria []domain.Criterion) error {
+	keep := make([]string, 0, len(criteria))
+	for i, criterion := range criteria {
+		var id string
+		err := q.QueryRow(ctx, `
+			INSERT INTO tender_criterion AS c (id, tender_id, position, name, weight)
+			VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, $3, $4, $5)
+			ON CONFLICT (id) DO UPDATE
+			SET position = EXCLUDED.position, name = EXCLUDED.name, weight = EXCLUDED.weight
+			WHERE c.tender_id = EXCLUDED.tender_id
+			RETURNING id
+		`, criterion.ID, tenderID, i+1, criterion.Name, criterion.Weight).Scan(&id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: criterion %s belongs to another tender", domain.ErrInvalidInput, criterion.ID)
+			}
+			return err
+		}
+		keep = append(keep, id)
+	}
+
+	var scored bool
+	err := q.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM tender_criterion c
+			JOIN bid_score s ON s.criterion_id = c.id
+			WHERE c.tender_id = $1 AND NOT (c.id::text = ANY($2::text[]))
+		)
+	`, tenderID, keep).Scan(&scored)
+	if err != nil {
+		return err
+	}
+
+	if scored {
+		return fmt.Errorf("%w: criteria with scores cannot be removed", domain.ErrConflict)
+	}
+
+	_, err = q.Exec(ctx, `DELETE FROM tender_criterion WHERE tender_id = $1 AND NOT (id::text = ANY($2::text[]))`, tenderID, keep)
+
+	return err
+}
+
+// ScoreBid stores the scores of the evaluator for a bid, replacing the scores
+// they gave for the same criteria before. Scoring is locked once the tender is
+// closed.
+func (r *EvaluationService) ScoreBid(ctx context.Context, bidID string, scores []domain.CriterionScore, username string) ([]domain.BidScore, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ScoreBid: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		tenderID, status, organizationID string
+		revealed                         bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT t.id, t.status, t.organization_id, `+bidsRevealedSQL+`
+		FROM bid b
+		JOIN tender t ON t.id = b.tender_id
+		WHERE b.id = $1
+		FOR SHARE OF t
+	`, bidID).Scan(&tenderID, &status, &organizationID, &revealed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("repository.ScoreBid: %w", domain.ErrBidNotFound)
+		}
+		return nil, fmt.Errorf("repository.ScoreBid: %w", err)
+	}
+
+	if err := checkResponsible(ctx, tx, username, organizationID); err != nil {
+		return nil, fmt.Errorf("repository.ScoreBid: %w", err)
+	}
+
+	if status == "CLOSED" {
+		return nil, fmt.Errorf("repository.ScoreBid: %w: evaluation is locked", domain.ErrTenderClosed)
+	}
+
+	if !revealed {
+		return nil, fmt.Errorf("repository.ScoreBid: %w", domain.ErrBidsSealed)
+	}
+
+	saved := make([]domain.BidScore, 0, len(scores))
+	for _, score := range scores {
+		var bidScore domain.BidScore
+		err := tx.QueryRow(ctx, `
+			INSERT INTO bid_score (bid_id, criterion_id, evaluator, score)
+			SELECT $1, c.id, $3, $4
+			FROM tender_criterion c
+			WHERE c.id = $2 AND c.tender_id = $5
+			ON CONFLICT (bid_id, criterion_id, evaluator) DO UPDATE
+			SET score = EXCLUDED.score, updated_at = NOW()
+			RETURNING bid_id, criterion_id, evaluator, score, updated_at
+		`, bidID, score.CriterionID, username, score.Score, tenderID).Scan(
+			&bidScore.BidID,
+			&bidScore.CriterionID,
+			&bidScore.Evaluator,
+			&bidScore.Score,
+			&bidScore.UpdatedAt,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("repository.ScoreBid: %w: unknown criterion %s", domain.ErrInvalidInput, score.CriterionID)
+			}
+			return nil, fmt.Errorf("repository.ScoreBid: %w", err)
+		}
+		saved = append(saved, bidScore)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("repository.ScoreBid: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (r *EvaluationService) GetEvaluationSheet(ctx context.Context, tenderID string, username string) (domain.EvaluationSheet, error) {
+	status, organizationID, err := tenderOwnership(ctx, r.pool, tenderID, false)
+	if err != nil {
+		return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", err)
+	}
+
+	if err := checkResponsible(ctx, r.pool, username, organizationID); err != nil {
+		return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", err)
+	}
+
+	var revealed bool
+	err = r.pool.QueryRow(ctx, `SELECT `+bidsRevealedSQL+` FROM tender t WHERE t.id = $1`, tenderID).Scan(&revealed)
+	if err != nil {
+		return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", err)
+	}
+
+	if !revealed {
+		return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", domain.ErrBidsSealed)
+	}
+
+	sheet := domain.EvaluationSheet{TenderID: tenderID, TenderStatus: status}
+
+	sheet.Criteria, err = listCriteria(ctx, r.pool, tenderID)
+	if err != nil {
+		return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+bidColumns+`
+		FROM bid b
+		WHERE b.tender_id = $1
+		ORDER BY b.created_at
+	`, tenderID)
+	if err != nil {
+		return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", err)
+	}
+	defer rows.Close()
+
+	sheet.Bids, err = scanBids(rows)
+	if err != nil {
+		return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", err)
+	}
+
+	scoreRows, err := r.pool.Query(ctx, `
+		SELECT s.bid_id, s.criterion_id, s.evaluator, s.score, s.updated_at
+		FROM bid_score s
+		JOIN bid b ON b.id = s.bid_id
+		WHERE b.tender_id = $1
+		ORDER BY s.evaluator
+	`, tenderID)
+	if err != nil {
+		return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", err)
+	}
+	defer scoreRows.Close()
+
+	sheet.Scores = []domain.BidScore{}
+	for scoreRows.Next() {
+		var score domain.BidScore
+		err := scoreRows.Scan(&score.BidID, &score.CriterionID, &score.Evaluator, &score.Score, &score.UpdatedAt)
+		if err != nil {
+			return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", err)
+		}
+		sheet.Scores = append(sheet.Scores, score)
+	}
+	if err := scoreRows.Err(); err != nil {
+		return domain.EvaluationSheet{}, fmt.Errorf("repository.GetEvaluationSheet: %w", err)
+	}
+
+	return sheet, nil
+}
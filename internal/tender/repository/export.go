@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.ExportRepository = (*ExportService)(nil)
+)
+
+type ExportService struct {
+	pool *pgxpool.Pool
+}
+
+func NewExportService(pool *pgxpool.Pool) *ExportService {
+	return &ExportService{pool: pool}
+}
+
+// paged appends the paging to the query. A zero limit selects every row.
+func paged(query string, args []any, limit, offset int) (string, []any) {
+	if limit > 0 {
+		query += ` LIMIT $` + strconv.Itoa(len(args)+1)
+		args = append(args, limit)
+	}
+	if offset > 0 {
+		query += ` OFFSET $` + strconv.Itoa(len(args)+1)
+		args = append(args, offset)
+	}
+
+	return query, args
+}
+
+// streamRows scans the rows one at a time and hands each to fn before the
+// next one is read from the connection.
+func streamRows[T any](rows pgx.Rows, scan func(rowScanner) (T, error), fn func(T) error) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		row, err := scan(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *ExportService) StreamTenders(ctx context.Context, filter domain.TenderListFilter, fn func(domain.Tender) error) error {
+	query, args := tenderListQuery(filter)
+	query, args = paged(query+` ORDER BY t.created_at, t.id`, args, filter.Limit, filter.Offset)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repository.StreamTenders: %w", err)
+	}
+
+	if err := streamRows(rows, scanTender, fn); err != nil {
+		return fmt.Errorf("repository.StreamTenders: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ExportService) StreamUserTenders(ctx context.Context, limit, offset int, username string, fn func(domain.Tender) error) error {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return fmt.Errorf("repository.StreamUserTenders: %w", err)
+	}
+
+	query, args := paged(`SELECT `+tenderColumns+` FROM tender WHERE created_by_user = $1 ORDER BY created_at, id`, []any{username}, limit, offset)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repository.StreamUserTenders: %w", err)
+	}
+
+	if err := streamRows(rows, scanTender, fn); err != nil {
+		return fmt.Errorf("repository.StreamUserTenders: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ExportService) StreamUserBids(ctx context.Context, limit, offset int, username string, fn func(domain.Bid) error) error {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return fmt.Errorf("repository.StreamUserBids: %w", err)
+	}
+
+	query, args := paged(`SELECT `+bidColumns+` FROM bid b WHERE b.created_by_user = $1 ORDER BY b.name, b.id`, []any{username}, limit, offset)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repository.StreamUserBids: %w", err)
+	}
+
+	if err := streamRows(rows, scanBid, fn); err != nil {
+		return fmt.Errorf("repository.StreamUserBids: %w", err)
+	}
+
+	return nil
+}
+
+// StreamTenderBids is open to the responsibles of the tender organization
+// once the bids are revealed, like the bid list.
+func (r *ExportService) StreamTenderBids(ctx context.Context, tenderID string, limit, offset int, username string, fn func(domain.Bid) error) error {
+	count, err := ownedTenderBids(ctx, r.pool, tenderID, username)
+	if err != nil {
+		return fmt.Errorf("repository.StreamTenderBids: %w", err)
+	}
+
+	if !count.Revealed {
+		return fmt.Errorf("repository.StreamTenderBids: %w", domain.ErrBidsSealed)
+	}
+
+	query, args := paged(`SELECT `+bidColumns+` FROM bid b WHERE b.tender_id = $1 ORDER BY b.name, b.id`, []any{tenderID}, limit, offset)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repository.StreamTenderBids: %w", err)
+	}
+
+	if err := streamRows(rows, scanBid, fn); err != nil {
+		return fmt.Errorf("repository.StreamTenderBids: %w", err)
+	}
+
+	return nil
+}
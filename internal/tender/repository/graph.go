@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.GraphRepository = (*GraphService)(nil)
+)
+
+// GraphService answers the batched lookups of the GraphQL API with a single
+// query per batch.
+type GraphService struct {
+	pool *pgxpool.Pool
+}
+
+func NewGraphService(pool *pgxpool.Pool) *GraphService {
+	return &GraphService{pool: pool}
+}
+
+// GetTenders leaves out the tenders the employee may not see.
+func (r *GraphService) GetTenders(ctx context.Context, tenderIDs []string, username string) (map[string]domain.Tender, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+tenderColumns+`
+		FROM tender t
+		WHERE t.id::text = ANY($1) AND `+tenderVisibleTo("t", "$2"), tenderIDs, username)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetTenders: %w", err)
+	}
+	defer rows.Close()
+
+	tenders := make(map[string]domain.Tender, len(tenderIDs))
+	for rows.Next() {
+		tender, err := scanTender(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.GetTenders: %w", err)
+		}
+		tenders[tender.ID] = tender
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.GetTenders: %w", err)
+	}
+
+	return tenders, nil
+}
+
+func (r *GraphService) GetTenderVersions(ctx context.Context, tenderIDs []string) (map[string][]domain.TenderVersion, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+tenderVersionColumns+`
+		FROM tender_versions
+		WHERE tender_id::text = ANY($1)
+		ORDER BY version, id
+	`, tenderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetTenderVersions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[string][]domain.TenderVersion, len(tenderIDs))
+	for rows.Next() {
+		version, err := scanTenderVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.GetTenderVersions: %w", err)
+		}
+		versions[version.TenderID] = append(versions[version.TenderID], version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.GetTenderVersions: %w", err)
+	}
+
+	return versions, nil
+}
+
+func (r *GraphService) GetTenderBids(ctx context.Context, tenderIDs []string) (map[string][]domain.Bid, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+bidColumns+`
+		FROM bid b
+		WHERE b.tender_id::text = ANY($1)
+		ORDER BY b.name
+	`, tenderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetTenderBids: %w", err)
+	}
+	defer rows.Close()
+
+	list, err := scanBids(rows)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetTenderBids: %w", err)
+	}
+
+	bids := make(map[string][]domain.Bid, len(tenderIDs))
+	for _, bid := range list {
+		bids[bid.TenderId] = append(bids[bid.TenderId], bid)
+	}
+
+	return bids, nil
+}
+
+func (r *GraphService) GetOrganizations(ctx context.Context, organizationIDs []string) (map[string]domain.Organization, error) {
+	organizations, err := listOrganizations(ctx, r.pool, organizationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetOrganizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (r *GraphService) GetEmployees(ctx context.Context, usernames []string) (map[string]domain.Employee, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, username, COALESCE(first_name, ''), COALESCE(last_name, '')
+		FROM employee
+		WHERE username = ANY($1)
+	`, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetEmployees: %w", err)
+	}
+	defer rows.Close()
+
+	employees := make(map[string]domain.Employee, len(usernames))
+	for rows.Next() {
+		var employee domain.Employee
+		if err := rows.Scan(&employee.ID, &employee.Username, &employee.FirstName, &employee.LastName); err != nil {
+			return nil, fmt.Errorf("repository.GetEmployees: %w", err)
+		}
+		employees[employee.Username] = employee
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.GetEmployees: %w", err)
+	}
+
+	return employees, nil
+}
+
+func (r *GraphService) GetResponsibleOrganizations(ctx context.Context, usernames []string) (map[string][]domain.Organization, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT e.username, o.id, o.name, COALESCE(o.description, ''), o.type::text
+		FROM organization_responsible r
+		JOIN employee e ON e.id = r.user_id
+		JOIN organization o ON o.id = r.organization_id
+		WHERE e.username = ANY($1)
+		ORDER BY o.name
+	`, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetResponsibleOrganizations: %w", err)
+	}
+	defer rows.Close()
+
+	organizations := make(map[string][]domain.Organization, len(usernames))
+	for rows.Next() {
+		var (
+			username     string
+			organization domain.Organization
+		)
+		err := rows.Scan(&username, &organization.ID, &organization.Name, &organization.Description, &organization.Type)
+		if err != nil {
+			return nil, fmt.Errorf("repository.GetResponsibleOrganizations: %w", err)
+		}
+		organizations[username] = append(organizations[username], organization)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.GetResponsibleOrganizations: %w", err)
+	}
+
+	return organizations, nil
+}
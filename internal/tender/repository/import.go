@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// importBatchSize is the number of tenders sent with one COPY.
+const importBatchSize = 500
+
+var (
+	_ domain.ImportRepository = (*ImportService)(nil)
+)
+
+type ImportService struct {
+	pool *pgxpool.Pool
+}
+
+func NewImportService(pool *pgxpool.Pool) *ImportService {
+	return &ImportService{pool: pool}
+}
+
+func (r *ImportService) IsUserAuthorizedForOrganization(ctx context.Context, username, organizationID string) (bool, error) {
+	return NewTenderService(r.pool).IsUserAuthorizedForOrganization(ctx, username, organizationID)
+}
+
+// ImportTenders copies the tenders with their lots, criteria and first
+// versions in batches. Either all of them are stored or none.
+func (r *ImportService) ImportTenders(ctx context.Context, tenders []domain.Tender) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.ImportTenders: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for start := 0; start < len(tenders); start += importBatchSize {
+		if err := copyTenders(ctx, tx, tenders[start:min(start+importBatchSize, len(tenders))]); err != nil {
+			return fmt.Errorf("repository.ImportTenders: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("repository.ImportTenders: %w", err)
+	}
+
+	return nil
+}
+
+func copyTenders(ctx context.Context, tx pgx.Tx, tenders []domain.Tender) error {
+	count := len(tenders)
+	for _, tender := range tenders {
+		count += len(tender.Lots) + len(tender.Criteria)
+	}
+
+	// COPY returns nothing, so the IDs linking lots, criteria and versions
+	// to their tenders are generated beforehand.
+	rows, err := tx.Query(ctx, `SELECT uuid_generate_v4()::text FROM generate_series(1, $1)`, count)
+	if err != nil {
+		return err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+
+	var tenderRows, lotRows, criterionRows, versionRows [][]any
+	for _, tender := range tenders {
+		tenderID := ids[0]
+		ids = ids[1:]
+
+		lots := make([]domain.Lot, len(tender.Lots))
+		for i, lot := range tender.Lots {
+			lot.ID = ids[0]
+			ids = ids[1:]
+			lots[i] = lot
+			lotRows = append(lotRows, []any{lot.ID, tenderID, i + 1, lot.Name, lot.Description, lot.Quantity, lot.Unit, lot.Budget})
+		}
+
+		criteria := make([]domain.Criterion, len(tender.Criteria))
+		for i, criterion := range tender.Criteria {
+			criterion.ID = ids[0]
+			ids = ids[1:]
+			criteria[i] = criterion
+			criterionRows = append(criterionRows, []any{criterion.ID, tenderID, i + 1, criterion.Name, criterion.Weight})
+		}
+
+		tags := tender.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		customFields := tender.CustomFields
+		if customFields == nil {
+			customFields = map[string]any{}
+		}
+
+		tenderRows = append(tenderRows, []any{
+			tenderID, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationId, tender.CreatorUsername,
+			tender.Version, string(tender.Type), string(tender.Visibility), tender.Sealed, tender.SealKey, tender.Budget, tender.ClosesAt, tags, customFields,
+		})
+		versionRows = append(versionRows, []any{
+			tenderID, tender.Version, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationId, tender.CreatorUsername,
+			tender.Budget, lots, tags, customFields, string(tender.Visibility), []string{}, criteria,
+		})
+	}
+
+	for _, c := range []struct {
+		table   string
+		columns []string
+		rows    [][]any
+	}{
+		{"tender", []string{"id", "name", "description", "service_type", "status", "organization_id", "created_by_user", "version", "type", "visibility", "sealed", "seal_key", "budget", "closes_at", "tags", "custom_fields"}, tenderRows},
+		{"tender_lot", []string{"id", "tender_id", "position", "name", "description", "quantity", "unit", "budget"}, lotRows},
+		{"tender_criterion", []string{"id", "tender_id", "position", "name", "weight"}, criterionRows},
+		{"tender_versions", []string{"tender_id", "version", "name", "description", "service_type", "status", "organization_id", "created_by_user", "budget", "lots", "tags", "custom_fields", "visibility", "invited_organizations", "criteria"}, versionRows},
+	} {
+		if len(c.rows) == 0 {
+			continue
+		}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{c.table}, c.columns, pgx.CopyFromRows(c.rows)); err != nil {
+			return fmt.Errorf("copy %s: %w", c.table, err)
+		}
+	}
+
+	return nil
+}
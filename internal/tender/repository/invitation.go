@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.InvitationRepository = (*InvitationService)(nil)
+)
+
+const invitationColumns = `tender_id, organization_id, COALESCE(invited_by, ''), created_at`
+
+type InvitationService struct {
+	pool *pgxpool.Pool
+}
+
+func NewInvitationService(pool *pgxpool.Pool) *InvitationService {
+	return &InvitationService{pool: pool}
+}
+
+func scanInvitation(row rowScanner) (domain.Invitation, error) {
+	var invitation domain.Invitation
+	err := row.Scan(
+		&invitation.TenderID,
+		&invitation.OrganizationID,
+		&invitation.InvitedBy,
+		&invitation.CreatedAt,
+	)
+
+	return invitation, err
+}
+
+func (r *InvitationService) checkOwner(ctx context.Context, tenderID string, username string) (string, error) {
+	_, organizationID, err := tenderOwnership(ctx, r.pool, tenderID, false)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkResponsible(ctx, r.pool, username, organizationID); err != nil {
+		return "", err
+	}
+
+	return organizationID, nil
+}
+
+func (r *InvitationService) CreateInvitation(ctx context.Context, tenderID string, organizationID string, username string) (domain.Invitation, error) {
+	ownerID, err := r.checkOwner(ctx, tenderID, username)
+	if err != nil {
+		return domain.Invitation{}, fmt.Errorf("repository.CreateInvitation: %w", err)
+	}
+
+	if organizationID == ownerID {
+		return domain.Invitation{}, fmt.Errorf("repository.CreateInvitation: %w: tender organization cannot be invited", domain.ErrInvalidInput)
+	}
+
+	var exists bool
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM organization WHERE id = $1)`, organizationID).Scan(&exists)
+	if err != nil {
+		return domain.Invitation{}, fmt.Errorf("repository.CreateInvitation: %w", err)
+	}
+
+	if !exists {
+		return domain.Invitation{}, fmt.Errorf("repository.CreateInvitation: %w: organization does not exist", domain.ErrNotFound)
+	}
+
+	invitation, err := scanInvitation(r.pool.QueryRow(ctx, `
+		INSERT INTO tender_invitation (tender_id, organization_id, invited_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+		RETURNING `+invitationColumns,
+		tenderID, organizationID, username,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Invitation{}, fmt.Errorf("repository.CreateInvitation: %w: organization is already invited", domain.ErrConflict)
+		}
+		return domain.Invitation{}, fmt.Errorf("repository.CreateInvitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+func (r *InvitationService) ListInvitations(ctx context.Context, tenderID string, username string) ([]domain.Invitation, error) {
+	if _, err := r.checkOwner(ctx, tenderID, username); err != nil {
+		return nil, fmt.Errorf("repository.ListInvitations: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+invitationColumns+`
+		FROM tender_invitation
+		WHERE tender_id = $1
+		ORDER BY created_at
+	`, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListInvitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []domain.Invitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListInvitations: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListInvitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+func (r *InvitationService) DeleteInvitation(ctx context.Context, tenderID string, organizationID string, username string) error {
+	if _, err := r.checkOwner(ctx, tenderID, username); err != nil {
+		return fmt.Errorf("repository.DeleteInvitation: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, `DELETE FROM tender_invitation WHERE tender_id = $1 AND organization_id = $2`, tenderID, organizationID)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteInvitation: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository.DeleteInvitation: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// replaceInvitations makes the organizations the complete invitation list of
+// the tender. Organizations that no longer exist are left out, the ones
+// still invited keep their invitation.
+func replaceInvitations(ctx context.Context, q querier, tenderID string, organizationIDs []string, username string) error {
+	_, err := q.Exec(ctx, `DELETE FROM tender_invitation WHERE tender_id = $1 AND organization_id::text <> ALL($2)`, tenderID, organizationIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, `
+		INSERT INTO tender_invitation (tender_id, organization_id, invited_by)
+		SELECT $1, id, $3
+		FROM organization
+		WHERE id::text = ANY($2)
+		ON CONFLICT DO NOTHING
+	`, tenderID, organizationIDs, username)
+
+	return err
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.LotRepository = (*LotService)(nil)
+)
+
+const lotColumns = `id, name, description, quantity, unit, budget, awarded_bid_id, awarded_at`
+
+type LotService struct {
+	pool *pgxpool.Pool
+}
+
+func NewLotService(pool *pgxpool.Pool) *LotService {
+	return &LotService{pool: pool}
+}
+
+func scanLot(row rowScanner) (domain.Lot, error) {
+	var lot domain.Lot
+	err := row.Scan(
+		&lot.ID,
+		&lot.Name,
+		&lot.Description,
+		&lot.Quantity,
+		&lot.Unit,
+		&lot.Budget,
+		&lot.AwardedBidID,
+		&lot.AwardedAt,
+	)
+
+	return lot, err
+}
+
+func listLots(ctx context.Context, q querier, tenderID string) ([]domain.Lot, error) {
+	rows, err := q.Query(ctx, `
+		SELECT `+lotColumns+`
+		FROM tender_lot
+		WHERE tender_id = $1
+		ORDER BY position
+	`, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lots := []domain.Lot{}
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, lot)
+	}
+
+	return lots, rows.Err()
+}
+
+// replaceLots makes lots the complete lot list of the tender. Lots with an ID
+// are updated in place, lots without one are created, and lots missing from
+// the list are removed unless some bid already targets them.
+func replaceLots(ctx context.Context, q querier, tenderID string, lots []domain.Lot) error {
+	keep := make([]string, 0, len(lots))
+	for i, lot := range lots {
+		var id string
+		err := q.QueryRow(ctx, `
+			INSERT INTO tender_lot AS l (id, tender_id, position, name, description, quantity, unit, budget)
+			VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (id) DO UPDATE
+			SET position = EXCLUDED.position, name = EXCLUDED.name, description = EXCLUDED.description,
+				quantity = EXCLUDED.quantity, unit = EXCLUDED.unit, budget = EXCLUDED.budget
+			WHERE l.tender_id = EXCLUDED.tender_id
+			RETURNING id
+		`, lot.ID, tenderID, i+1, lot.Name, lot.Description, lot.Quantity, lot.Unit, lot.Budget).Scan(&id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: lot %s belongs to another tender", domain.ErrInvalidInput, lot.ID)
+			}
+			return err
+		}
+		keep = append(keep, id)
+	}
+
+	var referenced bool
+	err := q.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM tender_lot l
+			JOIN bid_lot bl ON bl.lot_id = l.id
+			WHERE l.tender_id = $1 AND NOT (l.id::text = ANY($2::text[]))
+		)
+	`, tenderID, keep).Scan(&referenced)
+	if err != nil {
+		return err
+	}
+
+	if referenced {
+		return fmt.Errorf("%w: lots with bids cannot be removed", domain.ErrConflict)
+	}
+
+	_, err = q.Exec(ctx, `DELETE FROM tender_lot WHERE tender_id = $1 AND NOT (id::text = ANY($2::text[]))`, tenderID, keep)
+
+	return err
+}
+
+func (r *LotService) ListLots(ctx context.Context, tenderID string, username string) ([]domain.Lot, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return nil, fmt.Errorf("repository.ListLots: %w", err)
+	}
+
+	if err := checkTenderVisible(ctx, r.pool, tenderID, username); err != nil {
+		return nil, fmt.Errorf("repository.ListLots: %w", err)
+	}
+
+	lots, err := listLots(ctx, r.pool, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListLots: %w", err)
+	}
+
+	return lots, nil
+}
+
+// AwardLot records the decision on a lot. The tender is closed once every lot
+// has been decided, and the awards of all its lots are recorded then.
+func (r *LotService) AwardLot(ctx context.Context, tenderID string, lotID string, bidID string, username string) (domain.Lot, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		status, organizationID string
+		revealed               bool
+	)
+	err = tx.QueryRow(ctx, `SELECT t.status, t.organization_id, `+bidsRevealedSQL+` FROM tender t WHERE t.id = $1 FOR UPDATE`, tenderID).
+		Scan(&status, &organizationID, &revealed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", domain.ErrTenderNotFound)
+		}
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", err)
+	}
+
+	if err := checkResponsible(ctx, tx, username, organizationID); err != nil {
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", err)
+	}
+
+	switch status {
+	case "PUBLISHED":
+	case "CLOSED":
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", domain.ErrTenderClosed)
+	default:
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", domain.ErrTenderNotOpen)
+	}
+
+	if !revealed {
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", domain.ErrBidsSealed)
+	}
+
+	var awarded bool
+	err = tx.QueryRow(ctx, `SELECT awarded_bid_id IS NOT NULL FROM tender_lot WHERE id = $1 AND tender_id = $2`, lotID, tenderID).
+		Scan(&awarded)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w: lot does not exist", domain.ErrNotFound)
+		}
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", err)
+	}
+
+	if awarded {
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w: lot is already awarded", domain.ErrConflict)
+	}
+
+	lot, err := scanLot(tx.QueryRow(ctx, `
+		UPDATE tender_lot
+		SET awarded_bid_id = $1, awarded_at = NOW(), awarded_by = $3
+		WHERE id = $2 AND EXISTS (SELECT 1 FROM bid_lot WHERE bid_id = $1 AND lot_id = $2)
+		RETURNING `+lotColumns,
+		bidID, lotID, username,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", domain.ErrBidNotFound)
+		}
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE tender
+		SET status = 'CLOSED'
+		WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM tender_lot WHERE tender_id = $1 AND awarded_bid_id IS NULL)
+	`, tenderID)
+	if err != nil {
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		if err := createLotAwards(ctx, tx, tenderID); err != nil {
+			return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Lot{}, fmt.Errorf("repository.AwardLot: %w", err)
+	}
+
+	return lot, nil
+}
@@ -0,0 +1,274 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	_ domain.DirectoryRepository = (*TenderService)(nil)
+)
+
+func (r *TenderService) checkAdmin(username string) error {
+	if !r.userExists(username) {
+		return domain.ErrUserNotFound
+	}
+
+	if !r.admins[username] {
+		return fmt.Errorf("%w: administrators only", domain.ErrUserNotAuthorized)
+	}
+
+	return nil
+}
+
+// page returns the items from offset on, at most limit of them unless limit
+// is negative.
+func page[T any](items []T, limit, offset int) []T {
+	items = items[min(offset, len(items)):]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+
+	return items
+}
+
+func (r *TenderService) ListOrganizations(ctx context.Context, limit int, offset int, username string) ([]domain.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return nil, fmt.Errorf("repository.ListOrganizations: %w", err)
+	}
+
+	organizations := make([]domain.Organization, 0, len(r.organizations))
+	for _, organization := range r.organizations {
+		organizations = append(organizations, organization)
+	}
+	slices.SortFunc(organizations, func(a, b domain.Organization) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+
+	return page(organizations, limit, offset), nil
+}
+
+func (r *TenderService) CreateOrganization(ctx context.Context, req domain.CreateOrganizationRequest, username string) (domain.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return domain.Organization{}, fmt.Errorf("repository.CreateOrganization: %w", err)
+	}
+
+	now := time.Now()
+	organization := domain.Organization{
+		ID:          uuid.NewString(),
+		Name:        req.Name,
+		Description: req.Description,
+		Type:        req.Type,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	r.organizations[organization.ID] = organization
+
+	return organization, nil
+}
+
+func (r *TenderService) UpdateOrganization(ctx context.Context, organizationID string, update domain.OrganizationUpdate, username string) (domain.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return domain.Organization{}, fmt.Errorf("repository.UpdateOrganization: %w", err)
+	}
+
+	organization, ok := r.organizations[organizationID]
+	if !ok {
+		return domain.Organization{}, fmt.Errorf("repository.UpdateOrganization: %w: organization %s does not exist", domain.ErrNotFound, organizationID)
+	}
+
+	if update.Name != nil {
+		organization.Name = *update.Name
+	}
+	if update.Description != nil {
+		organization.Description = *update.Description
+	}
+	if update.Type != nil {
+		organization.Type = *update.Type
+	}
+	organization.UpdatedAt = time.Now()
+	r.organizations[organizationID] = organization
+
+	return organization, nil
+}
+
+func (r *TenderService) DeleteOrganization(ctx context.Context, organizationID string, username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return fmt.Errorf("repository.DeleteOrganization: %w", err)
+	}
+
+	if _, ok := r.organizations[organizationID]; !ok {
+		return fmt.Errorf("repository.DeleteOrganization: %w: organization %s does not exist", domain.ErrNotFound, organizationID)
+	}
+
+	for _, tender := range r.tenders {
+		if tender.OrganizationId == organizationID {
+			return fmt.Errorf("repository.DeleteOrganization: %w: the organization has tenders or bids", domain.ErrConflict)
+		}
+	}
+
+	delete(r.organizations, organizationID)
+	delete(r.responsible, organizationID)
+	for tenderID, invited := range r.invitations {
+		r.invitations[tenderID] = slices.DeleteFunc(invited, func(id string) bool { return id == organizationID })
+	}
+
+	return nil
+}
+
+func (r *TenderService) ListEmployees(ctx context.Context, limit int, offset int, username string) ([]domain.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return nil, fmt.Errorf("repository.ListEmployees: %w", err)
+	}
+
+	employees := make([]domain.Employee, 0, len(r.employees))
+	for _, employee := range r.employees {
+		employees = append(employees, employee)
+	}
+	slices.SortFunc(employees, func(a, b domain.Employee) int {
+		return cmp.Compare(a.Username, b.Username)
+	})
+
+	return page(employees, limit, offset), nil
+}
+
+func (r *TenderService) CreateEmployee(ctx context.Context, req domain.CreateEmployeeRequest, username string) (domain.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return domain.Employee{}, fmt.Errorf("repository.CreateEmployee: %w", err)
+	}
+
+	if r.userExists(req.Username) {
+		return domain.Employee{}, fmt.Errorf("repository.CreateEmployee: %w: employee %q already exists", domain.ErrConflict, req.Username)
+	}
+
+	now := time.Now()
+	employee := domain.Employee{
+		ID:        uuid.NewString(),
+		Username:  req.Username,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.employees[employee.Username] = employee
+
+	return employee, nil
+}
+
+func (r *TenderService) UpdateEmployee(ctx context.Context, employeeUsername string, update domain.EmployeeUpdate, username string) (domain.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return domain.Employee{}, fmt.Errorf("repository.UpdateEmployee: %w", err)
+	}
+
+	employee, ok := r.employees[employeeUsername]
+	if !ok {
+		return domain.Employee{}, fmt.Errorf("repository.UpdateEmployee: %w: employee %q does not exist", domain.ErrNotFound, employeeUsername)
+	}
+
+	if update.FirstName != nil {
+		employee.FirstName = *update.FirstName
+	}
+	if update.LastName != nil {
+		employee.LastName = *update.LastName
+	}
+	employee.UpdatedAt = time.Now()
+	r.employees[employeeUsername] = employee
+
+	return employee, nil
+}
+
+func (r *TenderService) DeleteEmployee(ctx context.Context, employeeUsername string, username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return fmt.Errorf("repository.DeleteEmployee: %w", err)
+	}
+
+	employee, ok := r.employees[employeeUsername]
+	if !ok {
+		return fmt.Errorf("repository.DeleteEmployee: %w: employee %q does not exist", domain.ErrNotFound, employeeUsername)
+	}
+
+	for _, tender := range r.tenders {
+		if tender.CreatorUsername == employeeUsername {
+			return fmt.Errorf("repository.DeleteEmployee: %w: the employee has authored tenders, bids, offers, scores or questions", domain.ErrConflict)
+		}
+	}
+
+	delete(r.employees, employeeUsername)
+	delete(r.admins, employeeUsername)
+	for organizationID, responsible := range r.responsible {
+		r.responsible[organizationID] = slices.DeleteFunc(responsible, func(id string) bool { return id == employee.ID })
+	}
+
+	return nil
+}
+
+func (r *TenderService) AddOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return fmt.Errorf("repository.AddOrganizationResponsible: %w", err)
+	}
+
+	if _, ok := r.organizations[organizationID]; !ok {
+		return fmt.Errorf("repository.AddOrganizationResponsible: %w: organization %s does not exist", domain.ErrNotFound, organizationID)
+	}
+	employee, ok := r.employees[employeeUsername]
+	if !ok {
+		return fmt.Errorf("repository.AddOrganizationResponsible: %w: employee %q does not exist", domain.ErrNotFound, employeeUsername)
+	}
+
+	if !slices.Contains(r.responsible[organizationID], employee.ID) {
+		r.responsible[organizationID] = append(r.responsible[organizationID], employee.ID)
+	}
+
+	return nil
+}
+
+func (r *TenderService) RemoveOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkAdmin(username); err != nil {
+		return fmt.Errorf("repository.RemoveOrganizationResponsible: %w", err)
+	}
+
+	if !r.isResponsible(employeeUsername, organizationID) {
+		return fmt.Errorf("repository.RemoveOrganizationResponsible: %w: %q is not responsible for organization %s", domain.ErrNotFound, employeeUsername, organizationID)
+	}
+
+	employeeID := r.employees[employeeUsername].ID
+	r.responsible[organizationID] = slices.DeleteFunc(r.responsible[organizationID], func(id string) bool { return id == employeeID })
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+var (
+	_ domain.GraphRepository = (*TenderService)(nil)
+)
+
+// GetTenders leaves out the tenders the employee may not see.
+func (r *TenderService) GetTenders(ctx context.Context, tenderIDs []string, username string) (map[string]domain.Tender, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenders := make(map[string]domain.Tender, len(tenderIDs))
+	for _, id := range tenderIDs {
+		if tender, ok := r.tenders[id]; ok && r.visible(tender, username) {
+			tenders[id] = tenderRow(tender)
+		}
+	}
+
+	return tenders, nil
+}
+
+func (r *TenderService) GetTenderVersions(ctx context.Context, tenderIDs []string) (map[string][]domain.TenderVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make(map[string][]domain.TenderVersion, len(tenderIDs))
+	for _, id := range tenderIDs {
+		if list := r.versions[id]; len(list) > 0 {
+			versions[id] = slices.Clone(list)
+		}
+	}
+
+	return versions, nil
+}
+
+// GetTenderBids finds no bids, the memory repository does not keep them.
+func (r *TenderService) GetTenderBids(ctx context.Context, tenderIDs []string) (map[string][]domain.Bid, error) {
+	return map[string][]domain.Bid{}, nil
+}
+
+func (r *TenderService) GetOrganizations(ctx context.Context, organizationIDs []string) (map[string]domain.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	organizations := make(map[string]domain.Organization, len(organizationIDs))
+	for _, id := range organizationIDs {
+		if organization, ok := r.organizations[id]; ok {
+			organizations[id] = organization
+		}
+	}
+
+	return organizations, nil
+}
+
+func (r *TenderService) GetEmployees(ctx context.Context, usernames []string) (map[string]domain.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	employees := make(map[string]domain.Employee, len(usernames))
+	for _, username := range usernames {
+		if employee, ok := r.employees[username]; ok {
+			employees[username] = employee
+		}
+	}
+
+	return employees, nil
+}
+
+func (r *TenderService) GetResponsibleOrganizations(ctx context.Context, usernames []string) (map[string][]domain.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	organizations := make(map[string][]domain.Organization, len(usernames))
+	for _, username := range usernames {
+		for id, organization := range r.organizations {
+			if r.isResponsible(username, id) {
+				organizations[username] = append(organizations[username], organization)
+			}
+		}
+		slices.SortFunc(organizations[username], func(a, b domain.Organization) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+
+	return organizations, nil
+}
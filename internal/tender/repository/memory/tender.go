@@ -0,0 +1,782 @@
+// Package memory keeps tenders in memory with the semantics of the Postgres
+// repository, for tests and demos that run without a database.
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	_ domain.TenderRepository             = (*TenderService)(nil)
+	_ domain.TenderRepositoryPingProvider = (*TenderService)(nil)
+)
+
+var tenderStatuses = []string{"CREATED", "PUBLISHED", "CLOSED", "OPEN"}
+
+// TenderService is a domain.TenderRepository over maps guarded by a single
+// lock. Employees, organizations and the responsibility links between them
+// are seeded with the Add methods, like the tables the service does not
+// manage itself.
+type TenderService struct {
+	mu            sync.RWMutex
+	employees     map[string]domain.Employee
+	admins        map[string]bool
+	organizations map[string]domain.Organization
+	responsible   map[string][]string
+	invitations   map[string][]string
+	tenders       map[string]domain.Tender
+	order         []string
+	versions      map[string][]domain.TenderVersion
+	versionSeq    int
+	lots          map[string]string
+	criteria      map[string]string
+}
+
+func NewTenderService() *TenderService {
+	return &TenderService{
+		employees:     map[string]domain.Employee{},
+		admins:        map[string]bool{},
+		organizations: map[string]domain.Organization{},
+		responsible:   map[string][]string{},
+		invitations:   map[string][]string{},
+		tenders:       map[string]domain.Tender{},
+		versions:      map[string][]domain.TenderVersion{},
+		lots:          map[string]string{},
+		criteria:      map[string]string{},
+	}
+}
+
+// AddEmployee adds or replaces the employee with the username.
+func (r *TenderService) AddEmployee(employee domain.Employee) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if employee.ID == "" {
+		employee.ID = uuid.NewString()
+	}
+	r.employees[employee.Username] = employee
+}
+
+// MakeAdmin lets the employee with the username manage the directory.
+func (r *TenderService) MakeAdmin(username string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.admins[username] = true
+}
+
+// AddOrganization adds or replaces the organization with the ID.
+func (r *TenderService) AddOrganization(organization domain.Organization) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if organization.ID == "" {
+		organization.ID = uuid.NewString()
+	}
+	r.organizations[organization.ID] = organization
+}
+
+// AddResponsible makes the employee with the UserID responsible for the
+// organization.
+func (r *TenderService) AddResponsible(responsible domain.OrganizationResponsible) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !slices.Contains(r.responsible[responsible.OrganizationID], responsible.UserID) {
+		r.responsible[responsible.OrganizationID] = append(r.responsible[responsible.OrganizationID], responsible.UserID)
+	}
+}
+
+// Invite lets the employees responsible for the organization see the
+// tender even when it is private.
+func (r *TenderService) Invite(tenderID, organizationID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !slices.Contains(r.invitations[tenderID], organizationID) {
+		r.invitations[tenderID] = append(r.invitations[tenderID], organizationID)
+	}
+}
+
+func (r *TenderService) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (r *TenderService) userExists(username string) bool {
+	_, ok := r.employees[username]
+	return ok
+}
+
+func (r *TenderService) isResponsible(username, organizationID string) bool {
+	employee, ok := r.employees[username]
+	return ok && slices.Contains(r.responsible[organizationID], employee.ID)
+}
+
+// visible holds for public tenders and, for private ones, when the employee
+// is responsible for the owning or an invited organization.
+func (r *TenderService) visible(tender domain.Tender, username string) bool {
+	if tender.Visibility == domain.TenderVisibilityPublic || r.isResponsible(username, tender.OrganizationId) {
+		return true
+	}
+
+	return slices.ContainsFunc(r.invitations[tender.ID], func(organizationID string) bool {
+		return r.isResponsible(username, organizationID)
+	})
+}
+
+func (r *TenderService) ListTender(ctx context.Context, filter domain.TenderListFilter) ([]domain.Tender, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tenders []domain.Tender
+	skipped := 0
+	for _, id := range r.order {
+		if filter.Limit >= 0 && len(tenders) >= filter.Limit {
+			break
+		}
+
+		tender := r.tenders[id]
+		if !r.visible(tender, filter.Username) || !matches(tender, filter) {
+			continue
+		}
+		if skipped < filter.Offset {
+			skipped++
+			continue
+		}
+		tenders = append(tenders, tenderRow(tender))
+	}
+
+	return tenders, nil
+}
+
+// matches applies the filter the way the Postgres query does, except that
+// service types match only themselves: there is no catalog to find their
+// subcategories in.
+func matches(tender domain.Tender, filter domain.TenderListFilter) bool {
+	if len(filter.ServiceTypes) > 0 && !slices.Contains(filter.ServiceTypes, tender.ServiceType) {
+		return false
+	}
+
+	for _, tag := range filter.Tags {
+		if !slices.Contains(tender.Tags, tag) {
+			return false
+		}
+	}
+
+	if text := strings.ToLower(filter.Text); text != "" &&
+		!strings.Contains(strings.ToLower(tender.Name), text) && !strings.Contains(strings.ToLower(tender.Description), text) {
+		return false
+	}
+
+	for key, value := range filter.CustomFields {
+		text, ok := fieldText(tender.CustomFields[key])
+		if !ok || text != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldText is the text form of a custom field value, as jsonb ->> gives
+// it. Missing and null values have none.
+func fieldText(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	}
+}
+
+func (r *TenderService) CreateTender(ctx context.Context, tender domain.Tender) (domain.Tender, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.userExists(tender.CreatorUsername) {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", domain.ErrUserNotFound)
+	}
+
+	if !r.isResponsible(tender.CreatorUsername, tender.OrganizationId) {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", domain.ErrUserNotAuthorized)
+	}
+
+	tender = cloneTender(tender)
+	tender.ID = uuid.NewString()
+	tender.CreatedAt = now()
+	if tender.Tags == nil {
+		tender.Tags = []string{}
+	}
+	if tender.CustomFields == nil {
+		tender.CustomFields = map[string]any{}
+	}
+	tender.Attachments = nil
+
+	var err error
+	tender.Lots, err = r.replaceLots(tender.ID, tender.Lots)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+	}
+
+	tender.Criteria, err = r.replaceCriteria(tender.ID, tender.Criteria)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+	}
+
+	if tender.Type == domain.TenderTypeAuction && tender.Auction != nil {
+		tender.Auction.TenderID = tender.ID
+	} else {
+		tender.Auction = nil
+	}
+
+	r.store(tender)
+	r.saveVersion(tender)
+
+	return cloneTender(tender), nil
+}
+
+func (r *TenderService) GetUserTenders(ctx context.Context, limit, offset int, username string) ([]domain.Tender, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.userExists(username) {
+		return nil, fmt.Errorf("repository.GetUserTenders: %w", domain.ErrUserNotFound)
+	}
+
+	tenders := []domain.Tender{}
+	skipped := 0
+	for _, id := range r.order {
+		if limit >= 0 && len(tenders) >= limit {
+			break
+		}
+
+		tender := r.tenders[id]
+		if tender.CreatorUsername != username {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		tenders = append(tenders, domain.Tender{
+			ID:          tender.ID,
+			Name:        tender.Name,
+			Description: tender.Description,
+			Status:      tender.Status,
+			ServiceType: tender.ServiceType,
+			CreatedAt:   tender.CreatedAt,
+			Version:     tender.Version,
+		})
+	}
+
+	return tenders, nil
+}
+
+func (r *TenderService) GetTenderStatus(ctx context.Context, tenderID string, username string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.userExists(username) {
+		return "", fmt.Errorf("repository.GetTenderStatus: %w", domain.ErrUserNotFound)
+	}
+
+	tender, ok := r.tenders[tenderID]
+	if !ok || !r.visible(tender, username) {
+		return "", fmt.Errorf("repository.GetTenderStatus: %w", domain.ErrTenderNotFound)
+	}
+
+	return tender.Status, nil
+}
+
+func (r *TenderService) UpdateTenderStatus(ctx context.Context, tenderID string, status string, from []string, username string) (domain.Tender, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.userExists(username) {
+		return domain.Tender{}, false, fmt.Errorf("repository.UpdateTenderStatus: %w", domain.ErrUserNotFound)
+	}
+
+	if !slices.Contains(tenderStatuses, status) {
+		return domain.Tender{}, false, fmt.Errorf("repository.UpdateTenderStatus: %w: unknown status %q", domain.ErrInvalidInput, status)
+	}
+
+	staged := map[string]string{}
+	change := r.changeTenderStatus(staged, tenderID, status, from, username)
+	if change.Err != nil {
+		return domain.Tender{}, false, fmt.Errorf("repository.UpdateTenderStatus: %w", change.Err)
+	}
+	r.applyStatuses(staged)
+
+	return tenderRow(r.tenders[tenderID]), len(staged) > 0, nil
+}
+
+func (r *TenderService) UpdateTenderStatuses(ctx context.Context, tenderIDs []string, status string, from []string, username string, atomic bool) ([]domain.TenderStatusChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.userExists(username) {
+		return nil, fmt.Errorf("repository.UpdateTenderStatuses: %w", domain.ErrUserNotFound)
+	}
+
+	changes := make([]domain.TenderStatusChange, 0, len(tenderIDs))
+
+	if atomic {
+		staged := map[string]string{}
+		failed := false
+		for _, tenderID := range tenderIDs {
+			change := r.changeTenderStatus(staged, tenderID, status, from, username)
+			failed = failed || change.Err != nil
+			changes = append(changes, change)
+		}
+
+		if failed {
+			return changes, nil
+		}
+
+		r.applyStatuses(staged)
+		for i := range changes {
+			changes[i].Applied = changes[i].PreviousStatus != status
+		}
+
+		return changes, nil
+	}
+
+	for _, tenderID := range tenderIDs {
+		staged := map[string]string{}
+		change := r.changeTenderStatus(staged, tenderID, status, from, username)
+		if change.Err == nil {
+			r.applyStatuses(staged)
+			change.Applied = change.PreviousStatus != status
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// changeTenderStatus checks the change of one tender against the statuses
+// staged so far and stages it. Private tenders the employee may not see are
+// reported as not found.
+func (r *TenderService) changeTenderStatus(staged map[string]string, tenderID, status string, from []string, username string) domain.TenderStatusChange {
+	change := domain.TenderStatusChange{TenderID: tenderID}
+
+	tender, ok := r.tenders[tenderID]
+	if !ok || !r.visible(tender, username) {
+		change.Err = domain.ErrTenderNotFound
+		return change
+	}
+
+	change.PreviousStatus = tender.Status
+	if previous, ok := staged[tenderID]; ok {
+		change.PreviousStatus = previous
+	}
+
+	if !r.isResponsible(username, tender.OrganizationId) {
+		change.Err = domain.ErrUserNotAuthorized
+		return change
+	}
+
+	if change.PreviousStatus == status {
+		change.Status = status
+		return change
+	}
+
+	if !slices.Contains(from, change.PreviousStatus) {
+		change.Err = fmt.Errorf("%w: tender can not move from %s to %s", domain.ErrConflict, change.PreviousStatus, status)
+		return change
+	}
+
+	staged[tenderID] = status
+	change.Status = status
+
+	return change
+}
+
+func (r *TenderService) applyStatuses(staged map[string]string) {
+	for tenderID, status := range staged {
+		tender := r.tenders[tenderID]
+		tender.Status = status
+		r.tenders[tenderID] = tender
+	}
+}
+
+func (r *TenderService) GetTender(ctx context.Context, tenderID string, username string) (domain.Tender, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.userExists(username) {
+		return domain.Tender{}, fmt.Errorf("repository.GetTender: %w", domain.ErrUserNotFound)
+	}
+
+	tender, ok := r.tenders[tenderID]
+	if !ok || !r.visible(tender, username) {
+		return domain.Tender{}, fmt.Errorf("repository.GetTender: %w", domain.ErrTenderNotFound)
+	}
+
+	tender = cloneTender(tender)
+	tender.Attachments = []domain.Attachment{}
+
+	return tender, nil
+}
+
+func (r *TenderService) UpdatePartTender(ctx context.Context, id string, updates map[string]interface{}, username string) (domain.Tender, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.userExists(username) {
+		return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", domain.ErrUserNotFound)
+	}
+
+	current, ok := r.tenders[id]
+	if !ok || !r.visible(current, username) {
+		return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", domain.ErrTenderNotFound)
+	}
+	if !r.isResponsible(username, current.OrganizationId) {
+		return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", domain.ErrUserNotAuthorized)
+	}
+
+	tender := cloneTender(current)
+	if name, ok := updates["name"].(string); ok && name != "" {
+		tender.Name = name
+	}
+	if description, ok := updates["description"].(string); ok && description != "" {
+		tender.Description = description
+	}
+	if serviceType, ok := updates["serviceType"].(string); ok && serviceType != "" {
+		tender.ServiceType = serviceType
+	}
+	if visibility, ok := updates["visibility"].(string); ok && visibility != "" {
+		if visibility != string(domain.TenderVisibilityPublic) && visibility != string(domain.TenderVisibilityPrivate) {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w: unknown visibility %q", domain.ErrInvalidInput, visibility)
+		}
+		tender.Visibility = domain.TenderVisibility(visibility)
+	}
+	if budget, ok := updates["budget"].(float64); ok && budget >= 0 {
+		tender.Budget = &budget
+	}
+	if closesAtStr, ok := updates["closesAt"].(string); ok && closesAtStr != "" {
+		closesAt, err := time.Parse(time.RFC3339, closesAtStr)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("invalid closesAt: %w", err)
+		}
+		if tender.Type == domain.TenderTypeAuction {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w: the deadline of an auction follows its end time", domain.ErrConflict)
+		}
+		if tender.Sealed && tender.ClosesAt != nil && closesAt.Before(*tender.ClosesAt) {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w: deadline of a sealed tender can only be extended", domain.ErrConflict)
+		}
+		closesAt = closesAt.UTC()
+		tender.ClosesAt = &closesAt
+	}
+	if tags, ok := updates["tags"].([]string); ok {
+		tender.Tags = slices.Clone(tags)
+	}
+	if customFields, ok := updates["customFields"].(map[string]any); ok {
+		tender.CustomFields = maps.Clone(customFields)
+	}
+	tender.Version++
+
+	var err error
+	if lots, ok := updates["lots"].([]domain.Lot); ok {
+		if err := checkClosedLots(current, lots); err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+		}
+		tender.Lots, err = r.replaceLots(id, lots)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+		}
+	}
+
+	if criteria, ok := updates["criteria"].([]domain.Criterion); ok {
+		if err := checkClosedCriteria(current, criteria); err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+		}
+		tender.Criteria, err = r.replaceCriteria(id, criteria)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+		}
+	}
+
+	r.store(tender)
+	r.saveVersion(tender)
+
+	tender = cloneTender(tender)
+	tender.Auction = nil
+
+	return tender, nil
+}
+
+func (r *TenderService) RollbackTenderVersion(ctx context.Context, id string, targetVersion int, username string) (domain.Tender, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.userExists(username) {
+		return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w", domain.ErrUserNotFound)
+	}
+
+	current, ok := r.tenders[id]
+	if !ok || !r.visible(current, username) {
+		return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w", domain.ErrTenderNotFound)
+	}
+	if !r.isResponsible(username, current.OrganizationId) {
+		return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w", domain.ErrUserNotAuthorized)
+	}
+
+	versions := r.versions[id]
+	i := slices.IndexFunc(versions, func(v domain.TenderVersion) bool { return v.Version == targetVersion })
+	if i < 0 {
+		return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w: version %d of tender %s", domain.ErrNotFound, targetVersion, id)
+	}
+	target := versions[i]
+
+	maxVersion := 0
+	for _, version := range versions {
+		maxVersion = max(maxVersion, version.Version)
+	}
+
+	tender := cloneTender(current)
+	tender.Name = target.Name
+	tender.Description = target.Description
+	tender.ServiceType = target.ServiceType
+	tender.Version = maxVersion + 1
+	tender.Budget = clonePointer(target.Budget)
+	tender.Tags = slices.Clone(target.Tags)
+	tender.CustomFields = maps.Clone(target.CustomFields)
+	if target.Visibility != "" {
+		tender.Visibility = target.Visibility
+	}
+	if target.InvitedOrganizations != nil {
+		invited := []string{}
+		for _, organizationID := range target.InvitedOrganizations {
+			if _, ok := r.organizations[organizationID]; ok {
+				invited = append(invited, organizationID)
+			}
+		}
+		r.invitations[id] = invited
+	}
+
+	if err := checkClosedLots(tender, target.Lots); err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w", err)
+	}
+	if target.Criteria != nil {
+		if err := checkClosedCriteria(tender, target.Criteria); err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w", err)
+		}
+	}
+
+	var err error
+	tender.Lots, err = r.replaceLots(id, target.Lots)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("failed to restore lots: %w", err)
+	}
+
+	if target.Criteria != nil {
+		tender.Criteria, err = r.replaceCriteria(id, target.Criteria)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("failed to restore criteria: %w", err)
+		}
+	}
+
+	r.store(tender)
+	r.saveVersion(tender)
+
+	tender = cloneTender(tender)
+	tender.Auction = nil
+
+	return tender, nil
+}
+
+// saveVersion records the tender as its current version.
+func (r *TenderService) saveVersion(tender domain.Tender) {
+	lots := cloneLots(tender.Lots)
+	if lots == nil {
+		lots = []domain.Lot{}
+	}
+	tags := slices.Clone(tender.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+	customFields := maps.Clone(tender.CustomFields)
+	if customFields == nil {
+		customFields = map[string]any{}
+	}
+
+	r.versionSeq++
+	r.versions[tender.ID] = append(r.versions[tender.ID], domain.TenderVersion{
+		ID:                   r.versionSeq,
+		TenderID:             tender.ID,
+		Name:                 tender.Name,
+		Description:          tender.Description,
+		ServiceType:          tender.ServiceType,
+		Status:               tender.Status,
+		OrganizationId:       tender.OrganizationId,
+		CreatorUsername:      tender.CreatorUsername,
+		Version:              tender.Version,
+		Budget:               clonePointer(tender.Budget),
+		Lots:                 lots,
+		Tags:                 tags,
+		CustomFields:         customFields,
+		CreatedAt:            now(),
+		Visibility:           tender.Visibility,
+		InvitedOrganizations: append([]string{}, r.invitations[tender.ID]...),
+		Criteria:             append([]domain.Criterion{}, tender.Criteria...),
+	})
+}
+
+// replaceLots returns lots as the complete lot list of the tender. Lots with
+// an ID keep their award, lots without one get a new ID.
+func (r *TenderService) replaceLots(tenderID string, lots []domain.Lot) ([]domain.Lot, error) {
+	current := map[string]domain.Lot{}
+	for _, lot := range r.tenders[tenderID].Lots {
+		current[lot.ID] = lot
+	}
+
+	replaced := make([]domain.Lot, 0, len(lots))
+	for _, lot := range cloneLots(lots) {
+		if lot.ID == "" {
+			lot.ID = uuid.NewString()
+		} else if owner, ok := r.lots[lot.ID]; ok && owner != tenderID {
+			return nil, fmt.Errorf("%w: lot %s belongs to another tender", domain.ErrInvalidInput, lot.ID)
+		}
+
+		lot.AwardedBidID, lot.AwardedAt = nil, nil
+		if previous, ok := current[lot.ID]; ok {
+			lot.AwardedBidID, lot.AwardedAt = previous.AwardedBidID, previous.AwardedAt
+		}
+		replaced = append(replaced, lot)
+	}
+
+	return replaced, nil
+}
+
+func (r *TenderService) replaceCriteria(tenderID string, criteria []domain.Criterion) ([]domain.Criterion, error) {
+	replaced := make([]domain.Criterion, 0, len(criteria))
+	for _, criterion := range criteria {
+		if criterion.ID == "" {
+			criterion.ID = uuid.NewString()
+		} else if owner, ok := r.criteria[criterion.ID]; ok && owner != tenderID {
+			return nil, fmt.Errorf("%w: criterion %s belongs to another tender", domain.ErrInvalidInput, criterion.ID)
+		}
+		replaced = append(replaced, criterion)
+	}
+
+	return replaced, nil
+}
+
+// checkClosedLots rejects a change of the lots of a closed tender.
+func checkClosedLots(tender domain.Tender, lots []domain.Lot) error {
+	if tender.Status == "CLOSED" && !domain.SameLots(tender.Lots, lots) {
+		return fmt.Errorf("%w: the lots of a closed tender are locked", domain.ErrTenderClosed)
+	}
+	return nil
+}
+
+// checkClosedCriteria rejects a change of the criteria or their weights once
+// the tender is closed.
+func checkClosedCriteria(tender domain.Tender, criteria []domain.Criterion) error {
+	if tender.Status == "CLOSED" && !slices.Equal(tender.Criteria, criteria) {
+		return fmt.Errorf("%w: the criteria of a closed tender are locked", domain.ErrTenderClosed)
+	}
+	return nil
+}
+
+// store replaces the stored tender, keeping track of the tenders the lots
+// and criteria belong to.
+func (r *TenderService) store(tender domain.Tender) {
+	previous, ok := r.tenders[tender.ID]
+	if !ok {
+		r.order = append(r.order, tender.ID)
+	}
+
+	for _, lot := range previous.Lots {
+		delete(r.lots, lot.ID)
+	}
+	for _, lot := range tender.Lots {
+		r.lots[lot.ID] = tender.ID
+	}
+
+	for _, criterion := range previous.Criteria {
+		delete(r.criteria, criterion.ID)
+	}
+	for _, criterion := range tender.Criteria {
+		r.criteria[criterion.ID] = tender.ID
+	}
+
+	r.tenders[tender.ID] = tender
+}
+
+// now has the precision of a Postgres timestamp.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// tenderRow is the tender without the lists kept beside it, as read from its
+// table alone.
+func tenderRow(tender domain.Tender) domain.Tender {
+	tender = cloneTender(tender)
+	tender.Auction = nil
+	tender.Lots = nil
+	tender.Criteria = nil
+	tender.Attachments = nil
+
+	return tender
+}
+
+// cloneTender copies the tender so that callers can not change the stored
+// one through it.
+func cloneTender(tender domain.Tender) domain.Tender {
+	tender.SealKey = slices.Clone(tender.SealKey)
+	tender.Budget = clonePointer(tender.Budget)
+	tender.ClosesAt = clonePointer(tender.ClosesAt)
+	if tender.Auction != nil {
+		auction := *tender.Auction
+		auction.BestPrice = clonePointer(auction.BestPrice)
+		tender.Auction = &auction
+	}
+	tender.Lots = cloneLots(tender.Lots)
+	tender.Criteria = slices.Clone(tender.Criteria)
+	tender.Tags = slices.Clone(tender.Tags)
+	tender.CustomFields = maps.Clone(tender.CustomFields)
+	tender.Attachments = slices.Clone(tender.Attachments)
+
+	return tender
+}
+
+func cloneLots(lots []domain.Lot) []domain.Lot {
+	lots = slices.Clone(lots)
+	for i := range lots {
+		lots[i].Budget = clonePointer(lots[i].Budget)
+		lots[i].AwardedBidID = clonePointer(lots[i].AwardedBidID)
+		lots[i].AwardedAt = clonePointer(lots[i].AwardedAt)
+	}
+
+	return lots
+}
+
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+
+	return &v
+}
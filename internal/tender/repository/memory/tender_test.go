@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+func newSeeded() *TenderService {
+	r := NewTenderService()
+	for _, employee := range repotest.Employees {
+		r.AddEmployee(employee)
+	}
+	for _, organization := range repotest.Organizations {
+		r.AddOrganization(organization)
+	}
+	for _, responsible := range repotest.Responsible {
+		r.AddResponsible(responsible)
+	}
+
+	return r
+}
+
+func TestContract(t *testing.T) {
+	repotest.Suite{
+		New: func(t *testing.T) domain.TenderRepository { return newSeeded() },
+	}.Run(t)
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	ctx := context.Background()
+	r := newSeeded()
+
+	tender, err := r.CreateTender(ctx, domain.Tender{
+		Name:            "Охрана",
+		Status:          "CREATED",
+		ServiceType:     "Delivery",
+		OrganizationId:  repotest.Organization,
+		CreatorUsername: repotest.Owner,
+		Version:         1,
+		Visibility:      domain.TenderVisibilityPrivate,
+	})
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+
+	const updates = 50
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := r.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"tags": []string{"it"}}, repotest.Owner); err != nil {
+				t.Errorf("UpdatePartTender: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := r.GetTender(ctx, tender.ID, repotest.Colleague); err != nil {
+				t.Errorf("GetTender: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := r.GetTender(ctx, tender.ID, repotest.Owner)
+	if err != nil || got.Version != updates+1 || len(r.versions[tender.ID]) != updates+1 {
+		t.Errorf("tender after %d updates = version %d with %d saved versions, %v", updates, got.Version, len(r.versions[tender.ID]), err)
+	}
+}
+
+func TestInvite(t *testing.T) {
+	ctx := context.Background()
+	r := newSeeded()
+
+	tender, err := r.CreateTender(ctx, domain.Tender{
+		Name:            "Охрана",
+		Status:          "CREATED",
+		ServiceType:     "Delivery",
+		OrganizationId:  repotest.Organization,
+		CreatorUsername: repotest.Owner,
+		Version:         1,
+		Visibility:      domain.TenderVisibilityPrivate,
+	})
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+
+	if _, err := r.GetTender(ctx, tender.ID, repotest.Outsider); err == nil {
+		t.Error("private tender is visible before the invitation")
+	}
+
+	r.Invite(tender.ID, repotest.OtherOrganization)
+	if _, err := r.GetTender(ctx, tender.ID, repotest.Outsider); err != nil {
+		t.Errorf("private tender after the invitation: %v", err)
+	}
+}
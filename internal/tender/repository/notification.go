@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.NotificationRepository = (*NotificationService)(nil)
+)
+
+type NotificationService struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationService(pool *pgxpool.Pool) *NotificationService {
+	return &NotificationService{pool: pool}
+}
+
+func (r *NotificationService) GetSettings(ctx context.Context, username string) (domain.NotificationSettings, error) {
+	var settings domain.NotificationSettings
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(email, ''), locale, notify_on_bid, notify_on_closing
+		FROM employee
+		WHERE username = $1
+	`, username).Scan(&settings.Email, &settings.Locale, &settings.NotifyOnBid, &settings.NotifyOnClosing)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.NotificationSettings{}, fmt.Errorf("repository.GetSettings: %w", domain.ErrUserNotFound)
+		}
+		return domain.NotificationSettings{}, fmt.Errorf("repository.GetSettings: %w", err)
+	}
+
+	return settings, nil
+}
+
+func (r *NotificationService) UpdateSettings(ctx context.Context, username string, settings domain.NotificationSettings) (domain.NotificationSettings, error) {
+	var updated domain.NotificationSettings
+	err := r.pool.QueryRow(ctx, `
+		UPDATE employee
+		SET email = NULLIF($1, ''), locale = $2, notify_on_bid = $3, notify_on_closing = $4, updated_at = NOW()
+		WHERE username = $5
+		RETURNING COALESCE(email, ''), locale, notify_on_bid, notify_on_closing
+	`, settings.Email, settings.Locale, settings.NotifyOnBid, settings.NotifyOnClosing, username).Scan(
+		&updated.Email,
+		&updated.Locale,
+		&updated.NotifyOnBid,
+		&updated.NotifyOnClosing,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.NotificationSettings{}, fmt.Errorf("repository.UpdateSettings: %w", domain.ErrUserNotFound)
+		}
+		return domain.NotificationSettings{}, fmt.Errorf("repository.UpdateSettings: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *NotificationService) ListRecipients(ctx context.Context, organizationID string, event domain.NotificationEvent) ([]domain.Recipient, error) {
+	query := `
+		SELECT e.username, COALESCE(e.first_name, ''), COALESCE(e.last_name, ''), e.email, e.locale
+		FROM employee e
+		JOIN organization_responsible r ON r.user_id = e.id
+		WHERE r.organization_id = $1 AND e.email IS NOT NULL`
+
+	switch event {
+	case domain.NotificationBidCreated:
+		query += ` AND e.notify_on_bid`
+	case domain.NotificationTenderClosing:
+		query += ` AND e.notify_on_closing`
+	}
+
+	rows, err := r.pool.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListRecipients: %w", err)
+	}
+	defer rows.Close()
+
+	recipients := []domain.Recipient{}
+	for rows.Next() {
+		var recipient domain.Recipient
+		err := rows.Scan(&recipient.Username, &recipient.FirstName, &recipient.LastName, &recipient.Email, &recipient.Locale)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListRecipients: %w", err)
+		}
+		recipients = append(recipients, recipient)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListRecipients: %w", err)
+	}
+
+	return recipients, nil
+}
+
+func (r *NotificationService) ListClosingTenders(ctx context.Context, before time.Time) ([]domain.Tender, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+tenderColumns+`
+		FROM tender
+		WHERE status = 'PUBLISHED' AND closes_at IS NOT NULL AND closes_at > NOW() AND closes_at <= $1
+			AND closing_notified_at IS NULL
+	`, before)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListClosingTenders: %w", err)
+	}
+	defer rows.Close()
+
+	tenders := []domain.Tender{}
+	for rows.Next() {
+		tender, err := scanTender(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListClosingTenders: %w", err)
+		}
+		tenders = append(tenders, tender)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListClosingTenders: %w", err)
+	}
+
+	return tenders, nil
+}
+
+func (r *NotificationService) MarkClosingNotified(ctx context.Context, tenderID string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE tender SET closing_notified_at = NOW() WHERE id = $1`, tenderID)
+	if err != nil {
+		return fmt.Errorf("repository.MarkClosingNotified: %w", err)
+	}
+
+	return nil
+}
+
+const notificationColumns = `id, username, event, COALESCE(tender_id::text, ''), COALESCE(saved_search_id::text, ''),
+	title, message, read_at IS NOT NULL, read_at, created_at`
+
+func scanNotification(row rowScanner) (domain.InAppNotification, error) {
+	var notification domain.InAppNotification
+	err := row.Scan(
+		&notification.ID,
+		&notification.Username,
+		&notification.Event,
+		&notification.TenderID,
+		&notification.SavedSearchID,
+		&notification.Title,
+		&notification.Message,
+		&notification.Read,
+		&notification.ReadAt,
+		&notification.CreatedAt,
+	)
+
+	return notification, err
+}
+
+func (r *NotificationService) CreateNotification(ctx context.Context, notification domain.InAppNotification) (domain.InAppNotification, error) {
+	created, err := scanNotification(r.pool.QueryRow(ctx, `
+		INSERT INTO notification (username, event, tender_id, saved_search_id, title, message)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5, $6)
+		RETURNING `+notificationColumns,
+		notification.Username, notification.Event, notification.TenderID, notification.SavedSearchID,
+		notification.Title, notification.Message,
+	))
+	if err != nil {
+		return domain.InAppNotification{}, fmt.Errorf("repository.CreateNotification: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *NotificationService) ListNotifications(ctx context.Context, username string, unreadOnly bool, limit, offset int) ([]domain.InAppNotification, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM employee WHERE username = $1)`, username).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListNotifications: %w", err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("repository.ListNotifications: %w", domain.ErrUserNotFound)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+notificationColumns+`
+		FROM notification
+		WHERE username = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`, username, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListNotifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []domain.InAppNotification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListNotifications: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListNotifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationService) MarkNotificationRead(ctx context.Context, notificationID string, username string) (domain.InAppNotification, error) {
+	notification, err := scanNotification(r.pool.QueryRow(ctx, `
+		UPDATE notification
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND username = $2
+		RETURNING `+notificationColumns,
+		notificationID, username,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.InAppNotification{}, fmt.Errorf("repository.MarkNotificationRead: %w", domain.ErrNotFound)
+		}
+		return domain.InAppNotification{}, fmt.Errorf("repository.MarkNotificationRead: %w", err)
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,73 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+func TestNotificationRead(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewNotificationService(repotest.Postgres(t))
+
+	var created []domain.InAppNotification
+	for _, title := range []string{"Первое", "Второе"} {
+		notification, err := repo.CreateNotification(ctx, domain.InAppNotification{
+			Username: repotest.Owner,
+			Event:    string(domain.NotificationTenderPublished),
+			Title:    title,
+			Message:  title,
+		})
+		if err != nil {
+			t.Fatalf("CreateNotification: %v", err)
+		}
+		if notification.Read || notification.ReadAt != nil {
+			t.Errorf("new notification = %+v, want it unread", notification)
+		}
+		created = append(created, notification)
+	}
+
+	read, err := repo.MarkNotificationRead(ctx, created[0].ID, repotest.Owner)
+	if err != nil {
+		t.Fatalf("MarkNotificationRead: %v", err)
+	}
+	if !read.Read || read.ReadAt == nil {
+		t.Errorf("read notification = %+v, want it read", read)
+	}
+
+	again, err := repo.MarkNotificationRead(ctx, created[0].ID, repotest.Owner)
+	if err != nil {
+		t.Fatalf("MarkNotificationRead again: %v", err)
+	}
+	if again.ReadAt == nil || !again.ReadAt.Equal(*read.ReadAt) {
+		t.Errorf("read again at %v, want the first read time %v kept", again.ReadAt, read.ReadAt)
+	}
+
+	if _, err := repo.MarkNotificationRead(ctx, created[1].ID, repotest.Colleague); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("MarkNotificationRead of another employee's notification: error = %v, want %v", err, domain.ErrNotFound)
+	}
+
+	unread, err := repo.ListNotifications(ctx, repotest.Owner, true, 10, 0)
+	if err != nil {
+		t.Fatalf("ListNotifications: %v", err)
+	}
+	if len(unread) != 1 || unread[0].ID != created[1].ID {
+		t.Errorf("unread notifications = %+v, want only %s", unread, created[1].ID)
+	}
+
+	all, err := repo.ListNotifications(ctx, repotest.Owner, false, 10, 0)
+	if err != nil {
+		t.Fatalf("ListNotifications: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("notifications = %+v, want both", all)
+	}
+
+	if _, err := repo.ListNotifications(ctx, repotest.Unknown, false, 10, 0); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("ListNotifications of an unknown employee: error = %v, want %v", err, domain.ErrUserNotFound)
+	}
+}
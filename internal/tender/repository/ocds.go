@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.OCDSRepository = (*OCDSService)(nil)
+)
+
+// ocdsPublishedSQL holds for the tenders aliased as t that are published in
+// OCDS: public ones that left the draft state.
+const ocdsPublishedSQL = `t.visibility = 'PUBLIC' AND t.status IN ('PUBLISHED', 'CLOSED')`
+
+const tenderVersionColumns = `id, tender_id, version, COALESCE(name, ''), COALESCE(description, ''), COALESCE(service_type, ''),
+	COALESCE(status, ''), organization_id, created_by_user, budget, COALESCE(lots, '[]'), tags, custom_fields, created_at`
+
+type OCDSService struct {
+	pool *pgxpool.Pool
+}
+
+func NewOCDSService(pool *pgxpool.Pool) *OCDSService {
+	return &OCDSService{pool: pool}
+}
+
+func (r *OCDSService) GetOCDSTender(ctx context.Context, tenderID string) (domain.OCDSTender, error) {
+	tender, err := scanTender(r.pool.QueryRow(ctx, `SELECT `+tenderColumns+` FROM tender t WHERE t.id = $1 AND `+ocdsPublishedSQL, tenderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.OCDSTender{}, fmt.Errorf("repository.GetOCDSTender: %w", domain.ErrTenderNotFound)
+		}
+		return domain.OCDSTender{}, fmt.Errorf("repository.GetOCDSTender: %w", err)
+	}
+
+	data, err := r.loadOCDSTender(ctx, tender)
+	if err != nil {
+		return domain.OCDSTender{}, fmt.Errorf("repository.GetOCDSTender: %w", err)
+	}
+
+	return data, nil
+}
+
+// ListOCDSTenders pages through the published tenders in the order they were
+// created. A tender counts as changed when it was created, got a version or
+// an award decision.
+func (r *OCDSService) ListOCDSTenders(ctx context.Context, filter domain.OCDSFilter) ([]domain.OCDSTender, error) {
+	query := `SELECT ` + tenderColumns + ` FROM tender t WHERE ` + ocdsPublishedSQL
+	args := []any{filter.Limit, filter.Offset}
+
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		query += ` AND (t.created_at >= $3
+			OR EXISTS (SELECT 1 FROM tender_versions v WHERE v.tender_id = t.id AND v.created_at >= $3)
+			OR EXISTS (SELECT 1 FROM award aw WHERE aw.tender_id = t.id AND aw.decided_at >= $3))`
+	}
+
+	rows, err := r.pool.Query(ctx, query+` ORDER BY t.created_at, t.id LIMIT $1 OFFSET $2`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListOCDSTenders: %w", err)
+	}
+	defer rows.Close()
+
+	tenders := []domain.Tender{}
+	for rows.Next() {
+		tender, err := scanTender(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListOCDSTenders: %w", err)
+		}
+		tenders = append(tenders, tender)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListOCDSTenders: %w", err)
+	}
+
+	result := make([]domain.OCDSTender, 0, len(tenders))
+	for _, tender := range tenders {
+		data, err := r.loadOCDSTender(ctx, tender)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListOCDSTenders: %w", err)
+		}
+		result = append(result, data)
+	}
+
+	return result, nil
+}
+
+// loadOCDSTender gathers the versions, the parties and the award decisions of
+// the tender. Bids are only disclosed once the tender is closed.
+func (r *OCDSService) loadOCDSTender(ctx context.Context, tender domain.Tender) (domain.OCDSTender, error) {
+	var err error
+	data := domain.OCDSTender{Tender: tender, Bids: []domain.Bid{}}
+
+	data.Tender.Lots, err = listLots(ctx, r.pool, tender.ID)
+	if err != nil {
+		return domain.OCDSTender{}, err
+	}
+
+	err = r.pool.QueryRow(ctx, `
+		SELECT t.status_changed_at, COALESCE(s.scheme, $2)
+		FROM tender t
+		LEFT JOIN service_types s ON s.code = t.service_type
+		WHERE t.id = $1
+	`, tender.ID, domain.ServiceTypeSchemeCustom).Scan(&data.StatusChangedAt, &data.ServiceTypeScheme)
+	if err != nil {
+		return domain.OCDSTender{}, err
+	}
+
+	data.Versions, err = listTenderVersions(ctx, r.pool, tender.ID)
+	if err != nil {
+		return domain.OCDSTender{}, err
+	}
+
+	if tender.Status == "CLOSED" {
+		rows, err := r.pool.Query(ctx, `SELECT `+bidColumns+` FROM bid b WHERE b.tender_id = $1 ORDER BY b.created_at, b.id`, tender.ID)
+		if err != nil {
+			return domain.OCDSTender{}, err
+		}
+
+		data.Bids, err = scanBids(rows)
+		if err != nil {
+			return domain.OCDSTender{}, err
+		}
+	}
+
+	data.Awards, err = listAwards(ctx, r.pool, tender.ID)
+	if err != nil {
+		return domain.OCDSTender{}, err
+	}
+
+	organizationIDs := []string{tender.OrganizationId}
+	for _, bid := range data.Bids {
+		organizationIDs = append(organizationIDs, bid.OrganizationId)
+	}
+	for _, award := range data.Awards {
+		organizationIDs = append(organizationIDs, award.OrganizationID)
+	}
+
+	data.Organizations, err = listOrganizations(ctx, r.pool, organizationIDs)
+	if err != nil {
+		return domain.OCDSTender{}, err
+	}
+
+	return data, nil
+}
+
+// listTenderVersions returns the versions of the tender, oldest first.
+func listTenderVersions(ctx context.Context, q querier, tenderID string) ([]domain.TenderVersion, error) {
+	rows, err := q.Query(ctx, `
+		SELECT `+tenderVersionColumns+`
+		FROM tender_versions
+		WHERE tender_id = $1
+		ORDER BY version, id
+	`, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []domain.TenderVersion{}
+	for rows.Next() {
+		version, err := scanTenderVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, rows.Err()
+}
+
+func scanTenderVersion(row rowScanner) (domain.TenderVersion, error) {
+	var version domain.TenderVersion
+	err := row.Scan(
+		&version.ID,
+		&version.TenderID,
+		&version.Version,
+		&version.Name,
+		&version.Description,
+		&version.ServiceType,
+		&version.Status,
+		&version.OrganizationId,
+		&version.CreatorUsername,
+		&version.Budget,
+		&version.Lots,
+		&version.Tags,
+		&version.CustomFields,
+		&version.CreatedAt,
+	)
+
+	return version, err
+}
+
+func listOrganizations(ctx context.Context, q querier, organizationIDs []string) (map[string]domain.Organization, error) {
+	rows, err := q.Query(ctx, `
+		SELECT id, name, COALESCE(description, ''), type::text
+		FROM organization
+		WHERE id::text = ANY($1)
+	`, organizationIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := map[string]domain.Organization{}
+	for rows.Next() {
+		var organization domain.Organization
+		if err := rows.Scan(&organization.ID, &organization.Name, &organization.Description, &organization.Type); err != nil {
+			return nil, err
+		}
+		organizations[organization.ID] = organization
+	}
+
+	return organizations, rows.Err()
+}
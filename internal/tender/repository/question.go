@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.QuestionRepository = (*QuestionService)(nil)
+)
+
+const questionColumns = `id, tender_id, author_username, question, answer, answered_by, answered_at, visibility, created_at`
+
+type QuestionService struct {
+	pool *pgxpool.Pool
+}
+
+func NewQuestionService(pool *pgxpool.Pool) *QuestionService {
+	return &QuestionService{pool: pool}
+}
+
+func scanQuestion(row rowScanner) (domain.Question, error) {
+	var question domain.Question
+	err := row.Scan(
+		&question.ID,
+		&question.TenderID,
+		&question.AuthorUsername,
+		&question.Text,
+		&question.Answer,
+		&question.AnsweredBy,
+		&question.AnsweredAt,
+		&question.Visibility,
+		&question.CreatedAt,
+	)
+
+	return question, err
+}
+
+// tenderOwnership returns status and organization of the tender, locking the
+// row against concurrent status changes when q is a transaction.
+func tenderOwnership(ctx context.Context, q querier, tenderID string, lock bool) (string, string, error) {
+	query := `SELECT status, organization_id FROM tender WHERE id = $1`
+	if lock {
+		query += ` FOR SHARE`
+	}
+
+	var status, organizationID string
+	err := q.QueryRow(ctx, query, tenderID).Scan(&status, &organizationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", domain.ErrTenderNotFound
+		}
+		return "", "", err
+	}
+
+	return status, organizationID, nil
+}
+
+func (r *QuestionService) CreateQuestion(ctx context.Context, tenderID string, text string, username string) (domain.Question, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return domain.Question{}, fmt.Errorf("repository.CreateQuestion: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Question{}, fmt.Errorf("repository.CreateQuestion: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	status, _, err := tenderOwnership(ctx, tx, tenderID, true)
+	if err != nil {
+		return domain.Question{}, fmt.Errorf("repository.CreateQuestion: %w", err)
+	}
+
+	if err := checkTenderVisible(ctx, tx, tenderID, username); err != nil {
+		return domain.Question{}, fmt.Errorf("repository.CreateQuestion: %w", err)
+	}
+
+	switch status {
+	case "PUBLISHED":
+	case "CLOSED":
+		return domain.Question{}, fmt.Errorf("repository.CreateQuestion: %w", domain.ErrTenderClosed)
+	default:
+		return domain.Question{}, fmt.Errorf("repository.CreateQuestion: %w", domain.ErrTenderNotOpen)
+	}
+
+	question, err := scanQuestion(tx.QueryRow(ctx, `
+		INSERT INTO tender_question (tender_id, author_username, question)
+		VALUES ($1, $2, $3)
+		RETURNING `+questionColumns,
+		tenderID, username, text,
+	))
+	if err != nil {
+		return domain.Question{}, fmt.Errorf("repository.CreateQuestion: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Question{}, fmt.Errorf("repository.CreateQuestion: %w", err)
+	}
+
+	return question, nil
+}
+
+func (r *QuestionService) ListQuestions(ctx context.Context, tenderID string, username string) ([]domain.Question, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return nil, fmt.Errorf("repository.ListQuestions: %w", err)
+	}
+
+	_, organizationID, err := tenderOwnership(ctx, r.pool, tenderID, false)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListQuestions: %w", err)
+	}
+
+	if err := checkTenderVisible(ctx, r.pool, tenderID, username); err != nil {
+		return nil, fmt.Errorf("repository.ListQuestions: %w", err)
+	}
+
+	owner, err := isResponsible(ctx, r.pool, username, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListQuestions: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+questionColumns+`
+		FROM tender_question
+		WHERE tender_id = $1
+			AND ($2 OR author_username = $3 OR (answer IS NOT NULL AND visibility = 'PUBLIC'))
+		ORDER BY created_at
+	`, tenderID, owner, username)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListQuestions: %w", err)
+	}
+	defer rows.Close()
+
+	questions := []domain.Question{}
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListQuestions: %w", err)
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListQuestions: %w", err)
+	}
+
+	return questions, nil
+}
+
+func (r *QuestionService) AnswerQuestion(ctx context.Context, tenderID string, questionID string, answer string,
+	visibility domain.QuestionVisibility, username string) (domain.Question, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Question{}, fmt.Errorf("repository.AnswerQuestion: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	status, organizationID, err := tenderOwnership(ctx, tx, tenderID, true)
+	if err != nil {
+		return domain.Question{}, fmt.Errorf("repository.AnswerQuestion: %w", err)
+	}
+
+	if err := checkResponsible(ctx, tx, username, organizationID); err != nil {
+		return domain.Question{}, fmt.Errorf("repository.AnswerQuestion: %w", err)
+	}
+
+	if status == "CLOSED" {
+		return domain.Question{}, fmt.Errorf("repository.AnswerQuestion: %w", domain.ErrTenderClosed)
+	}
+
+	question, err := scanQuestion(tx.QueryRow(ctx, `
+		UPDATE tender_question
+		SET answer = $1, visibility = $2, answered_by = $3, answered_at = NOW()
+		WHERE id = $4 AND tender_id = $5
+		RETURNING `+questionColumns,
+		answer, visibility, username, questionID, tenderID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Question{}, fmt.Errorf("repository.AnswerQuestion: %w", domain.ErrNotFound)
+		}
+		return domain.Question{}, fmt.Errorf("repository.AnswerQuestion: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Question{}, fmt.Errorf("repository.AnswerQuestion: %w", err)
+	}
+
+	return question, nil
+}
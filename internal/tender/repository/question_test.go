@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+func TestQuestionAnswerVisibility(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	tenders := repository.NewTenderService(pool)
+	questions := repository.NewQuestionService(pool)
+
+	// reader is neither responsible for the tender nor the author.
+	const reader = "question_reader"
+	if _, err := pool.Exec(ctx, `INSERT INTO employee (username) VALUES ($1)`, reader); err != nil {
+		t.Fatalf("inserting employee: %v", err)
+	}
+
+	tender, err := tenders.CreateTender(ctx, newTender("Вопросы", 1000, domain.TenderVisibilityPublic))
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+
+	ask := func(text string) domain.Question {
+		t.Helper()
+
+		question, err := questions.CreateQuestion(ctx, tender.ID, text, repotest.Outsider)
+		if err != nil {
+			t.Fatalf("CreateQuestion: %v", err)
+		}
+		return question
+	}
+	public, private, open := ask("Публичный"), ask("Частный"), ask("Без ответа")
+
+	if _, err := questions.AnswerQuestion(ctx, tender.ID, public.ID, "Да", domain.QuestionVisibilityPublic, repotest.Owner); err != nil {
+		t.Fatalf("AnswerQuestion: %v", err)
+	}
+	if _, err := questions.AnswerQuestion(ctx, tender.ID, private.ID, "Нет", domain.QuestionVisibilityPrivate, repotest.Colleague); err != nil {
+		t.Fatalf("AnswerQuestion: %v", err)
+	}
+
+	_, err = questions.AnswerQuestion(ctx, tender.ID, open.ID, "Сам", domain.QuestionVisibilityPublic, repotest.Outsider)
+	if !errors.Is(err, domain.ErrUserNotAuthorized) {
+		t.Errorf("AnswerQuestion by the bidder: error = %v, want %v", err, domain.ErrUserNotAuthorized)
+	}
+
+	for _, c := range []struct {
+		username string
+		want     []string
+	}{
+		{repotest.Owner, []string{public.ID, private.ID, open.ID}},
+		{repotest.Outsider, []string{public.ID, private.ID, open.ID}},
+		{reader, []string{public.ID}},
+	} {
+		listed, err := questions.ListQuestions(ctx, tender.ID, c.username)
+		if err != nil {
+			t.Fatalf("ListQuestions(%s): %v", c.username, err)
+		}
+
+		got := []string{}
+		for _, question := range listed {
+			got = append(got, question.ID)
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("questions %s sees = %v, want %v", c.username, got, c.want)
+		}
+	}
+}
+
+func TestQuestionsLockedWhenClosed(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	tenders := repository.NewTenderService(pool)
+	questions := repository.NewQuestionService(pool)
+
+	tender, err := tenders.CreateTender(ctx, newTender("Закрытие", 1000, domain.TenderVisibilityPublic))
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+
+	question, err := questions.CreateQuestion(ctx, tender.ID, "Сроки?", repotest.Outsider)
+	if err != nil {
+		t.Fatalf("CreateQuestion: %v", err)
+	}
+
+	if _, _, err := tenders.UpdateTenderStatus(ctx, tender.ID, "CLOSED", []string{"CREATED", "OPEN", "PUBLISHED"}, repotest.Owner); err != nil {
+		t.Fatalf("UpdateTenderStatus: %v", err)
+	}
+
+	if _, err := questions.CreateQuestion(ctx, tender.ID, "Ещё вопрос", repotest.Outsider); !errors.Is(err, domain.ErrTenderClosed) {
+		t.Errorf("CreateQuestion on a closed tender: error = %v, want %v", err, domain.ErrTenderClosed)
+	}
+
+	_, err = questions.AnswerQuestion(ctx, tender.ID, question.ID, "Месяц", domain.QuestionVisibilityPublic, repotest.Owner)
+	if !errors.Is(err, domain.ErrTenderClosed) {
+		t.Errorf("AnswerQuestion on a closed tender: error = %v, want %v", err, domain.ErrTenderClosed)
+	}
+
+	listed, err := questions.ListQuestions(ctx, tender.ID, repotest.Outsider)
+	if err != nil || len(listed) != 1 || listed[0].Answer != nil {
+		t.Errorf("ListQuestions on a closed tender = %+v, %v, want the unanswered question", listed, err)
+	}
+}
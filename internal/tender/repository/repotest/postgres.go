@@ -0,0 +1,164 @@
+package repotest
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Te8va/Tender/internal/pkg/pgtest"
+	"github.com/Te8va/Tender/internal/tender/repository"
+)
+
+// schema creates the tables of the platform the migrations build on.
+//
+//go:embed schema.sql
+var schema string
+
+// template is the database the migrations and the fixtures are applied to
+// once. Every test gets a copy of it. The names carry the process ID, so
+// that test binaries sharing the server of TENDER_TEST_DSN keep apart.
+var template = fmt.Sprintf("tender_template_%d", os.Getpid())
+
+var (
+	startOnce sync.Once
+	server    *pgtest.Server
+	startErr  error
+	databases atomic.Int64
+)
+
+// Postgres returns a pool to a database of its own, migrated and seeded
+// with the fixtures of this package. The server is started on the first call
+// and runs until StopPostgres, or the one TENDER_TEST_DSN points to is used.
+// The test is skipped when Postgres is not installed or can not be run.
+func Postgres(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	startOnce.Do(func() { server, startErr = startPostgres() })
+	if errors.Is(startErr, pgtest.ErrNotInstalled) || errors.Is(startErr, pgtest.ErrRoot) {
+		t.Skip(startErr)
+	}
+	if startErr != nil {
+		t.Fatal(startErr)
+	}
+
+	name := fmt.Sprintf("tender_test_%d_%d", os.Getpid(), databases.Add(1))
+	if err := server.CreateDatabase(context.Background(), name, template); err != nil {
+		t.Fatal(err)
+	}
+
+	pool, err := repository.GetPgxPool(server.DSN(name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	return pool
+}
+
+// StopPostgres stops the server Postgres started, if any, or drops the
+// databases it created on the server of TENDER_TEST_DSN. It is meant for
+// TestMain, after the tests have run.
+func StopPostgres() error {
+	if server == nil {
+		return nil
+	}
+
+	return server.Stop()
+}
+
+func startPostgres() (*pgtest.Server, error) {
+	var (
+		s   *pgtest.Server
+		err error
+	)
+	if dsn := os.Getenv("TENDER_TEST_DSN"); dsn != "" {
+		s, err = pgtest.Connect(dsn)
+	} else {
+		s, err = pgtest.Start()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := prepareTemplate(s); err != nil {
+		s.Stop()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func prepareTemplate(s *pgtest.Server) error {
+	ctx := context.Background()
+
+	if err := s.CreateDatabase(ctx, template, ""); err != nil {
+		return err
+	}
+
+	conn, err := pgx.Connect(ctx, s.DSN(template))
+	if err != nil {
+		return fmt.Errorf("repotest: %w", err)
+	}
+	// No session may be left on the template when it is copied.
+	defer conn.Close(ctx)
+
+	if _, err := conn.Exec(ctx, schema); err != nil {
+		return fmt.Errorf("repotest: creating the platform tables: %w", err)
+	}
+
+	m, err := migrate.New("file://"+filepath.ToSlash(migrationsDir()), s.DSN(template))
+	if err != nil {
+		return fmt.Errorf("repotest: %w", err)
+	}
+	if err := repository.ApplyMigrations(m); err != nil {
+		return err
+	}
+
+	return seed(ctx, conn)
+}
+
+// seed inserts the employees and organizations of the fixtures.
+func seed(ctx context.Context, conn *pgx.Conn) error {
+	for _, employee := range Employees {
+		_, err := conn.Exec(ctx, `INSERT INTO employee (id, username, first_name, last_name) VALUES ($1, $2, $3, $4)`,
+			employee.ID, employee.Username, employee.FirstName, employee.LastName)
+		if err != nil {
+			return fmt.Errorf("repotest: seeding employee %s: %w", employee.Username, err)
+		}
+	}
+
+	for _, organization := range Organizations {
+		_, err := conn.Exec(ctx, `INSERT INTO organization (id, name, description, type) VALUES ($1, $2, $3, $4)`,
+			organization.ID, organization.Name, organization.Description, string(organization.Type))
+		if err != nil {
+			return fmt.Errorf("repotest: seeding organization %s: %w", organization.Name, err)
+		}
+	}
+
+	for _, responsible := range Responsible {
+		_, err := conn.Exec(ctx, `INSERT INTO organization_responsible (organization_id, user_id) VALUES ($1, $2)`,
+			responsible.OrganizationID, responsible.UserID)
+		if err != nil {
+			return fmt.Errorf("repotest: seeding responsible employee: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// migrationsDir is the migrations directory at the root of the module.
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "..", "migrations")
+}
@@ -0,0 +1,697 @@
+// Package repotest holds the contract every domain.TenderRepository has to
+// meet. The implementations run it from their own tests against a
+// repository seeded with the fixtures of this package.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// The fixtures: Owner and Colleague are responsible for Organization,
+// Outsider for OtherOrganization. Unknown is not an employee.
+const (
+	Owner     = "contract_owner"
+	Colleague = "contract_colleague"
+	Outsider  = "contract_outsider"
+	Unknown   = "contract_unknown"
+
+	Organization      = "2f0e9c4a-7d3b-4e51-a8c6-0b1d2e3f4a01"
+	OtherOrganization = "2f0e9c4a-7d3b-4e51-a8c6-0b1d2e3f4a02"
+
+	// MissingTender is a well formed ID no tender has.
+	MissingTender = "2f0e9c4a-7d3b-4e51-a8c6-0b1d2e3f4aff"
+)
+
+var Employees = []domain.Employee{
+	{ID: "2f0e9c4a-7d3b-4e51-a8c6-0b1d2e3f4b01", Username: Owner, FirstName: "Olga", LastName: "Owner"},
+	{ID: "2f0e9c4a-7d3b-4e51-a8c6-0b1d2e3f4b02", Username: Colleague, FirstName: "Kirill", LastName: "Colleague"},
+	{ID: "2f0e9c4a-7d3b-4e51-a8c6-0b1d2e3f4b03", Username: Outsider, FirstName: "Oleg", LastName: "Outsider"},
+}
+
+var Organizations = []domain.Organization{
+	{ID: Organization, Name: "Contract LLC", Description: "Owns the tenders", Type: domain.OrganizationTypeLLC},
+	{ID: OtherOrganization, Name: "Other JSC", Description: "Bids on them", Type: domain.OrganizationTypeJSC},
+}
+
+var Responsible = []domain.OrganizationResponsible{
+	{OrganizationID: Organization, UserID: Employees[0].ID},
+	{OrganizationID: Organization, UserID: Employees[1].ID},
+	{OrganizationID: OtherOrganization, UserID: Employees[2].ID},
+}
+
+// Suite runs the contract against the repositories New returns. Each case
+// gets a repository of its own, seeded with the fixtures and no tenders.
+type Suite struct {
+	New func(t *testing.T) domain.TenderRepository
+}
+
+func (s Suite) Run(t *testing.T) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, repo domain.TenderRepository)
+	}{
+		{"Ping", testPing},
+		{"CreateTender", testCreateTender},
+		{"GetTender", testGetTender},
+		{"ListTender", testListTender},
+		{"GetUserTenders", testGetUserTenders},
+		{"GetTenderStatus", testGetTenderStatus},
+		{"UpdateTenderStatus", testUpdateTenderStatus},
+		{"UpdateTenderStatuses", testUpdateTenderStatuses},
+		{"UpdatePartTender", testUpdatePartTender},
+		{"RollbackTenderVersion", testRollbackTenderVersion},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, s.New(t))
+		})
+	}
+}
+
+func newTender(name string) domain.Tender {
+	return domain.Tender{
+		Name:            name,
+		Description:     "Описание " + name,
+		Status:          "CREATED",
+		ServiceType:     "Delivery",
+		OrganizationId:  Organization,
+		CreatorUsername: Owner,
+		Version:         1,
+		Type:            domain.TenderTypeStandard,
+		Visibility:      domain.TenderVisibilityPublic,
+	}
+}
+
+func create(t *testing.T, repo domain.TenderRepository, tender domain.Tender) domain.Tender {
+	t.Helper()
+
+	created, err := repo.CreateTender(context.Background(), tender)
+	if err != nil {
+		t.Fatalf("CreateTender(%q): %v", tender.Name, err)
+	}
+
+	return created
+}
+
+func wantErrorIs(t *testing.T, call string, err, want error) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Errorf("%s: error = %v, want %v", call, err, want)
+	}
+}
+
+func ids(tenders []domain.Tender) []string {
+	ids := make([]string, len(tenders))
+	for i, tender := range tenders {
+		ids[i] = tender.ID
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+func sorted(ids ...string) []string {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+
+	return ids
+}
+
+func testPing(t *testing.T, repo domain.TenderRepository) {
+	pinger, ok := repo.(domain.TenderRepositoryPingProvider)
+	if !ok {
+		t.Skip("the repository can not be pinged")
+	}
+
+	if err := pinger.Ping(context.Background()); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+}
+
+func testCreateTender(t *testing.T, repo domain.TenderRepository) {
+	ctx := context.Background()
+
+	budget := 1000.0
+	tender := newTender("Поставка серверов")
+	tender.Budget = &budget
+	tender.Lots = []domain.Lot{{Name: "Стойка", Quantity: 2, Unit: "шт"}, {Name: "Монтаж", Quantity: 1, Unit: "усл"}}
+	tender.Criteria = []domain.Criterion{{Name: "Цена", Weight: 0.7}, {Name: "Срок", Weight: 0.3}}
+
+	created := create(t, repo, tender)
+	if created.ID == "" || created.Version != 1 || created.Status != "CREATED" || created.CreatedAt.IsZero() {
+		t.Errorf("created tender = %+v", created)
+	}
+	if created.Budget == nil || *created.Budget != budget {
+		t.Errorf("budget = %v, want %v", created.Budget, budget)
+	}
+	if created.Tags == nil || len(created.Tags) != 0 || created.CustomFields == nil || len(created.CustomFields) != 0 {
+		t.Errorf("tags = %#v, custom fields = %#v, want empty ones", created.Tags, created.CustomFields)
+	}
+	if len(created.Lots) != 2 || created.Lots[0].ID == "" || created.Lots[0].Name != "Стойка" || created.Lots[1].Name != "Монтаж" {
+		t.Errorf("lots = %+v", created.Lots)
+	}
+	if len(created.Criteria) != 2 || created.Criteria[0].ID == "" || created.Criteria[1].Name != "Срок" {
+		t.Errorf("criteria = %+v", created.Criteria)
+	}
+
+	if again := create(t, repo, newTender("Поставка серверов")); again.ID == created.ID {
+		t.Errorf("tenders share the ID %s", created.ID)
+	}
+
+	unknown := newTender("Уборка")
+	unknown.CreatorUsername = Unknown
+	_, err := repo.CreateTender(ctx, unknown)
+	wantErrorIs(t, "CreateTender by an unknown employee", err, domain.ErrUserNotFound)
+
+	foreign := newTender("Уборка")
+	foreign.CreatorUsername = Outsider
+	_, err = repo.CreateTender(ctx, foreign)
+	wantErrorIs(t, "CreateTender for another organization", err, domain.ErrUserNotAuthorized)
+}
+
+func testGetTender(t *testing.T, repo domain.TenderRepository) {
+	ctx := context.Background()
+
+	public := newTender("Открытый")
+	public.Lots = []domain.Lot{{Name: "Лот", Quantity: 1, Unit: "шт"}}
+	public = create(t, repo, public)
+
+	private := newTender("Закрытый")
+	private.Visibility = domain.TenderVisibilityPrivate
+	private = create(t, repo, private)
+
+	got, err := repo.GetTender(ctx, public.ID, Outsider)
+	if err != nil {
+		t.Fatalf("GetTender: %v", err)
+	}
+	if got.ID != public.ID || got.Name != public.Name || got.OrganizationId != Organization || got.CreatorUsername != Owner {
+		t.Errorf("tender = %+v", got)
+	}
+	if len(got.Lots) != 1 || got.Lots[0].ID != public.Lots[0].ID {
+		t.Errorf("lots = %+v, want %+v", got.Lots, public.Lots)
+	}
+
+	if got, err := repo.GetTender(ctx, private.ID, Colleague); err != nil || got.ID != private.ID {
+		t.Errorf("private tender of the own organization = %+v, %v", got, err)
+	}
+
+	_, err = repo.GetTender(ctx, private.ID, Outsider)
+	wantErrorIs(t, "GetTender of a private tender", err, domain.ErrTenderNotFound)
+
+	_, err = repo.GetTender(ctx, MissingTender, Owner)
+	wantErrorIs(t, "GetTender of a missing tender", err, domain.ErrTenderNotFound)
+
+	_, err = repo.GetTender(ctx, public.ID, Unknown)
+	wantErrorIs(t, "GetTender by an unknown employee", err, domain.ErrUserNotFound)
+}
+
+func testListTender(t *testing.T, repo domain.TenderRepository) {
+	ctx := context.Background()
+
+	servers := newTender("Серверы")
+	servers.Tags = []string{"it", "hardware"}
+	servers.CustomFields = map[string]any{"region": "Москва", "floor": 3.0, "deadline": "2026-03-01"}
+	servers = create(t, repo, servers)
+
+	office := newTender("Ремонт офиса")
+	office.ServiceType = "Construction"
+	office.Description = "Покраска стен и замена окон"
+	office.Tags = []string{"office"}
+	office.CustomFields = map[string]any{"region": "Казань"}
+	office = create(t, repo, office)
+
+	private := newTender("Охрана")
+	private.Visibility = domain.TenderVisibilityPrivate
+	private.Tags = []string{"it"}
+	private = create(t, repo, private)
+
+	tests := []struct {
+		name   string
+		filter domain.TenderListFilter
+		want   []string
+	}{
+		{"owner", domain.TenderListFilter{Limit: 10, Username: Owner}, sorted(servers.ID, office.ID, private.ID)},
+		{"outsider", domain.TenderListFilter{Limit: 10, Username: Outsider}, sorted(servers.ID, office.ID)},
+		{"unknown employee", domain.TenderListFilter{Limit: 10, Username: Unknown}, sorted(servers.ID, office.ID)},
+		{"service type", domain.TenderListFilter{Limit: 10, Username: Owner, ServiceTypes: []string{"Construction"}}, sorted(office.ID)},
+		{"tag", domain.TenderListFilter{Limit: 10, Username: Owner, Tags: []string{"it"}}, sorted(servers.ID, private.ID)},
+		{"all tags", domain.TenderListFilter{Limit: 10, Username: Owner, Tags: []string{"it", "hardware"}}, sorted(servers.ID)},
+		{"custom field", domain.TenderListFilter{Limit: 10, Username: Owner, CustomFields: map[string]string{"region": "Казань"}}, sorted(office.ID)},
+		{"number custom field", domain.TenderListFilter{Limit: 10, Username: Owner, CustomFields: map[string]string{"floor": "3"}}, sorted(servers.ID)},
+		{"missing custom field", domain.TenderListFilter{Limit: 10, Username: Owner, CustomFields: map[string]string{"floor": ""}}, []string{}},
+		{"date custom field", domain.TenderListFilter{Limit: 10, Username: Owner, CustomFields: map[string]string{"deadline": "2026-03-01"}}, sorted(servers.ID)},
+		// Values compare in the text form jsonb gives them, 3.0 is stored as 3.
+		{"number in another form", domain.TenderListFilter{Limit: 10, Username: Owner, CustomFields: map[string]string{"floor": "3.0"}}, []string{}},
+		{"all custom fields", domain.TenderListFilter{Limit: 10, Username: Owner, CustomFields: map[string]string{"region": "Москва", "floor": "4"}}, []string{}},
+		{"unknown tag", domain.TenderListFilter{Limit: 10, Username: Owner, Tags: []string{"it", "absent"}}, []string{}},
+		{"tag and custom field", domain.TenderListFilter{Limit: 10, Username: Owner, Tags: []string{"it"}, CustomFields: map[string]string{"region": "Москва"}}, sorted(servers.ID)},
+		{"tag and service type", domain.TenderListFilter{Limit: 10, Username: Owner, ServiceTypes: []string{"Construction"}, Tags: []string{"it"}}, []string{}},
+		{"tag of a private tender", domain.TenderListFilter{Limit: 10, Username: Outsider, Tags: []string{"it"}}, sorted(servers.ID)},
+		{"text", domain.TenderListFilter{Limit: 10, Username: Owner, Text: "СЕРВЕР"}, sorted(servers.ID)},
+		{"text in the description", domain.TenderListFilter{Limit: 10, Username: Owner, Text: "окон"}, sorted(office.ID)},
+		{"text and tag", domain.TenderListFilter{Limit: 10, Username: Owner, Text: "описание", Tags: []string{"it"}}, sorted(servers.ID, private.ID)},
+		{"text of a private tender", domain.TenderListFilter{Limit: 10, Username: Outsider, Text: "охрана"}, []string{}},
+		{"zero limit", domain.TenderListFilter{Username: Owner}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenders, err := repo.ListTender(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("ListTender: %v", err)
+			}
+			if got := ids(tenders); !slices.Equal(got, tt.want) {
+				t.Errorf("tenders = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	seen := map[string]bool{}
+	for offset := 0; offset < 3; offset += 2 {
+		tenders, err := repo.ListTender(ctx, domain.TenderListFilter{Limit: 2, Offset: offset, Username: Owner})
+		if err != nil {
+			t.Fatalf("ListTender: %v", err)
+		}
+		if want := min(2, 3-offset); len(tenders) != want {
+			t.Errorf("page at %d has %d tenders, want %d", offset, len(tenders), want)
+		}
+		for _, tender := range tenders {
+			seen[tender.ID] = true
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("pages hold %d distinct tenders, want 3", len(seen))
+	}
+}
+
+func testGetUserTenders(t *testing.T, repo domain.TenderRepository) {
+	ctx := context.Background()
+
+	mine := []string{
+		create(t, repo, newTender("Первый")).ID,
+		create(t, repo, newTender("Второй")).ID,
+		create(t, repo, newTender("Третий")).ID,
+	}
+
+	colleagues := newTender("Чужой")
+	colleagues.CreatorUsername = Colleague
+	create(t, repo, colleagues)
+
+	tenders, err := repo.GetUserTenders(ctx, 10, 0, Owner)
+	if err != nil {
+		t.Fatalf("GetUserTenders: %v", err)
+	}
+	if got := ids(tenders); !slices.Equal(got, sorted(mine...)) {
+		t.Errorf("tenders = %v, want %v", got, sorted(mine...))
+	}
+	for _, tender := range tenders {
+		if tender.Name == "" || tender.Status != "CREATED" || tender.Version != 1 || tender.CreatedAt.IsZero() {
+			t.Errorf("tender = %+v", tender)
+		}
+	}
+
+	if tenders, err := repo.GetUserTenders(ctx, 2, 2, Owner); err != nil || len(tenders) != 1 {
+		t.Errorf("last page = %d tenders, %v, want 1", len(tenders), err)
+	}
+
+	if tenders, err := repo.GetUserTenders(ctx, 10, 0, Outsider); err != nil || tenders == nil || len(tenders) != 0 {
+		t.Errorf("tenders of an employee without any = %#v, %v, want an empty list", tenders, err)
+	}
+
+	_, err = repo.GetUserTenders(ctx, 10, 0, Unknown)
+	wantErrorIs(t, "GetUserTenders by an unknown employee", err, domain.ErrUserNotFound)
+}
+
+func testGetTenderStatus(t *testing.T, repo domain.TenderRepository) {
+	ctx := context.Background()
+
+	public := create(t, repo, newTender("Открытый"))
+	private := newTender("Закрытый")
+	private.Visibility = domain.TenderVisibilityPrivate
+	private = create(t, repo, private)
+
+	if status, err := repo.GetTenderStatus(ctx, public.ID, Outsider); err != nil || status != "CREATED" {
+		t.Errorf("GetTenderStatus = %q, %v", status, err)
+	}
+
+	_, err := repo.GetTenderStatus(ctx, private.ID, Outsider)
+	wantErrorIs(t, "GetTenderStatus of a private tender", err, domain.ErrTenderNotFound)
+
+	_, err = repo.GetTenderStatus(ctx, MissingTender, Owner)
+	wantErrorIs(t, "GetTenderStatus of a missing tender", err, domain.ErrTenderNotFound)
+
+	_, err = repo.GetTenderStatus(ctx, public.ID, Unknown)
+	wantErrorIs(t, "GetTenderStatus by an unknown employee", err, domain.ErrUserNotFound)
+}
+
+func testUpdateTenderStatus(t *testing.T, repo domain.TenderRepository) {
+	ctx := context.Background()
+	toPublished := []string{"CREATED", "OPEN"}
+	toClosed := []string{"CREATED", "OPEN", "PUBLISHED"}
+
+	tender := create(t, repo, newTender("Охрана"))
+
+	updated, changed, err := repo.UpdateTenderStatus(ctx, tender.ID, "PUBLISHED", toPublished, Owner)
+	if err != nil {
+		t.Fatalf("UpdateTenderStatus: %v", err)
+	}
+	if updated.ID != tender.ID || updated.Status != "PUBLISHED" || updated.Version != 1 || !changed {
+		t.Errorf("updated tender = %+v, %v, status changes do not make a version", updated, changed)
+	}
+
+	if _, changed, err := repo.UpdateTenderStatus(ctx, tender.ID, "PUBLISHED", toPublished, Owner); err != nil || changed {
+		t.Errorf("UpdateTenderStatus to the same status = %v, %v, want unchanged", changed, err)
+	}
+
+	if status, err := repo.GetTenderStatus(ctx, tender.ID, Owner); err != nil || status != "PUBLISHED" {
+		t.Errorf("GetTenderStatus = %q, %v", status, err)
+	}
+
+	_, _, err = repo.UpdateTenderStatus(ctx, MissingTender, "CLOSED", toClosed, Owner)
+	wantErrorIs(t, "UpdateTenderStatus of a missing tender", err, domain.ErrTenderNotFound)
+
+	_, _, err = repo.UpdateTenderStatus(ctx, tender.ID, "ARCHIVED", toClosed, Owner)
+	wantErrorIs(t, "UpdateTenderStatus to an unknown status", err, domain.ErrInvalidInput)
+
+	_, _, err = repo.UpdateTenderStatus(ctx, tender.ID, "CLOSED", toClosed, Unknown)
+	wantErrorIs(t, "UpdateTenderStatus by an unknown employee", err, domain.ErrUserNotFound)
+
+	_, _, err = repo.UpdateTenderStatus(ctx, tender.ID, "CLOSED", toClosed, Outsider)
+	wantErrorIs(t, "UpdateTenderStatus by another organization", err, domain.ErrUserNotAuthorized)
+
+	// Private tenders are not disclosed to employees who may not see them.
+	private := newTender("Охрана склада")
+	private.Visibility = domain.TenderVisibilityPrivate
+	private = create(t, repo, private)
+	_, _, err = repo.UpdateTenderStatus(ctx, private.ID, "PUBLISHED", toPublished, Outsider)
+	wantErrorIs(t, "UpdateTenderStatus of a private tender by another organization", err, domain.ErrTenderNotFound)
+	if _, changed, err := repo.UpdateTenderStatus(ctx, private.ID, "PUBLISHED", toPublished, Colleague); err != nil || !changed {
+		t.Errorf("UpdateTenderStatus of a private tender = %v, %v", changed, err)
+	}
+
+	if _, changed, err := repo.UpdateTenderStatus(ctx, tender.ID, "CLOSED", toClosed, Colleague); err != nil || !changed {
+		t.Fatalf("UpdateTenderStatus to CLOSED = %v, %v", changed, err)
+	}
+
+	// Closing is final: the closed tender can not be published again.
+	_, _, err = repo.UpdateTenderStatus(ctx, tender.ID, "PUBLISHED", toPublished, Owner)
+	wantErrorIs(t, "UpdateTenderStatus of a closed tender", err, domain.ErrConflict)
+
+	if status, err := repo.GetTenderStatus(ctx, tender.ID, Owner); err != nil || status != "CLOSED" {
+		t.Errorf("GetTenderStatus after reopening = %q, %v, want CLOSED", status, err)
+	}
+}
+
+func testUpdateTenderStatuses(t *testing.T, repo domain.TenderRepository) {
+	ctx := context.Background()
+	from := []string{"CREATED", "PUBLISHED"}
+
+	first := create(t, repo, newTender("Первый"))
+	second := create(t, repo, newTender("Второй"))
+	closed := create(t, repo, newTender("Закрытый"))
+	if _, _, err := repo.UpdateTenderStatus(ctx, closed.ID, "CLOSED", from, Owner); err != nil {
+		t.Fatalf("UpdateTenderStatus: %v", err)
+	}
+
+	changes, err := repo.UpdateTenderStatuses(ctx, []string{first.ID, MissingTender}, "PUBLISHED", from, Owner, true)
+	if err != nil {
+		t.Fatalf("UpdateTenderStatuses: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Err != nil || changes[0].Applied || !errors.Is(changes[1].Err, domain.ErrTenderNotFound) {
+		t.Errorf("atomic batch with a missing tender = %+v", changes)
+	}
+	if status, _ := repo.GetTenderStatus(ctx, first.ID, Owner); status != "CREATED" {
+		t.Errorf("status after a failed atomic batch = %q, want CREATED", status)
+	}
+
+	changes, err = repo.UpdateTenderStatuses(ctx, []string{first.ID, second.ID}, "PUBLISHED", from, Owner, true)
+	if err != nil {
+		t.Fatalf("UpdateTenderStatuses: %v", err)
+	}
+	for _, change := range changes {
+		if change.Err != nil || !change.Applied || change.PreviousStatus != "CREATED" || change.Status != "PUBLISHED" {
+			t.Errorf("atomic change = %+v", change)
+		}
+	}
+
+	changes, err = repo.UpdateTenderStatuses(ctx, []string{first.ID, closed.ID, MissingTender}, "PUBLISHED", from, Owner, false)
+	if err != nil {
+		t.Fatalf("UpdateTenderStatuses: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("best effort batch = %+v", changes)
+	}
+	if change := changes[0]; change.Err != nil || change.Applied || change.Status != "PUBLISHED" {
+		t.Errorf("change to the current status = %+v", change)
+	}
+	if change := changes[1]; !errors.Is(change.Err, domain.ErrConflict) || change.Applied || change.PreviousStatus != "CLOSED" {
+		t.Errorf("change from a status not allowed = %+v", change)
+	}
+	if change := changes[2]; !errors.Is(change.Err, domain.ErrTenderNotFound) {
+		t.Errorf("change of a missing tender = %+v", change)
+	}
+
+	changes, err = repo.UpdateTenderStatuses(ctx, []string{first.ID, second.ID}, "CLOSED", from, Outsider, false)
+	if err != nil {
+		t.Fatalf("UpdateTenderStatuses: %v", err)
+	}
+	for _, change := range changes {
+		if !errors.Is(change.Err, domain.ErrUserNotAuthorized) || change.Applied {
+			t.Errorf("change by an employee of another organization = %+v", change)
+		}
+	}
+
+	private := newTender("Закрытый")
+	private.Visibility = domain.TenderVisibilityPrivate
+	private = create(t, repo, private)
+	changes, err = repo.UpdateTenderStatuses(ctx, []string{private.ID}, "CLOSED", from, Outsider, false)
+	if err != nil {
+		t.Fatalf("UpdateTenderStatuses: %v", err)
+	}
+	if change := changes[0]; !errors.Is(change.Err, domain.ErrTenderNotFound) || change.Applied || change.PreviousStatus != "" {
+		t.Errorf("change of a private tender by another organization = %+v, want it reported as missing", change)
+	}
+
+	changes, err = repo.UpdateTenderStatuses(ctx, []string{first.ID, closed.ID}, "CLOSED", from, Colleague, false)
+	if err != nil {
+		t.Fatalf("UpdateTenderStatuses: %v", err)
+	}
+	if len(changes) != 2 || !changes[0].Applied || changes[1].Applied || changes[1].Err != nil {
+		t.Errorf("best effort batch = %+v", changes)
+	}
+	if status, _ := repo.GetTenderStatus(ctx, first.ID, Owner); status != "CLOSED" {
+		t.Errorf("status after a best effort batch = %q, want CLOSED", status)
+	}
+
+	_, err = repo.UpdateTenderStatuses(ctx, []string{first.ID}, "CLOSED", from, Unknown, true)
+	wantErrorIs(t, "UpdateTenderStatuses by an unknown employee", err, domain.ErrUserNotFound)
+}
+
+func testUpdatePartTender(t *testing.T, repo domain.TenderRepository) {
+	ctx := context.Background()
+
+	tender := newTender("Серверы")
+	tender.Lots = []domain.Lot{{Name: "Стойка", Quantity: 2, Unit: "шт"}}
+	tender = create(t, repo, tender)
+
+	updated, err := repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{
+		"name":         "Серверы и СХД",
+		"description":  "",
+		"budget":       2500.0,
+		"tags":         []string{"it"},
+		"customFields": map[string]any{"region": "Москва"},
+		"lots": []domain.Lot{
+			{ID: tender.Lots[0].ID, Name: "Стойка 42U", Quantity: 3, Unit: "шт"},
+			{Name: "СХД", Quantity: 1, Unit: "шт"},
+		},
+	}, Owner)
+	if err != nil {
+		t.Fatalf("UpdatePartTender: %v", err)
+	}
+	if updated.Name != "Серверы и СХД" || updated.Description != tender.Description || updated.Version != 2 {
+		t.Errorf("updated tender = %+v", updated)
+	}
+	if updated.Budget == nil || *updated.Budget != 2500 || !slices.Equal(updated.Tags, []string{"it"}) || updated.CustomFields["region"] != "Москва" {
+		t.Errorf("budget = %v, tags = %v, custom fields = %v", updated.Budget, updated.Tags, updated.CustomFields)
+	}
+	if len(updated.Lots) != 2 || updated.Lots[0].ID != tender.Lots[0].ID || updated.Lots[0].Quantity != 3 || updated.Lots[1].ID == "" {
+		t.Errorf("lots = %+v", updated.Lots)
+	}
+
+	got, err := repo.GetTender(ctx, tender.ID, Owner)
+	if err != nil || got.Name != updated.Name || got.Version != 2 || len(got.Lots) != 2 {
+		t.Errorf("stored tender = %+v, %v", got, err)
+	}
+
+	_, err = repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"visibility": "SECRET"}, Owner)
+	wantErrorIs(t, "UpdatePartTender with an unknown visibility", err, domain.ErrInvalidInput)
+
+	_, err = repo.UpdatePartTender(ctx, MissingTender, map[string]interface{}{"name": "Нет"}, Owner)
+	wantErrorIs(t, "UpdatePartTender of a missing tender", err, domain.ErrTenderNotFound)
+
+	_, err = repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"name": "Нет"}, Unknown)
+	wantErrorIs(t, "UpdatePartTender by an unknown employee", err, domain.ErrUserNotFound)
+
+	_, err = repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"name": "Нет"}, Outsider)
+	wantErrorIs(t, "UpdatePartTender by an employee of another organization", err, domain.ErrUserNotAuthorized)
+
+	private := newTender("Закрытый")
+	private.Visibility = domain.TenderVisibilityPrivate
+	private = create(t, repo, private)
+	_, err = repo.UpdatePartTender(ctx, private.ID, map[string]interface{}{"name": "Нет"}, Outsider)
+	wantErrorIs(t, "UpdatePartTender of a private tender by another organization", err, domain.ErrTenderNotFound)
+
+	if got, err := repo.GetTender(ctx, tender.ID, Owner); err != nil || got.Name != updated.Name || got.Version != 2 {
+		t.Errorf("tender after the refused edits = %+v, %v", got, err)
+	}
+
+	edited, err := repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"description": "Для ЦОД"}, Colleague)
+	if err != nil {
+		t.Fatalf("UpdatePartTender by another responsible: %v", err)
+	}
+	if edited.Description != "Для ЦОД" || edited.Version != 3 {
+		t.Errorf("tender edited by another responsible = %+v", edited)
+	}
+
+	closesAt := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Second)
+	sealed := newTender("Запечатанный")
+	sealed.Sealed = true
+	sealed.SealKey = []byte("0123456789abcdef0123456789abcdef")
+	sealed.ClosesAt = &closesAt
+	sealed = create(t, repo, sealed)
+
+	_, err = repo.UpdatePartTender(ctx, sealed.ID, map[string]interface{}{"closesAt": closesAt.Add(-time.Hour).Format(time.RFC3339)}, Owner)
+	wantErrorIs(t, "UpdatePartTender moving the deadline of a sealed tender forward", err, domain.ErrConflict)
+
+	extended, err := repo.UpdatePartTender(ctx, sealed.ID, map[string]interface{}{"closesAt": closesAt.Add(time.Hour).Format(time.RFC3339)}, Owner)
+	if err != nil {
+		t.Fatalf("UpdatePartTender extending the deadline: %v", err)
+	}
+	if extended.ClosesAt == nil || !extended.ClosesAt.Equal(closesAt.Add(time.Hour)) {
+		t.Errorf("deadline = %v, want %v", extended.ClosesAt, closesAt.Add(time.Hour))
+	}
+}
+
+func testRollbackTenderVersion(t *testing.T, repo domain.TenderRepository) {
+	ctx := context.Background()
+
+	budget := 1000.0
+	tender := newTender("Серверы")
+	tender.Budget = &budget
+	tender.Tags = []string{"it"}
+	tender.Lots = []domain.Lot{{Name: "Стойка", Quantity: 2, Unit: "шт"}}
+	tender.Criteria = []domain.Criterion{{Name: "Цена", Weight: 1}}
+	tender = create(t, repo, tender)
+
+	_, err := repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{
+		"name":     "Серверы и СХД",
+		"budget":   2500.0,
+		"tags":     []string{"it", "storage"},
+		"lots":     []domain.Lot{},
+		"criteria": []domain.Criterion{{Name: "Срок", Weight: 1}},
+	}, Owner)
+	if err != nil {
+		t.Fatalf("UpdatePartTender: %v", err)
+	}
+
+	rolledBack, err := repo.RollbackTenderVersion(ctx, tender.ID, 1, Owner)
+	if err != nil {
+		t.Fatalf("RollbackTenderVersion: %v", err)
+	}
+	if rolledBack.Name != tender.Name || rolledBack.Version != 3 || rolledBack.Budget == nil || *rolledBack.Budget != budget {
+		t.Errorf("rolled back tender = %+v", rolledBack)
+	}
+	if !slices.Equal(rolledBack.Tags, tender.Tags) {
+		t.Errorf("tags = %v, want %v", rolledBack.Tags, tender.Tags)
+	}
+	if len(rolledBack.Lots) != 1 || rolledBack.Lots[0].ID != tender.Lots[0].ID || rolledBack.Lots[0].Name != "Стойка" {
+		t.Errorf("lots = %+v, want %+v", rolledBack.Lots, tender.Lots)
+	}
+	if len(rolledBack.Criteria) != 1 || rolledBack.Criteria[0] != tender.Criteria[0] {
+		t.Errorf("criteria = %+v, want %+v", rolledBack.Criteria, tender.Criteria)
+	}
+
+	again, err := repo.RollbackTenderVersion(ctx, tender.ID, 2, Owner)
+	if err != nil {
+		t.Fatalf("RollbackTenderVersion: %v", err)
+	}
+	if again.Name != "Серверы и СХД" || again.Version != 4 || len(again.Lots) != 0 || len(again.Criteria) != 1 || again.Criteria[0].Name != "Срок" {
+		t.Errorf("tender rolled back to the edit = %+v", again)
+	}
+
+	private, err := repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"visibility": "PRIVATE"}, Owner)
+	if err != nil {
+		t.Fatalf("UpdatePartTender: %v", err)
+	}
+	if private.Visibility != domain.TenderVisibilityPrivate {
+		t.Fatalf("visibility = %q, want PRIVATE", private.Visibility)
+	}
+	_, err = repo.RollbackTenderVersion(ctx, tender.ID, 4, Outsider)
+	wantErrorIs(t, "RollbackTenderVersion of a private tender by another organization", err, domain.ErrTenderNotFound)
+
+	restored, err := repo.RollbackTenderVersion(ctx, tender.ID, 4, Owner)
+	if err != nil {
+		t.Fatalf("RollbackTenderVersion: %v", err)
+	}
+	if restored.Visibility != domain.TenderVisibilityPublic {
+		t.Errorf("visibility after rollback = %q, want the PUBLIC of the version", restored.Visibility)
+	}
+	if _, err := repo.GetTender(ctx, tender.ID, Outsider); err != nil {
+		t.Errorf("GetTender of the public tender by an outsider: %v", err)
+	}
+	_, err = repo.RollbackTenderVersion(ctx, tender.ID, 1, Outsider)
+	wantErrorIs(t, "RollbackTenderVersion by an employee of another organization", err, domain.ErrUserNotAuthorized)
+	_, err = repo.RollbackTenderVersion(ctx, MissingTender, 1, Owner)
+	wantErrorIs(t, "RollbackTenderVersion of a missing tender", err, domain.ErrTenderNotFound)
+
+	// The status is not versioned: rolling a closed tender back to a created
+	// version leaves it closed.
+	if _, _, err := repo.UpdateTenderStatus(ctx, tender.ID, "CLOSED", []string{"CREATED"}, Owner); err != nil {
+		t.Fatalf("UpdateTenderStatus: %v", err)
+	}
+	reopened, err := repo.RollbackTenderVersion(ctx, tender.ID, 2, Owner)
+	if err != nil {
+		t.Fatalf("RollbackTenderVersion of a closed tender: %v", err)
+	}
+	if reopened.Status != "CLOSED" {
+		t.Errorf("status after rollback = %s, want CLOSED", reopened.Status)
+	}
+	if status, err := repo.GetTenderStatus(ctx, tender.ID, Owner); err != nil || status != "CLOSED" {
+		t.Errorf("GetTenderStatus after rollback = %q, %v, want CLOSED", status, err)
+	}
+
+	// The bids of a closed tender were evaluated against its lots and
+	// criteria, neither an edit nor a rollback may change them.
+	_, err = repo.RollbackTenderVersion(ctx, tender.ID, 1, Owner)
+	wantErrorIs(t, "RollbackTenderVersion of a closed tender to other lots and criteria", err, domain.ErrTenderClosed)
+
+	reweighted := slices.Clone(reopened.Criteria)
+	reweighted[0].Weight = 2
+	_, err = repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"criteria": reweighted}, Owner)
+	wantErrorIs(t, "UpdatePartTender of the criteria weights of a closed tender", err, domain.ErrTenderClosed)
+
+	_, err = repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"lots": []domain.Lot{{Name: "Стойка", Quantity: 1, Unit: "шт"}}}, Owner)
+	wantErrorIs(t, "UpdatePartTender of the lots of a closed tender", err, domain.ErrTenderClosed)
+
+	renamed, err := repo.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"name": "Серверы 2024", "criteria": reopened.Criteria}, Owner)
+	if err != nil {
+		t.Fatalf("UpdatePartTender of a closed tender with its criteria unchanged: %v", err)
+	}
+	if renamed.Name != "Серверы 2024" || len(renamed.Criteria) != 1 || renamed.Criteria[0] != reopened.Criteria[0] {
+		t.Errorf("closed tender after edit = %+v", renamed)
+	}
+
+	_, err = repo.RollbackTenderVersion(ctx, tender.ID, 9, Owner)
+	wantErrorIs(t, "RollbackTenderVersion to a missing version", err, domain.ErrNotFound)
+
+	_, err = repo.RollbackTenderVersion(ctx, tender.ID, 1, Unknown)
+	wantErrorIs(t, "RollbackTenderVersion by an unknown employee", err, domain.ErrUserNotFound)
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.SavedSearchRepository = (*SavedSearchService)(nil)
+)
+
+const savedSearchColumns = `s.id, s.name, s.username, s.service_types, s.keywords, s.budget_min, s.budget_max,
+	s.organization_id, s.subscribed, COALESCE(s.webhook_url, ''), COALESCE(s.webhook_secret, ''), s.created_at`
+
+type SavedSearchService struct {
+	pool *pgxpool.Pool
+}
+
+func NewSavedSearchService(pool *pgxpool.Pool) *SavedSearchService {
+	return &SavedSearchService{pool: pool}
+}
+
+func scanSavedSearch(row rowScanner, extra ...any) (domain.SavedSearch, error) {
+	var search domain.SavedSearch
+	dest := []any{
+		&search.ID,
+		&search.Name,
+		&search.Username,
+		&search.ServiceTypes,
+		&search.Keywords,
+		&search.BudgetMin,
+		&search.BudgetMax,
+		&search.OrganizationId,
+		&search.Subscribed,
+		&search.WebhookURL,
+		&search.WebhookSecret,
+		&search.CreatedAt,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+
+	return search, err
+}
+
+func (r *SavedSearchService) CreateSavedSearch(ctx context.Context, search domain.SavedSearch) (domain.SavedSearch, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM employee WHERE username = $1)`, search.Username).Scan(&exists)
+	if err != nil {
+		return domain.SavedSearch{}, fmt.Errorf("repository.CreateSavedSearch: %w", err)
+	}
+
+	if !exists {
+		return domain.SavedSearch{}, fmt.Errorf("repository.CreateSavedSearch: %w", domain.ErrUserNotFound)
+	}
+
+	created, err := scanSavedSearch(r.pool.QueryRow(ctx, `
+		INSERT INTO saved_search AS s (name, username, service_types, keywords, budget_min, budget_max,
+			organization_id, subscribed, webhook_url, webhook_secret)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''))
+		RETURNING `+savedSearchColumns,
+		search.Name, search.Username, search.ServiceTypes, search.Keywords, search.BudgetMin, search.BudgetMax,
+		search.OrganizationId, search.Subscribed, search.WebhookURL, search.WebhookSecret,
+	))
+	if err != nil {
+		return domain.SavedSearch{}, fmt.Errorf("repository.CreateSavedSearch: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *SavedSearchService) ListSavedSearches(ctx context.Context, username string) ([]domain.SavedSearch, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+savedSearchColumns+`
+		FROM saved_search s
+		WHERE s.username = $1
+		ORDER BY s.created_at
+	`, username)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListSavedSearches: %w", err)
+	}
+	defer rows.Close()
+
+	searches := []domain.SavedSearch{}
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListSavedSearches: %w", err)
+		}
+		searches = append(searches, search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListSavedSearches: %w", err)
+	}
+
+	return searches, nil
+}
+
+func (r *SavedSearchService) SetSubscription(ctx context.Context, searchID string, subscribed bool, username string) (domain.SavedSearch, error) {
+	search, err := scanSavedSearch(r.pool.QueryRow(ctx, `
+		UPDATE saved_search AS s
+		SET subscribed = $1
+		WHERE s.id = $2 AND s.username = $3
+		RETURNING `+savedSearchColumns,
+		subscribed, searchID, username,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.SavedSearch{}, fmt.Errorf("repository.SetSubscription: %w", domain.ErrNotFound)
+		}
+		return domain.SavedSearch{}, fmt.Errorf("repository.SetSubscription: %w", err)
+	}
+
+	return search, nil
+}
+
+func (r *SavedSearchService) DeleteSavedSearch(ctx context.Context, searchID string, username string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM saved_search WHERE id = $1 AND username = $2`, searchID, username)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteSavedSearch: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository.DeleteSavedSearch: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListMatchingSearches returns subscribed searches whose every criterion is
+// satisfied by the tender. A service type of a search also matches tenders of
+// its subcategories, like the filter of the tender list. All keywords have to
+// occur in the tender name or description, case-insensitively. Private
+// tenders only match searches of employees allowed to see them.
+func (r *SavedSearchService) ListMatchingSearches(ctx context.Context, tender domain.Tender) ([]domain.SearchMatch, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+savedSearchColumns+`, e.locale
+		FROM saved_search s
+		JOIN employee e ON e.username = s.username
+		JOIN tender t ON t.id = $6
+		WHERE s.subscribed
+			AND `+tenderVisibleTo("t", "s.username")+`
+			AND (cardinality(s.service_types) = 0 OR EXISTS (
+				SELECT 1
+				FROM unnest(s.service_types) AS st(code)
+				WHERE lower(st.code) IN (SELECT lower(code) FROM (`+serviceTypeAncestors("$1")+`) a)
+			))
+			AND (s.organization_id IS NULL OR s.organization_id = $2)
+			AND (s.budget_min IS NULL OR $3::numeric >= s.budget_min)
+			AND (s.budget_max IS NULL OR $3::numeric <= s.budget_max)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM unnest(s.keywords) AS k(keyword)
+				WHERE strpos(lower($4 || ' ' || $5), lower(k.keyword)) = 0
+			)
+	`, tender.ServiceType, tender.OrganizationId, tender.Budget, tender.Name, tender.Description, tender.ID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListMatchingSearches: %w", err)
+	}
+	defer rows.Close()
+
+	matches := []domain.SearchMatch{}
+	for rows.Next() {
+		var match domain.SearchMatch
+		match.Search, err = scanSavedSearch(rows, &match.Locale)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListMatchingSearches: %w", err)
+		}
+		matches = append(matches, match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListMatchingSearches: %w", err)
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,96 @@
+package repository_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+func newTender(name string, budget float64, visibility domain.TenderVisibility) domain.Tender {
+	return domain.Tender{
+		Name:            name,
+		Description:     "Описание " + name,
+		Status:          "PUBLISHED",
+		ServiceType:     "Delivery",
+		OrganizationId:  repotest.Organization,
+		CreatorUsername: repotest.Owner,
+		Version:         1,
+		Type:            domain.TenderTypeStandard,
+		Visibility:      visibility,
+		Budget:          &budget,
+	}
+}
+
+func TestListMatchingSearches(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	tenders := repository.NewTenderService(pool)
+	searches := repository.NewSavedSearchService(pool)
+
+	organization := repotest.Organization
+	otherOrganization := repotest.OtherOrganization
+	low, high := 1000.0, 5000.0
+
+	saved := map[string]string{}
+	for _, search := range []domain.SavedSearch{
+		{Name: "all", Username: repotest.Outsider, Subscribed: true},
+		{Name: "delivery", Username: repotest.Outsider, ServiceTypes: []string{"Construction", "Delivery"}, Subscribed: true},
+		{Name: "construction", Username: repotest.Outsider, ServiceTypes: []string{"Construction"}, Subscribed: true},
+		{Name: "keywords", Username: repotest.Outsider, Keywords: []string{"СЕРВЕРЫ", "цод"}, Subscribed: true},
+		{Name: "missing keyword", Username: repotest.Outsider, Keywords: []string{"серверы", "кабели"}, Subscribed: true},
+		{Name: "budget", Username: repotest.Outsider, BudgetMin: &low, BudgetMax: &high, Subscribed: true},
+		{Name: "over budget", Username: repotest.Outsider, BudgetMax: &low, Subscribed: true},
+		{Name: "organization", Username: repotest.Outsider, OrganizationId: &organization, Subscribed: true},
+		{Name: "other organization", Username: repotest.Outsider, OrganizationId: &otherOrganization, Subscribed: true},
+		{Name: "unsubscribed", Username: repotest.Outsider},
+		{Name: "colleague", Username: repotest.Colleague, Subscribed: true},
+	} {
+		if search.ServiceTypes == nil {
+			search.ServiceTypes = []string{}
+		}
+		if search.Keywords == nil {
+			search.Keywords = []string{}
+		}
+
+		created, err := searches.CreateSavedSearch(ctx, search)
+		if err != nil {
+			t.Fatalf("CreateSavedSearch(%q): %v", search.Name, err)
+		}
+		saved[created.ID] = created.Name
+	}
+
+	matching := func(tender domain.Tender) []string {
+		t.Helper()
+
+		tender, err := tenders.CreateTender(ctx, tender)
+		if err != nil {
+			t.Fatalf("CreateTender: %v", err)
+		}
+
+		matches, err := searches.ListMatchingSearches(ctx, tender)
+		if err != nil {
+			t.Fatalf("ListMatchingSearches: %v", err)
+		}
+
+		names := []string{}
+		for _, match := range matches {
+			names = append(names, saved[match.Search.ID])
+		}
+		slices.Sort(names)
+
+		return names
+	}
+
+	want := []string{"all", "budget", "colleague", "delivery", "keywords", "organization"}
+	if got := matching(newTender("Серверы для ЦОД", 2000, domain.TenderVisibilityPublic)); !slices.Equal(got, want) {
+		t.Errorf("searches matching a public tender = %v, want %v", got, want)
+	}
+
+	if got := matching(newTender("Серверы для ЦОД", 2000, domain.TenderVisibilityPrivate)); !slices.Equal(got, []string{"colleague"}) {
+		t.Errorf("searches matching a private tender = %v, want only the ones of employees who may see it", got)
+	}
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.ServiceTypeRepository = (*ServiceTypeService)(nil)
+)
+
+const serviceTypeColumns = `code, parent_code, scheme, names, active, created_at, updated_at`
+
+// serviceTypeSubtree selects the catalog codes matching the text array given
+// by the expression, ignoring case, together with the codes of all their
+// subcategories.
+func serviceTypeSubtree(codes string) string {
+	return `WITH RECURSIVE subtree AS (
+			SELECT code FROM service_types WHERE lower(code) IN (SELECT lower(c) FROM unnest(` + codes + `::text[]) c)
+			UNION
+			SELECT s.code FROM service_types s JOIN subtree ON s.parent_code = subtree.code
+		)
+		SELECT code FROM subtree`
+}
+
+// serviceTypeAncestors selects the catalog code given by the expression
+// together with the codes of all the categories above it.
+func serviceTypeAncestors(code string) string {
+	return `WITH RECURSIVE ancestors AS (
+			SELECT code, parent_code FROM service_types WHERE code = ` + code + `
+			UNION
+			SELECT s.code, s.parent_code FROM service_types s JOIN ancestors ON s.code = ancestors.parent_code
+		)
+		SELECT code FROM ancestors`
+}
+
+type ServiceTypeService struct {
+	pool *pgxpool.Pool
+}
+
+func NewServiceTypeService(pool *pgxpool.Pool) *ServiceTypeService {
+	return &ServiceTypeService{pool: pool}
+}
+
+func scanServiceType(row rowScanner) (domain.ServiceType, error) {
+	var serviceType domain.ServiceType
+	err := row.Scan(
+		&serviceType.Code,
+		&serviceType.ParentCode,
+		&serviceType.Scheme,
+		&serviceType.Names,
+		&serviceType.Active,
+		&serviceType.CreatedAt,
+		&serviceType.UpdatedAt,
+	)
+
+	return serviceType, err
+}
+
+func (r *ServiceTypeService) ListServiceTypes(ctx context.Context, filter domain.ServiceTypeFilter) ([]domain.ServiceType, error) {
+	query := `SELECT ` + serviceTypeColumns + ` FROM service_types WHERE TRUE`
+	args := []any{}
+
+	switch {
+	case filter.Parent != "":
+		args = append(args, filter.Parent)
+		query += ` AND lower(parent_code) = lower($` + strconv.Itoa(len(args)) + `)`
+	case !filter.All:
+		query += ` AND parent_code IS NULL`
+	}
+
+	if !filter.IncludeInactive {
+		query += ` AND active`
+	}
+
+	rows, err := r.pool.Query(ctx, query+` ORDER BY code`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListServiceTypes: %w", err)
+	}
+	defer rows.Close()
+
+	serviceTypes := []domain.ServiceType{}
+	for rows.Next() {
+		serviceType, err := scanServiceType(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListServiceTypes: %w", err)
+		}
+		serviceTypes = append(serviceTypes, serviceType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListServiceTypes: %w", err)
+	}
+
+	return serviceTypes, nil
+}
+
+func getServiceType(ctx context.Context, q querier, code string, lock bool) (domain.ServiceType, error) {
+	query := `SELECT ` + serviceTypeColumns + ` FROM service_types WHERE lower(code) = lower($1)`
+	if lock {
+		query += ` FOR UPDATE`
+	}
+
+	serviceType, err := scanServiceType(q.QueryRow(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ServiceType{}, fmt.Errorf("%w: service type %q does not exist", domain.ErrNotFound, code)
+		}
+		return domain.ServiceType{}, err
+	}
+
+	return serviceType, nil
+}
+
+// GetServiceType looks the code up ignoring case.
+func (r *ServiceTypeService) GetServiceType(ctx context.Context, code string) (domain.ServiceType, error) {
+	serviceType, err := getServiceType(ctx, r.pool, code, false)
+	if err != nil {
+		return domain.ServiceType{}, fmt.Errorf("repository.GetServiceType: %w", err)
+	}
+
+	return serviceType, nil
+}
+
+// resolveParent returns the catalog code of the parent given by a client.
+func resolveParent(ctx context.Context, q querier, code string) (string, error) {
+	parent, err := getServiceType(ctx, q, code, false)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", fmt.Errorf("%w: parent service type %q does not exist", domain.ErrInvalidInput, code)
+		}
+		return "", err
+	}
+
+	return parent.Code, nil
+}
+
+func (r *ServiceTypeService) CreateServiceType(ctx context.Context, serviceType domain.ServiceType, username string) (domain.ServiceType, error) {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return domain.ServiceType{}, fmt.Errorf("repository.CreateServiceType: %w", err)
+	}
+
+	if serviceType.ParentCode != nil {
+		parent, err := resolveParent(ctx, r.pool, *serviceType.ParentCode)
+		if err != nil {
+			return domain.ServiceType{}, fmt.Errorf("repository.CreateServiceType: %w", err)
+		}
+		serviceType.ParentCode = &parent
+	}
+
+	created, err := scanServiceType(r.pool.QueryRow(ctx, `
+		INSERT INTO service_types (code, parent_code, scheme, names)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+		RETURNING `+serviceTypeColumns,
+		serviceType.Code, serviceType.ParentCode, serviceType.Scheme, serviceType.Names,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ServiceType{}, fmt.Errorf("repository.CreateServiceType: %w: service type %q already exists", domain.ErrConflict, serviceType.Code)
+		}
+		return domain.ServiceType{}, fmt.Errorf("repository.CreateServiceType: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateServiceType merges the given names into the existing ones. Moving an
+// entry below one of its own subcategories is rejected, so is renaming it to
+// the code of another entry.
+func (r *ServiceTypeService) UpdateServiceType(ctx context.Context, code string, update domain.ServiceTypeUpdate, username string) (domain.ServiceType, error) {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := getServiceType(ctx, tx, code, true)
+	if err != nil {
+		return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w", err)
+	}
+
+	parent := current.ParentCode
+	if update.ParentCode != nil {
+		parent = nil
+		if *update.ParentCode != "" {
+			parentCode, err := resolveParent(ctx, tx, *update.ParentCode)
+			if err != nil {
+				return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w", err)
+			}
+
+			var cycle bool
+			err = tx.QueryRow(ctx, `SELECT $2 IN (`+serviceTypeSubtree("ARRAY[$1]")+`)`, current.Code, parentCode).Scan(&cycle)
+			if err != nil {
+				return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w", err)
+			}
+			if cycle {
+				return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w: %q is a subcategory of %q", domain.ErrConflict, parentCode, current.Code)
+			}
+			parent = &parentCode
+		}
+	}
+
+	if update.Code != nil && *update.Code != current.Code {
+		var taken bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM service_types WHERE lower(code) = lower($2) AND code <> $1)`, current.Code, *update.Code).Scan(&taken)
+		if err != nil {
+			return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w", err)
+		}
+		if taken {
+			return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w: service type %q already exists", domain.ErrConflict, *update.Code)
+		}
+	}
+
+	// Tenders and subcategories follow the code through their foreign keys,
+	// a trigger renames it in versions, saved searches and templates.
+	updated, err := scanServiceType(tx.QueryRow(ctx, `
+		UPDATE service_types
+		SET code = COALESCE($5, code), parent_code = $2, names = names || COALESCE($3::jsonb, '{}'), active = COALESCE($4, active), updated_at = NOW()
+		WHERE code = $1
+		RETURNING `+serviceTypeColumns,
+		current.Code, parent, update.Names, update.Active, update.Code,
+	))
+	if err != nil {
+		return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ServiceType{}, fmt.Errorf("repository.UpdateServiceType: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteServiceType removes an entry nothing refers to. Entries in use are
+// deactivated instead.
+func (r *ServiceTypeService) DeleteServiceType(ctx context.Context, code string, username string) error {
+	if err := checkAdmin(ctx, r.pool, username); err != nil {
+		return fmt.Errorf("repository.DeleteServiceType: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteServiceType: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := getServiceType(ctx, tx, code, true)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteServiceType: %w", err)
+	}
+
+	var hasChildren, inUse bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM service_types WHERE parent_code = $1),
+			EXISTS (SELECT 1 FROM tender WHERE service_type = $1)
+	`, current.Code).Scan(&hasChildren, &inUse)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteServiceType: %w", err)
+	}
+
+	if hasChildren {
+		return fmt.Errorf("repository.DeleteServiceType: %w: service type has subcategories", domain.ErrConflict)
+	}
+	if inUse {
+		return fmt.Errorf("repository.DeleteServiceType: %w: service type is used by tenders, deactivate it instead", domain.ErrConflict)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM service_types WHERE code = $1`, current.Code); err != nil {
+		return fmt.Errorf("repository.DeleteServiceType: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("repository.DeleteServiceType: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+// newCatalog makes Owner an administrator and adds Delivery.Food below
+// Delivery and Delivery.Food.Frozen below that.
+func newCatalog(t *testing.T, pool *pgxpool.Pool) *repository.ServiceTypeService {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := pool.Exec(ctx, `UPDATE employee SET is_admin = TRUE WHERE username = $1`, repotest.Owner); err != nil {
+		t.Fatalf("making %s an administrator: %v", repotest.Owner, err)
+	}
+
+	catalog := repository.NewServiceTypeService(pool)
+	for _, entry := range [][2]string{{"Delivery.Food", "Delivery"}, {"Delivery.Food.Frozen", "delivery.food"}} {
+		parent := entry[1]
+		_, err := catalog.CreateServiceType(ctx, domain.ServiceType{
+			Code:       entry[0],
+			ParentCode: &parent,
+			Scheme:     domain.ServiceTypeSchemeCustom,
+			Names:      map[string]string{"ru": entry[0]},
+		}, repotest.Owner)
+		if err != nil {
+			t.Fatalf("CreateServiceType(%q): %v", entry[0], err)
+		}
+	}
+
+	return catalog
+}
+
+func TestServiceTypeCycles(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	catalog := newCatalog(t, pool)
+
+	for _, parent := range []string{"Delivery", "delivery.food", "Delivery.Food.Frozen"} {
+		_, err := catalog.UpdateServiceType(ctx, "Delivery", domain.ServiceTypeUpdate{ParentCode: &parent}, repotest.Owner)
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("moving Delivery below %s: error = %v, want %v", parent, err, domain.ErrConflict)
+		}
+	}
+
+	// Moving a subcategory up or to another branch is fine.
+	top, construction := "", "Construction"
+	for _, parent := range []*string{&construction, &top} {
+		moved, err := catalog.UpdateServiceType(ctx, "Delivery.Food.Frozen", domain.ServiceTypeUpdate{ParentCode: parent}, repotest.Owner)
+		if err != nil {
+			t.Fatalf("UpdateServiceType: %v", err)
+		}
+		if (*parent == "") != (moved.ParentCode == nil) || moved.ParentCode != nil && *moved.ParentCode != *parent {
+			t.Errorf("parent = %v, want %q", moved.ParentCode, *parent)
+		}
+	}
+
+	taken := "DELIVERY"
+	if _, err := catalog.UpdateServiceType(ctx, "Delivery.Food", domain.ServiceTypeUpdate{Code: &taken}, repotest.Owner); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("renaming to the code of another entry: error = %v, want %v", err, domain.ErrConflict)
+	}
+}
+
+func TestServiceTypeSubtree(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	newCatalog(t, pool)
+	tenders := repository.NewTenderService(pool)
+	searches := repository.NewSavedSearchService(pool)
+
+	created := map[string]domain.Tender{}
+	for _, serviceType := range []string{"Delivery", "Delivery.Food", "Delivery.Food.Frozen", "Construction"} {
+		tender := newTender(serviceType, 1000, domain.TenderVisibilityPublic)
+		tender.ServiceType = serviceType
+		tender, err := tenders.CreateTender(ctx, tender)
+		if err != nil {
+			t.Fatalf("CreateTender(%q): %v", serviceType, err)
+		}
+		created[serviceType] = tender
+	}
+
+	for _, c := range []struct {
+		filter []string
+		want   []string
+	}{
+		{[]string{"delivery"}, []string{"Delivery", "Delivery.Food", "Delivery.Food.Frozen"}},
+		{[]string{"Delivery.Food"}, []string{"Delivery.Food", "Delivery.Food.Frozen"}},
+		{[]string{"Delivery.Food.Frozen", "Construction"}, []string{"Construction", "Delivery.Food.Frozen"}},
+	} {
+		list, err := tenders.ListTender(ctx, domain.TenderListFilter{Limit: 10, ServiceTypes: c.filter, Username: repotest.Owner})
+		if err != nil {
+			t.Fatalf("ListTender: %v", err)
+		}
+
+		names := []string{}
+		for _, tender := range list {
+			names = append(names, tender.Name)
+		}
+		slices.Sort(names)
+		if !slices.Equal(names, c.want) {
+			t.Errorf("tenders of %v = %v, want %v", c.filter, names, c.want)
+		}
+	}
+
+	// Saved searches match subcategories the same way.
+	search, err := searches.CreateSavedSearch(ctx, domain.SavedSearch{
+		Name:         "food",
+		Username:     repotest.Outsider,
+		ServiceTypes: []string{"delivery.food"},
+		Keywords:     []string{},
+		Subscribed:   true,
+	})
+	if err != nil {
+		t.Fatalf("CreateSavedSearch: %v", err)
+	}
+
+	for serviceType, want := range map[string]bool{"Delivery": false, "Delivery.Food": true, "Delivery.Food.Frozen": true, "Construction": false} {
+		matches, err := searches.ListMatchingSearches(ctx, created[serviceType])
+		if err != nil {
+			t.Fatalf("ListMatchingSearches: %v", err)
+		}
+		matched := slices.ContainsFunc(matches, func(match domain.SearchMatch) bool { return match.Search.ID == search.ID })
+		if matched != want {
+			t.Errorf("search of Delivery.Food matches a %s tender: %t, want %t", serviceType, matched, want)
+		}
+	}
+}
+
+func TestServiceTypeRollback(t *testing.T) {
+	ctx := context.Background()
+	pool := repotest.Postgres(t)
+	catalog := newCatalog(t, pool)
+	tenders := repository.NewTenderService(pool)
+	searches := repository.NewSavedSearchService(pool)
+
+	food := newTender("Продукты", 1000, domain.TenderVisibilityPublic)
+	food.ServiceType = "Delivery.Food.Frozen"
+	renamed, err := tenders.CreateTender(ctx, food)
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+	deleted, err := tenders.CreateTender(ctx, food)
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+	for _, tender := range []domain.Tender{renamed, deleted} {
+		if _, err := tenders.UpdatePartTender(ctx, tender.ID, map[string]interface{}{"serviceType": "Construction"}, repotest.Owner); err != nil {
+			t.Fatalf("UpdatePartTender: %v", err)
+		}
+	}
+	search, err := searches.CreateSavedSearch(ctx, domain.SavedSearch{
+		Name:         "frozen",
+		Username:     repotest.Outsider,
+		ServiceTypes: []string{"Construction", "delivery.food.frozen"},
+		Keywords:     []string{},
+		Subscribed:   true,
+	})
+	if err != nil {
+		t.Fatalf("CreateSavedSearch: %v", err)
+	}
+
+	// The first version of both tenders is of the renamed entry.
+	code := "Delivery.Frozen"
+	if _, err := catalog.UpdateServiceType(ctx, "Delivery.Food.Frozen", domain.ServiceTypeUpdate{Code: &code}, repotest.Owner); err != nil {
+		t.Fatalf("UpdateServiceType: %v", err)
+	}
+
+	tender, err := tenders.RollbackTenderVersion(ctx, renamed.ID, 1, repotest.Owner)
+	if err != nil {
+		t.Fatalf("RollbackTenderVersion after a rename: %v", err)
+	}
+	if tender.ServiceType != code {
+		t.Errorf("service type after rollback = %q, want %q", tender.ServiceType, code)
+	}
+
+	saved, err := searches.ListSavedSearches(ctx, repotest.Outsider)
+	if err != nil {
+		t.Fatalf("ListSavedSearches: %v", err)
+	}
+	for _, s := range saved {
+		if s.ID == search.ID && !slices.Equal(s.ServiceTypes, []string{"Construction", code}) {
+			t.Errorf("service types of the saved search = %v, want the new code", s.ServiceTypes)
+		}
+	}
+
+	// Entries no tender uses any more can be deleted, the versions of the
+	// tenders that used them can not be restored then.
+	if err := catalog.DeleteServiceType(ctx, code, repotest.Owner); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("DeleteServiceType of an entry in use: error = %v, want %v", err, domain.ErrConflict)
+	}
+	if _, err := tenders.UpdatePartTender(ctx, renamed.ID, map[string]interface{}{"serviceType": "Construction"}, repotest.Owner); err != nil {
+		t.Fatalf("UpdatePartTender: %v", err)
+	}
+	if err := catalog.DeleteServiceType(ctx, code, repotest.Owner); err != nil {
+		t.Fatalf("DeleteServiceType: %v", err)
+	}
+
+	if _, err := tenders.RollbackTenderVersion(ctx, deleted.ID, 1, repotest.Owner); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("RollbackTenderVersion after a delete: error = %v, want %v", err, domain.ErrConflict)
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ domain.TemplateRepository = (*TemplateService)(nil)
+)
+
+const templateColumns = `id, organization_id, name, tender, COALESCE(created_by_user, ''), created_at`
+
+type TemplateService struct {
+	pool *pgxpool.Pool
+}
+
+func NewTemplateService(pool *pgxpool.Pool) *TemplateService {
+	return &TemplateService{pool: pool}
+}
+
+func scanTemplate(row rowScanner) (domain.TenderTemplate, error) {
+	var template domain.TenderTemplate
+	err := row.Scan(
+		&template.ID,
+		&template.OrganizationID,
+		&template.Name,
+		&template.Tender,
+		&template.CreatorUsername,
+		&template.CreatedAt,
+	)
+
+	return template, err
+}
+
+func (r *TemplateService) CreateTemplate(ctx context.Context, template domain.TenderTemplate) (domain.TenderTemplate, error) {
+	if err := checkResponsible(ctx, r.pool, template.CreatorUsername, template.OrganizationID); err != nil {
+		return domain.TenderTemplate{}, fmt.Errorf("repository.CreateTemplate: %w", err)
+	}
+
+	created, err := scanTemplate(r.pool.QueryRow(ctx, `
+		INSERT INTO tender_template (organization_id, name, tender, created_by_user)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_id, name) DO NOTHING
+		RETURNING `+templateColumns,
+		template.OrganizationID, template.Name, template.Tender, template.CreatorUsername,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TenderTemplate{}, fmt.Errorf("repository.CreateTemplate: %w: template %q already exists", domain.ErrConflict, template.Name)
+		}
+		return domain.TenderTemplate{}, fmt.Errorf("repository.CreateTemplate: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *TemplateService) ListTemplates(ctx context.Context, organizationID string, username string) ([]domain.TenderTemplate, error) {
+	if err := checkResponsible(ctx, r.pool, username, organizationID); err != nil {
+		return nil, fmt.Errorf("repository.ListTemplates: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+templateColumns+`
+		FROM tender_template
+		WHERE organization_id = $1
+		ORDER BY name
+	`, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListTemplates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []domain.TenderTemplate{}
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListTemplates: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListTemplates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// GetTemplate returns a template to a responsible of its organization.
+func (r *TemplateService) GetTemplate(ctx context.Context, templateID string, username string) (domain.TenderTemplate, error) {
+	template, err := scanTemplate(r.pool.QueryRow(ctx, `SELECT `+templateColumns+` FROM tender_template WHERE id = $1`, templateID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TenderTemplate{}, fmt.Errorf("repository.GetTemplate: %w: template does not exist", domain.ErrNotFound)
+		}
+		return domain.TenderTemplate{}, fmt.Errorf("repository.GetTemplate: %w", err)
+	}
+
+	if err := checkResponsible(ctx, r.pool, username, template.OrganizationID); err != nil {
+		return domain.TenderTemplate{}, fmt.Errorf("repository.GetTemplate: %w", err)
+	}
+
+	return template, nil
+}
+
+func (r *TemplateService) DeleteTemplate(ctx context.Context, organizationID string, templateID string, username string) error {
+	if err := checkResponsible(ctx, r.pool, username, organizationID); err != nil {
+		return fmt.Errorf("repository.DeleteTemplate: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, `DELETE FROM tender_template WHERE id = $1 AND organization_id = $2`, templateID, organizationID)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteTemplate: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository.DeleteTemplate: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/Te8va/Tender/internal/tender/domain"
-	"github.com/jackc/pgx"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lib/pq"
 )
@@ -36,6 +40,37 @@ var (
 	_ domain.TenderRepository = (*TenderService)(nil)
 )
 
+const tenderColumns = `id, name, description, service_type, status, organization_id, created_by_user, version, type, visibility, sealed, seal_key, budget, closes_at, tags, custom_fields, created_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTender(row rowScanner) (domain.Tender, error) {
+	var tender domain.Tender
+	err := row.Scan(
+		&tender.ID,
+		&tender.Name,
+		&tender.Description,
+		&tender.ServiceType,
+		&tender.Status,
+		&tender.OrganizationId,
+		&tender.CreatorUsername,
+		&tender.Version,
+		&tender.Type,
+		&tender.Visibility,
+		&tender.Sealed,
+		&tender.SealKey,
+		&tender.Budget,
+		&tender.ClosesAt,
+		&tender.Tags,
+		&tender.CustomFields,
+		&tender.CreatedAt,
+	)
+
+	return tender, err
+}
+
 type TenderService struct {
 	pool *pgxpool.Pool
 }
@@ -44,20 +79,53 @@ func NewTenderService(pool *pgxpool.Pool) *TenderService {
 	return &TenderService{pool: pool}
 }
 
-func (t *TenderService) ListTender(ctx context.Context, limit, offset int, serviceTypes []string) ([]domain.Tender, error) {
-	query := `SELECT id, name, description, service_type, status, version, created_at
-              FROM tender`
-	var args []interface{}
-	argIndex := 1
+// tenderListQuery selects the tenders matching the filter, leaving paging to
+// the caller. The next free argument is $len(args)+1.
+func tenderListQuery(filter domain.TenderListFilter) (string, []interface{}) {
+	query := `SELECT ` + tenderColumns + `
+              FROM tender t
+              WHERE ` + tenderVisibleTo("t", "$1")
+	args := []interface{}{filter.Username}
+	argIndex := 2
+
+	if len(filter.ServiceTypes) > 0 {
+		query += ` AND t.service_type IN (` + serviceTypeSubtree(`$`+strconv.Itoa(argIndex)) + `)`
+		args = append(args, pq.Array(filter.ServiceTypes))
+		argIndex++
+	}
+
+	if len(filter.Tags) > 0 {
+		query += ` AND t.tags @> $` + strconv.Itoa(argIndex) + `::varchar[]`
+		args = append(args, filter.Tags)
+		argIndex++
+	}
 
-	if len(serviceTypes) > 0 {
-		query += ` WHERE service_type = ANY($` + strconv.Itoa(argIndex) + `)`
-		args = append(args, pq.Array(serviceTypes))
+	if filter.Text != "" {
+		query += ` AND (strpos(lower(t.name), lower($` + strconv.Itoa(argIndex) + `)) > 0 OR strpos(lower(t.description), lower($` + strconv.Itoa(argIndex) + `)) > 0)`
+		args = append(args, filter.Text)
 		argIndex++
 	}
 
+	keys := make([]string, 0, len(filter.CustomFields))
+	for key := range filter.CustomFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		query += ` AND t.custom_fields ->> $` + strconv.Itoa(argIndex) + `::text = $` + strconv.Itoa(argIndex+1) + `::text`
+		args = append(args, key, filter.CustomFields[key])
+		argIndex += 2
+	}
+
+	return query, args
+}
+
+func (t *TenderService) ListTender(ctx context.Context, filter domain.TenderListFilter) ([]domain.Tender, error) {
+	query, args := tenderListQuery(filter)
+	argIndex := len(args) + 1
+
 	query += ` LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1)
-	args = append(args, limit, offset)
+	args = append(args, filter.Limit, filter.Offset)
 
 	rows, err := t.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -67,9 +135,8 @@ func (t *TenderService) ListTender(ctx context.Context, limit, offset int, servi
 
 	var tenders []domain.Tender
 	for rows.Next() {
-		var tender domain.Tender
-		if err := rows.Scan(&tender.ID, &tender.Name, &tender.Description, &tender.ServiceType,
-			&tender.Status, &tender.Version, &tender.CreatedAt); err != nil {
+		tender, err := scanTender(rows)
+		if err != nil {
 			return nil, fmt.Errorf("repository.GetAllTenders: %w", err)
 		}
 		tenders = append(tenders, tender)
@@ -82,58 +149,81 @@ func (t *TenderService) ListTender(ctx context.Context, limit, offset int, servi
 }
 
 func (r *TenderService) CreateTender(ctx context.Context, tender domain.Tender) (domain.Tender, error) {
-	isAuthorized, err := r.IsUserAuthorizedForOrganization(ctx, tender.CreatorUsername, tender.OrganizationId)
-	if err != nil {
-		if err.Error() == "user does not exist" {
-			return domain.Tender{}, fmt.Errorf("user does not exist")
-		}
+	if err := checkResponsible(ctx, r.pool, tender.CreatorUsername, tender.OrganizationId); err != nil {
 		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
 	}
 
-	if !isAuthorized {
-		return domain.Tender{}, fmt.Errorf("user is not authorized to create tender for this organization")
-	}
+	query := `INSERT INTO tender (id, name, description, service_type, status, organization_id, created_by_user, version, type, visibility, sealed, seal_key, budget, closes_at, tags, custom_fields, created_at)
+			  VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, COALESCE($14::varchar[], '{}'), COALESCE(NULLIF($15::jsonb, 'null'), '{}'), NOW()) RETURNING id`
 
-	query := `INSERT INTO tender (id, name, description, service_type, status, organization_id, created_by_user, version, created_at)
-			  VALUES (uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, NOW()) RETURNING id`
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
 	var tenderID string
-	err = r.pool.QueryRow(ctx, query, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationId, tender.CreatorUsername, tender.Version).Scan(&tenderID)
+	err = tx.QueryRow(ctx, query, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationId, tender.CreatorUsername, tender.Version, tender.Type, tender.Visibility, tender.Sealed, tender.SealKey, tender.Budget, tender.ClosesAt, tender.Tags, tender.CustomFields).Scan(&tenderID)
 	if err != nil {
 		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
 	}
 
-	var createdTender domain.Tender
-	err = r.pool.QueryRow(ctx, `
-		SELECT id, name, description, service_type, status, organization_id, created_by_user, version, created_at
-		FROM tender
-		WHERE id = $1
-	`, tenderID).Scan(
-		&createdTender.ID,
-		&createdTender.Name,
-		&createdTender.Description,
-		&createdTender.ServiceType,
-		&createdTender.Status,
-		&createdTender.OrganizationId,
-		&createdTender.CreatorUsername,
-		&createdTender.Version,
-		&createdTender.CreatedAt,
-	)
+	if err := replaceLots(ctx, tx, tenderID, tender.Lots); err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+	}
+
+	if err := replaceCriteria(ctx, tx, tenderID, tender.Criteria); err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+	}
+
+	if tender.Auction != nil {
+		if err := createAuction(ctx, tx, tenderID, *tender.Auction); err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+	}
+
+	createdTender, err := scanTender(r.pool.QueryRow(ctx, `SELECT `+tenderColumns+` FROM tender WHERE id = $1`, tenderID))
 	if err != nil {
 		return domain.Tender{}, fmt.Errorf("repository.GetTenderByID: %w", err)
 	}
 
+	createdTender.Lots, err = listLots(ctx, r.pool, tenderID)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+	}
+
+	createdTender.Criteria, err = listCriteria(ctx, r.pool, tenderID)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+	}
+
+	if createdTender.Type == domain.TenderTypeAuction {
+		auction, err := getAuction(ctx, r.pool, tenderID)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+		}
+		createdTender.Auction = &auction
+	}
+
+	if err := r.SaveTenderVersion(ctx, createdTender); err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.CreateTender: %w", err)
+	}
+
 	return createdTender, nil
 }
 
 func (r *TenderService) GetUserTenders(ctx context.Context, limit, offset int, username string) ([]domain.Tender, error) {
-	if exists, err := r.UserExists(ctx, username); err != nil || !exists {
-		return nil, fmt.Errorf("repository.UpdateTenderStatus: %w", err)
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return nil, fmt.Errorf("repository.GetUserTenders: %w", err)
 	}
 
 	query := `SELECT id, name, description, status, service_type, created_at, version
-	          FROM tenders WHERE created_by_user = $1 LIMIT $2 OFFSET $3`
-	rows, err := r.pool.Query(ctx, query, limit, offset, username)
+	          FROM tender WHERE created_by_user = $1 LIMIT $2 OFFSET $3`
+	rows, err := r.pool.Query(ctx, query, username, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("repository.GetUserTenders: %w", err)
 	}
@@ -156,23 +246,11 @@ func (r *TenderService) GetUserTenders(ctx context.Context, limit, offset int, u
 }
 
 func (r *TenderService) IsUserAuthorizedForOrganization(ctx context.Context, username, organizationId string) (bool, error) {
-	var userID string
-	err := r.pool.QueryRow(ctx, `SELECT id FROM employee WHERE username = $1`, username).Scan(&userID)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return false, fmt.Errorf("user does not exist")
-		}
-		return false, fmt.Errorf("user does not exist")
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return false, fmt.Errorf("repository.IsUserAuthorizedForOrganization: %w", err)
 	}
 
-	var isAuthorized bool
-	err = r.pool.QueryRow(ctx, `
-        SELECT EXISTS (
-            SELECT 1 
-            FROM organization_responsible 
-            WHERE user_id = $1 AND organization_id = $2
-        )
-    `, userID, organizationId).Scan(&isAuthorized)
+	isAuthorized, err := isResponsible(ctx, r.pool, username, organizationId)
 	if err != nil {
 		return false, fmt.Errorf("repository.IsUserAuthorizedForOrganization: %w", err)
 	}
@@ -181,7 +259,11 @@ func (r *TenderService) IsUserAuthorizedForOrganization(ctx context.Context, use
 }
 
 func (r *TenderService) GetTenderStatus(ctx context.Context, tenderID string, username string) (string, error) {
-	if exists, err := r.UserExists(ctx, username); err != nil || !exists {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return "", fmt.Errorf("repository.GetTenderStatus: %w", err)
+	}
+
+	if err := checkTenderVisible(ctx, r.pool, tenderID, username); err != nil {
 		return "", fmt.Errorf("repository.GetTenderStatus: %w", err)
 	}
 
@@ -194,64 +276,82 @@ func (r *TenderService) GetTenderStatus(ctx context.Context, tenderID string, us
 	return status, nil
 }
 
-func (r *TenderService) UpdateTenderStatus(ctx context.Context, tenderID string, status string, username string) (domain.Tender, error) {
-	if exists, err := r.UserExists(ctx, username); err != nil || !exists {
-		return domain.Tender{}, fmt.Errorf("repository.UpdateTenderStatus: %w", err)
+func (r *TenderService) UpdateTenderStatus(ctx context.Context, tenderID string, status string, from []string, username string) (domain.Tender, bool, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return domain.Tender{}, false, fmt.Errorf("repository.UpdateTenderStatus: %w", err)
 	}
 
-	updateQuery := `UPDATE tender SET status = $1 WHERE id = $2`
-	tag, err := r.pool.Exec(ctx, updateQuery, status, tenderID)
-	if err != nil {
-		return domain.Tender{}, fmt.Errorf("repository.UpdateTenderStatus: %w", err)
+	change, err := r.changeTenderStatus(ctx, tenderID, status, from, username)
+	if err == nil {
+		err = change.Err
 	}
-
-	if tag.RowsAffected() == 0 {
-		return domain.Tender{}, fmt.Errorf("no rows updated; check the ID")
+	if err != nil {
+		return domain.Tender{}, false, fmt.Errorf("repository.UpdateTenderStatus: %w", statusError(err, status))
 	}
 
 	updatedTender, err := r.GetTenderByID(ctx, tenderID)
 	if err != nil {
-		return domain.Tender{}, fmt.Errorf("repository.UpdateTenderStatus: failed to retrieve updated tender: %w", err)
+		return domain.Tender{}, false, fmt.Errorf("repository.UpdateTenderStatus: failed to retrieve updated tender: %w", err)
 	}
 
-	return updatedTender, nil
+	return updatedTender, change.Applied, nil
 }
 
-func (r *TenderService) UserExists(ctx context.Context, username string) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS (SELECT 1 FROM employee WHERE username = $1)`
-	err := r.pool.QueryRow(ctx, query, username).Scan(&exists)
+func (r *TenderService) GetTenderByID(ctx context.Context, tenderID string) (domain.Tender, error) {
+	query := `SELECT ` + tenderColumns + ` FROM tender WHERE id = $1`
+	tender, err := scanTender(r.pool.QueryRow(ctx, query, tenderID))
 	if err != nil {
-		return false, fmt.Errorf("repository.UserExists: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Tender{}, fmt.Errorf("repository.GetTenderByID: %w", domain.ErrTenderNotFound)
+		}
+		return domain.Tender{}, fmt.Errorf("repository.GetTenderByID: %w", err)
 	}
-	return exists, nil
+
+	return tender, nil
 }
 
-func (r *TenderService) GetTenderByID(ctx context.Context, tenderID string) (domain.Tender, error) {
-	var tender domain.Tender
+func (r *TenderService) GetTender(ctx context.Context, tenderID string, username string) (domain.Tender, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.GetTender: %w", err)
+	}
 
-	query := `SELECT id, name, description, service_type, status, organization_id, created_by_user, version, created_at FROM tender WHERE id = $1`
-	err := r.pool.QueryRow(ctx, query, tenderID).Scan(
-		&tender.ID,
-		&tender.Name,
-		&tender.Description,
-		&tender.ServiceType,
-		&tender.Status,
-		&tender.OrganizationId,
-		&tender.CreatorUsername,
-		&tender.Version,
-		&tender.CreatedAt,
-	)
+	if err := checkTenderVisible(ctx, r.pool, tenderID, username); err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.GetTender: %w", err)
+	}
 
+	tender, err := r.GetTenderByID(ctx, tenderID)
 	if err != nil {
-		return domain.Tender{}, fmt.Errorf("repository.GetTenderByID: %w", err)
+		return domain.Tender{}, fmt.Errorf("repository.GetTender: %w", err)
+	}
+
+	tender.Lots, err = listLots(ctx, r.pool, tenderID)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.GetTender: %w", err)
+	}
+
+	tender.Criteria, err = listCriteria(ctx, r.pool, tenderID)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.GetTender: %w", err)
+	}
+
+	tender.Attachments, err = listAttachments(ctx, r.pool, domain.AttachmentOwner{Type: domain.AttachmentOwnerTender, ID: tenderID})
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.GetTender: %w", err)
+	}
+
+	if tender.Type == domain.TenderTypeAuction {
+		auction, err := getAuction(ctx, r.pool, tenderID)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.GetTender: %w", err)
+		}
+		tender.Auction = &auction
 	}
 
 	return tender, nil
 }
 
 func (r *TenderService) UpdatePartTender(ctx context.Context, id string, updates map[string]interface{}, username string) (domain.Tender, error) {
-	if exists, err := r.UserExists(ctx, username); err != nil || !exists {
+	if err := checkUser(ctx, r.pool, username); err != nil {
 		return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
 	}
 
@@ -261,19 +361,41 @@ func (r *TenderService) UpdatePartTender(ctx context.Context, id string, updates
 	}
 	defer tx.Rollback(ctx)
 
-	var currentVersion int
+	status, err := lockTender(ctx, tx, id, username)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+	}
+
+	var (
+		currentVersion  int
+		tenderType      domain.TenderType
+		sealed          bool
+		currentClosesAt *time.Time
+	)
 	err = tx.QueryRow(ctx, `
-		SELECT version
+		SELECT version, type, sealed, closes_at
 		FROM tender
 		WHERE id = $1
-	`, id).Scan(&currentVersion)
+		FOR UPDATE
+	`, id).Scan(&currentVersion, &tenderType, &sealed, &currentClosesAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", domain.ErrTenderNotFound)
+	}
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return domain.Tender{}, fmt.Errorf("tender not found: %w", err)
-		}
 		return domain.Tender{}, fmt.Errorf("error fetching current version: %w", err)
 	}
 
+	if lots, ok := updates["lots"].([]domain.Lot); ok && status == "CLOSED" {
+		if err := checkClosedLots(ctx, tx, id, lots); err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+		}
+	}
+	if criteria, ok := updates["criteria"].([]domain.Criterion); ok && status == "CLOSED" {
+		if err := checkClosedCriteria(ctx, tx, id, criteria); err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+		}
+	}
+
 	query := "UPDATE tender SET "
 	values := []interface{}{}
 	i := 1
@@ -292,13 +414,51 @@ func (r *TenderService) UpdatePartTender(ctx context.Context, id string, updates
 		values = append(values, serviceType)
 		i++
 	}
+	if visibility, ok := updates["visibility"].(string); ok && visibility != "" {
+		if visibility != string(domain.TenderVisibilityPublic) && visibility != string(domain.TenderVisibilityPrivate) {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w: unknown visibility %q", domain.ErrInvalidInput, visibility)
+		}
+		query += fmt.Sprintf("visibility = $%d, ", i)
+		values = append(values, visibility)
+		i++
+	}
+	if budget, ok := updates["budget"].(float64); ok && budget >= 0 {
+		query += fmt.Sprintf("budget = $%d, ", i)
+		values = append(values, budget)
+		i++
+	}
+	if closesAtStr, ok := updates["closesAt"].(string); ok && closesAtStr != "" {
+		closesAt, err := time.Parse(time.RFC3339, closesAtStr)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w: invalid closesAt: %v", domain.ErrInvalidInput, err)
+		}
+		if tenderType == domain.TenderTypeAuction {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w: the deadline of an auction follows its end time", domain.ErrConflict)
+		}
+		if sealed && currentClosesAt != nil && closesAt.Before(*currentClosesAt) {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w: deadline of a sealed tender can only be extended", domain.ErrConflict)
+		}
+		query += fmt.Sprintf("closes_at = $%d, closing_notified_at = NULL, ", i)
+		values = append(values, closesAt)
+		i++
+	}
+	if tags, ok := updates["tags"].([]string); ok {
+		query += fmt.Sprintf("tags = $%d, ", i)
+		values = append(values, tags)
+		i++
+	}
+	if customFields, ok := updates["customFields"].(map[string]any); ok {
+		query += fmt.Sprintf("custom_fields = $%d, ", i)
+		values = append(values, customFields)
+		i++
+	}
 
 	query += fmt.Sprintf("version = version + 1 ")
 	query += fmt.Sprintf("WHERE id = $%d", i)
 	values = append(values, id)
 
 	if len(values) == 0 {
-		return domain.Tender{}, fmt.Errorf("no fields to update")
+		return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w: no fields to update", domain.ErrInvalidInput)
 	}
 
 	_, err = tx.Exec(ctx, query, values...)
@@ -306,32 +466,40 @@ func (r *TenderService) UpdatePartTender(ctx context.Context, id string, updates
 		return domain.Tender{}, fmt.Errorf("failed to execute update query: %w", err)
 	}
 
+	if lots, ok := updates["lots"].([]domain.Lot); ok {
+		if err := replaceLots(ctx, tx, id, lots); err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+		}
+	}
+
+	if criteria, ok := updates["criteria"].([]domain.Criterion); ok {
+		if err := replaceCriteria(ctx, tx, id, criteria); err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return domain.Tender{}, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	var updatedTender domain.Tender
-	err = r.pool.QueryRow(ctx, `
-		SELECT id, name, description, service_type, status, organization_id, created_by_user, version
-		FROM tender
-		WHERE id = $1
-	`, id).Scan(
-		&updatedTender.ID,
-		&updatedTender.Name,
-		&updatedTender.Description,
-		&updatedTender.ServiceType,
-		&updatedTender.Status,
-		&updatedTender.OrganizationId,
-		&updatedTender.CreatorUsername,
-		&updatedTender.Version,
-	)
+	updatedTender, err := scanTender(r.pool.QueryRow(ctx, `SELECT `+tenderColumns+` FROM tender WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Tender{}, fmt.Errorf("error fetching updated tender: %w", domain.ErrTenderNotFound)
+	}
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return domain.Tender{}, fmt.Errorf("tender not found: %w", err)
-		}
 		return domain.Tender{}, fmt.Errorf("error fetching updated tender: %w", err)
 	}
 
+	updatedTender.Lots, err = listLots(ctx, r.pool, id)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("error fetching updated lots: %w", err)
+	}
+
+	updatedTender.Criteria, err = listCriteria(ctx, r.pool, id)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("error fetching updated criteria: %w", err)
+	}
+
 	if err := r.SaveTenderVersion(ctx, updatedTender); err != nil {
 		return domain.Tender{}, fmt.Errorf("failed to save tender version: %w", err)
 	}
@@ -339,12 +507,80 @@ func (r *TenderService) UpdatePartTender(ctx context.Context, id string, updates
 	return updatedTender, nil
 }
 
+// checkClosedLots rejects a change of the lots of a closed tender, its bids
+// were made and evaluated against them.
+func checkClosedLots(ctx context.Context, q querier, tenderID string, lots []domain.Lot) error {
+	current, err := listLots(ctx, q, tenderID)
+	if err != nil {
+		return err
+	}
+	if !domain.SameLots(current, lots) {
+		return fmt.Errorf("%w: the lots of a closed tender are locked", domain.ErrTenderClosed)
+	}
+	return nil
+}
+
+// checkClosedCriteria rejects a change of the criteria or their weights once
+// the tender is closed and its evaluation is locked.
+func checkClosedCriteria(ctx context.Context, q querier, tenderID string, criteria []domain.Criterion) error {
+	current, err := listCriteria(ctx, q, tenderID)
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(current, criteria) {
+		return fmt.Errorf("%w: the criteria of a closed tender are locked", domain.ErrTenderClosed)
+	}
+	return nil
+}
+
+// lockTender locks the tender for a change by the employee and returns its
+// status. Tenders the employee may not see are reported as not found and
+// only the responsibles of the owning organization may change them.
+func lockTender(ctx context.Context, tx pgx.Tx, tenderID, username string) (string, error) {
+	var organizationID, status string
+	var visible bool
+	err := tx.QueryRow(ctx, `
+		SELECT t.organization_id, t.status, `+tenderVisibleTo("t", "$2")+`
+		FROM tender t
+		WHERE t.id = $1
+		FOR UPDATE OF t
+	`, tenderID, username).Scan(&organizationID, &status, &visible)
+	if errors.Is(err, pgx.ErrNoRows) || err == nil && !visible {
+		return "", domain.ErrTenderNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	responsible, err := isResponsible(ctx, tx, username, organizationID)
+	if err != nil {
+		return "", err
+	}
+	if !responsible {
+		return "", domain.ErrUserNotAuthorized
+	}
+
+	return status, nil
+}
+
+// invitedOrganizations and versionCriteria select the invitations and the
+// criteria of the tender $1, for the version being saved.
+const (
+	invitedOrganizations = `(SELECT COALESCE(array_agg(organization_id ORDER BY created_at), '{}') FROM tender_invitation WHERE tender_id = $1)`
+	versionCriteria      = `(SELECT COALESCE(jsonb_agg(jsonb_build_object('id', id, 'name', name, 'weight', weight) ORDER BY position), '[]') FROM tender_criterion WHERE tender_id = $1)`
+)
+
 func (r *TenderService) SaveTenderVersion(ctx context.Context, tender domain.Tender) error {
+	lots := tender.Lots
+	if lots == nil {
+		lots = []domain.Lot{}
+	}
+
 	query := `
-        INSERT INTO tender_versions (tender_id, version, name, description, service_type, status, organization_id, created_by_user)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        INSERT INTO tender_versions (tender_id, version, name, description, service_type, status, organization_id, created_by_user, budget, lots, tags, custom_fields, visibility, invited_organizations, criteria)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE($11::varchar[], '{}'), COALESCE(NULLIF($12::jsonb, 'null'), '{}'), $13, ` + invitedOrganizations + `, ` + versionCriteria + `)
     `
-	_, err := r.pool.Exec(ctx, query, tender.ID, tender.Version, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationId, tender.CreatorUsername)
+	_, err := r.pool.Exec(ctx, query, tender.ID, tender.Version, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationId, tender.CreatorUsername, tender.Budget, lots, tender.Tags, tender.CustomFields, tender.Visibility)
 	if err != nil {
 		return fmt.Errorf("failed to save tender version: %w", err)
 	}
@@ -352,8 +588,8 @@ func (r *TenderService) SaveTenderVersion(ctx context.Context, tender domain.Ten
 }
 
 func (r *TenderService) RollbackTenderVersion(ctx context.Context, id string, targetVersion int, username string) (domain.Tender, error) {
-	if exists, err := r.UserExists(ctx, username); err != nil || !exists {
-		return domain.Tender{}, fmt.Errorf("repository.UpdatePartTender: %w", err)
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w", err)
 	}
 
 	tx, err := r.pool.Begin(ctx)
@@ -362,26 +598,60 @@ func (r *TenderService) RollbackTenderVersion(ctx context.Context, id string, ta
 	}
 	defer tx.Rollback(ctx)
 
+	status, err := lockTender(ctx, tx, id, username)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w", err)
+	}
+
 	var targetTender domain.Tender
+	var invited []string
 	err = tx.QueryRow(ctx, `
-        SELECT name, description, service_type, status, organization_id, created_by_user
+        SELECT name, description, service_type, organization_id, created_by_user, budget, lots, tags, custom_fields,
+            COALESCE(visibility, ''), invited_organizations::text[], criteria
         FROM tender_versions
         WHERE tender_id = $1 AND version = $2
     `, id, targetVersion).Scan(
 		&targetTender.Name,
 		&targetTender.Description,
 		&targetTender.ServiceType,
-		&targetTender.Status,
 		&targetTender.OrganizationId,
 		&targetTender.CreatorUsername,
+		&targetTender.Budget,
+		&targetTender.Lots,
+		&targetTender.Tags,
+		&targetTender.CustomFields,
+		&targetTender.Visibility,
+		&invited,
+		&targetTender.Criteria,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w: version %d of tender %s", domain.ErrNotFound, targetVersion, id)
+	}
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return domain.Tender{}, fmt.Errorf("target version not found: %w", err)
-		}
 		return domain.Tender{}, fmt.Errorf("error fetching target version: %w", err)
 	}
 
+	if status == "CLOSED" {
+		if err := checkClosedLots(ctx, tx, id, targetTender.Lots); err != nil {
+			return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w", err)
+		}
+		if targetTender.Criteria != nil {
+			if err := checkClosedCriteria(ctx, tx, id, targetTender.Criteria); err != nil {
+				return domain.Tender{}, fmt.Errorf("repository.RollbackTenderVersion: %w", err)
+			}
+		}
+	}
+
+	var serviceTypeExists bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM service_types WHERE code = $1)`, targetTender.ServiceType).Scan(&serviceTypeExists)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("error checking service type: %w", err)
+	}
+
+	if !serviceTypeExists {
+		return domain.Tender{}, fmt.Errorf("%w: service type %q of version %d no longer exists", domain.ErrConflict, targetTender.ServiceType, targetVersion)
+	}
+
 	var maxVersion int
 	err = tx.QueryRow(ctx, `
         SELECT COALESCE(MAX(version), 0)
@@ -396,17 +666,36 @@ func (r *TenderService) RollbackTenderVersion(ctx context.Context, id string, ta
 
 	_, err = tx.Exec(ctx, `
         UPDATE tender
-        SET name = $1, description = $2, service_type = $3, status = $4, version = $5
-        WHERE id = $6
-    `, targetTender.Name, targetTender.Description, targetTender.ServiceType, targetTender.Status, newVersion, id)
+        SET name = $1, description = $2, service_type = $3, version = $4, budget = $5, tags = $6, custom_fields = $7,
+            visibility = COALESCE(NULLIF($8, ''), visibility)
+        WHERE id = $9
+    `, targetTender.Name, targetTender.Description, targetTender.ServiceType, newVersion, targetTender.Budget, targetTender.Tags, targetTender.CustomFields, targetTender.Visibility, id)
 	if err != nil {
 		return domain.Tender{}, fmt.Errorf("failed to update tender: %w", err)
 	}
 
+	if invited != nil {
+		if err := replaceInvitations(ctx, tx, id, invited, username); err != nil {
+			return domain.Tender{}, fmt.Errorf("failed to restore invitations: %w", err)
+		}
+	}
+
+	if err := replaceLots(ctx, tx, id, targetTender.Lots); err != nil {
+		return domain.Tender{}, fmt.Errorf("failed to restore lots: %w", err)
+	}
+
+	if targetTender.Criteria != nil {
+		if err := replaceCriteria(ctx, tx, id, targetTender.Criteria); err != nil {
+			return domain.Tender{}, fmt.Errorf("failed to restore criteria: %w", err)
+		}
+	}
+
 	_, err = tx.Exec(ctx, `
-        INSERT INTO tender_versions (tender_id, version, name, description, service_type, status, organization_id, created_by_user)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-    `, id, newVersion, targetTender.Name, targetTender.Description, targetTender.ServiceType, targetTender.Status, targetTender.OrganizationId, targetTender.CreatorUsername)
+        INSERT INTO tender_versions (tender_id, version, name, description, service_type, status, organization_id, created_by_user, budget, lots, tags, custom_fields, visibility, invited_organizations, criteria)
+        SELECT $1, $2, $3, $4, $5, status, $6, $7, $8, $9, $10, $11, visibility, `+invitedOrganizations+`, `+versionCriteria+`
+        FROM tender
+        WHERE id = $1
+    `, id, newVersion, targetTender.Name, targetTender.Description, targetTender.ServiceType, targetTender.OrganizationId, targetTender.CreatorUsername, targetTender.Budget, targetTender.Lots, targetTender.Tags, targetTender.CustomFields)
 	if err != nil {
 		return domain.Tender{}, fmt.Errorf("failed to save new version: %w", err)
 	}
@@ -415,27 +704,23 @@ func (r *TenderService) RollbackTenderVersion(ctx context.Context, id string, ta
 		return domain.Tender{}, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	var updatedTender domain.Tender
-	err = r.pool.QueryRow(ctx, `
-        SELECT id, name, description, service_type, status, organization_id, created_by_user, version
-        FROM tender
-        WHERE id = $1
-    `, id).Scan(
-		&updatedTender.ID,
-		&updatedTender.Name,
-		&updatedTender.Description,
-		&updatedTender.ServiceType,
-		&updatedTender.Status,
-		&updatedTender.OrganizationId,
-		&updatedTender.CreatorUsername,
-		&updatedTender.Version,
-	)
+	updatedTender, err := scanTender(r.pool.QueryRow(ctx, `SELECT `+tenderColumns+` FROM tender WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Tender{}, fmt.Errorf("error fetching updated tender: %w", domain.ErrTenderNotFound)
+	}
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return domain.Tender{}, fmt.Errorf("tender not found: %w", err)
-		}
 		return domain.Tender{}, fmt.Errorf("error fetching updated tender: %w", err)
 	}
 
+	updatedTender.Lots, err = listLots(ctx, r.pool, id)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("error fetching updated lots: %w", err)
+	}
+
+	updatedTender.Criteria, err = listCriteria(ctx, r.pool, id)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("error fetching updated criteria: %w", err)
+	}
+
 	return updatedTender, nil
 }
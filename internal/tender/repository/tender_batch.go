@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (r *TenderService) UpdateTenderStatuses(ctx context.Context, tenderIDs []string, status string, from []string, username string, atomic bool) ([]domain.TenderStatusChange, error) {
+	if err := checkUser(ctx, r.pool, username); err != nil {
+		return nil, fmt.Errorf("repository.UpdateTenderStatuses: %w", err)
+	}
+
+	changes := make([]domain.TenderStatusChange, 0, len(tenderIDs))
+
+	if atomic {
+		tx, err := r.pool.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("repository.UpdateTenderStatuses: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		failed := false
+		for _, tenderID := range tenderIDs {
+			change, err := changeTenderStatus(ctx, tx, tenderID, status, from, username)
+			if err != nil {
+				return nil, fmt.Errorf("repository.UpdateTenderStatuses: %w", statusError(err, status))
+			}
+			failed = failed || change.Err != nil
+			changes = append(changes, change)
+		}
+
+		if failed {
+			return changes, nil
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("repository.UpdateTenderStatuses: %w", err)
+		}
+
+		for i := range changes {
+			changes[i].Applied = changes[i].PreviousStatus != status
+		}
+
+		return changes, nil
+	}
+
+	for _, tenderID := range tenderIDs {
+		change, err := r.changeTenderStatus(ctx, tenderID, status, from, username)
+		if err != nil {
+			return nil, fmt.Errorf("repository.UpdateTenderStatuses: %w", statusError(err, status))
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// changeTenderStatus changes the status of a single tender, alone or as part
+// of a best effort batch, in a transaction of its own.
+func (r *TenderService) changeTenderStatus(ctx context.Context, tenderID, status string, from []string, username string) (domain.TenderStatusChange, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.TenderStatusChange{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := changeTenderStatus(ctx, tx, tenderID, status, from, username)
+	if err != nil || change.Err != nil {
+		return change, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.TenderStatusChange{}, err
+	}
+	change.Applied = change.PreviousStatus != status
+
+	return change, nil
+}
+
+// changeTenderStatus checks and changes the status within tx. Failed checks
+// are returned in the Err of the change, database errors as the error.
+// Private tenders the employee may not see are reported as not found.
+func changeTenderStatus(ctx context.Context, tx pgx.Tx, tenderID, status string, from []string, username string) (domain.TenderStatusChange, error) {
+	change := domain.TenderStatusChange{TenderID: tenderID}
+
+	var previous, organizationID string
+	var visible bool
+	err := tx.QueryRow(ctx, `
+		SELECT t.status, t.organization_id, `+tenderVisibleTo("t", "$2")+`
+		FROM tender t
+		WHERE t.id = $1
+		FOR UPDATE OF t
+	`, tenderID, username).Scan(&previous, &organizationID, &visible)
+	if errors.Is(err, pgx.ErrNoRows) || err == nil && !visible {
+		change.Err = domain.ErrTenderNotFound
+		return change, nil
+	}
+	if err != nil {
+		return domain.TenderStatusChange{}, err
+	}
+	change.PreviousStatus = previous
+
+	responsible, err := isResponsible(ctx, tx, username, organizationID)
+	if err != nil {
+		return domain.TenderStatusChange{}, err
+	}
+	if !responsible {
+		change.Err = domain.ErrUserNotAuthorized
+		return change, nil
+	}
+
+	if change.PreviousStatus == status {
+		change.Status = status
+		return change, nil
+	}
+
+	if !slices.Contains(from, change.PreviousStatus) {
+		change.Err = fmt.Errorf("%w: tender can not move from %s to %s", domain.ErrConflict, change.PreviousStatus, status)
+		return change, nil
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE tender SET status = $1 WHERE id = $2`, status, tenderID); err != nil {
+		return domain.TenderStatusChange{}, err
+	}
+	change.Status = status
+
+	return change, nil
+}
+
+// statusError reports a status the tender_status_check constraint rejects
+// as invalid input and leaves other errors alone.
+func statusError(err error, status string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == "tender_status_check" {
+		return fmt.Errorf("%w: unknown status %q", domain.ErrInvalidInput, status)
+	}
+
+	return err
+}
@@ -0,0 +1,29 @@
+package repository_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/repository"
+	"github.com/Te8va/Tender/internal/tender/repository/repotest"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if err := repotest.StopPostgres(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	os.Exit(code)
+}
+
+// TestContract runs the repository contract against a disposable Postgres,
+// a fresh migrated database for every case.
+func TestContract(t *testing.T) {
+	repotest.Suite{
+		New: func(t *testing.T) domain.TenderRepository {
+			return repository.NewTenderService(repotest.Postgres(t))
+		},
+	}.Run(t)
+}
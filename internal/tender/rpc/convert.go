@@ -0,0 +1,266 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	tenderv1 "github.com/Te8va/Tender/pkg/api/tender/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	value := t.AsTime()
+	return &value
+}
+
+func customFields(values map[string]any) (*structpb.Struct, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	return structpb.NewStruct(values)
+}
+
+func toTender(tender domain.Tender) (*tenderv1.Tender, error) {
+	fields, err := customFields(tender.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenderv1.Tender{
+		Id:           tender.ID,
+		Name:         tender.Name,
+		Description:  tender.Description,
+		Status:       tender.Status,
+		ServiceType:  tender.ServiceType,
+		Version:      int32(tender.Version),
+		Type:         string(tender.Type),
+		Visibility:   string(tender.Visibility),
+		Sealed:       tender.Sealed,
+		Auction:      toAuction(tender.Auction),
+		Budget:       tender.Budget,
+		ClosesAt:     timestamp(tender.ClosesAt),
+		CreatedAt:    timestamppb.New(tender.CreatedAt),
+		Lots:         toLots(tender.Lots),
+		Criteria:     toCriteria(tender.Criteria),
+		Tags:         tender.Tags,
+		CustomFields: fields,
+	}, nil
+}
+
+func toTenders(tenders []domain.Tender) (*tenderv1.ListTendersResponse, error) {
+	response := &tenderv1.ListTendersResponse{Tenders: make([]*tenderv1.Tender, 0, len(tenders))}
+	for _, tender := range tenders {
+		converted, err := toTender(tender)
+		if err != nil {
+			return nil, err
+		}
+		response.Tenders = append(response.Tenders, converted)
+	}
+
+	return response, nil
+}
+
+func toAuction(auction *domain.Auction) *tenderv1.Auction {
+	if auction == nil {
+		return nil
+	}
+
+	return &tenderv1.Auction{
+		StartsAt:         timestamppb.New(auction.StartsAt),
+		EndsAt:           timestamppb.New(auction.EndsAt),
+		StartPrice:       auction.StartPrice,
+		MinDecrement:     auction.MinDecrement,
+		ExtensionSeconds: int32(auction.ExtensionSeconds),
+		BestPrice:        auction.BestPrice,
+		OfferCount:       int32(auction.OfferCount),
+		Running:          auction.Running,
+	}
+}
+
+func fromAuction(auction *tenderv1.Auction) *domain.Auction {
+	if auction == nil {
+		return nil
+	}
+
+	return &domain.Auction{
+		StartsAt:         auction.GetStartsAt().AsTime(),
+		EndsAt:           auction.GetEndsAt().AsTime(),
+		StartPrice:       auction.StartPrice,
+		MinDecrement:     auction.MinDecrement,
+		ExtensionSeconds: int(auction.ExtensionSeconds),
+	}
+}
+
+func toLots(lots []domain.Lot) []*tenderv1.Lot {
+	converted := make([]*tenderv1.Lot, 0, len(lots))
+	for _, lot := range lots {
+		converted = append(converted, &tenderv1.Lot{
+			Id:           lot.ID,
+			Name:         lot.Name,
+			Description:  lot.Description,
+			Quantity:     lot.Quantity,
+			Unit:         lot.Unit,
+			Budget:       lot.Budget,
+			AwardedBidId: lot.AwardedBidID,
+			AwardedAt:    timestamp(lot.AwardedAt),
+		})
+	}
+
+	return converted
+}
+
+func fromLots(lots []*tenderv1.Lot) []domain.Lot {
+	if lots == nil {
+		return nil
+	}
+
+	converted := make([]domain.Lot, 0, len(lots))
+	for _, lot := range lots {
+		converted = append(converted, domain.Lot{
+			ID:          lot.Id,
+			Name:        lot.Name,
+			Description: lot.Description,
+			Quantity:    lot.Quantity,
+			Unit:        lot.Unit,
+			Budget:      lot.Budget,
+		})
+	}
+
+	return converted
+}
+
+func toCriteria(criteria []domain.Criterion) []*tenderv1.Criterion {
+	converted := make([]*tenderv1.Criterion, 0, len(criteria))
+	for _, criterion := range criteria {
+		converted = append(converted, &tenderv1.Criterion{Id: criterion.ID, Name: criterion.Name, Weight: criterion.Weight})
+	}
+
+	return converted
+}
+
+func fromCriteria(criteria []*tenderv1.Criterion) []domain.Criterion {
+	if criteria == nil {
+		return nil
+	}
+
+	converted := make([]domain.Criterion, 0, len(criteria))
+	for _, criterion := range criteria {
+		converted = append(converted, domain.Criterion{ID: criterion.Id, Name: criterion.Name, Weight: criterion.Weight})
+	}
+
+	return converted
+}
+
+// tenderUpdates builds the partial update the service expects from the set
+// fields, with the values encoding/json would have decoded.
+func tenderUpdates(req *tenderv1.EditTenderRequest) map[string]interface{} {
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = req.GetName()
+	}
+	if req.Description != nil {
+		updates["description"] = req.GetDescription()
+	}
+	if req.ServiceType != nil {
+		updates["serviceType"] = req.GetServiceType()
+	}
+	if req.Visibility != nil {
+		updates["visibility"] = req.GetVisibility()
+	}
+	if req.Budget != nil {
+		updates["budget"] = req.GetBudget()
+	}
+	if req.ClosesAt != nil {
+		updates["closesAt"] = req.ClosesAt.AsTime().Format(time.RFC3339)
+	}
+	if req.Tags != nil {
+		updates["tags"] = req.Tags.GetTags()
+	}
+	if req.CustomFields != nil {
+		updates["customFields"] = req.CustomFields.AsMap()
+	}
+	if req.Lots != nil {
+		updates["lots"] = fromLots(req.Lots.GetLots())
+	}
+	if req.Criteria != nil {
+		updates["criteria"] = fromCriteria(req.Criteria.GetCriteria())
+	}
+
+	return updates
+}
+
+func toStatusResult(result domain.BatchStatusResult) *tenderv1.UpdateTenderStatusesResponse {
+	response := &tenderv1.UpdateTenderStatusesResponse{
+		Status:  result.Status,
+		Mode:    string(result.Mode),
+		Applied: int32(result.Applied),
+		Failed:  int32(result.Failed),
+	}
+	for _, change := range result.Results {
+		response.Results = append(response.Results, &tenderv1.TenderStatusChange{
+			TenderId:       change.TenderID,
+			PreviousStatus: change.PreviousStatus,
+			Status:         change.Status,
+			Applied:        change.Applied,
+			Error:          change.Error,
+		})
+	}
+
+	return response
+}
+
+func toBid(bid domain.Bid) *tenderv1.Bid {
+	converted := &tenderv1.Bid{
+		Id:             bid.ID,
+		Name:           bid.Name,
+		Description:    bid.Description,
+		Status:         bid.Status,
+		TenderId:       bid.TenderId,
+		OrganizationId: bid.OrganizationId,
+		Version:        int32(bid.Version),
+		Price:          bid.Price,
+		Sealed:         bid.Sealed,
+		CreatedAt:      timestamppb.New(bid.CreatedAt),
+	}
+	for _, lot := range bid.Lots {
+		converted.Lots = append(converted.Lots, &tenderv1.BidLot{LotId: lot.LotID, Price: lot.Price})
+	}
+
+	return converted
+}
+
+func toBids(bids []domain.Bid) *tenderv1.ListBidsResponse {
+	response := &tenderv1.ListBidsResponse{Bids: make([]*tenderv1.Bid, 0, len(bids))}
+	for _, bid := range bids {
+		response.Bids = append(response.Bids, toBid(bid))
+	}
+
+	return response
+}
+
+func fromBidLots(lots []*tenderv1.BidLot) []domain.BidLot {
+	if len(lots) == 0 {
+		return nil
+	}
+
+	converted := make([]domain.BidLot, 0, len(lots))
+	for _, lot := range lots {
+		converted = append(converted, domain.BidLot{LotID: lot.LotId, Price: lot.Price})
+	}
+
+	return converted
+}
@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	tenderv1 "github.com/Te8va/Tender/pkg/api/tender/v1"
+	"github.com/Te8va/Tender/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UsernameKey is the metadata key identifying the employee, the counterpart
+// of the username query parameter.
+const UsernameKey = "username"
+
+type usernameKey struct{}
+
+// anonymous lists the methods that may be called without a username, like
+// their HTTP routes.
+var anonymous = map[string]bool{
+	tenderv1.TenderService_ListTenders_FullMethodName:  true,
+	tenderv1.TenderService_CreateTender_FullMethodName: true,
+	tenderv1.BidService_CreateBid_FullMethodName:       true,
+}
+
+func username(ctx context.Context) string {
+	name, _ := ctx.Value(usernameKey{}).(string)
+	return name
+}
+
+// authenticate reads the username from the metadata into the context.
+func authenticate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var name string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(UsernameKey); len(values) > 0 {
+			name = values[0]
+		}
+	}
+
+	if name == "" && !anonymous[info.FullMethod] {
+		return nil, status.Error(codes.Unauthenticated, "missing username")
+	}
+
+	return handler(context.WithValue(ctx, usernameKey{}, name), req)
+}
+
+func logCall(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	logger.Logger().Info("gRPC call: ", info.FullMethod)
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.Logger().Errorln("gRPC call", info.FullMethod, "failed:", err.Error())
+	}
+	logger.Logger().Info("gRPC status for ", info.FullMethod, ": ", status.Code(err), ", processing duration: ", duration)
+
+	return resp, err
+}
+
+// mapErrors turns service errors into gRPC statuses, the way statusFromError
+// turns them into HTTP status codes.
+func mapErrors(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return nil, err
+	}
+
+	return nil, status.Error(codeFromError(err), err.Error())
+}
+
+func codeFromError(err error) codes.Code {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return codes.Unauthenticated
+	case errors.Is(err, domain.ErrUserNotAuthorized), errors.Is(err, domain.ErrBidsSealed):
+		return codes.PermissionDenied
+	case errors.Is(err, domain.ErrTenderNotFound), errors.Is(err, domain.ErrBidNotFound), errors.Is(err, domain.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, domain.ErrTenderNotOpen), errors.Is(err, domain.ErrInvalidInput), errors.Is(err, domain.ErrUnsupportedType):
+		return codes.InvalidArgument
+	case errors.Is(err, domain.ErrTenderClosed), errors.Is(err, domain.ErrConflict),
+		errors.Is(err, domain.ErrAuctionNotRunning), errors.Is(err, domain.ErrOfferTooHigh):
+		return codes.FailedPrecondition
+	case errors.Is(err, domain.ErrTooLarge):
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,252 @@
+// Package rpc serves the tender and bid services over gRPC, next to the
+// HTTP API and on top of the same service layer.
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	tenderv1 "github.com/Te8va/Tender/pkg/api/tender/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+const defaultLimit = 10
+
+// NewServer returns a gRPC server with both services, the interceptors and
+// server reflection registered.
+func NewServer(tenders domain.TenderService, bids domain.BidService) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(logCall, mapErrors, authenticate))
+
+	tenderv1.RegisterTenderServiceServer(server, NewTenderServer(tenders))
+	tenderv1.RegisterBidServiceServer(server, NewBidServer(bids))
+	reflection.Register(server)
+
+	return server
+}
+
+func pagination(limit, offset int32) (int, int) {
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	return int(limit), int(offset)
+}
+
+type TenderServer struct {
+	tenderv1.UnimplementedTenderServiceServer
+	srv domain.TenderService
+}
+
+func NewTenderServer(srv domain.TenderService) *TenderServer {
+	return &TenderServer{srv: srv}
+}
+
+func (s *TenderServer) ListTenders(ctx context.Context, req *tenderv1.ListTendersRequest) (*tenderv1.ListTendersResponse, error) {
+	limit, offset := pagination(req.Limit, req.Offset)
+
+	tenders, err := s.srv.ListTender(ctx, domain.TenderListFilter{
+		Limit:        limit,
+		Offset:       offset,
+		ServiceTypes: req.ServiceTypes,
+		Tags:         req.Tags,
+		CustomFields: req.CustomFields,
+		Username:     username(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTenders(tenders)
+}
+
+func (s *TenderServer) CreateTender(ctx context.Context, req *tenderv1.CreateTenderRequest) (*tenderv1.Tender, error) {
+	if req.Name == "" || req.ServiceType == "" || req.OrganizationId == "" || req.CreatorUsername == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	var fields map[string]any
+	if req.CustomFields != nil {
+		fields = req.CustomFields.AsMap()
+	}
+
+	tender, err := s.srv.CreateTender(ctx, domain.Tender{
+		Name:            req.Name,
+		Description:     req.Description,
+		ServiceType:     req.ServiceType,
+		Status:          "CREATED",
+		OrganizationId:  req.OrganizationId,
+		CreatorUsername: req.CreatorUsername,
+		Version:         1,
+		Type:            domain.TenderType(req.Type),
+		Visibility:      domain.TenderVisibility(req.Visibility),
+		Sealed:          req.Sealed,
+		Auction:         fromAuction(req.Auction),
+		Lots:            fromLots(req.Lots),
+		Criteria:        fromCriteria(req.Criteria),
+		Tags:            req.Tags,
+		CustomFields:    fields,
+		Budget:          req.Budget,
+		ClosesAt:        fromTimestamp(req.ClosesAt),
+		CreatedAt:       time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTender(tender)
+}
+
+func (s *TenderServer) ListUserTenders(ctx context.Context, req *tenderv1.ListUserTendersRequest) (*tenderv1.ListTendersResponse, error) {
+	limit, offset := pagination(req.Limit, req.Offset)
+
+	tenders, err := s.srv.GetUserTenders(ctx, limit, offset, username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return toTenders(tenders)
+}
+
+func (s *TenderServer) GetTender(ctx context.Context, req *tenderv1.GetTenderRequest) (*tenderv1.Tender, error) {
+	tender, err := s.srv.GetTender(ctx, req.TenderId, username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return toTender(tender)
+}
+
+func (s *TenderServer) GetTenderStatus(ctx context.Context, req *tenderv1.GetTenderStatusRequest) (*tenderv1.GetTenderStatusResponse, error) {
+	tenderStatus, err := s.srv.GetTenderStatus(ctx, req.TenderId, username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenderv1.GetTenderStatusResponse{Status: tenderStatus}, nil
+}
+
+func (s *TenderServer) UpdateTenderStatus(ctx context.Context, req *tenderv1.UpdateTenderStatusRequest) (*tenderv1.Tender, error) {
+	if req.Status == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing status")
+	}
+
+	tender, err := s.srv.UpdateTenderStatus(ctx, req.TenderId, req.Status, username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return toTender(tender)
+}
+
+func (s *TenderServer) UpdateTenderStatuses(ctx context.Context, req *tenderv1.UpdateTenderStatusesRequest) (*tenderv1.UpdateTenderStatusesResponse, error) {
+	result, err := s.srv.UpdateTenderStatuses(ctx, domain.BatchStatusRequest{
+		TenderIDs: req.TenderIds,
+		Status:    req.Status,
+		Mode:      domain.BatchStatusMode(req.Mode),
+	}, username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return toStatusResult(result), nil
+}
+
+func (s *TenderServer) EditTender(ctx context.Context, req *tenderv1.EditTenderRequest) (*tenderv1.Tender, error) {
+	tender, err := s.srv.UpdatePartTender(ctx, req.TenderId, tenderUpdates(req), username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return toTender(tender)
+}
+
+func (s *TenderServer) RollbackTender(ctx context.Context, req *tenderv1.RollbackTenderRequest) (*tenderv1.Tender, error) {
+	tender, err := s.srv.RollbackTenderVersion(ctx, req.TenderId, int(req.Version), username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return toTender(tender)
+}
+
+func (s *TenderServer) CloneTender(ctx context.Context, req *tenderv1.CloneTenderRequest) (*tenderv1.Tender, error) {
+	tender, err := s.srv.CloneTender(ctx, req.TenderId, username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return toTender(tender)
+}
+
+type BidServer struct {
+	tenderv1.UnimplementedBidServiceServer
+	srv domain.BidService
+}
+
+func NewBidServer(srv domain.BidService) *BidServer {
+	return &BidServer{srv: srv}
+}
+
+func (s *BidServer) CreateBid(ctx context.Context, req *tenderv1.CreateBidRequest) (*tenderv1.Bid, error) {
+	if req.Name == "" || req.TenderId == "" || req.OrganizationId == "" || req.CreatorUsername == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	bid, err := s.srv.CreateBid(ctx, domain.Bid{
+		Name:            req.Name,
+		Description:     req.Description,
+		Status:          "CREATED",
+		TenderId:        req.TenderId,
+		OrganizationId:  req.OrganizationId,
+		CreatorUsername: req.CreatorUsername,
+		Version:         1,
+		Price:           req.Price,
+		Lots:            fromBidLots(req.Lots),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toBid(bid), nil
+}
+
+func (s *BidServer) ListUserBids(ctx context.Context, req *tenderv1.ListUserBidsRequest) (*tenderv1.ListBidsResponse, error) {
+	limit, offset := pagination(req.Limit, req.Offset)
+
+	bids, err := s.srv.GetUserBids(ctx, limit, offset, username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return toBids(bids), nil
+}
+
+func (s *BidServer) ListTenderBids(ctx context.Context, req *tenderv1.ListTenderBidsRequest) (*tenderv1.ListBidsResponse, error) {
+	limit, offset := pagination(req.Limit, req.Offset)
+
+	bids, err := s.srv.ListTenderBids(ctx, req.TenderId, limit, offset, username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return toBids(bids), nil
+}
+
+func (s *BidServer) CountTenderBids(ctx context.Context, req *tenderv1.CountTenderBidsRequest) (*tenderv1.BidCount, error) {
+	count, err := s.srv.CountTenderBids(ctx, req.TenderId, username(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenderv1.BidCount{
+		TenderId: count.TenderID,
+		Count:    int32(count.Count),
+		Sealed:   count.Sealed,
+		Revealed: count.Revealed,
+		RevealAt: timestamp(count.RevealAt),
+	}, nil
+}
@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	tenderv1 "github.com/Te8va/Tender/pkg/api/tender/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+type stubTenders struct {
+	domain.TenderService
+	tenders  map[string]domain.Tender
+	username string
+	updates  map[string]interface{}
+}
+
+func (s *stubTenders) GetTender(ctx context.Context, tenderID string, username string) (domain.Tender, error) {
+	s.username = username
+	tender, ok := s.tenders[tenderID]
+	if !ok {
+		return domain.Tender{}, fmt.Errorf("service.GetTender: %w", domain.ErrTenderNotFound)
+	}
+
+	return tender, nil
+}
+
+func (s *stubTenders) UpdatePartTender(ctx context.Context, id string, updates map[string]interface{}, username string) (domain.Tender, error) {
+	s.updates = updates
+	return s.tenders[id], nil
+}
+
+type stubBids struct {
+	domain.BidService
+}
+
+func (stubBids) CountTenderBids(ctx context.Context, tenderID string, username string) (domain.BidCount, error) {
+	return domain.BidCount{}, fmt.Errorf("service.CountTenderBids: %w", domain.ErrUserNotAuthorized)
+}
+
+func dial(t *testing.T, tenders domain.TenderService) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(tenders, stubBids{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestTenderServer(t *testing.T) {
+	budget := 1000.0
+	tenders := &stubTenders{tenders: map[string]domain.Tender{
+		"tender-1": {ID: "tender-1", Name: "Поставка серверов", Status: "PUBLISHED", Budget: &budget, Tags: []string{"it"}, CustomFields: map[string]any{"region": "77"}},
+	}}
+	conn := dial(t, tenders)
+	client := tenderv1.NewTenderServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), UsernameKey, "user1")
+
+	if _, err := client.GetTender(context.Background(), &tenderv1.GetTenderRequest{TenderId: "tender-1"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("GetTender without username = %v, want Unauthenticated", err)
+	}
+
+	tender, err := client.GetTender(ctx, &tenderv1.GetTenderRequest{TenderId: "tender-1"})
+	if err != nil {
+		t.Fatalf("GetTender: %v", err)
+	}
+	if tenders.username != "user1" {
+		t.Errorf("service called for %q, want user1", tenders.username)
+	}
+	if tender.Name != "Поставка серверов" || tender.GetBudget() != 1000 || tender.CustomFields.Fields["region"].GetStringValue() != "77" {
+		t.Errorf("tender = %v", tender)
+	}
+
+	if _, err := client.GetTender(ctx, &tenderv1.GetTenderRequest{TenderId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetTender of a missing tender = %v, want NotFound", err)
+	}
+
+	_, err = client.EditTender(ctx, &tenderv1.EditTenderRequest{
+		TenderId: "tender-1",
+		Name:     proto.String("Серверы"),
+		Tags:     &tenderv1.TagList{},
+	})
+	if err != nil {
+		t.Fatalf("EditTender: %v", err)
+	}
+	if len(tenders.updates) != 2 || tenders.updates["name"] != "Серверы" || len(tenders.updates["tags"].([]string)) != 0 {
+		t.Errorf("updates = %v, want the name and cleared tags", tenders.updates)
+	}
+
+	bids := tenderv1.NewBidServiceClient(conn)
+	if _, err := bids.CountTenderBids(ctx, &tenderv1.CountTenderBidsRequest{TenderId: "tender-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CountTenderBids = %v, want PermissionDenied", err)
+	}
+}
+
+func TestReflection(t *testing.T) {
+	stream, err := reflectionpb.NewServerReflectionClient(dial(t, &stubTenders{})).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	services := map[string]bool{}
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services[service.Name] = true
+	}
+	for _, name := range []string{"tender.v1.TenderService", "tender.v1.BidService"} {
+		if !services[name] {
+			t.Errorf("%s is not listed, got %v", name, services)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package sealing encrypts the contents of sealed bids. Every sealed tender
+// gets its own data key, which is stored wrapped with the master key.
+package sealing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const keySize = 32
+
+var (
+	_ domain.BidSealer = (*Sealer)(nil)
+)
+
+var ErrMalformed = errors.New("sealed data is malformed")
+
+type Sealer struct {
+	master cipher.AEAD
+}
+
+// NewSealer accepts a base64-encoded 256-bit master key.
+func NewSealer(masterKey string) (*Sealer, error) {
+	key, err := base64.StdEncoding.DecodeString(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("sealing.NewSealer: %w", err)
+	}
+
+	if len(key) != keySize {
+		return nil, fmt.Errorf("sealing.NewSealer: master key must be %d bytes, got %d", keySize, len(key))
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("sealing.NewSealer: %w", err)
+	}
+
+	return &Sealer{master: aead}, nil
+}
+
+// NewKey generates a data key for a tender and returns it wrapped with the
+// master key.
+func (s *Sealer) NewKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("sealing.NewKey: %w", err)
+	}
+
+	return seal(s.master, key)
+}
+
+func (s *Sealer) Seal(wrappedKey []byte, plaintext []byte) ([]byte, error) {
+	aead, err := s.unwrap(wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("sealing.Seal: %w", err)
+	}
+
+	return seal(aead, plaintext)
+}
+
+func (s *Sealer) Open(wrappedKey []byte, ciphertext []byte) ([]byte, error) {
+	aead, err := s.unwrap(wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("sealing.Open: %w", err)
+	}
+
+	plaintext, err := open(aead, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("sealing.Open: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func (s *Sealer) unwrap(wrappedKey []byte) (cipher.AEAD, error) {
+	key, err := open(s.master, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAEAD(key)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal prepends a random nonce to the ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+
+	nonce, data := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, data, nil)
+}
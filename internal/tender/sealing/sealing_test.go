@@ -0,0 +1,81 @@
+package sealing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+)
+
+func newTestSealer(t *testing.T) *Sealer {
+	t.Helper()
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("rand: %v", err)
+	}
+
+	sealer, err := NewSealer(base64.StdEncoding.EncodeToString(key))
+	if err != nil {
+		t.Fatalf("NewSealer: %v", err)
+	}
+
+	return sealer
+}
+
+func TestSealOpen(t *testing.T) {
+	sealer := newTestSealer(t)
+
+	key, err := sealer.NewKey()
+	if err != nil {
+		t.Fatalf("NewKey: %v", err)
+	}
+
+	plaintext := []byte(`{"name":"Server delivery","price":125000}`)
+	ciphertext, err := sealer.Seal(key, plaintext)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	if bytes.Contains(ciphertext, []byte("Server delivery")) {
+		t.Fatal("ciphertext contains the plaintext")
+	}
+
+	got, err := sealer.Open(key, ciphertext)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatalf("Open returned %q, want %q", got, plaintext)
+	}
+}
+
+func TestOpenWithAnotherTenderKey(t *testing.T) {
+	sealer := newTestSealer(t)
+
+	key, _ := sealer.NewKey()
+	otherKey, _ := sealer.NewKey()
+
+	ciphertext, err := sealer.Seal(key, []byte("bid"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	if _, err := sealer.Open(otherKey, ciphertext); err == nil {
+		t.Fatal("Open with another tender key succeeded")
+	}
+}
+
+func TestOpenWithAnotherMasterKey(t *testing.T) {
+	key, _ := newTestSealer(t).NewKey()
+
+	if _, err := newTestSealer(t).Seal(key, []byte("bid")); err == nil {
+		t.Fatal("Seal with a key wrapped by another master key succeeded")
+	}
+}
+
+func TestNewSealerRejectsShortKey(t *testing.T) {
+	if _, err := NewSealer(base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Fatal("NewSealer accepted a short key")
+	}
+}
@@ -0,0 +1,209 @@
+package sealing
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A sealed stream starts with a random nonce prefix followed by segments of
+// segmentSize plaintext bytes, the last one possibly shorter. The nonce of a
+// segment is the prefix, its counter and a flag marking the last segment, so
+// segments can neither be reordered nor cut off unnoticed.
+const (
+	segmentSize = 64 << 10
+	prefixSize  = 7
+	tagSize     = 16
+)
+
+var errTooLong = errors.New("sealed stream is too long")
+
+// SealWriter encrypts what is written to it into w segment by segment. Close
+// writes the last segment and must be called.
+func (s *Sealer) SealWriter(wrappedKey []byte, w io.Writer) (io.WriteCloser, error) {
+	aead, err := s.unwrap(wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("sealing.SealWriter: %w", err)
+	}
+
+	prefix := make([]byte, prefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("sealing.SealWriter: %w", err)
+	}
+
+	if _, err := w.Write(prefix); err != nil {
+		return nil, fmt.Errorf("sealing.SealWriter: %w", err)
+	}
+
+	return &sealWriter{
+		aead:   aead,
+		w:      w,
+		prefix: prefix,
+		buf:    make([]byte, 0, segmentSize),
+		out:    make([]byte, 0, segmentSize+tagSize),
+	}, nil
+}
+
+// OpenReader decrypts a stream written by SealWriter. A segment is only
+// returned once it has been authenticated.
+func (s *Sealer) OpenReader(wrappedKey []byte, r io.Reader) (io.Reader, error) {
+	aead, err := s.unwrap(wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("sealing.OpenReader: %w", err)
+	}
+
+	prefix := make([]byte, prefixSize)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, fmt.Errorf("sealing.OpenReader: %w", ErrMalformed)
+	}
+
+	return &openReader{
+		aead:   aead,
+		r:      bufio.NewReaderSize(r, segmentSize+tagSize),
+		prefix: prefix,
+		in:     make([]byte, segmentSize+tagSize),
+	}, nil
+}
+
+// SealedSize is the length of the stream SealWriter writes for size bytes.
+func (s *Sealer) SealedSize(size int64) int64 {
+	segments := max(1, (size+segmentSize-1)/segmentSize)
+
+	return prefixSize + size + segments*tagSize
+}
+
+func segmentNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, prefixSize+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if last {
+		return append(nonce, 1)
+	}
+
+	return append(nonce, 0)
+}
+
+type sealWriter struct {
+	aead    cipher.AEAD
+	w       io.Writer
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	out     []byte
+	err     error
+}
+
+// Write holds back a full segment until more data arrives, only Close knows
+// which segment is the last one.
+func (w *sealWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	written := 0
+	for len(p) > 0 {
+		if len(w.buf) == segmentSize {
+			if w.err = w.flush(false); w.err != nil {
+				return written, w.err
+			}
+		}
+
+		n := copy(w.buf[len(w.buf):segmentSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+func (w *sealWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.err = w.flush(true)
+	if w.err != nil {
+		return w.err
+	}
+	w.err = errors.New("sealing: write to a closed stream")
+
+	return nil
+}
+
+func (w *sealWriter) flush(last bool) error {
+	if w.counter == ^uint32(0) && !last {
+		return errTooLong
+	}
+
+	w.out = w.aead.Seal(w.out[:0], segmentNonce(w.prefix, w.counter, last), w.buf, nil)
+	if _, err := w.w.Write(w.out); err != nil {
+		return err
+	}
+	w.counter++
+	w.buf = w.buf[:0]
+
+	return nil
+}
+
+type openReader struct {
+	aead    cipher.AEAD
+	r       *bufio.Reader
+	prefix  []byte
+	counter uint32
+	in      []byte
+	buf     []byte
+	done    bool
+	err     error
+}
+
+func (r *openReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.next()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+// next opens the following segment. A segment is the last one when nothing
+// follows it.
+func (r *openReader) next() error {
+	n, err := io.ReadFull(r.r, r.in)
+	last := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	if err != nil && !last {
+		return err
+	}
+	if !last {
+		if _, err := r.r.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+	if !last && r.counter == ^uint32(0) {
+		return errTooLong
+	}
+
+	plaintext, err := r.aead.Open(r.in[:0], segmentNonce(r.prefix, r.counter, last), r.in[:n], nil)
+	if err != nil {
+		return ErrMalformed
+	}
+	r.counter++
+	r.buf = plaintext
+	r.done = last
+
+	return nil
+}
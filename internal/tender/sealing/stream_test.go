@@ -0,0 +1,106 @@
+package sealing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+func sealStream(t *testing.T, sealer *Sealer, key, plaintext []byte) []byte {
+	t.Helper()
+
+	var sealed bytes.Buffer
+	w, err := sealer.SealWriter(key, &sealed)
+	if err != nil {
+		t.Fatalf("SealWriter: %v", err)
+	}
+	// Odd writes cross the segment boundaries.
+	for chunk := plaintext; len(chunk) > 0; {
+		n := min(len(chunk), 1000)
+		if _, err := w.Write(chunk[:n]); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		chunk = chunk[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	return sealed.Bytes()
+}
+
+func openStream(sealer *Sealer, key, sealed []byte) ([]byte, error) {
+	r, err := sealer.OpenReader(key, bytes.NewReader(sealed))
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(r)
+}
+
+func TestStream(t *testing.T) {
+	sealer := newTestSealer(t)
+	key, err := sealer.NewKey()
+	if err != nil {
+		t.Fatalf("NewKey: %v", err)
+	}
+
+	for _, size := range []int{0, 1, segmentSize - 1, segmentSize, segmentSize + 1, 3*segmentSize + 17} {
+		plaintext := make([]byte, size)
+		if _, err := rand.Read(plaintext); err != nil {
+			t.Fatalf("rand: %v", err)
+		}
+
+		sealed := sealStream(t, sealer, key, plaintext)
+		if int64(len(sealed)) != sealer.SealedSize(int64(size)) {
+			t.Errorf("%d bytes: sealed %d bytes, SealedSize = %d", size, len(sealed), sealer.SealedSize(int64(size)))
+		}
+
+		got, err := openStream(sealer, key, sealed)
+		if err != nil {
+			t.Fatalf("%d bytes: open: %v", size, err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("%d bytes: opened content differs", size)
+		}
+	}
+}
+
+func TestStreamTampering(t *testing.T) {
+	sealer := newTestSealer(t)
+	key, _ := sealer.NewKey()
+	otherKey, _ := sealer.NewKey()
+
+	plaintext := bytes.Repeat([]byte("bid"), segmentSize)
+	sealed := sealStream(t, sealer, key, plaintext)
+	segment := segmentSize + tagSize
+
+	swapped := bytes.Join([][]byte{
+		sealed[:prefixSize],
+		sealed[prefixSize+segment : prefixSize+2*segment],
+		sealed[prefixSize : prefixSize+segment],
+		sealed[prefixSize+2*segment:],
+	}, nil)
+	flipped := bytes.Clone(sealed)
+	flipped[prefixSize+10] ^= 1
+
+	tests := map[string]struct {
+		key    []byte
+		sealed []byte
+	}{
+		"another tender key":       {otherKey, sealed},
+		"cut off at a segment":     {key, sealed[:prefixSize+segment]},
+		"cut off inside a segment": {key, sealed[:len(sealed)-1]},
+		"segments reordered":       {key, swapped},
+		"flipped bit":              {key, flipped},
+		"shorter than the prefix":  {key, sealed[:prefixSize-1]},
+		"only the prefix":          {key, sealed[:prefixSize]},
+	}
+	for name, test := range tests {
+		if _, err := openStream(sealer, test.key, test.sealed); !errors.Is(err, ErrMalformed) {
+			t.Errorf("%s: error = %v, want %v", name, err, ErrMalformed)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type Attachment struct {
+	repo         domain.AttachmentRepository
+	storage      domain.FileStorage
+	sealer       domain.BidSealer
+	maxSize      int64
+	allowedTypes []string
+}
+
+func NewAttachment(repo domain.AttachmentRepository, storage domain.FileStorage, sealer domain.BidSealer, maxSize int64, allowedTypes []string) *Attachment {
+	return &Attachment{repo: repo, storage: storage, sealer: sealer, maxSize: maxSize, allowedTypes: allowedTypes}
+}
+
+// UploadAttachment spools the file to a temporary file while computing its
+// size and SHA-256, checks the limits and only then hands it to the storage.
+// Files attached to bids on sealed tenders are stored encrypted.
+func (s *Attachment) UploadAttachment(ctx context.Context, owner domain.AttachmentOwner, fileName string, contentType string,
+	r io.Reader, username string) (domain.Attachment, error) {
+	fileName = filepath.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if fileName == "." || fileName == "/" || fileName == "" {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w: missing file name", domain.ErrInvalidInput)
+	}
+
+	target, err := s.repo.GetUploadTarget(ctx, owner, username)
+	if err != nil {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w", err)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w", err)
+	}
+
+	if size > s.maxSize {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w: limit is %d bytes", domain.ErrTooLarge, s.maxSize)
+	}
+
+	head := make([]byte, 512)
+	n, err := tmp.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w", err)
+	}
+
+	contentType = detectContentType(head[:n], contentType)
+	if !slices.Contains(s.allowedTypes, contentType) {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w: %s", domain.ErrUnsupportedType, contentType)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w", err)
+	}
+
+	key, err := storageKey(owner)
+	if err != nil {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w", err)
+	}
+
+	var (
+		content    io.Reader = tmp
+		storedSize           = size
+	)
+	if target.SealKey != nil {
+		if s.sealer == nil {
+			return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w: sealed tenders are not enabled", domain.ErrInvalidInput)
+		}
+
+		// The file is encrypted on its way to the storage.
+		pr, pw := io.Pipe()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			pw.CloseWithError(sealTo(s.sealer, target.SealKey, pw, tmp))
+		}()
+		defer func() {
+			pr.Close()
+			<-done
+		}()
+		content, storedSize = pr, s.sealer.SealedSize(size)
+	}
+
+	if err := s.storage.Put(ctx, key, content, storedSize, contentType); err != nil {
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w", err)
+	}
+
+	attachment, err := s.repo.CreateAttachment(ctx, owner, domain.Attachment{
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		Version:     target.Version,
+		StorageKey:  key,
+		UploadedBy:  username,
+		Sealed:      target.SealKey != nil,
+	})
+	if err != nil {
+		if deleteErr := s.storage.Delete(ctx, key); deleteErr != nil {
+			logger.Logger().Errorln("Error removing orphaned attachment:", deleteErr.Error())
+		}
+		return domain.Attachment{}, fmt.Errorf("service.UploadAttachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (s *Attachment) ListAttachments(ctx context.Context, owner domain.AttachmentOwner, username string) ([]domain.Attachment, error) {
+	attachments, err := s.repo.ListAttachments(ctx, owner, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListAttachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (s *Attachment) DownloadAttachment(ctx context.Context, owner domain.AttachmentOwner, attachmentID string,
+	username string) (domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.repo.GetAttachment(ctx, owner, attachmentID, username)
+	if err != nil {
+		return domain.Attachment{}, nil, fmt.Errorf("service.DownloadAttachment: %w", err)
+	}
+
+	rc, err := s.storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return domain.Attachment{}, nil, fmt.Errorf("service.DownloadAttachment: %w", err)
+	}
+
+	if !attachment.Sealed {
+		return attachment, rc, nil
+	}
+
+	if s.sealer == nil {
+		rc.Close()
+		return domain.Attachment{}, nil, fmt.Errorf("service.DownloadAttachment: %w: sealed tenders are not enabled", domain.ErrInvalidInput)
+	}
+
+	plaintext, err := s.sealer.OpenReader(attachment.SealKey, rc)
+	if err != nil {
+		rc.Close()
+		return domain.Attachment{}, nil, fmt.Errorf("service.DownloadAttachment: %w", err)
+	}
+
+	return attachment, struct {
+		io.Reader
+		io.Closer
+	}{plaintext, rc}, nil
+}
+
+func (s *Attachment) DeleteAttachment(ctx context.Context, owner domain.AttachmentOwner, attachmentID string, username string) error {
+	attachment, err := s.repo.DeleteAttachment(ctx, owner, attachmentID, username)
+	if err != nil {
+		return fmt.Errorf("service.DeleteAttachment: %w", err)
+	}
+
+	if err := s.storage.Delete(ctx, attachment.StorageKey); err != nil {
+		logger.Logger().Errorln("Error removing attachment from storage:", err.Error())
+	}
+
+	return nil
+}
+
+// sealTo encrypts the content of r into w.
+func sealTo(sealer domain.BidSealer, wrappedKey []byte, w io.Writer, r io.Reader) error {
+	sw, err := sealer.SealWriter(wrappedKey, w)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(sw, r); err != nil {
+		return err
+	}
+
+	return sw.Close()
+}
+
+// detectContentType sniffs the content. Formats that cannot be told apart by
+// their first bytes (OOXML documents are plain zip archives, for example) keep
+// the type declared by the client.
+func detectContentType(head []byte, declared string) string {
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	declared, _, _ = mime.ParseMediaType(declared)
+
+	switch sniffed {
+	case "application/octet-stream", "application/zip", "text/plain":
+		if declared != "" && declared != "application/octet-stream" {
+			return declared
+		}
+	}
+
+	return sniffed
+}
+
+func storageKey(owner domain.AttachmentOwner) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return string(owner.Type) + "/" + owner.ID + "/" + hex.EncodeToString(b), nil
+}
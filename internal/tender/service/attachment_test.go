@@ -0,0 +1,149 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/sealing"
+)
+
+type attachmentRepoStub struct {
+	domain.AttachmentRepository
+	target      domain.AttachmentTarget
+	attachments map[string]domain.Attachment
+}
+
+func (r *attachmentRepoStub) GetUploadTarget(context.Context, domain.AttachmentOwner, string) (domain.AttachmentTarget, error) {
+	return r.target, nil
+}
+
+func (r *attachmentRepoStub) CreateAttachment(_ context.Context, _ domain.AttachmentOwner, attachment domain.Attachment) (domain.Attachment, error) {
+	attachment.ID = attachment.StorageKey
+	r.attachments[attachment.ID] = attachment
+	return attachment, nil
+}
+
+func (r *attachmentRepoStub) GetAttachment(_ context.Context, _ domain.AttachmentOwner, attachmentID string, _ string) (domain.Attachment, error) {
+	attachment, ok := r.attachments[attachmentID]
+	if !ok {
+		return domain.Attachment{}, domain.ErrNotFound
+	}
+	if attachment.Sealed {
+		attachment.SealKey = r.target.SealKey
+	}
+	return attachment, nil
+}
+
+type storageStub struct {
+	files map[string][]byte
+}
+
+func (s *storageStub) Put(_ context.Context, key string, r io.Reader, size int64, _ string) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if int64(len(content)) != size {
+		return errors.New("size does not match the content")
+	}
+	s.files[key] = content
+	return nil
+}
+
+func (s *storageStub) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.files[key])), nil
+}
+
+func (s *storageStub) Delete(_ context.Context, key string) error {
+	delete(s.files, key)
+	return nil
+}
+
+func newTestSealer(t *testing.T) *sealing.Sealer {
+	t.Helper()
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("rand: %v", err)
+	}
+
+	sealer, err := sealing.NewSealer(base64.StdEncoding.EncodeToString(key))
+	if err != nil {
+		t.Fatalf("NewSealer: %v", err)
+	}
+
+	return sealer
+}
+
+func TestSealedBidAttachment(t *testing.T) {
+	const content = "steel pipes, 900"
+	ctx := context.Background()
+	owner := domain.AttachmentOwner{Type: domain.AttachmentOwnerBid, ID: batchCreated}
+
+	sealer := newTestSealer(t)
+	sealKey, err := sealer.NewKey()
+	if err != nil {
+		t.Fatalf("NewKey: %v", err)
+	}
+
+	repo := &attachmentRepoStub{target: domain.AttachmentTarget{Version: 1, SealKey: sealKey}, attachments: map[string]domain.Attachment{}}
+	storage := &storageStub{files: map[string][]byte{}}
+	s := NewAttachment(repo, storage, sealer, 1024, []string{"text/plain"})
+
+	attachment, err := s.UploadAttachment(ctx, owner, "offer.txt", "text/plain", strings.NewReader(content), "ivan")
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+	if !attachment.Sealed || attachment.Size != int64(len(content)) {
+		t.Errorf("attachment = %+v, want a sealed one of the uploaded size", attachment)
+	}
+	if stored := storage.files[attachment.StorageKey]; bytes.Contains(stored, []byte("steel pipes")) {
+		t.Errorf("stored file %q is not encrypted", stored)
+	}
+
+	_, rc, err := s.DownloadAttachment(ctx, owner, attachment.ID, "ivan")
+	if err != nil {
+		t.Fatalf("DownloadAttachment: %v", err)
+	}
+	defer rc.Close()
+	if downloaded, _ := io.ReadAll(rc); string(downloaded) != content {
+		t.Errorf("downloaded %q, want %q", downloaded, content)
+	}
+
+	// Files larger than a segment of the sealed stream.
+	large := strings.Repeat("steel pipes, 900\n", 10000)
+	s.maxSize = int64(len(large))
+	attachment, err = s.UploadAttachment(ctx, owner, "offer.txt", "text/plain", strings.NewReader(large), "ivan")
+	if err != nil {
+		t.Fatalf("UploadAttachment of a large file: %v", err)
+	}
+	_, rc, err = s.DownloadAttachment(ctx, owner, attachment.ID, "ivan")
+	if err != nil {
+		t.Fatalf("DownloadAttachment of a large file: %v", err)
+	}
+	defer rc.Close()
+	if downloaded, _ := io.ReadAll(rc); string(downloaded) != large {
+		t.Errorf("downloaded %d bytes, want the %d uploaded", len(downloaded), len(large))
+	}
+
+	disabled := NewAttachment(repo, storage, nil, 1024, []string{"text/plain"})
+	if _, err := disabled.UploadAttachment(ctx, owner, "offer.txt", "text/plain", strings.NewReader(content), "ivan"); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("UploadAttachment without a sealer: error = %v, want %v", err, domain.ErrInvalidInput)
+	}
+
+	repo.target.SealKey = nil
+	plain, err := s.UploadAttachment(ctx, owner, "offer.txt", "text/plain", strings.NewReader(content), "ivan")
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+	if plain.Sealed || string(storage.files[plain.StorageKey]) != content {
+		t.Errorf("attachment of an open tender = %+v, stored %q", plain, storage.files[plain.StorageKey])
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// auctionWatcherBuffer is how many events a watcher may fall behind before it
+// is dropped.
+const auctionWatcherBuffer = 16
+
+// Auction places offers and fans accepted offers out to the watchers of the
+// auction connected to this instance.
+type Auction struct {
+	repo domain.AuctionRepository
+
+	mu       sync.Mutex
+	watchers map[string]map[chan domain.AuctionEvent]struct{}
+}
+
+func NewAuction(repo domain.AuctionRepository) *Auction {
+	return &Auction{repo: repo, watchers: map[string]map[chan domain.AuctionEvent]struct{}{}}
+}
+
+// validateAuction checks the auction settings of a new tender.
+func validateAuction(tender domain.Tender) error {
+	auction := tender.Auction
+	if tender.Type != domain.TenderTypeAuction {
+		if auction != nil {
+			return fmt.Errorf("%w: auction settings require type %s", domain.ErrInvalidInput, domain.TenderTypeAuction)
+		}
+		return nil
+	}
+
+	switch {
+	case auction == nil:
+		return fmt.Errorf("%w: auction settings are required", domain.ErrInvalidInput)
+	case tender.Sealed:
+		return fmt.Errorf("%w: an auction cannot be sealed", domain.ErrInvalidInput)
+	case len(tender.Lots) > 0:
+		return fmt.Errorf("%w: an auction cannot have lots", domain.ErrInvalidInput)
+	case auction.StartsAt.IsZero() || !auction.EndsAt.After(auction.StartsAt):
+		return fmt.Errorf("%w: auction must end after it starts", domain.ErrInvalidInput)
+	case auction.StartPrice <= 0:
+		return fmt.Errorf("%w: start price must be positive", domain.ErrInvalidInput)
+	case auction.MinDecrement <= 0:
+		return fmt.Errorf("%w: minimum decrement must be positive", domain.ErrInvalidInput)
+	case auction.ExtensionSeconds < 0:
+		return fmt.Errorf("%w: extension must not be negative", domain.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+func (s *Auction) GetAuction(ctx context.Context, tenderID string, username string) (domain.Auction, error) {
+	auction, err := s.repo.GetAuction(ctx, tenderID, username)
+	if err != nil {
+		return domain.Auction{}, fmt.Errorf("service.GetAuction: %w", err)
+	}
+
+	return auction, nil
+}
+
+func (s *Auction) ListOffers(ctx context.Context, tenderID string, limit, offset int, username string) ([]domain.AuctionOffer, error) {
+	offers, err := s.repo.ListOffers(ctx, tenderID, limit, offset, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListOffers: %w", err)
+	}
+
+	return offers, nil
+}
+
+func (s *Auction) PlaceOffer(ctx context.Context, tenderID string, offer domain.AuctionOffer) (domain.AuctionOffer, error) {
+	if offer.OrganizationID == "" {
+		return domain.AuctionOffer{}, fmt.Errorf("service.PlaceOffer: %w: organizationId is required", domain.ErrInvalidInput)
+	}
+	if offer.Price <= 0 {
+		return domain.AuctionOffer{}, fmt.Errorf("service.PlaceOffer: %w: price must be positive", domain.ErrInvalidInput)
+	}
+
+	created, auction, err := s.repo.PlaceOffer(ctx, tenderID, offer)
+	if err != nil {
+		return domain.AuctionOffer{}, fmt.Errorf("service.PlaceOffer: %w", err)
+	}
+
+	s.publish(tenderID, domain.AuctionEvent{
+		Type:    domain.AuctionEventOffer,
+		Auction: auction,
+		Offer:   &domain.AuctionOffer{TenderID: created.TenderID, Seq: created.Seq, Price: created.Price, CreatedAt: created.CreatedAt},
+	})
+
+	return created, nil
+}
+
+// Subscribe returns the current state of the auction and a channel of the
+// following events. The channel is closed by the returned cancel function,
+// or early if the watcher falls behind.
+func (s *Auction) Subscribe(ctx context.Context, tenderID string, username string) (domain.Auction, <-chan domain.AuctionEvent, func(), error) {
+	events := make(chan domain.AuctionEvent, auctionWatcherBuffer)
+
+	// Register before reading the state so no offer accepted in between is
+	// missed; a watcher may see an offer both in the state and as an event.
+	s.mu.Lock()
+	if s.watchers[tenderID] == nil {
+		s.watchers[tenderID] = map[chan domain.AuctionEvent]struct{}{}
+	}
+	s.watchers[tenderID][events] = struct{}{}
+	s.mu.Unlock()
+
+	cancel := func() { s.unsubscribe(tenderID, events) }
+
+	auction, err := s.repo.GetAuction(ctx, tenderID, username)
+	if err != nil {
+		cancel()
+		return domain.Auction{}, nil, nil, fmt.Errorf("service.Subscribe: %w", err)
+	}
+
+	return auction, events, cancel, nil
+}
+
+func (s *Auction) unsubscribe(tenderID string, events chan domain.AuctionEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.watchers[tenderID][events]; !ok {
+		return
+	}
+
+	delete(s.watchers[tenderID], events)
+	if len(s.watchers[tenderID]) == 0 {
+		delete(s.watchers, tenderID)
+	}
+	close(events)
+}
+
+func (s *Auction) publish(tenderID string, event domain.AuctionEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for events := range s.watchers[tenderID] {
+		select {
+		case events <- event:
+		default:
+			delete(s.watchers[tenderID], events)
+			close(events)
+		}
+	}
+
+	if len(s.watchers[tenderID]) == 0 {
+		delete(s.watchers, tenderID)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type Award struct {
+	repo     domain.AwardRepository
+	tenders  domain.TenderGetter
+	sealer   domain.BidSealer
+	renderer domain.ContractRenderer
+}
+
+func NewAward(repo domain.AwardRepository, tenders domain.TenderGetter, sealer domain.BidSealer, renderer domain.ContractRenderer) *Award {
+	return &Award{repo: repo, tenders: tenders, sealer: sealer, renderer: renderer}
+}
+
+func (s *Award) AwardTender(ctx context.Context, tenderID string, bidID string, username string) ([]domain.Award, error) {
+	awards, err := s.repo.AwardTender(ctx, tenderID, bidID, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.AwardTender: %w", err)
+	}
+
+	if err := openAwards(ctx, s.tenders, s.sealer, awards); err != nil {
+		return nil, fmt.Errorf("service.AwardTender: %w", err)
+	}
+
+	return awards, nil
+}
+
+func (s *Award) GetAwards(ctx context.Context, tenderID string, username string) ([]domain.Award, error) {
+	awards, err := s.repo.ListAwards(ctx, tenderID, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetAwards: %w", err)
+	}
+
+	if err := openAwards(ctx, s.tenders, s.sealer, awards); err != nil {
+		return nil, fmt.Errorf("service.GetAwards: %w", err)
+	}
+
+	return awards, nil
+}
+
+func (s *Award) GenerateContract(ctx context.Context, tenderID string, format domain.ContractFormat, username string) ([]byte, error) {
+	if format != domain.ContractFormatMarkdown && format != domain.ContractFormatPDF {
+		return nil, fmt.Errorf("service.GenerateContract: %w: format must be markdown or pdf", domain.ErrInvalidInput)
+	}
+
+	data, err := s.repo.GetContractData(ctx, tenderID, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.GenerateContract: %w", err)
+	}
+
+	awards := make([]domain.Award, len(data.Awards))
+	for i := range data.Awards {
+		awards[i] = data.Awards[i].Award
+	}
+
+	if err := openAwards(ctx, s.tenders, s.sealer, awards); err != nil {
+		return nil, fmt.Errorf("service.GenerateContract: %w", err)
+	}
+
+	for i := range data.Awards {
+		data.Awards[i].Award = awards[i]
+		if awards[i].Bid != nil {
+			data.Awards[i].BidName = awards[i].Bid.Name
+		}
+	}
+	data.GeneratedAt = time.Now()
+
+	var document []byte
+	if format == domain.ContractFormatPDF {
+		document, err = s.renderer.PDF(data)
+	} else {
+		document, err = s.renderer.Markdown(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("service.GenerateContract: %w", err)
+	}
+
+	return document, nil
+}
+
+// openAwards decrypts the winning bids of sealed tenders, whose prices are
+// not stored in the clear, and takes the awarded amounts from them.
+func openAwards(ctx context.Context, tenders domain.TenderGetter, sealer domain.BidSealer, awards []domain.Award) error {
+	bids := []domain.Bid{}
+	for _, award := range awards {
+		if award.Bid != nil {
+			bids = append(bids, *award.Bid)
+		}
+	}
+
+	if err := openBids(ctx, tenders, sealer, bids); err != nil {
+		return err
+	}
+
+	opened := make(map[string]domain.Bid, len(bids))
+	for _, bid := range bids {
+		opened[bid.ID] = bid
+	}
+
+	for i := range awards {
+		if awards[i].Bid == nil {
+			continue
+		}
+
+		bid := opened[awards[i].Bid.ID]
+		awards[i].Bid = &bid
+		if awards[i].Amount != nil {
+			continue
+		}
+
+		if awards[i].LotID == nil {
+			awards[i].Amount = bid.Price
+			continue
+		}
+		for _, lot := range bid.Lots {
+			if lot.LotID == *awards[i].LotID {
+				awards[i].Amount = lot.Price
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+type Bid struct {
+	repo     domain.BidRepository
+	tenders  domain.TenderGetter
+	notifier domain.Notifier
+	sealer   domain.BidSealer
+}
+
+func NewBid(repo domain.BidRepository, tenders domain.TenderGetter, notifier domain.Notifier, sealer domain.BidSealer) *Bid {
+	return &Bid{repo: repo, tenders: tenders, notifier: notifier, sealer: sealer}
+}
+
+// sealedBid is the encrypted part of a bid on a sealed tender.
+type sealedBid struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Price       *float64        `json:"price,omitempty"`
+	Lots        []domain.BidLot `json:"lots,omitempty"`
+}
+
+func (s *Bid) CreateBid(ctx context.Context, bid domain.Bid) (domain.Bid, error) {
+	seen := make(map[string]bool, len(bid.Lots))
+	for _, lot := range bid.Lots {
+		if lot.LotID == "" || seen[lot.LotID] {
+			return domain.Bid{}, fmt.Errorf("service.CreateBid: %w: invalid or duplicate lot", domain.ErrInvalidInput)
+		}
+		if lot.Price != nil && *lot.Price < 0 {
+			return domain.Bid{}, fmt.Errorf("service.CreateBid: %w: price must not be negative", domain.ErrInvalidInput)
+		}
+		seen[lot.LotID] = true
+	}
+
+	if bid.Price != nil && *bid.Price < 0 {
+		return domain.Bid{}, fmt.Errorf("service.CreateBid: %w: price must not be negative", domain.ErrInvalidInput)
+	}
+
+	tender, err := s.tenders.GetTenderByID(ctx, bid.TenderId)
+	if err != nil {
+		return domain.Bid{}, fmt.Errorf("service.CreateBid: %w", err)
+	}
+
+	plain := bid
+	if tender.Sealed {
+		bid, err = s.seal(tender, bid)
+		if err != nil {
+			return domain.Bid{}, fmt.Errorf("service.CreateBid: %w", err)
+		}
+	}
+
+	createdBid, err := s.repo.CreateBid(ctx, bid)
+	if err != nil {
+		return domain.Bid{}, fmt.Errorf("service.CreateBid: %w", err)
+	}
+
+	notified := createdBid
+	err = s.notifier.Notify(ctx, domain.Notification{Event: domain.NotificationBidCreated, Tender: tender, Bid: &notified})
+	if err != nil {
+		logger.Logger().Errorln("Error queueing bid notification:", err.Error())
+	}
+
+	if createdBid.Sealed {
+		createdBid.Name, createdBid.Description, createdBid.Price, createdBid.Lots = plain.Name, plain.Description, plain.Price, plain.Lots
+	}
+
+	return createdBid, nil
+}
+
+// seal moves the contents of the bid into its encrypted payload, leaving only
+// the targeted lots in the clear.
+func (s *Bid) seal(tender domain.Tender, bid domain.Bid) (domain.Bid, error) {
+	if s.sealer == nil {
+		return domain.Bid{}, fmt.Errorf("%w: sealed tenders are not enabled", domain.ErrInvalidInput)
+	}
+
+	payload, err := json.Marshal(sealedBid{Name: bid.Name, Description: bid.Description, Price: bid.Price, Lots: bid.Lots})
+	if err != nil {
+		return domain.Bid{}, err
+	}
+
+	bid.SealedPayload, err = s.sealer.Seal(tender.SealKey, payload)
+	if err != nil {
+		return domain.Bid{}, err
+	}
+
+	lots := make([]domain.BidLot, 0, len(bid.Lots))
+	for _, lot := range bid.Lots {
+		lots = append(lots, domain.BidLot{LotID: lot.LotID})
+	}
+
+	bid.Name, bid.Description, bid.Price, bid.Lots = "", "", nil, lots
+	bid.Sealed = true
+
+	return bid, nil
+}
+
+// openBids decrypts sealed bids in place. Callers are responsible for only
+// passing bids the requesting employee may read.
+func openBids(ctx context.Context, tenders domain.TenderGetter, sealer domain.BidSealer, bids []domain.Bid) error {
+	keys := map[string][]byte{}
+	for i := range bids {
+		if !bids[i].Sealed {
+			continue
+		}
+
+		if sealer == nil {
+			return fmt.Errorf("%w: sealed tenders are not enabled", domain.ErrInvalidInput)
+		}
+
+		key, ok := keys[bids[i].TenderId]
+		if !ok {
+			tender, err := tenders.GetTenderByID(ctx, bids[i].TenderId)
+			if err != nil {
+				return err
+			}
+			key = tender.SealKey
+			keys[bids[i].TenderId] = key
+		}
+
+		payload, err := sealer.Open(key, bids[i].SealedPayload)
+		if err != nil {
+			return err
+		}
+
+		var contents sealedBid
+		if err := json.Unmarshal(payload, &contents); err != nil {
+			return err
+		}
+
+		bids[i].Name, bids[i].Description, bids[i].Price = contents.Name, contents.Description, contents.Price
+		if len(contents.Lots) > 0 {
+			bids[i].Lots = contents.Lots
+		}
+	}
+
+	return nil
+}
+
+func (s *Bid) GetUserBids(ctx context.Context, limit, offset int, username string) ([]domain.Bid, error) {
+	bids, err := s.repo.GetUserBids(ctx, limit, offset, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetUserBids: %w", err)
+	}
+
+	if err := openBids(ctx, s.tenders, s.sealer, bids); err != nil {
+		return nil, fmt.Errorf("service.GetUserBids: %w", err)
+	}
+
+	return bids, nil
+}
+
+func (s *Bid) ListTenderBids(ctx context.Context, tenderID string, limit, offset int, username string) ([]domain.Bid, error) {
+	bids, err := s.repo.ListTenderBids(ctx, tenderID, limit, offset, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListTenderBids: %w", err)
+	}
+
+	if err := openBids(ctx, s.tenders, s.sealer, bids); err != nil {
+		return nil, fmt.Errorf("service.ListTenderBids: %w", err)
+	}
+
+	return bids, nil
+}
+
+func (s *Bid) CountTenderBids(ctx context.Context, tenderID string, username string) (domain.BidCount, error) {
+	count, err := s.repo.CountTenderBids(ctx, tenderID, username)
+	if err != nil {
+		return domain.BidCount{}, fmt.Errorf("service.CountTenderBids: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const clonedTender = "5b8f2a0e-6b1c-4f43-9a57-0d7a51b6c010"
+
+type cloneRepoStub struct {
+	domain.TenderRepository
+	source  domain.Tender
+	created []domain.Tender
+}
+
+func (r *cloneRepoStub) GetTender(_ context.Context, tenderID string, _ string) (domain.Tender, error) {
+	if tenderID != r.source.ID {
+		return domain.Tender{}, domain.ErrTenderNotFound
+	}
+	return r.source, nil
+}
+
+func (r *cloneRepoStub) CreateTender(_ context.Context, tender domain.Tender) (domain.Tender, error) {
+	tender.ID = clonedTender
+	r.created = append(r.created, tender)
+	return tender, nil
+}
+
+type cloneFieldsStub struct {
+	fields []domain.CustomField
+}
+
+func (s *cloneFieldsStub) GetOrganizationFields(context.Context, string) ([]domain.CustomField, error) {
+	return s.fields, nil
+}
+
+func TestCloneTender(t *testing.T) {
+	ctx := context.Background()
+	budget, lotBudget := 5000.0, 1200.0
+	awardedBid := "4e5f6a7b-8c9d-4e0f-a1b2-c3d4e5f6a7b8"
+	awardedAt := time.Now()
+	closesAt := time.Now().Add(48 * time.Hour)
+
+	source := domain.Tender{
+		ID:              batchCreated,
+		Name:            "Поставка труб",
+		Description:     "Трубы и фитинги",
+		ServiceType:     "Delivery",
+		Status:          "CLOSED",
+		OrganizationId:  importOrg,
+		CreatorUsername: "ivan",
+		Version:         4,
+		Visibility:      domain.TenderVisibilityPrivate,
+		Sealed:          true,
+		SealKey:         []byte("key of the source"),
+		Budget:          &budget,
+		ClosesAt:        &closesAt,
+		Lots: []domain.Lot{
+			{ID: "lot-1", Name: "Трубы", Quantity: 100, Unit: "м", Budget: &lotBudget, AwardedBidID: &awardedBid, AwardedAt: &awardedAt},
+			{ID: "lot-2", Name: "Фитинги", Quantity: 40, Unit: "шт"},
+		},
+		Criteria:     []domain.Criterion{{ID: "criterion-1", Name: "Цена", Weight: 3}, {ID: "criterion-2", Name: "Срок", Weight: 1}},
+		Tags:         []string{"металл", "трубы"},
+		CustomFields: map[string]any{"region": "Москва", "lots": 2.0},
+	}
+	repo := &cloneRepoStub{source: source}
+	fields := &cloneFieldsStub{fields: []domain.CustomField{
+		{Key: "region", Type: domain.CustomFieldTypeString},
+		{Key: "lots", Type: domain.CustomFieldTypeNumber},
+	}}
+	s := NewTender(repo, &notifierStub{}, nil, importCatalogStub{}, fields)
+
+	clone, err := s.CloneTender(ctx, source.ID, "petr")
+	if err != nil {
+		t.Fatalf("CloneTender: %v", err)
+	}
+
+	if clone.Status != "CREATED" || clone.Version != 1 || clone.CreatorUsername != "petr" || clone.OrganizationId != importOrg {
+		t.Errorf("clone = %+v, want a new created tender of the organization by petr", clone)
+	}
+	if clone.Name != source.Name || clone.Description != source.Description || clone.ServiceType != "Delivery" ||
+		clone.Visibility != domain.TenderVisibilityPrivate || clone.Budget == nil || *clone.Budget != budget {
+		t.Errorf("clone = %+v, want the fields of the source", clone)
+	}
+	if clone.Sealed || clone.SealKey != nil || clone.ClosesAt != nil || clone.Auction != nil {
+		t.Errorf("clone = %+v, deadlines and sealing do not carry over", clone)
+	}
+
+	if len(clone.Lots) != 2 {
+		t.Fatalf("lots = %+v, want both lots of the source", clone.Lots)
+	}
+	for i, lot := range clone.Lots {
+		want := source.Lots[i]
+		if lot.ID != "" || lot.AwardedBidID != nil || lot.AwardedAt != nil {
+			t.Errorf("lot %d = %+v, the identity and award stay with the source", i, lot)
+		}
+		if lot.Name != want.Name || lot.Quantity != want.Quantity || lot.Unit != want.Unit {
+			t.Errorf("lot %d = %+v, want %+v", i, lot, want)
+		}
+	}
+	if clone.Lots[0].Budget == nil || *clone.Lots[0].Budget != lotBudget {
+		t.Errorf("lot budget = %v, want %v", clone.Lots[0].Budget, lotBudget)
+	}
+	if source.Lots[0].ID != "lot-1" || source.Lots[0].AwardedBidID == nil {
+		t.Errorf("source lots = %+v, cloning must not change them", source.Lots)
+	}
+
+	if len(clone.Criteria) != 2 || clone.Criteria[0] != (domain.Criterion{Name: "Цена", Weight: 3}) || clone.Criteria[1] != (domain.Criterion{Name: "Срок", Weight: 1}) {
+		t.Errorf("criteria = %+v, want those of the source without IDs", clone.Criteria)
+	}
+	if !slices.Equal(clone.Tags, source.Tags) {
+		t.Errorf("tags = %v, want %v", clone.Tags, source.Tags)
+	}
+	if !maps.Equal(clone.CustomFields, source.CustomFields) {
+		t.Errorf("custom fields = %v, want %v", clone.CustomFields, source.CustomFields)
+	}
+
+	// The copy is checked against the fields the organization has now.
+	fields.fields = fields.fields[1:]
+	if _, err := s.CloneTender(ctx, source.ID, "petr"); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("CloneTender with a removed custom field: error = %v, want %v", err, domain.ErrInvalidInput)
+	}
+	if len(repo.created) != 1 {
+		t.Errorf("created %d tenders, want only the first clone", len(repo.created))
+	}
+
+	if _, err := s.CloneTender(ctx, batchMissing, "petr"); !errors.Is(err, domain.ErrTenderNotFound) {
+		t.Errorf("CloneTender of a missing tender: error = %v, want %v", err, domain.ErrTenderNotFound)
+	}
+}
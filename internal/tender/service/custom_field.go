@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const (
+	maxTags      = 20
+	maxTagLength = 50
+)
+
+// customFieldKey keeps keys usable as cf.<key> query parameters.
+var customFieldKey = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,49}$`)
+
+type CustomField struct {
+	repo domain.CustomFieldRepository
+}
+
+func NewCustomField(repo domain.CustomFieldRepository) *CustomField {
+	return &CustomField{repo: repo}
+}
+
+func (s *CustomField) CreateCustomField(ctx context.Context, field domain.CustomField, username string) (domain.CustomField, error) {
+	if !customFieldKey.MatchString(field.Key) {
+		return domain.CustomField{}, fmt.Errorf("service.CreateCustomField: %w: key must start with a letter and contain only letters, digits and underscores", domain.ErrInvalidInput)
+	}
+
+	field.Name = strings.TrimSpace(field.Name)
+	if field.Name == "" {
+		field.Name = field.Key
+	}
+
+	options := []string{}
+	for _, option := range field.Options {
+		if option = strings.TrimSpace(option); option != "" && !slices.Contains(options, option) {
+			options = append(options, option)
+		}
+	}
+	field.Options = options
+
+	switch field.Type {
+	case domain.CustomFieldTypeString, domain.CustomFieldTypeNumber, domain.CustomFieldTypeDate:
+		if len(field.Options) > 0 {
+			return domain.CustomField{}, fmt.Errorf("service.CreateCustomField: %w: options are only allowed for ENUM fields", domain.ErrInvalidInput)
+		}
+	case domain.CustomFieldTypeEnum:
+		if len(field.Options) == 0 {
+			return domain.CustomField{}, fmt.Errorf("service.CreateCustomField: %w: ENUM field needs options", domain.ErrInvalidInput)
+		}
+	default:
+		return domain.CustomField{}, fmt.Errorf("service.CreateCustomField: %w: unknown type %q", domain.ErrInvalidInput, field.Type)
+	}
+
+	created, err := s.repo.CreateCustomField(ctx, field, username)
+	if err != nil {
+		return domain.CustomField{}, fmt.Errorf("service.CreateCustomField: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *CustomField) ListCustomFields(ctx context.Context, organizationID string, username string) ([]domain.CustomField, error) {
+	fields, err := s.repo.ListCustomFields(ctx, organizationID, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListCustomFields: %w", err)
+	}
+
+	return fields, nil
+}
+
+func (s *CustomField) DeleteCustomField(ctx context.Context, organizationID string, key string, username string) error {
+	if err := s.repo.DeleteCustomField(ctx, organizationID, key, username); err != nil {
+		return fmt.Errorf("service.DeleteCustomField: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeTags trims and lowercases the tags and drops empty and repeated
+// ones.
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || slices.Contains(normalized, tag) {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, fmt.Errorf("%w: tag %q is longer than %d characters", domain.ErrInvalidInput, tag, maxTagLength)
+		}
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTags {
+		return nil, fmt.Errorf("%w: at most %d tags", domain.ErrInvalidInput, maxTags)
+	}
+
+	return normalized, nil
+}
+
+// validateCustomFields checks the values against the field definitions of
+// the organization and returns them normalized. Null values leave a field
+// unset.
+func validateCustomFields(fields []domain.CustomField, values map[string]any) (map[string]any, error) {
+	validated := map[string]any{}
+	for key, value := range values {
+		i := slices.IndexFunc(fields, func(field domain.CustomField) bool { return field.Key == key })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: unknown custom field %q", domain.ErrInvalidInput, key)
+		}
+		if value == nil {
+			continue
+		}
+
+		field := fields[i]
+		text, isText := value.(string)
+		text = strings.TrimSpace(text)
+
+		switch field.Type {
+		case domain.CustomFieldTypeString:
+			if !isText || text == "" {
+				return nil, fmt.Errorf("%w: custom field %q must be a non-empty string", domain.ErrInvalidInput, key)
+			}
+			validated[key] = text
+		case domain.CustomFieldTypeNumber:
+			number, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%w: custom field %q must be a number", domain.ErrInvalidInput, key)
+			}
+			validated[key] = number
+		case domain.CustomFieldTypeDate:
+			date, err := time.Parse(domain.CustomFieldDateLayout, text)
+			if !isText || err != nil {
+				return nil, fmt.Errorf("%w: custom field %q must be a date in the YYYY-MM-DD format", domain.ErrInvalidInput, key)
+			}
+			validated[key] = date.Format(domain.CustomFieldDateLayout)
+		case domain.CustomFieldTypeEnum:
+			if !isText || !slices.Contains(field.Options, text) {
+				return nil, fmt.Errorf("%w: custom field %q must be one of %s", domain.ErrInvalidInput, key, strings.Join(field.Options, ", "))
+			}
+			validated[key] = text
+		}
+	}
+
+	for _, field := range fields {
+		if _, ok := validated[field.Key]; field.Required && !ok {
+			return nil, fmt.Errorf("%w: custom field %q is required", domain.ErrInvalidInput, field.Key)
+		}
+	}
+
+	return validated, nil
+}
+
+// decodeTags and decodeCustomFields convert the values of a partial update,
+// which arrive as generic JSON values.
+func decodeTags(raw interface{}) ([]string, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid tags", domain.ErrInvalidInput)
+	}
+
+	tags := []string{}
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("%w: invalid tags", domain.ErrInvalidInput)
+	}
+
+	return normalizeTags(tags)
+}
+
+func decodeCustomFields(raw interface{}) (map[string]any, error) {
+	values, ok := raw.(map[string]interface{})
+	if !ok && raw != nil {
+		return nil, fmt.Errorf("%w: invalid customFields", domain.ErrInvalidInput)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+func TestValidateCustomFields(t *testing.T) {
+	fields := []domain.CustomField{
+		{Key: "region", Type: domain.CustomFieldTypeString, Required: true},
+		{Key: "floors", Type: domain.CustomFieldTypeNumber},
+		{Key: "deadline", Type: domain.CustomFieldTypeDate},
+		{Key: "payment", Type: domain.CustomFieldTypeEnum, Options: []string{"prepaid", "postpaid"}},
+	}
+
+	valid := []struct {
+		name   string
+		values map[string]any
+		want   map[string]any
+	}{
+		{"required only", map[string]any{"region": "Москва"}, map[string]any{"region": "Москва"}},
+		{
+			"all types",
+			map[string]any{"region": " Москва ", "floors": 3.5, "deadline": "2026-03-01", "payment": "prepaid"},
+			map[string]any{"region": "Москва", "floors": 3.5, "deadline": "2026-03-01", "payment": "prepaid"},
+		},
+		{"null leaves a field unset", map[string]any{"region": "Москва", "floors": nil}, map[string]any{"region": "Москва"}},
+	}
+
+	for _, tt := range valid {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateCustomFields(fields, tt.values)
+			if err != nil {
+				t.Fatalf("validateCustomFields: %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	invalid := []struct {
+		name   string
+		values map[string]any
+	}{
+		{"missing required", map[string]any{"floors": 3.0}},
+		{"null required", map[string]any{"region": nil}},
+		{"unknown key", map[string]any{"region": "Москва", "color": "red"}},
+		{"empty string", map[string]any{"region": "  "}},
+		{"string of another type", map[string]any{"region": 7.0}},
+		{"number as text", map[string]any{"region": "Москва", "floors": "3"}},
+		{"date in another format", map[string]any{"region": "Москва", "deadline": "01.03.2026"}},
+		{"date with a time", map[string]any{"region": "Москва", "deadline": "2026-03-01T10:00:00Z"}},
+		{"impossible date", map[string]any{"region": "Москва", "deadline": "2026-02-30"}},
+		{"date of another type", map[string]any{"region": "Москва", "deadline": 20260301.0}},
+		{"enum outside the options", map[string]any{"region": "Москва", "payment": "barter"}},
+		{"enum in another case", map[string]any{"region": "Москва", "payment": "Prepaid"}},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := validateCustomFields(fields, tt.values); !errors.Is(err, domain.ErrInvalidInput) {
+				t.Errorf("validateCustomFields(%v): error = %v, want %v", tt.values, err, domain.ErrInvalidInput)
+			}
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := normalizeTags([]string{" IT ", "it", "", "Склад", "склад "})
+	if err != nil {
+		t.Fatalf("normalizeTags: %v", err)
+	}
+	if want := []string{"it", "склад"}; !slices.Equal(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+
+	// The length counts characters, not bytes.
+	if _, err := normalizeTags([]string{strings.Repeat("я", maxTagLength)}); err != nil {
+		t.Errorf("normalizeTags of a tag of %d characters: %v", maxTagLength, err)
+	}
+	if _, err := normalizeTags([]string{strings.Repeat("я", maxTagLength+1)}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("normalizeTags of a longer tag: error = %v, want %v", err, domain.ErrInvalidInput)
+	}
+
+	many := []string{}
+	for i := range maxTags + 1 {
+		many = append(many, strings.Repeat("t", i+1))
+	}
+	if _, err := normalizeTags(many); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("normalizeTags of %d tags: error = %v, want %v", len(many), err, domain.ErrInvalidInput)
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// The lengths of the shared organization and employee columns.
+const (
+	maxOrganizationName = 100
+	maxUsername         = 50
+	maxPersonName       = 50
+)
+
+var organizationTypes = map[domain.OrganizationType]bool{
+	domain.OrganizationTypeIE:  true,
+	domain.OrganizationTypeLLC: true,
+	domain.OrganizationTypeJSC: true,
+}
+
+type Directory struct {
+	repo domain.DirectoryRepository
+}
+
+func NewDirectory(repo domain.DirectoryRepository) *Directory {
+	return &Directory{repo: repo}
+}
+
+func (s *Directory) ListOrganizations(ctx context.Context, limit int, offset int, username string) ([]domain.Organization, error) {
+	organizations, err := s.repo.ListOrganizations(ctx, limit, offset, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListOrganizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (s *Directory) CreateOrganization(ctx context.Context, req domain.CreateOrganizationRequest, username string) (domain.Organization, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := validateOrganizationName(req.Name); err != nil {
+		return domain.Organization{}, fmt.Errorf("service.CreateOrganization: %w", err)
+	}
+	if !organizationTypes[req.Type] {
+		return domain.Organization{}, fmt.Errorf("service.CreateOrganization: %w: unknown organization type %q", domain.ErrInvalidInput, req.Type)
+	}
+
+	created, err := s.repo.CreateOrganization(ctx, req, username)
+	if err != nil {
+		return domain.Organization{}, fmt.Errorf("service.CreateOrganization: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *Directory) UpdateOrganization(ctx context.Context, organizationID string, update domain.OrganizationUpdate, username string) (domain.Organization, error) {
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if err := validateOrganizationName(name); err != nil {
+			return domain.Organization{}, fmt.Errorf("service.UpdateOrganization: %w", err)
+		}
+		update.Name = &name
+	}
+	if update.Type != nil && !organizationTypes[*update.Type] {
+		return domain.Organization{}, fmt.Errorf("service.UpdateOrganization: %w: unknown organization type %q", domain.ErrInvalidInput, *update.Type)
+	}
+
+	updated, err := s.repo.UpdateOrganization(ctx, organizationID, update, username)
+	if err != nil {
+		return domain.Organization{}, fmt.Errorf("service.UpdateOrganization: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (s *Directory) DeleteOrganization(ctx context.Context, organizationID string, username string) error {
+	if err := s.repo.DeleteOrganization(ctx, organizationID, username); err != nil {
+		return fmt.Errorf("service.DeleteOrganization: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Directory) ListEmployees(ctx context.Context, limit int, offset int, username string) ([]domain.Employee, error) {
+	employees, err := s.repo.ListEmployees(ctx, limit, offset, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListEmployees: %w", err)
+	}
+
+	return employees, nil
+}
+
+func (s *Directory) CreateEmployee(ctx context.Context, req domain.CreateEmployeeRequest, username string) (domain.Employee, error) {
+	req.Username = strings.TrimSpace(req.Username)
+	if req.Username == "" || strings.ContainsFunc(req.Username, unicode.IsSpace) || utf8.RuneCountInString(req.Username) > maxUsername {
+		return domain.Employee{}, fmt.Errorf("service.CreateEmployee: %w: the username has to be a single word of at most %d characters", domain.ErrInvalidInput, maxUsername)
+	}
+	if err := validatePersonNames(&req.FirstName, &req.LastName); err != nil {
+		return domain.Employee{}, fmt.Errorf("service.CreateEmployee: %w", err)
+	}
+
+	created, err := s.repo.CreateEmployee(ctx, req, username)
+	if err != nil {
+		return domain.Employee{}, fmt.Errorf("service.CreateEmployee: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *Directory) UpdateEmployee(ctx context.Context, employeeUsername string, update domain.EmployeeUpdate, username string) (domain.Employee, error) {
+	if err := validatePersonNames(update.FirstName, update.LastName); err != nil {
+		return domain.Employee{}, fmt.Errorf("service.UpdateEmployee: %w", err)
+	}
+
+	updated, err := s.repo.UpdateEmployee(ctx, employeeUsername, update, username)
+	if err != nil {
+		return domain.Employee{}, fmt.Errorf("service.UpdateEmployee: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (s *Directory) DeleteEmployee(ctx context.Context, employeeUsername string, username string) error {
+	if err := s.repo.DeleteEmployee(ctx, employeeUsername, username); err != nil {
+		return fmt.Errorf("service.DeleteEmployee: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Directory) AddOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error {
+	if err := s.repo.AddOrganizationResponsible(ctx, organizationID, employeeUsername, username); err != nil {
+		return fmt.Errorf("service.AddOrganizationResponsible: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Directory) RemoveOrganizationResponsible(ctx context.Context, organizationID string, employeeUsername string, username string) error {
+	if err := s.repo.RemoveOrganizationResponsible(ctx, organizationID, employeeUsername, username); err != nil {
+		return fmt.Errorf("service.RemoveOrganizationResponsible: %w", err)
+	}
+
+	return nil
+}
+
+func validateOrganizationName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > maxOrganizationName {
+		return fmt.Errorf("%w: the organization name has to have 1 to %d characters", domain.ErrInvalidInput, maxOrganizationName)
+	}
+
+	return nil
+}
+
+// validatePersonNames trims the names that are set and checks that they fit
+// their columns.
+func validatePersonNames(names ...*string) error {
+	for _, name := range names {
+		if name == nil {
+			continue
+		}
+		*name = strings.TrimSpace(*name)
+		if utf8.RuneCountInString(*name) > maxPersonName {
+			return fmt.Errorf("%w: names have at most %d characters", domain.ErrInvalidInput, maxPersonName)
+		}
+	}
+
+	return nil
+}
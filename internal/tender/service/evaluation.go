@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type Evaluation struct {
+	repo    domain.EvaluationRepository
+	tenders domain.TenderGetter
+	sealer  domain.BidSealer
+}
+
+func NewEvaluation(repo domain.EvaluationRepository, tenders domain.TenderGetter, sealer domain.BidSealer) *Evaluation {
+	return &Evaluation{repo: repo, tenders: tenders, sealer: sealer}
+}
+
+func (s *Evaluation) ScoreBid(ctx context.Context, bidID string, scores []domain.CriterionScore, username string) ([]domain.BidScore, error) {
+	if len(scores) == 0 {
+		return nil, fmt.Errorf("service.ScoreBid: %w: no scores", domain.ErrInvalidInput)
+	}
+
+	seen := make(map[string]bool, len(scores))
+	for _, score := range scores {
+		if score.CriterionID == "" || seen[score.CriterionID] {
+			return nil, fmt.Errorf("service.ScoreBid: %w: invalid or duplicate criterion", domain.ErrInvalidInput)
+		}
+		if score.Score < 0 || score.Score > domain.MaxScore {
+			return nil, fmt.Errorf("service.ScoreBid: %w: score must be between 0 and %d", domain.ErrInvalidInput, domain.MaxScore)
+		}
+		seen[score.CriterionID] = true
+	}
+
+	saved, err := s.repo.ScoreBid(ctx, bidID, scores, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ScoreBid: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (s *Evaluation) GetEvaluation(ctx context.Context, tenderID string, username string) (domain.Evaluation, error) {
+	sheet, err := s.repo.GetEvaluationSheet(ctx, tenderID, username)
+	if err != nil {
+		return domain.Evaluation{}, fmt.Errorf("service.GetEvaluation: %w", err)
+	}
+
+	if err := openBids(ctx, s.tenders, s.sealer, sheet.Bids); err != nil {
+		return domain.Evaluation{}, fmt.Errorf("service.GetEvaluation: %w", err)
+	}
+
+	return buildEvaluation(sheet), nil
+}
+
+// buildEvaluation averages the scores of all evaluators per criterion, weighs
+// the averages into a total and ranks the bids by it. Criteria nobody scored
+// yet count as zero. Bids with equal totals share a rank.
+func buildEvaluation(sheet domain.EvaluationSheet) domain.Evaluation {
+	var totalWeight float64
+	weights := make(map[string]float64, len(sheet.Criteria))
+	for _, criterion := range sheet.Criteria {
+		weights[criterion.ID] = criterion.Weight
+		totalWeight += criterion.Weight
+	}
+
+	weighted := func(scores map[string]float64) float64 {
+		if totalWeight == 0 {
+			return 0
+		}
+		var total float64
+		for criterionID, score := range scores {
+			total += score * weights[criterionID]
+		}
+		return round2(total / totalWeight)
+	}
+
+	byBid := make(map[string]map[string]map[string]float64, len(sheet.Bids))
+	for _, score := range sheet.Scores {
+		if byBid[score.BidID] == nil {
+			byBid[score.BidID] = map[string]map[string]float64{}
+		}
+		if byBid[score.BidID][score.Evaluator] == nil {
+			byBid[score.BidID][score.Evaluator] = map[string]float64{}
+		}
+		byBid[score.BidID][score.Evaluator][score.CriterionID] = score.Score
+	}
+
+	bids := make([]domain.BidEvaluation, 0, len(sheet.Bids))
+	for _, bid := range sheet.Bids {
+		sums := map[string]float64{}
+		counts := map[string]int{}
+		evaluators := []domain.EvaluatorBreakdown{}
+		for evaluator, scores := range byBid[bid.ID] {
+			for criterionID, score := range scores {
+				sums[criterionID] += score
+				counts[criterionID]++
+			}
+			evaluators = append(evaluators, domain.EvaluatorBreakdown{
+				Evaluator:     evaluator,
+				Scores:        scores,
+				WeightedTotal: weighted(scores),
+			})
+		}
+		sort.Slice(evaluators, func(i, j int) bool { return evaluators[i].Evaluator < evaluators[j].Evaluator })
+
+		averages := make(map[string]float64, len(sums))
+		for criterionID, sum := range sums {
+			averages[criterionID] = round2(sum / float64(counts[criterionID]))
+		}
+
+		bids = append(bids, domain.BidEvaluation{
+			BidID:          bid.ID,
+			BidName:        bid.Name,
+			OrganizationID: bid.OrganizationId,
+			Scores:         averages,
+			WeightedTotal:  weighted(averages),
+			Evaluators:     evaluators,
+		})
+	}
+
+	sort.SliceStable(bids, func(i, j int) bool { return bids[i].WeightedTotal > bids[j].WeightedTotal })
+	for i := range bids {
+		if i > 0 && bids[i].WeightedTotal == bids[i-1].WeightedTotal {
+			bids[i].Rank = bids[i-1].Rank
+		} else {
+			bids[i].Rank = i + 1
+		}
+	}
+
+	criteria := sheet.Criteria
+	if criteria == nil {
+		criteria = []domain.Criterion{}
+	}
+
+	return domain.Evaluation{
+		TenderID: sheet.TenderID,
+		Locked:   sheet.TenderStatus == "CLOSED",
+		Criteria: criteria,
+		Bids:     bids,
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
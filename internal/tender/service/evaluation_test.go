@@ -0,0 +1,91 @@
+package service
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+func TestBuildEvaluation(t *testing.T) {
+	score := func(bidID, evaluator, criterionID string, value float64) domain.BidScore {
+		return domain.BidScore{BidID: bidID, Evaluator: evaluator, CriterionID: criterionID, Score: value}
+	}
+
+	evaluation := buildEvaluation(domain.EvaluationSheet{
+		TenderID:     "t-1",
+		TenderStatus: "CLOSED",
+		Criteria:     []domain.Criterion{{ID: "price", Name: "Цена", Weight: 3}, {ID: "term", Name: "Срок", Weight: 2}},
+		Bids: []domain.Bid{
+			{ID: "b-1", Name: "Первое"}, {ID: "b-2", Name: "Второе"}, {ID: "b-3", Name: "Третье"}, {ID: "b-4", Name: "Без оценок"},
+		},
+		Scores: []domain.BidScore{
+			score("b-1", "bob", "price", 7), score("b-1", "bob", "term", 9),
+			score("b-1", "alice", "price", 8), score("b-1", "alice", "term", 6),
+			score("b-1", "carol", "price", 8), score("b-1", "carol", "term", 7),
+			score("b-2", "alice", "price", 9), score("b-2", "alice", "term", 5),
+			score("b-3", "alice", "price", 7), score("b-3", "alice", "term", 8),
+		},
+	})
+
+	if evaluation.TenderID != "t-1" || !evaluation.Locked || len(evaluation.Criteria) != 2 {
+		t.Errorf("evaluation = %+v, want the criteria of the closed tender t-1", evaluation)
+	}
+
+	want := []struct {
+		bidID  string
+		rank   int
+		total  float64
+		scores map[string]float64
+	}{
+		// (7.67*3 + 7.33*2) / 5 = 7.534
+		{"b-1", 1, 7.53, map[string]float64{"price": 7.67, "term": 7.33}},
+		{"b-2", 2, 7.4, map[string]float64{"price": 9, "term": 5}},
+		{"b-3", 2, 7.4, map[string]float64{"price": 7, "term": 8}},
+		{"b-4", 4, 0, map[string]float64{}},
+	}
+	if len(evaluation.Bids) != len(want) {
+		t.Fatalf("bids = %+v, want %d", evaluation.Bids, len(want))
+	}
+	for i, w := range want {
+		bid := evaluation.Bids[i]
+		if bid.BidID != w.bidID || bid.Rank != w.rank || bid.WeightedTotal != w.total || !maps.Equal(bid.Scores, w.scores) {
+			t.Errorf("bid %d = %+v, want %s ranked %d with %v and total %v", i, bid, w.bidID, w.rank, w.scores, w.total)
+		}
+	}
+
+	evaluators := evaluation.Bids[0].Evaluators
+	wantTotals := []struct {
+		evaluator string
+		total     float64
+	}{{"alice", 7.2}, {"bob", 7.8}, {"carol", 7.6}}
+	if len(evaluators) != len(wantTotals) {
+		t.Fatalf("evaluators = %+v, want %d", evaluators, len(wantTotals))
+	}
+	for i, w := range wantTotals {
+		if evaluators[i].Evaluator != w.evaluator || evaluators[i].WeightedTotal != w.total {
+			t.Errorf("evaluator %d = %+v, want %s with total %v", i, evaluators[i], w.evaluator, w.total)
+		}
+	}
+	if evaluation.Bids[3].Evaluators == nil || len(evaluation.Bids[3].Evaluators) != 0 {
+		t.Errorf("evaluators of an unscored bid = %#v, want an empty list", evaluation.Bids[3].Evaluators)
+	}
+}
+
+func TestBuildEvaluationWithoutCriteria(t *testing.T) {
+	evaluation := buildEvaluation(domain.EvaluationSheet{
+		TenderID:     "t-1",
+		TenderStatus: "PUBLISHED",
+		Bids:         []domain.Bid{{ID: "b-1"}, {ID: "b-2"}},
+		Scores:       []domain.BidScore{{BidID: "b-1", Evaluator: "alice", CriterionID: "gone", Score: 10}},
+	})
+
+	if evaluation.Locked || evaluation.Criteria == nil {
+		t.Errorf("evaluation = %+v, want it open with an empty criteria list", evaluation)
+	}
+	for _, bid := range evaluation.Bids {
+		if bid.WeightedTotal != 0 || bid.Rank != 1 {
+			t.Errorf("bid = %+v, want a zero total sharing the first rank", bid)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/export"
+)
+
+var (
+	tenderExportHeader = []any{"id", "name", "description", "status", "serviceType", "organizationId", "version", "type", "visibility", "budget", "closesAt", "tags", "createdAt"}
+	bidExportHeader    = []any{"id", "name", "description", "status", "tenderId", "organizationId", "creatorUsername", "version", "price", "createdAt"}
+)
+
+type Export struct {
+	repo    domain.ExportRepository
+	tenders domain.TenderGetter
+	sealer  domain.BidSealer
+}
+
+func NewExport(repo domain.ExportRepository, tenders domain.TenderGetter, sealer domain.BidSealer) *Export {
+	return &Export{repo: repo, tenders: tenders, sealer: sealer}
+}
+
+func (s *Export) ExportTenders(ctx context.Context, filter domain.TenderListFilter, format domain.ExportFormat, w io.Writer) error {
+	for i := range filter.Tags {
+		filter.Tags[i] = strings.ToLower(strings.TrimSpace(filter.Tags[i]))
+	}
+
+	err := writeTable(w, format, "Tenders", tenderExportHeader, func(write func([]any) error) error {
+		return s.repo.StreamTenders(ctx, filter, func(tender domain.Tender) error {
+			return write(tenderExportRow(tender))
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("service.ExportTenders: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Export) ExportUserTenders(ctx context.Context, limit, offset int, username string, format domain.ExportFormat, w io.Writer) error {
+	err := writeTable(w, format, "Tenders", tenderExportHeader, func(write func([]any) error) error {
+		return s.repo.StreamUserTenders(ctx, limit, offset, username, func(tender domain.Tender) error {
+			return write(tenderExportRow(tender))
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("service.ExportUserTenders: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Export) ExportUserBids(ctx context.Context, limit, offset int, username string, format domain.ExportFormat, w io.Writer) error {
+	tenders := &tenderCache{tenders: s.tenders, cache: map[string]domain.Tender{}}
+
+	err := writeTable(w, format, "Bids", bidExportHeader, func(write func([]any) error) error {
+		return s.repo.StreamUserBids(ctx, limit, offset, username, func(bid domain.Bid) error {
+			return s.writeBid(ctx, tenders, write, bid)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("service.ExportUserBids: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Export) ExportTenderBids(ctx context.Context, tenderID string, limit, offset int, username string, format domain.ExportFormat, w io.Writer) error {
+	tenders := &tenderCache{tenders: s.tenders, cache: map[string]domain.Tender{}}
+
+	err := writeTable(w, format, "Bids", bidExportHeader, func(write func([]any) error) error {
+		return s.repo.StreamTenderBids(ctx, tenderID, limit, offset, username, func(bid domain.Bid) error {
+			return s.writeBid(ctx, tenders, write, bid)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("service.ExportTenderBids: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Export) writeBid(ctx context.Context, tenders domain.TenderGetter, write func([]any) error, bid domain.Bid) error {
+	bids := []domain.Bid{bid}
+	if err := openBids(ctx, tenders, s.sealer, bids); err != nil {
+		return err
+	}
+
+	return write(bidExportRow(bids[0]))
+}
+
+// writeTable starts the document with the first row, or after an empty
+// listing, so that errors found before any row is read leave w untouched.
+func writeTable(w io.Writer, format domain.ExportFormat, sheet string, header []any, stream func(write func([]any) error) error) error {
+	if format != domain.ExportFormatCSV && format != domain.ExportFormatXLSX {
+		return fmt.Errorf("%w: format must be csv or xlsx", domain.ErrInvalidInput)
+	}
+
+	var table export.Writer
+	start := func() error {
+		if table != nil {
+			return nil
+		}
+
+		var err error
+		table, err = export.NewWriter(format, w, sheet)
+		if err != nil {
+			return err
+		}
+
+		return table.Write(header)
+	}
+
+	err := stream(func(row []any) error {
+		if err := start(); err != nil {
+			return err
+		}
+		return table.Write(row)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := start(); err != nil {
+		return err
+	}
+
+	return table.Close()
+}
+
+func tenderExportRow(tender domain.Tender) []any {
+	return []any{
+		tender.ID,
+		tender.Name,
+		tender.Description,
+		tender.Status,
+		tender.ServiceType,
+		tender.OrganizationId,
+		tender.Version,
+		string(tender.Type),
+		string(tender.Visibility),
+		tender.Budget,
+		tender.ClosesAt,
+		strings.Join(tender.Tags, ", "),
+		tender.CreatedAt,
+	}
+}
+
+func bidExportRow(bid domain.Bid) []any {
+	return []any{
+		bid.ID,
+		bid.Name,
+		bid.Description,
+		bid.Status,
+		bid.TenderId,
+		bid.OrganizationId,
+		bid.CreatorUsername,
+		bid.Version,
+		bid.Price,
+		bid.CreatedAt,
+	}
+}
+
+// tenderCache keeps the tenders looked up while opening a stream of sealed
+// bids, which mostly belong to a few tenders.
+type tenderCache struct {
+	tenders domain.TenderGetter
+	cache   map[string]domain.Tender
+}
+
+func (c *tenderCache) GetTenderByID(ctx context.Context, tenderID string) (domain.Tender, error) {
+	if tender, ok := c.cache[tenderID]; ok {
+		return tender, nil
+	}
+
+	tender, err := c.tenders.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return domain.Tender{}, err
+	}
+	c.cache[tenderID] = tender
+
+	return tender, nil
+}
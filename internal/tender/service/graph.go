@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// Graph serves the GraphQL loaders. Deciding who may follow which edge is
+// left to the resolvers, the batches only leave out tenders the employee
+// may not see.
+type Graph struct {
+	repo   domain.GraphRepository
+	sealer domain.BidSealer
+}
+
+func NewGraph(repo domain.GraphRepository, sealer domain.BidSealer) *Graph {
+	return &Graph{repo: repo, sealer: sealer}
+}
+
+// tenderSet hands out the keys of tenders loaded already, so that opening a
+// batch of bids does not look the tenders up one by one.
+type tenderSet map[string]domain.Tender
+
+func (s tenderSet) GetTenderByID(_ context.Context, tenderID string) (domain.Tender, error) {
+	tender, ok := s[tenderID]
+	if !ok {
+		return domain.Tender{}, domain.ErrTenderNotFound
+	}
+
+	return tender, nil
+}
+
+func (s *Graph) GetTenders(ctx context.Context, tenderIDs []string, username string) (map[string]domain.Tender, error) {
+	tenders, err := s.repo.GetTenders(ctx, tenderIDs, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetTenders: %w", err)
+	}
+
+	return tenders, nil
+}
+
+func (s *Graph) GetTenderVersions(ctx context.Context, tenderIDs []string) (map[string][]domain.TenderVersion, error) {
+	versions, err := s.repo.GetTenderVersions(ctx, tenderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetTenderVersions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetTenderBids opens sealed bids, callers are responsible for only asking
+// for revealed bids the employee may read.
+func (s *Graph) GetTenderBids(ctx context.Context, tenderIDs []string, username string) (map[string][]domain.Bid, error) {
+	bids, err := s.repo.GetTenderBids(ctx, tenderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetTenderBids: %w", err)
+	}
+
+	var sealed []string
+	for tenderID, list := range bids {
+		for _, bid := range list {
+			if bid.Sealed {
+				sealed = append(sealed, tenderID)
+				break
+			}
+		}
+	}
+	if len(sealed) == 0 {
+		return bids, nil
+	}
+
+	tenders, err := s.repo.GetTenders(ctx, sealed, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetTenderBids: %w", err)
+	}
+
+	for tenderID := range bids {
+		if err := openBids(ctx, tenderSet(tenders), s.sealer, bids[tenderID]); err != nil {
+			return nil, fmt.Errorf("service.GetTenderBids: %w", err)
+		}
+	}
+
+	return bids, nil
+}
+
+func (s *Graph) GetOrganizations(ctx context.Context, organizationIDs []string) (map[string]domain.Organization, error) {
+	organizations, err := s.repo.GetOrganizations(ctx, organizationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetOrganizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (s *Graph) GetEmployees(ctx context.Context, usernames []string) (map[string]domain.Employee, error) {
+	employees, err := s.repo.GetEmployees(ctx, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetEmployees: %w", err)
+	}
+
+	return employees, nil
+}
+
+func (s *Graph) GetResponsibleOrganizations(ctx context.Context, usernames []string) (map[string][]domain.Organization, error) {
+	organizations, err := s.repo.GetResponsibleOrganizations(ctx, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetResponsibleOrganizations: %w", err)
+	}
+
+	return organizations, nil
+}
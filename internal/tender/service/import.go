@@ -0,0 +1,371 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// maxImportLine bounds a single NDJSON line.
+const maxImportLine = 1 << 20
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// importIgnoredColumns are written by the export but do not apply to new
+// tenders, so that an exported file can be imported as it is.
+var importIgnoredColumns = map[string]bool{"id": true, "status": true, "version": true, "createdAt": true}
+
+type Import struct {
+	repo    domain.ImportRepository
+	catalog domain.ServiceTypeResolver
+	fields  domain.CustomFieldSchema
+	sealer  domain.BidSealer
+	maxRows int
+}
+
+func NewImport(repo domain.ImportRepository, catalog domain.ServiceTypeResolver, fields domain.CustomFieldSchema, sealer domain.BidSealer, maxRows int) *Import {
+	return &Import{repo: repo, catalog: catalog, fields: fields, sealer: sealer, maxRows: maxRows}
+}
+
+// importLookups caches what the rows of one import look up, most of them
+// share the organization and service type.
+type importLookups struct {
+	authorized   map[string]bool
+	fields       map[string][]domain.CustomField
+	serviceTypes map[string]string
+}
+
+func (s *Import) ImportTenders(ctx context.Context, r io.Reader, format domain.ImportFormat, username string, dryRun bool) (domain.ImportReport, error) {
+	report := domain.ImportReport{DryRun: dryRun, Errors: []domain.ImportRowError{}}
+	lookups := importLookups{authorized: map[string]bool{}, fields: map[string][]domain.CustomField{}, serviceTypes: map[string]string{}}
+	tenders := []domain.Tender{}
+
+	err := readImport(r, format, func(line int, req domain.CreateTenderRequest, rowErr error) error {
+		report.Rows++
+		if report.Rows > s.maxRows {
+			return fmt.Errorf("%w: at most %d rows can be imported at once", domain.ErrTooLarge, s.maxRows)
+		}
+
+		var tender domain.Tender
+		if rowErr == nil {
+			tender, rowErr = s.validate(ctx, &lookups, req, username, format == domain.ImportFormatCSV)
+		}
+		if rowErr != nil {
+			var invalid importRowError
+			if !errors.As(rowErr, &invalid) {
+				return rowErr
+			}
+			report.Errors = append(report.Errors, domain.ImportRowError{Line: line, Error: invalid.Error()})
+			return nil
+		}
+
+		tenders = append(tenders, tender)
+		return nil
+	})
+	if err != nil {
+		return domain.ImportReport{}, fmt.Errorf("service.ImportTenders: %w", err)
+	}
+
+	report.Valid = len(tenders)
+	if dryRun || len(tenders) == 0 {
+		return report, nil
+	}
+
+	if err := s.repo.ImportTenders(ctx, tenders); err != nil {
+		return domain.ImportReport{}, fmt.Errorf("service.ImportTenders: %w", err)
+	}
+	report.Imported = len(tenders)
+
+	return report, nil
+}
+
+// importRowError rejects a single row, other errors abort the import.
+type importRowError struct {
+	err error
+}
+
+func (e importRowError) Error() string { return e.err.Error() }
+
+func (e importRowError) Unwrap() error { return e.err }
+
+func rejectRow(format string, args ...any) error {
+	return importRowError{fmt.Errorf(format, args...)}
+}
+
+// validate applies the checks of a single tender creation to a row.
+func (s *Import) validate(ctx context.Context, lookups *importLookups, req domain.CreateTenderRequest, username string, fromCSV bool) (domain.Tender, error) {
+	if req.CreatorUsername == "" {
+		req.CreatorUsername = username
+	}
+
+	switch {
+	case req.Name == "" || req.ServiceType == "" || req.OrganizationId == "":
+		return domain.Tender{}, rejectRow("%w: missing required fields", domain.ErrInvalidInput)
+	case req.CreatorUsername != username:
+		return domain.Tender{}, rejectRow("%w: creatorUsername must be the importing user", domain.ErrInvalidInput)
+	case req.TemplateID != "":
+		return domain.Tender{}, rejectRow("%w: templates are not supported by the import", domain.ErrInvalidInput)
+	case req.Type == domain.TenderTypeAuction || req.Auction != nil:
+		return domain.Tender{}, rejectRow("%w: auctions can not be imported", domain.ErrInvalidInput)
+	case !uuidPattern.MatchString(req.OrganizationId):
+		return domain.Tender{}, rejectRow("%w: invalid organizationId", domain.ErrInvalidInput)
+	}
+
+	authorized, ok := lookups.authorized[req.OrganizationId]
+	if !ok {
+		var err error
+		authorized, err = s.repo.IsUserAuthorizedForOrganization(ctx, username, req.OrganizationId)
+		if err != nil {
+			return domain.Tender{}, err
+		}
+		lookups.authorized[req.OrganizationId] = authorized
+	}
+	if !authorized {
+		return domain.Tender{}, rejectRow("user is not authorized to create tender for this organization")
+	}
+
+	serviceType, ok := lookups.serviceTypes[req.ServiceType]
+	if !ok {
+		var err error
+		serviceType, err = s.catalog.ResolveServiceType(ctx, req.ServiceType)
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return domain.Tender{}, importRowError{err}
+		}
+		if err != nil {
+			return domain.Tender{}, err
+		}
+		lookups.serviceTypes[req.ServiceType] = serviceType
+	}
+
+	fields, ok := lookups.fields[req.OrganizationId]
+	if !ok {
+		var err error
+		fields, err = s.fields.GetOrganizationFields(ctx, req.OrganizationId)
+		if err != nil {
+			return domain.Tender{}, err
+		}
+		lookups.fields[req.OrganizationId] = fields
+	}
+
+	if fromCSV {
+		if err := parseNumberFields(fields, req.CustomFields); err != nil {
+			return domain.Tender{}, importRowError{err}
+		}
+	}
+
+	tender, err := prepareTender(domain.Tender{
+		Name:            req.Name,
+		Description:     req.Description,
+		ServiceType:     serviceType,
+		Status:          "CREATED",
+		OrganizationId:  req.OrganizationId,
+		CreatorUsername: req.CreatorUsername,
+		Version:         1,
+		Type:            req.Type,
+		Visibility:      req.Visibility,
+		Sealed:          req.Sealed,
+		Lots:            req.Lots,
+		Criteria:        req.Criteria,
+		Tags:            req.Tags,
+		CustomFields:    req.CustomFields,
+		Budget:          req.Budget,
+		ClosesAt:        req.ClosesAt,
+		CreatedAt:       time.Now(),
+	}, fields, s.sealer)
+	if errors.Is(err, domain.ErrInvalidInput) {
+		return domain.Tender{}, importRowError{err}
+	}
+	if err != nil {
+		return domain.Tender{}, err
+	}
+
+	return tender, nil
+}
+
+// parseNumberFields converts the values of NUMBER fields, which arrive as
+// text from CSV cells.
+func parseNumberFields(fields []domain.CustomField, values map[string]any) error {
+	for _, field := range fields {
+		text, ok := values[field.Key].(string)
+		if field.Type != domain.CustomFieldTypeNumber || !ok {
+			continue
+		}
+
+		number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return fmt.Errorf("%w: custom field %q must be a number", domain.ErrInvalidInput, field.Key)
+		}
+		values[field.Key] = number
+	}
+
+	return nil
+}
+
+// readImport decodes the rows of the file and hands them to fn with the
+// line they start on. A row that can not be decoded is passed with its
+// error, errors of the file itself end the reading.
+func readImport(r io.Reader, format domain.ImportFormat, fn func(line int, req domain.CreateTenderRequest, err error) error) error {
+	switch format {
+	case domain.ImportFormatCSV:
+		return readImportCSV(r, fn)
+	case domain.ImportFormatNDJSON:
+		return readImportNDJSON(r, fn)
+	default:
+		return fmt.Errorf("%w: format must be csv or ndjson", domain.ErrInvalidInput)
+	}
+}
+
+func readImportNDJSON(r io.Reader, fn func(int, domain.CreateTenderRequest, error) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+
+	for line := 1; scanner.Scan(); line++ {
+		data := strings.TrimSpace(scanner.Text())
+		if data == "" {
+			continue
+		}
+
+		var req domain.CreateTenderRequest
+		var rowErr error
+		if err := json.Unmarshal([]byte(data), &req); err != nil {
+			rowErr = rejectRow("Invalid request payload")
+		}
+		if err := fn(line, req, rowErr); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("%w: a line is longer than %d bytes", domain.ErrTooLarge, maxImportLine)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func readImportCSV(r io.Reader, fn func(int, domain.CreateTenderRequest, error) error) error {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return csvError(err)
+	}
+
+	columns := make([]string, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if key, ok := strings.CutPrefix(column, "cf."); !ok || key == "" {
+			if _, known := csvImportColumns[column]; !known && !importIgnoredColumns[column] {
+				return fmt.Errorf("%w: unknown column %q", domain.ErrInvalidInput, column)
+			}
+		}
+		columns[i] = column
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return csvError(err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		var req domain.CreateTenderRequest
+		var rowErr error
+		if len(record) != len(columns) {
+			rowErr = rejectRow("%w: %d cells, the header has %d columns", domain.ErrInvalidInput, len(record), len(columns))
+			record = nil
+		}
+		for i, value := range record {
+			if value = strings.TrimSpace(value); value == "" {
+				continue
+			}
+
+			if key, ok := strings.CutPrefix(columns[i], "cf."); ok {
+				if req.CustomFields == nil {
+					req.CustomFields = map[string]any{}
+				}
+				req.CustomFields[key] = value
+				continue
+			}
+
+			if set := csvImportColumns[columns[i]]; set != nil {
+				if err := set(&req, value); err != nil {
+					rowErr = rejectRow("%w: invalid %s: %v", domain.ErrInvalidInput, columns[i], err)
+					break
+				}
+			}
+		}
+
+		if err := fn(line, req, rowErr); err != nil {
+			return err
+		}
+	}
+}
+
+// csvError reports malformed CSV as invalid input and passes read errors
+// through.
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	return err
+}
+
+// csvImportColumns fill the request fields from CSV cells. Tags are
+// separated by commas, as in the export.
+var csvImportColumns = map[string]func(*domain.CreateTenderRequest, string) error{
+	"name":            func(req *domain.CreateTenderRequest, value string) error { req.Name = value; return nil },
+	"description":     func(req *domain.CreateTenderRequest, value string) error { req.Description = value; return nil },
+	"serviceType":     func(req *domain.CreateTenderRequest, value string) error { req.ServiceType = value; return nil },
+	"organizationId":  func(req *domain.CreateTenderRequest, value string) error { req.OrganizationId = value; return nil },
+	"creatorUsername": func(req *domain.CreateTenderRequest, value string) error { req.CreatorUsername = value; return nil },
+	"type": func(req *domain.CreateTenderRequest, value string) error {
+		req.Type = domain.TenderType(value)
+		return nil
+	},
+	"visibility": func(req *domain.CreateTenderRequest, value string) error {
+		req.Visibility = domain.TenderVisibility(value)
+		return nil
+	},
+	"sealed": func(req *domain.CreateTenderRequest, value string) error {
+		sealed, err := strconv.ParseBool(value)
+		req.Sealed = sealed
+		return err
+	},
+	"budget": func(req *domain.CreateTenderRequest, value string) error {
+		budget, err := strconv.ParseFloat(value, 64)
+		req.Budget = &budget
+		return err
+	},
+	"closesAt": func(req *domain.CreateTenderRequest, value string) error {
+		closesAt, err := time.Parse(time.RFC3339, value)
+		req.ClosesAt = &closesAt
+		return err
+	},
+	"tags": func(req *domain.CreateTenderRequest, value string) error {
+		req.Tags = strings.Split(value, ",")
+		return nil
+	},
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const (
+	importOrg      = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	importOtherOrg = "16fd2706-8baf-433b-82eb-8c7fada847da"
+)
+
+type importRepoStub struct {
+	imported []domain.Tender
+}
+
+func (r *importRepoStub) IsUserAuthorizedForOrganization(_ context.Context, username, organizationID string) (bool, error) {
+	return username == "ivan" && organizationID == importOrg, nil
+}
+
+func (r *importRepoStub) ImportTenders(_ context.Context, tenders []domain.Tender) error {
+	r.imported = append(r.imported, tenders...)
+	return nil
+}
+
+type importCatalogStub struct{}
+
+func (importCatalogStub) ResolveServiceType(_ context.Context, code string) (string, error) {
+	if strings.EqualFold(code, "delivery") {
+		return "Delivery", nil
+	}
+	return "", fmt.Errorf("%w: unknown service type %q", domain.ErrInvalidInput, code)
+}
+
+type importFieldsStub struct{}
+
+func (importFieldsStub) GetOrganizationFields(context.Context, string) ([]domain.CustomField, error) {
+	return []domain.CustomField{{Key: "region", Type: domain.CustomFieldTypeString}, {Key: "lots", Type: domain.CustomFieldTypeNumber}}, nil
+}
+
+func TestImportTendersCSV(t *testing.T) {
+	csv := strings.Join([]string{
+		"name,serviceType,organizationId,budget,tags,cf.lots,status",
+		"Поставка бумаги,delivery," + importOrg + ",1200.50,\"Офис, бумага\",3,PUBLISHED",
+		"Без типа,unknown," + importOrg + ",,,,",
+		"Чужая организация,Delivery," + importOtherOrg + ",,,,",
+		"Неверный бюджет,Delivery," + importOrg + ",много,,,",
+		"Неверное поле,Delivery," + importOrg + ",,,три,",
+	}, "\n")
+
+	repo := &importRepoStub{}
+	s := NewImport(repo, importCatalogStub{}, importFieldsStub{}, nil, 100)
+
+	report, err := s.ImportTenders(context.Background(), strings.NewReader(csv), domain.ImportFormatCSV, "ivan", true)
+	if err != nil {
+		t.Fatalf("ImportTenders: %v", err)
+	}
+
+	if report.Rows != 5 || report.Valid != 1 || report.Imported != 0 || len(repo.imported) != 0 {
+		t.Fatalf("dry run report = %+v, imported %d", report, len(repo.imported))
+	}
+	lines := []int{}
+	for _, rowErr := range report.Errors {
+		lines = append(lines, rowErr.Line)
+	}
+	if fmt.Sprint(lines) != "[3 4 5 6]" {
+		t.Errorf("error lines = %v, want [3 4 5 6]: %+v", lines, report.Errors)
+	}
+
+	report, err = s.ImportTenders(context.Background(), strings.NewReader(csv), domain.ImportFormatCSV, "ivan", false)
+	if err != nil {
+		t.Fatalf("ImportTenders: %v", err)
+	}
+	if report.Imported != 1 || len(repo.imported) != 1 {
+		t.Fatalf("report = %+v, imported %d", report, len(repo.imported))
+	}
+
+	tender := repo.imported[0]
+	if tender.ServiceType != "Delivery" || tender.Status != "CREATED" || tender.Visibility != domain.TenderVisibilityPublic {
+		t.Errorf("tender = %+v", tender)
+	}
+	if fmt.Sprint(tender.Tags) != "[офис бумага]" || tender.CustomFields["lots"] != 3.0 || *tender.Budget != 1200.5 {
+		t.Errorf("tags = %v, custom fields = %v, budget = %v", tender.Tags, tender.CustomFields, *tender.Budget)
+	}
+}
+
+func TestImportTendersNDJSON(t *testing.T) {
+	ndjson := `{"name": "Ремонт", "serviceType": "Delivery", "organizationId": "` + importOrg + `", "lots": [{"name": "Кровля", "quantity": 1}]}
+
+{"name": "Аукцион", "serviceType": "Delivery", "organizationId": "` + importOrg + `", "type": "AUCTION"}
+{"name": "Другой автор", "serviceType": "Delivery", "organizationId": "` + importOrg + `", "creatorUsername": "petr"}
+{"name": `
+
+	s := NewImport(&importRepoStub{}, importCatalogStub{}, importFieldsStub{}, nil, 100)
+
+	report, err := s.ImportTenders(context.Background(), strings.NewReader(ndjson), domain.ImportFormatNDJSON, "ivan", true)
+	if err != nil {
+		t.Fatalf("ImportTenders: %v", err)
+	}
+	if report.Rows != 4 || report.Valid != 1 || len(report.Errors) != 3 || report.Errors[0].Line != 3 {
+		t.Errorf("report = %+v", report)
+	}
+
+	s.maxRows = 3
+	if _, err := s.ImportTenders(context.Background(), strings.NewReader(ndjson), domain.ImportFormatNDJSON, "ivan", true); err == nil {
+		t.Error("import over the row limit succeeded")
+	}
+}
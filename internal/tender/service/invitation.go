@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type Invitation struct {
+	repo domain.InvitationRepository
+}
+
+func NewInvitation(repo domain.InvitationRepository) *Invitation {
+	return &Invitation{repo: repo}
+}
+
+func (s *Invitation) CreateInvitation(ctx context.Context, tenderID string, organizationID string, username string) (domain.Invitation, error) {
+	organizationID = strings.TrimSpace(organizationID)
+	if organizationID == "" {
+		return domain.Invitation{}, fmt.Errorf("service.CreateInvitation: %w: missing organizationId", domain.ErrInvalidInput)
+	}
+
+	invitation, err := s.repo.CreateInvitation(ctx, tenderID, organizationID, username)
+	if err != nil {
+		return domain.Invitation{}, fmt.Errorf("service.CreateInvitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Invitation) ListInvitations(ctx context.Context, tenderID string, username string) ([]domain.Invitation, error) {
+	invitations, err := s.repo.ListInvitations(ctx, tenderID, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListInvitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+func (s *Invitation) DeleteInvitation(ctx context.Context, tenderID string, organizationID string, username string) error {
+	if err := s.repo.DeleteInvitation(ctx, tenderID, organizationID, username); err != nil {
+		return fmt.Errorf("service.DeleteInvitation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type Lot struct {
+	repo domain.LotRepository
+}
+
+func NewLot(repo domain.LotRepository) *Lot {
+	return &Lot{repo: repo}
+}
+
+func (s *Lot) ListLots(ctx context.Context, tenderID string, username string) ([]domain.Lot, error) {
+	lots, err := s.repo.ListLots(ctx, tenderID, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListLots: %w", err)
+	}
+
+	return lots, nil
+}
+
+func (s *Lot) AwardLot(ctx context.Context, tenderID string, lotID string, bidID string, username string) (domain.Lot, error) {
+	if bidID == "" {
+		return domain.Lot{}, fmt.Errorf("service.AwardLot: %w: missing bidId", domain.ErrInvalidInput)
+	}
+
+	lot, err := s.repo.AwardLot(ctx, tenderID, lotID, bidID, username)
+	if err != nil {
+		return domain.Lot{}, fmt.Errorf("service.AwardLot: %w", err)
+	}
+
+	return lot, nil
+}
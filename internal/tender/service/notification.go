@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type Notification struct {
+	repo domain.NotificationRepository
+}
+
+func NewNotification(repo domain.NotificationRepository) *Notification {
+	return &Notification{repo: repo}
+}
+
+func (s *Notification) GetSettings(ctx context.Context, username string) (domain.NotificationSettings, error) {
+	settings, err := s.repo.GetSettings(ctx, username)
+	if err != nil {
+		return domain.NotificationSettings{}, fmt.Errorf("service.GetSettings: %w", err)
+	}
+
+	return settings, nil
+}
+
+func (s *Notification) UpdateSettings(ctx context.Context, username string, settings domain.NotificationSettings) (domain.NotificationSettings, error) {
+	if settings.Email != "" {
+		if _, err := mail.ParseAddress(settings.Email); err != nil {
+			return domain.NotificationSettings{}, fmt.Errorf("service.UpdateSettings: %w: invalid email", domain.ErrInvalidInput)
+		}
+	}
+
+	if settings.Locale == "" {
+		settings.Locale = domain.LocaleRU
+	}
+
+	if settings.Locale != domain.LocaleRU && settings.Locale != domain.LocaleEN {
+		return domain.NotificationSettings{}, fmt.Errorf("service.UpdateSettings: %w: unsupported locale", domain.ErrInvalidInput)
+	}
+
+	updated, err := s.repo.UpdateSettings(ctx, username, settings)
+	if err != nil {
+		return domain.NotificationSettings{}, fmt.Errorf("service.UpdateSettings: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (s *Notification) ListNotifications(ctx context.Context, username string, unreadOnly bool, limit, offset int) ([]domain.InAppNotification, error) {
+	notifications, err := s.repo.ListNotifications(ctx, username, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListNotifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (s *Notification) MarkNotificationRead(ctx context.Context, notificationID string, username string) (domain.InAppNotification, error) {
+	notification, err := s.repo.MarkNotificationRead(ctx, notificationID, username)
+	if err != nil {
+		return domain.InAppNotification{}, fmt.Errorf("service.MarkNotificationRead: %w", err)
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type OCDS struct {
+	repo    domain.OCDSRepository
+	tenders domain.TenderGetter
+	sealer  domain.BidSealer
+	encoder domain.OCDSEncoder
+}
+
+func NewOCDS(repo domain.OCDSRepository, tenders domain.TenderGetter, sealer domain.BidSealer, encoder domain.OCDSEncoder) *OCDS {
+	return &OCDS{repo: repo, tenders: tenders, sealer: sealer, encoder: encoder}
+}
+
+// GetTenderPackage returns the record package of the tender, or the package
+// of its releases.
+func (s *OCDS) GetTenderPackage(ctx context.Context, tenderID string, packageType domain.OCDSPackageType, uri string) ([]byte, error) {
+	if packageType != domain.OCDSPackageRecord && packageType != domain.OCDSPackageRelease {
+		return nil, fmt.Errorf("service.GetTenderPackage: %w: package must be record or release", domain.ErrInvalidInput)
+	}
+
+	data, err := s.repo.GetOCDSTender(ctx, tenderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetTenderPackage: %w", err)
+	}
+
+	if err := s.open(ctx, &data); err != nil {
+		return nil, fmt.Errorf("service.GetTenderPackage: %w", err)
+	}
+
+	var pkg []byte
+	if packageType == domain.OCDSPackageRelease {
+		pkg, err = s.encoder.ReleasePackage(uri, []domain.OCDSTender{data}, nil)
+	} else {
+		pkg, err = s.encoder.RecordPackage(uri, []domain.OCDSTender{data})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("service.GetTenderPackage: %w", err)
+	}
+
+	return pkg, nil
+}
+
+func (s *OCDS) ListReleases(ctx context.Context, filter domain.OCDSFilter, uri string) ([]byte, error) {
+	tenders, err := s.repo.ListOCDSTenders(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListReleases: %w", err)
+	}
+
+	for i := range tenders {
+		if err := s.open(ctx, &tenders[i]); err != nil {
+			return nil, fmt.Errorf("service.ListReleases: %w", err)
+		}
+	}
+
+	pkg, err := s.encoder.ReleasePackage(uri, tenders, filter.Since)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListReleases: %w", err)
+	}
+
+	return pkg, nil
+}
+
+// open decrypts the bids and awarded amounts of sealed tenders. Bids are only
+// loaded for closed tenders, whose bids are revealed.
+func (s *OCDS) open(ctx context.Context, data *domain.OCDSTender) error {
+	if err := openBids(ctx, s.tenders, s.sealer, data.Bids); err != nil {
+		return err
+	}
+
+	return openAwards(ctx, s.tenders, s.sealer, data.Awards)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type Question struct {
+	repo domain.QuestionRepository
+}
+
+func NewQuestion(repo domain.QuestionRepository) *Question {
+	return &Question{repo: repo}
+}
+
+func (s *Question) CreateQuestion(ctx context.Context, tenderID string, text string, username string) (domain.Question, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return domain.Question{}, fmt.Errorf("service.CreateQuestion: %w: empty question", domain.ErrInvalidInput)
+	}
+
+	question, err := s.repo.CreateQuestion(ctx, tenderID, text, username)
+	if err != nil {
+		return domain.Question{}, fmt.Errorf("service.CreateQuestion: %w", err)
+	}
+
+	return question, nil
+}
+
+func (s *Question) ListQuestions(ctx context.Context, tenderID string, username string) ([]domain.Question, error) {
+	questions, err := s.repo.ListQuestions(ctx, tenderID, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListQuestions: %w", err)
+	}
+
+	return questions, nil
+}
+
+func (s *Question) AnswerQuestion(ctx context.Context, tenderID string, questionID string, answer string,
+	visibility domain.QuestionVisibility, username string) (domain.Question, error) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return domain.Question{}, fmt.Errorf("service.AnswerQuestion: %w: empty answer", domain.ErrInvalidInput)
+	}
+
+	question, err := s.repo.AnswerQuestion(ctx, tenderID, questionID, answer, visibility, username)
+	if err != nil {
+		return domain.Question{}, fmt.Errorf("service.AnswerQuestion: %w", err)
+	}
+
+	return question, nil
+}
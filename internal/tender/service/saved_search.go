@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type SavedSearch struct {
+	repo domain.SavedSearchRepository
+}
+
+func NewSavedSearch(repo domain.SavedSearchRepository) *SavedSearch {
+	return &SavedSearch{repo: repo}
+}
+
+func (s *SavedSearch) CreateSavedSearch(ctx context.Context, search domain.SavedSearch) (domain.SavedSearch, error) {
+	if search.BudgetMin != nil && search.BudgetMax != nil && *search.BudgetMin > *search.BudgetMax {
+		return domain.SavedSearch{}, fmt.Errorf("service.CreateSavedSearch: %w: budgetMin is greater than budgetMax", domain.ErrInvalidInput)
+	}
+
+	if search.WebhookURL != "" {
+		u, err := url.Parse(search.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return domain.SavedSearch{}, fmt.Errorf("service.CreateSavedSearch: %w: invalid webhookUrl", domain.ErrInvalidInput)
+		}
+	}
+
+	keywords := make([]string, 0, len(search.Keywords))
+	for _, keyword := range search.Keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	search.Keywords = keywords
+
+	if search.ServiceTypes == nil {
+		search.ServiceTypes = []string{}
+	}
+
+	created, err := s.repo.CreateSavedSearch(ctx, search)
+	if err != nil {
+		return domain.SavedSearch{}, fmt.Errorf("service.CreateSavedSearch: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *SavedSearch) ListSavedSearches(ctx context.Context, username string) ([]domain.SavedSearch, error) {
+	searches, err := s.repo.ListSavedSearches(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListSavedSearches: %w", err)
+	}
+
+	return searches, nil
+}
+
+func (s *SavedSearch) SetSubscription(ctx context.Context, searchID string, subscribed bool, username string) (domain.SavedSearch, error) {
+	search, err := s.repo.SetSubscription(ctx, searchID, subscribed, username)
+	if err != nil {
+		return domain.SavedSearch{}, fmt.Errorf("service.SetSubscription: %w", err)
+	}
+
+	return search, nil
+}
+
+func (s *SavedSearch) DeleteSavedSearch(ctx context.Context, searchID string, username string) error {
+	if err := s.repo.DeleteSavedSearch(ctx, searchID, username); err != nil {
+		return fmt.Errorf("service.DeleteSavedSearch: %w", err)
+	}
+
+	return nil
+}
@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
 )
 
 type pingProvider struct {
@@ -25,15 +28,25 @@ func (s *pingProvider) Ping(ctx context.Context) error {
 }
 
 type Tender struct {
-	repo domain.TenderRepository
+	repo     domain.TenderRepository
+	notifier domain.Notifier
+	sealer   domain.BidSealer
+	catalog  domain.ServiceTypeResolver
+	fields   domain.CustomFieldSchema
 }
 
-func NewTender(repo domain.TenderRepository) *Tender {
-	return &Tender{repo: repo}
+// NewTender returns the tender service. sealer may be nil, in which case
+// sealed tenders cannot be created.
+func NewTender(repo domain.TenderRepository, notifier domain.Notifier, sealer domain.BidSealer, catalog domain.ServiceTypeResolver, fields domain.CustomFieldSchema) *Tender {
+	return &Tender{repo: repo, notifier: notifier, sealer: sealer, catalog: catalog, fields: fields}
 }
 
-func (t *Tender) ListTender(ctx context.Context, limit, offset int, serviceTypes []string) ([]domain.Tender, error) {
-	tenders, err := t.repo.ListTender(ctx, limit, offset, serviceTypes)
+func (t *Tender) ListTender(ctx context.Context, filter domain.TenderListFilter) ([]domain.Tender, error) {
+	for i := range filter.Tags {
+		filter.Tags[i] = strings.ToLower(strings.TrimSpace(filter.Tags[i]))
+	}
+
+	tenders, err := t.repo.ListTender(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("service.ListBanners: %w", err)
 	}
@@ -42,6 +55,21 @@ func (t *Tender) ListTender(ctx context.Context, limit, offset int, serviceTypes
 }
 
 func (s *Tender) CreateTender(ctx context.Context, tender domain.Tender) (domain.Tender, error) {
+	serviceType, err := s.catalog.ResolveServiceType(ctx, tender.ServiceType)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("service.CreateTender: %w", err)
+	}
+	tender.ServiceType = serviceType
+
+	fields, err := s.fields.GetOrganizationFields(ctx, tender.OrganizationId)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("service.CreateTender: %w", err)
+	}
+
+	tender, err = prepareTender(tender, fields, s.sealer)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("service.CreateTender: %w", err)
+	}
 
 	createdTender, err := s.repo.CreateTender(ctx, tender)
 	if err != nil {
@@ -51,6 +79,79 @@ func (s *Tender) CreateTender(ctx context.Context, tender domain.Tender) (domain
 	return createdTender, nil
 }
 
+// prepareTender validates a new tender with a resolved service type against
+// the custom fields of its organization, fills in the defaults and issues
+// the seal key.
+func prepareTender(tender domain.Tender, fields []domain.CustomField, sealer domain.BidSealer) (domain.Tender, error) {
+	var err error
+	tender.Tags, err = normalizeTags(tender.Tags)
+	if err != nil {
+		return domain.Tender{}, err
+	}
+
+	tender.CustomFields, err = validateCustomFields(fields, tender.CustomFields)
+	if err != nil {
+		return domain.Tender{}, err
+	}
+
+	switch tender.Visibility {
+	case "":
+		tender.Visibility = domain.TenderVisibilityPublic
+	case domain.TenderVisibilityPublic, domain.TenderVisibilityPrivate:
+	default:
+		return domain.Tender{}, fmt.Errorf("%w: unknown visibility %q", domain.ErrInvalidInput, tender.Visibility)
+	}
+
+	switch tender.Type {
+	case "":
+		tender.Type = domain.TenderTypeStandard
+	case domain.TenderTypeStandard, domain.TenderTypeAuction:
+	default:
+		return domain.Tender{}, fmt.Errorf("%w: unknown type %q", domain.ErrInvalidInput, tender.Type)
+	}
+
+	if err := validateAuction(tender); err != nil {
+		return domain.Tender{}, err
+	}
+	if tender.Auction != nil {
+		endsAt := tender.Auction.EndsAt
+		tender.ClosesAt = &endsAt
+	}
+
+	if tender.Sealed {
+		if sealer == nil {
+			return domain.Tender{}, fmt.Errorf("%w: sealed tenders are not enabled", domain.ErrInvalidInput)
+		}
+		if tender.ClosesAt == nil {
+			return domain.Tender{}, fmt.Errorf("%w: sealed tender needs closesAt", domain.ErrInvalidInput)
+		}
+
+		key, err := sealer.NewKey()
+		if err != nil {
+			return domain.Tender{}, err
+		}
+		tender.SealKey = key
+	}
+
+	for i := range tender.Lots {
+		tender.Lots[i].ID = ""
+	}
+
+	if err := validateLots(tender.Lots); err != nil {
+		return domain.Tender{}, err
+	}
+
+	for i := range tender.Criteria {
+		tender.Criteria[i].ID = ""
+	}
+
+	if err := validateCriteria(tender.Criteria); err != nil {
+		return domain.Tender{}, err
+	}
+
+	return tender, nil
+}
+
 func (s *Tender) GetUserTenders(ctx context.Context, limit, offset int, username string) ([]domain.Tender, error) {
 	tenders, err := s.repo.GetUserTenders(ctx, limit, offset, username)
 	if err != nil {
@@ -61,9 +162,21 @@ func (s *Tender) GetUserTenders(ctx context.Context, limit, offset int, username
 }
 
 func (s *Tender) UpdateTenderStatus(ctx context.Context, tenderID string, status string, username string) (domain.Tender, error) {
-	updateTender,err := s.repo.UpdateTenderStatus(ctx, tenderID, status, username)
+	from := statusSources(status)
+	if len(from) == 0 {
+		return domain.Tender{}, fmt.Errorf("service.UpdateTenderStatus: %w: tenders can not be moved to status %q", domain.ErrInvalidInput, status)
+	}
+
+	updateTender, changed, err := s.repo.UpdateTenderStatus(ctx, tenderID, status, from, username)
 	if err != nil {
-		return domain.Tender{},fmt.Errorf("service.UpdateTenderStatus: %w", err)
+		return domain.Tender{}, fmt.Errorf("service.UpdateTenderStatus: %w", err)
+	}
+
+	if changed && updateTender.Status == "PUBLISHED" {
+		err = s.notifier.Notify(ctx, domain.Notification{Event: domain.NotificationTenderPublished, Tender: updateTender})
+		if err != nil {
+			logger.Logger().Errorln("Error queueing tender published notification:", err.Error())
+		}
 	}
 
 	return updateTender, nil
@@ -79,6 +192,60 @@ func (s *Tender) GetTenderStatus(ctx context.Context, tenderID string, username
 }
 
 func (s *Tender) UpdatePartTender(ctx context.Context, id string, updates map[string]interface{}, username string) (domain.Tender, error) {
+	if serviceType, ok := updates["serviceType"].(string); ok && serviceType != "" {
+		code, err := s.catalog.ResolveServiceType(ctx, serviceType)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("service.UpdatePartTender: %w", err)
+		}
+		updates["serviceType"] = code
+	}
+
+	if raw, ok := updates["tags"]; ok {
+		tags, err := decodeTags(raw)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("service.UpdatePartTender: %w", err)
+		}
+		updates["tags"] = tags
+	}
+
+	if raw, ok := updates["customFields"]; ok {
+		values, err := decodeCustomFields(raw)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("service.UpdatePartTender: %w", err)
+		}
+
+		tender, err := s.repo.GetTender(ctx, id, username)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("service.UpdatePartTender: %w", err)
+		}
+
+		fields, err := s.fields.GetOrganizationFields(ctx, tender.OrganizationId)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("service.UpdatePartTender: %w", err)
+		}
+
+		updates["customFields"], err = validateCustomFields(fields, values)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("service.UpdatePartTender: %w", err)
+		}
+	}
+
+	if raw, ok := updates["lots"]; ok {
+		lots, err := decodeLots(raw)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("service.UpdatePartTender: %w", err)
+		}
+		updates["lots"] = lots
+	}
+
+	if raw, ok := updates["criteria"]; ok {
+		criteria, err := decodeCriteria(raw)
+		if err != nil {
+			return domain.Tender{}, fmt.Errorf("service.UpdatePartTender: %w", err)
+		}
+		updates["criteria"] = criteria
+	}
+
 	updatedTender, err := s.repo.UpdatePartTender(ctx, id, updates, username)
 	if err != nil {
 		return domain.Tender{}, fmt.Errorf("failed to update tender in repository: %w", err)
@@ -94,3 +261,114 @@ func (s *Tender) RollbackTenderVersion(ctx context.Context, id string, version i
 	}
 	return tender, nil
 }
+
+func (s *Tender) GetTender(ctx context.Context, tenderID string, username string) (domain.Tender, error) {
+	tender, err := s.repo.GetTender(ctx, tenderID, username)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("service.GetTender: %w", err)
+	}
+
+	return tender, nil
+}
+
+// CloneTender creates a new tender of the same organization from the name,
+// description, service type, budget, lots, criteria, tags and custom fields
+// of an existing one.
+// The copy starts over as a created tender with its own version history.
+func (s *Tender) CloneTender(ctx context.Context, tenderID string, username string) (domain.Tender, error) {
+	source, err := s.repo.GetTender(ctx, tenderID, username)
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("service.CloneTender: %w", err)
+	}
+
+	fields := domain.TemplateFromTender(source)
+	clone, err := s.CreateTender(ctx, domain.Tender{
+		Name:            fields.Name,
+		Description:     fields.Description,
+		ServiceType:     fields.ServiceType,
+		Status:          "CREATED",
+		OrganizationId:  source.OrganizationId,
+		CreatorUsername: username,
+		Version:         1,
+		Visibility:      fields.Visibility,
+		Budget:          fields.Budget,
+		Lots:            fields.Lots,
+		Criteria:        fields.Criteria,
+		Tags:            fields.Tags,
+		CustomFields:    fields.CustomFields,
+	})
+	if err != nil {
+		return domain.Tender{}, fmt.Errorf("service.CloneTender: %w", err)
+	}
+
+	return clone, nil
+}
+
+// decodeLots converts the lots of a partial update, which arrive as generic
+// JSON values, into domain lots.
+func decodeLots(raw interface{}) ([]domain.Lot, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid lots", domain.ErrInvalidInput)
+	}
+
+	lots := []domain.Lot{}
+	if err := json.Unmarshal(data, &lots); err != nil {
+		return nil, fmt.Errorf("%w: invalid lots", domain.ErrInvalidInput)
+	}
+
+	if err := validateLots(lots); err != nil {
+		return nil, err
+	}
+
+	return lots, nil
+}
+
+func validateLots(lots []domain.Lot) error {
+	for i := range lots {
+		lots[i].Name = strings.TrimSpace(lots[i].Name)
+		if lots[i].Name == "" {
+			return fmt.Errorf("%w: lot %d has no name", domain.ErrInvalidInput, i+1)
+		}
+		if lots[i].Quantity <= 0 {
+			return fmt.Errorf("%w: lot %d quantity must be positive", domain.ErrInvalidInput, i+1)
+		}
+		if lots[i].Budget != nil && *lots[i].Budget < 0 {
+			return fmt.Errorf("%w: lot %d budget must not be negative", domain.ErrInvalidInput, i+1)
+		}
+	}
+
+	return nil
+}
+
+func decodeCriteria(raw interface{}) ([]domain.Criterion, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid criteria", domain.ErrInvalidInput)
+	}
+
+	criteria := []domain.Criterion{}
+	if err := json.Unmarshal(data, &criteria); err != nil {
+		return nil, fmt.Errorf("%w: invalid criteria", domain.ErrInvalidInput)
+	}
+
+	if err := validateCriteria(criteria); err != nil {
+		return nil, err
+	}
+
+	return criteria, nil
+}
+
+func validateCriteria(criteria []domain.Criterion) error {
+	for i := range criteria {
+		criteria[i].Name = strings.TrimSpace(criteria[i].Name)
+		if criteria[i].Name == "" {
+			return fmt.Errorf("%w: criterion %d has no name", domain.ErrInvalidInput, i+1)
+		}
+		if criteria[i].Weight <= 0 {
+			return fmt.Errorf("%w: criterion %d weight must be positive", domain.ErrInvalidInput, i+1)
+		}
+	}
+
+	return nil
+}
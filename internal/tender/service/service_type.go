@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+// serviceTypeCodes holds the code formats of the classification schemes:
+// CPV codes with their check digit (45210000-2) and OKPD2 codes of one to
+// four levels (41.20.40.000).
+var serviceTypeCodes = map[domain.ServiceTypeScheme]*regexp.Regexp{
+	domain.ServiceTypeSchemeCustom: regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,49}$`),
+	domain.ServiceTypeSchemeCPV:    regexp.MustCompile(`^\d{8}-\d$`),
+	domain.ServiceTypeSchemeOKPD2:  regexp.MustCompile(`^\d{2}(\.\d{1,2}(\.\d{1,2}(\.\d{1,3})?)?)?$`),
+}
+
+var serviceTypeLocales = map[string]bool{"ru": true, "en": true}
+
+type ServiceType struct {
+	repo domain.ServiceTypeRepository
+}
+
+func NewServiceType(repo domain.ServiceTypeRepository) *ServiceType {
+	return &ServiceType{repo: repo}
+}
+
+func (s *ServiceType) ListServiceTypes(ctx context.Context, filter domain.ServiceTypeFilter) ([]domain.ServiceType, error) {
+	serviceTypes, err := s.repo.ListServiceTypes(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListServiceTypes: %w", err)
+	}
+
+	for i := range serviceTypes {
+		serviceTypes[i].Localize(filter.Locale)
+	}
+
+	return serviceTypes, nil
+}
+
+func (s *ServiceType) GetServiceType(ctx context.Context, code string, locale string) (domain.ServiceType, error) {
+	serviceType, err := s.repo.GetServiceType(ctx, code)
+	if err != nil {
+		return domain.ServiceType{}, fmt.Errorf("service.GetServiceType: %w", err)
+	}
+
+	serviceType.Localize(locale)
+
+	return serviceType, nil
+}
+
+func (s *ServiceType) CreateServiceType(ctx context.Context, serviceType domain.ServiceType, username string) (domain.ServiceType, error) {
+	serviceType.Code = strings.TrimSpace(serviceType.Code)
+	if serviceType.Scheme == "" {
+		serviceType.Scheme = domain.ServiceTypeSchemeCustom
+	}
+
+	format, ok := serviceTypeCodes[serviceType.Scheme]
+	if !ok {
+		return domain.ServiceType{}, fmt.Errorf("service.CreateServiceType: %w: unknown scheme %q", domain.ErrInvalidInput, serviceType.Scheme)
+	}
+	if !format.MatchString(serviceType.Code) {
+		return domain.ServiceType{}, fmt.Errorf("service.CreateServiceType: %w: %q is not a %s code", domain.ErrInvalidInput, serviceType.Code, serviceType.Scheme)
+	}
+
+	if len(serviceType.Names) == 0 {
+		return domain.ServiceType{}, fmt.Errorf("service.CreateServiceType: %w: missing names", domain.ErrInvalidInput)
+	}
+	if err := validateServiceTypeNames(serviceType.Names); err != nil {
+		return domain.ServiceType{}, fmt.Errorf("service.CreateServiceType: %w", err)
+	}
+
+	if serviceType.ParentCode != nil && *serviceType.ParentCode == "" {
+		serviceType.ParentCode = nil
+	}
+
+	created, err := s.repo.CreateServiceType(ctx, serviceType, username)
+	if err != nil {
+		return domain.ServiceType{}, fmt.Errorf("service.CreateServiceType: %w", err)
+	}
+
+	created.Localize("")
+
+	return created, nil
+}
+
+func (s *ServiceType) UpdateServiceType(ctx context.Context, code string, update domain.ServiceTypeUpdate, username string) (domain.ServiceType, error) {
+	if err := validateServiceTypeNames(update.Names); err != nil {
+		return domain.ServiceType{}, fmt.Errorf("service.UpdateServiceType: %w", err)
+	}
+
+	if update.Code != nil {
+		current, err := s.repo.GetServiceType(ctx, code)
+		if err != nil {
+			return domain.ServiceType{}, fmt.Errorf("service.UpdateServiceType: %w", err)
+		}
+
+		newCode := strings.TrimSpace(*update.Code)
+		if !serviceTypeCodes[current.Scheme].MatchString(newCode) {
+			return domain.ServiceType{}, fmt.Errorf("service.UpdateServiceType: %w: %q is not a %s code", domain.ErrInvalidInput, newCode, current.Scheme)
+		}
+		update.Code = &newCode
+	}
+
+	updated, err := s.repo.UpdateServiceType(ctx, code, update, username)
+	if err != nil {
+		return domain.ServiceType{}, fmt.Errorf("service.UpdateServiceType: %w", err)
+	}
+
+	updated.Localize("")
+
+	return updated, nil
+}
+
+func (s *ServiceType) DeleteServiceType(ctx context.Context, code string, username string) error {
+	if err := s.repo.DeleteServiceType(ctx, code, username); err != nil {
+		return fmt.Errorf("service.DeleteServiceType: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveServiceType returns the catalog spelling of the code, so that
+// tenders always store the same code for a category.
+func (s *ServiceType) ResolveServiceType(ctx context.Context, code string) (string, error) {
+	serviceType, err := s.repo.GetServiceType(ctx, strings.TrimSpace(code))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", fmt.Errorf("%w: unknown service type %q", domain.ErrInvalidInput, code)
+		}
+		return "", err
+	}
+
+	if !serviceType.Active {
+		return "", fmt.Errorf("%w: service type %q is no longer in use", domain.ErrInvalidInput, serviceType.Code)
+	}
+
+	return serviceType.Code, nil
+}
+
+func validateServiceTypeNames(names map[string]string) error {
+	for locale, name := range names {
+		if !serviceTypeLocales[locale] {
+			return fmt.Errorf("%w: unsupported locale %q", domain.ErrInvalidInput, locale)
+		}
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: empty %s name", domain.ErrInvalidInput, locale)
+		}
+	}
+
+	return nil
+}
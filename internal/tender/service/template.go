@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type Template struct {
+	repo    domain.TemplateRepository
+	tenders domain.TenderRepository
+}
+
+func NewTemplate(repo domain.TemplateRepository, tenders domain.TenderRepository) *Template {
+	return &Template{repo: repo, tenders: tenders}
+}
+
+// CreateTemplate saves the template fields or, given sourceTenderID, the
+// fields of that tender, which has to belong to the template organization.
+func (s *Template) CreateTemplate(ctx context.Context, template domain.TenderTemplate, sourceTenderID string) (domain.TenderTemplate, error) {
+	template.Name = strings.TrimSpace(template.Name)
+	if template.Name == "" {
+		return domain.TenderTemplate{}, fmt.Errorf("service.CreateTemplate: %w: missing name", domain.ErrInvalidInput)
+	}
+
+	if sourceTenderID != "" {
+		tender, err := s.tenders.GetTender(ctx, sourceTenderID, template.CreatorUsername)
+		if err != nil {
+			return domain.TenderTemplate{}, fmt.Errorf("service.CreateTemplate: %w", err)
+		}
+		if tender.OrganizationId != template.OrganizationID {
+			return domain.TenderTemplate{}, fmt.Errorf("service.CreateTemplate: %w: tender belongs to another organization", domain.ErrInvalidInput)
+		}
+		template.Tender = domain.TemplateFromTender(tender)
+	}
+
+	switch template.Tender.Visibility {
+	case "", domain.TenderVisibilityPublic, domain.TenderVisibilityPrivate:
+	default:
+		return domain.TenderTemplate{}, fmt.Errorf("service.CreateTemplate: %w: unknown visibility %q", domain.ErrInvalidInput, template.Tender.Visibility)
+	}
+
+	if template.Tender.Budget != nil && *template.Tender.Budget < 0 {
+		return domain.TenderTemplate{}, fmt.Errorf("service.CreateTemplate: %w: budget must not be negative", domain.ErrInvalidInput)
+	}
+
+	// Lots and criteria get their identities when a tender is created.
+	for i := range template.Tender.Lots {
+		template.Tender.Lots[i].ID, template.Tender.Lots[i].AwardedBidID, template.Tender.Lots[i].AwardedAt = "", nil, nil
+	}
+	for i := range template.Tender.Criteria {
+		template.Tender.Criteria[i].ID = ""
+	}
+
+	if err := validateLots(template.Tender.Lots); err != nil {
+		return domain.TenderTemplate{}, fmt.Errorf("service.CreateTemplate: %w", err)
+	}
+
+	if err := validateCriteria(template.Tender.Criteria); err != nil {
+		return domain.TenderTemplate{}, fmt.Errorf("service.CreateTemplate: %w", err)
+	}
+
+	created, err := s.repo.CreateTemplate(ctx, template)
+	if err != nil {
+		return domain.TenderTemplate{}, fmt.Errorf("service.CreateTemplate: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *Template) ListTemplates(ctx context.Context, organizationID string, username string) ([]domain.TenderTemplate, error) {
+	templates, err := s.repo.ListTemplates(ctx, organizationID, username)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListTemplates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (s *Template) GetTemplate(ctx context.Context, templateID string, username string) (domain.TenderTemplate, error) {
+	template, err := s.repo.GetTemplate(ctx, templateID, username)
+	if err != nil {
+		return domain.TenderTemplate{}, fmt.Errorf("service.GetTemplate: %w", err)
+	}
+
+	return template, nil
+}
+
+func (s *Template) DeleteTemplate(ctx context.Context, organizationID string, templateID string, username string) error {
+	if err := s.repo.DeleteTemplate(ctx, organizationID, templateID, username); err != nil {
+		return fmt.Errorf("service.DeleteTemplate: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/pkg/logger"
+)
+
+// maxBatchStatusTenders bounds the tenders changed by one batch request.
+const maxBatchStatusTenders = 500
+
+// tenderStatusTransitions lists the statuses a tender may move to from each
+// status. Closing is final.
+var tenderStatusTransitions = map[string][]string{
+	"CREATED":   {"PUBLISHED", "CLOSED"},
+	"OPEN":      {"PUBLISHED", "CLOSED"},
+	"PUBLISHED": {"CLOSED"},
+}
+
+// statusSources returns the statuses a tender may move to status from.
+func statusSources(status string) []string {
+	var from []string
+	for source, targets := range tenderStatusTransitions {
+		if slices.Contains(targets, status) {
+			from = append(from, source)
+		}
+	}
+
+	return from
+}
+
+// errBatchRolledBack marks the tenders of an atomic batch that passed their
+// checks but were not changed because another one failed.
+var errBatchRolledBack = errors.New("not applied, another tender of the batch failed")
+
+func (s *Tender) UpdateTenderStatuses(ctx context.Context, req domain.BatchStatusRequest, username string) (domain.BatchStatusResult, error) {
+	switch req.Mode {
+	case "":
+		req.Mode = domain.BatchStatusAtomic
+	case domain.BatchStatusAtomic, domain.BatchStatusBestEffort:
+	default:
+		return domain.BatchStatusResult{}, fmt.Errorf("service.UpdateTenderStatuses: %w: unknown mode %q", domain.ErrInvalidInput, req.Mode)
+	}
+
+	from := statusSources(req.Status)
+	if len(from) == 0 {
+		return domain.BatchStatusResult{}, fmt.Errorf("service.UpdateTenderStatuses: %w: tenders can not be moved to status %q", domain.ErrInvalidInput, req.Status)
+	}
+
+	tenderIDs := []string{}
+	for _, tenderID := range req.TenderIDs {
+		if !uuidPattern.MatchString(tenderID) {
+			return domain.BatchStatusResult{}, fmt.Errorf("service.UpdateTenderStatuses: %w: invalid tender ID %q", domain.ErrInvalidInput, tenderID)
+		}
+		if !slices.Contains(tenderIDs, tenderID) {
+			tenderIDs = append(tenderIDs, tenderID)
+		}
+	}
+	if len(tenderIDs) == 0 || len(tenderIDs) > maxBatchStatusTenders {
+		return domain.BatchStatusResult{}, fmt.Errorf("service.UpdateTenderStatuses: %w: between 1 and %d tenders are changed at once", domain.ErrInvalidInput, maxBatchStatusTenders)
+	}
+
+	changes, err := s.repo.UpdateTenderStatuses(ctx, tenderIDs, req.Status, from, username, req.Mode == domain.BatchStatusAtomic)
+	if err != nil {
+		return domain.BatchStatusResult{}, fmt.Errorf("service.UpdateTenderStatuses: %w", err)
+	}
+
+	result := domain.BatchStatusResult{Status: req.Status, Mode: req.Mode, Results: changes}
+	for _, change := range changes {
+		if change.Err != nil {
+			result.Failed++
+		}
+	}
+
+	for i := range changes {
+		change := &changes[i]
+		if change.Err == nil && result.Failed > 0 && req.Mode == domain.BatchStatusAtomic {
+			change.Err, change.Status = errBatchRolledBack, change.PreviousStatus
+		}
+		if change.Err != nil {
+			change.Error = change.Err.Error()
+			continue
+		}
+		if !change.Applied {
+			continue
+		}
+		result.Applied++
+
+		if req.Status == "PUBLISHED" {
+			s.notifyPublished(ctx, change.TenderID, username)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Tender) notifyPublished(ctx context.Context, tenderID, username string) {
+	tender, err := s.repo.GetTender(ctx, tenderID, username)
+	if err == nil {
+		err = s.notifier.Notify(ctx, domain.Notification{Event: domain.NotificationTenderPublished, Tender: tender})
+	}
+	if err != nil {
+		logger.Logger().Errorln("Error queueing tender published notification:", err.Error())
+	}
+}
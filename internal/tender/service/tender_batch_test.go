@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type batchRepoStub struct {
+	domain.TenderRepository
+	statuses map[string]string
+	from     []string
+}
+
+func (r *batchRepoStub) UpdateTenderStatuses(_ context.Context, tenderIDs []string, status string, from []string, _ string, atomic bool) ([]domain.TenderStatusChange, error) {
+	r.from = from
+
+	changes := []domain.TenderStatusChange{}
+	failed := false
+	for _, tenderID := range tenderIDs {
+		change := domain.TenderStatusChange{TenderID: tenderID, PreviousStatus: r.statuses[tenderID], Status: status}
+		switch {
+		case change.PreviousStatus == "":
+			change.Err, change.Status = domain.ErrTenderNotFound, ""
+		case change.PreviousStatus != status && !slices.Contains(from, change.PreviousStatus):
+			change.Err, change.Status = domain.ErrConflict, ""
+		}
+		failed = failed || change.Err != nil
+		changes = append(changes, change)
+	}
+
+	for i := range changes {
+		changes[i].Applied = changes[i].Err == nil && changes[i].PreviousStatus != status && !(atomic && failed)
+	}
+
+	return changes, nil
+}
+
+const (
+	batchCreated = "5b8f2a0e-6b1c-4f43-9a57-0d7a51b6c001"
+	batchClosed  = "5b8f2a0e-6b1c-4f43-9a57-0d7a51b6c002"
+	batchMissing = "5b8f2a0e-6b1c-4f43-9a57-0d7a51b6c003"
+)
+
+func TestUpdateTenderStatuses(t *testing.T) {
+	repo := &batchRepoStub{statuses: map[string]string{batchCreated: "CREATED", batchClosed: "CLOSED"}}
+	s := &Tender{repo: repo}
+
+	result, err := s.UpdateTenderStatuses(context.Background(), domain.BatchStatusRequest{
+		TenderIDs: []string{batchCreated, batchClosed, batchMissing, batchCreated},
+		Status:    "CLOSED",
+	}, "ivan")
+	if err != nil {
+		t.Fatalf("UpdateTenderStatuses: %v", err)
+	}
+
+	slices.Sort(repo.from)
+	if !slices.Equal(repo.from, []string{"CREATED", "OPEN", "PUBLISHED"}) {
+		t.Errorf("from = %v", repo.from)
+	}
+	if result.Mode != domain.BatchStatusAtomic || result.Applied != 0 || result.Failed != 1 || len(result.Results) != 3 {
+		t.Fatalf("atomic result = %+v", result)
+	}
+	if first := result.Results[0]; !errors.Is(first.Err, errBatchRolledBack) || first.Status != "CREATED" {
+		t.Errorf("passing tender of a failed atomic batch = %+v", first)
+	}
+
+	result, err = s.UpdateTenderStatuses(context.Background(), domain.BatchStatusRequest{
+		TenderIDs: []string{batchCreated, batchClosed, batchMissing},
+		Status:    "CLOSED",
+		Mode:      domain.BatchStatusBestEffort,
+	}, "ivan")
+	if err != nil {
+		t.Fatalf("UpdateTenderStatuses: %v", err)
+	}
+	if result.Applied != 1 || result.Failed != 1 || result.Results[1].Error != "" || result.Results[2].Error != domain.ErrTenderNotFound.Error() {
+		t.Errorf("best effort result = %+v", result)
+	}
+
+	for _, req := range []domain.BatchStatusRequest{
+		{TenderIDs: []string{batchCreated}, Status: "CREATED"},
+		{TenderIDs: []string{"42"}, Status: "CLOSED"},
+		{Status: "CLOSED"},
+		{TenderIDs: []string{batchCreated}, Status: "CLOSED", Mode: "eventually"},
+	} {
+		if _, err := s.UpdateTenderStatuses(context.Background(), req, "ivan"); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("%+v: err = %v, want invalid input", req, err)
+		}
+	}
+}
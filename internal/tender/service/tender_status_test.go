@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+type statusRepoStub struct {
+	domain.TenderRepository
+	mu     sync.Mutex
+	tender domain.Tender
+	err    error
+}
+
+func (r *statusRepoStub) UpdateTenderStatus(_ context.Context, _ string, status string, from []string, _ string) (domain.Tender, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return domain.Tender{}, false, r.err
+	}
+
+	changed := r.tender.Status != status
+	if changed && !slices.Contains(from, r.tender.Status) {
+		return domain.Tender{}, false, domain.ErrConflict
+	}
+	r.tender.Status = status
+
+	return r.tender, changed, nil
+}
+
+type notifierStub struct {
+	mu            sync.Mutex
+	notifications []domain.Notification
+}
+
+func (n *notifierStub) Notify(_ context.Context, notification domain.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func TestUpdateTenderStatusNotifiesOnce(t *testing.T) {
+	repo := &statusRepoStub{tender: domain.Tender{ID: batchCreated, Status: "CREATED"}}
+	notifier := &notifierStub{}
+	s := &Tender{repo: repo, notifier: notifier}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.UpdateTenderStatus(context.Background(), batchCreated, "PUBLISHED", "ivan"); err != nil {
+				t.Errorf("UpdateTenderStatus: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(notifier.notifications) != 1 || notifier.notifications[0].Event != domain.NotificationTenderPublished {
+		t.Errorf("notifications = %+v, want a single published one", notifier.notifications)
+	}
+
+	repo.err = domain.ErrTenderNotFound
+	if _, err := s.UpdateTenderStatus(context.Background(), batchCreated, "PUBLISHED", "ivan"); !errors.Is(err, domain.ErrTenderNotFound) {
+		t.Errorf("UpdateTenderStatus error = %v, want %v", err, domain.ErrTenderNotFound)
+	}
+}
+
+func TestUpdateTenderStatusTransitions(t *testing.T) {
+	repo := &statusRepoStub{tender: domain.Tender{ID: batchCreated, Status: "CREATED"}}
+	s := &Tender{repo: repo, notifier: &notifierStub{}}
+	ctx := context.Background()
+
+	for _, status := range []string{"CREATED", "OPEN", "ARCHIVED"} {
+		if _, err := s.UpdateTenderStatus(ctx, batchCreated, status, "ivan"); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("UpdateTenderStatus to %s: error = %v, want %v", status, err, domain.ErrInvalidInput)
+		}
+	}
+
+	if _, err := s.UpdateTenderStatus(ctx, batchCreated, "CLOSED", "ivan"); err != nil {
+		t.Fatalf("UpdateTenderStatus to CLOSED: %v", err)
+	}
+
+	if _, err := s.UpdateTenderStatus(ctx, batchCreated, "PUBLISHED", "ivan"); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("UpdateTenderStatus of a closed tender: error = %v, want %v", err, domain.ErrConflict)
+	}
+	if repo.tender.Status != "CLOSED" {
+		t.Errorf("status = %s, closing must be final", repo.tender.Status)
+	}
+}
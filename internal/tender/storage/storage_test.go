@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+)
+
+const (
+	testAccessKey = "test-access-key"
+	testSecretKey = "test-secret-key"
+	testRegion    = "us-east-1"
+	testBucket    = "attachments"
+)
+
+// s3StandIn is an in-memory S3-compatible server that checks request
+// signatures the same way a real object storage would.
+type s3StandIn struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *s3StandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := verifySignature(r); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.objects[r.URL.Path] = body
+	case http.MethodGet:
+		body, ok := s.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func verifySignature(r *http.Request) error {
+	auth := r.Header.Get("Authorization")
+	fields := map[string]string{}
+	for _, field := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ", ") {
+		name, value, _ := strings.Cut(field, "=")
+		fields[name] = value
+	}
+
+	credential := strings.Split(fields["Credential"], "/")
+	if len(credential) != 5 || credential[0] != testAccessKey || credential[2] != testRegion {
+		return fmt.Errorf("bad credential %q", fields["Credential"])
+	}
+
+	var headers []string
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers = append(headers, name+":"+value+"\n")
+	}
+
+	canonical := r.Method + "\n" + r.URL.EscapedPath() + "\n" + r.URL.RawQuery + "\n" +
+		strings.Join(headers, "") + "\n" + fields["SignedHeaders"] + "\n" + r.Header.Get("X-Amz-Content-Sha256")
+	hash := sha256.Sum256([]byte(canonical))
+
+	scope := strings.Join(credential[1:], "/")
+	toSign := "AWS4-HMAC-SHA256\n" + r.Header.Get("X-Amz-Date") + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + testSecretKey)
+	for _, part := range credential[1:] {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(toSign))
+	if want := hex.EncodeToString(mac.Sum(nil)); fields["Signature"] != want {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	return nil
+}
+
+func testRoundTrip(t *testing.T, storage domain.FileStorage) {
+	t.Helper()
+
+	ctx := context.Background()
+	key := "tender/0d5e7b1c/1f2e3d4c"
+	content := "technical specification"
+
+	if err := storage.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	rc, err := storage.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(got) != content {
+		t.Fatalf("Get returned %q, want %q", got, content)
+	}
+
+	if err := storage.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := storage.Get(ctx, key); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("Get after Delete error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
+
+func TestLocal(t *testing.T) {
+	storage, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	testRoundTrip(t, storage)
+
+	if err := storage.Put(context.Background(), "../escape", strings.NewReader("x"), 1, ""); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("Put outside root error = %v, want %v", err, domain.ErrInvalidInput)
+	}
+}
+
+func TestS3(t *testing.T) {
+	server := httptest.NewServer(&s3StandIn{objects: map[string][]byte{}})
+	defer server.Close()
+
+	storage, err := NewS3(server.URL, testRegion, testBucket, testAccessKey, testSecretKey, true)
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+
+	testRoundTrip(t, storage)
+}
+
+func TestS3RejectsWrongCredentials(t *testing.T) {
+	server := httptest.NewServer(&s3StandIn{objects: map[string][]byte{}})
+	defer server.Close()
+
+	storage, err := NewS3(server.URL, testRegion, testBucket, testAccessKey, "wrong-secret", true)
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+
+	if err := storage.Put(context.Background(), "key", strings.NewReader("x"), 1, ""); err == nil {
+		t.Fatal("Put with wrong secret succeeded")
+	}
+}
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tender_lot (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    quantity NUMERIC(15, 3) NOT NULL CHECK (quantity > 0),
+    unit VARCHAR(50) NOT NULL DEFAULT '',
+    budget NUMERIC(15, 2) CHECK (budget >= 0),
+    awarded_bid_id UUID REFERENCES bid(id) ON DELETE SET NULL,
+    awarded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tender_lot_tender_id_idx ON tender_lot (tender_id, position);
+
+CREATE TABLE IF NOT EXISTS bid_lot (
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    lot_id UUID NOT NULL REFERENCES tender_lot(id) ON DELETE RESTRICT,
+    price NUMERIC(15, 2) CHECK (price >= 0),
+    PRIMARY KEY (bid_id, lot_id)
+);
+
+CREATE INDEX IF NOT EXISTS bid_lot_lot_id_idx ON bid_lot (lot_id);
+
+ALTER TABLE tender_versions
+    ADD COLUMN IF NOT EXISTS lots JSONB NOT NULL DEFAULT '[]';
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tender_criterion (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    weight NUMERIC(7, 3) NOT NULL CHECK (weight > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tender_criterion_tender_id_idx ON tender_criterion (tender_id, position);
+
+CREATE TABLE IF NOT EXISTS bid_score (
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    criterion_id UUID NOT NULL REFERENCES tender_criterion(id) ON DELETE RESTRICT,
+    evaluator VARCHAR(255) NOT NULL REFERENCES employee(username) ON DELETE CASCADE,
+    score NUMERIC(4, 2) NOT NULL CHECK (score >= 0 AND score <= 10),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (bid_id, criterion_id, evaluator)
+);
+
+CREATE INDEX IF NOT EXISTS bid_score_criterion_id_idx ON bid_score (criterion_id);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS seal_key BYTEA,
+    ADD CONSTRAINT tender_sealed_check CHECK (NOT sealed OR (seal_key IS NOT NULL AND closes_at IS NOT NULL));
+
+ALTER TABLE bid
+    ADD COLUMN IF NOT EXISTS price NUMERIC(15, 2) CHECK (price >= 0),
+    ADD COLUMN IF NOT EXISTS sealed_payload BYTEA;
+
+COMMIT;
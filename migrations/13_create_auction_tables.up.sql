@@ -0,0 +1,33 @@
+BEGIN;
+
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS type VARCHAR(20) NOT NULL DEFAULT 'STANDARD' CHECK (type IN ('STANDARD', 'AUCTION'));
+
+CREATE TABLE IF NOT EXISTS tender_auction (
+    tender_id UUID PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    start_price NUMERIC(15, 2) NOT NULL CHECK (start_price > 0),
+    min_decrement NUMERIC(15, 2) NOT NULL CHECK (min_decrement > 0),
+    extension_seconds INT NOT NULL DEFAULT 0 CHECK (extension_seconds >= 0),
+    best_offer_id UUID,
+    best_price NUMERIC(15, 2),
+    offer_count INT NOT NULL DEFAULT 0,
+    CONSTRAINT tender_auction_period_check CHECK (ends_at > starts_at)
+);
+
+CREATE TABLE IF NOT EXISTS auction_offer (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender_auction(tender_id) ON DELETE CASCADE,
+    seq INT NOT NULL,
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    created_by_user VARCHAR(255) NOT NULL REFERENCES employee(username) ON DELETE CASCADE,
+    price NUMERIC(15, 2) NOT NULL CHECK (price > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT auction_offer_seq_unique UNIQUE (tender_id, seq)
+);
+
+ALTER TABLE tender_auction
+    ADD CONSTRAINT tender_auction_best_offer_fk FOREIGN KEY (best_offer_id) REFERENCES auction_offer(id) ON DELETE SET NULL;
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+ALTER TABLE tender_lot
+    ADD COLUMN IF NOT EXISTS awarded_by VARCHAR(255) REFERENCES employee(username) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS award (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    lot_id UUID REFERENCES tender_lot(id) ON DELETE CASCADE,
+    bid_id UUID REFERENCES bid(id) ON DELETE RESTRICT,
+    offer_id UUID REFERENCES auction_offer(id) ON DELETE RESTRICT,
+    organization_id UUID REFERENCES organization(id) ON DELETE SET NULL,
+    amount NUMERIC(15, 2),
+    decided_at TIMESTAMP NOT NULL,
+    decision_makers VARCHAR(255)[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT award_winner_check CHECK (bid_id IS NOT NULL OR offer_id IS NOT NULL),
+    CONSTRAINT award_lot_unique UNIQUE (tender_id, lot_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS award_tender_unique_idx ON award (tender_id) WHERE lot_id IS NULL;
+
+COMMIT;
@@ -0,0 +1,47 @@
+BEGIN;
+
+ALTER TABLE employee
+    ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS service_types (
+    code VARCHAR(50) PRIMARY KEY,
+    parent_code VARCHAR(50) REFERENCES service_types(code) ON UPDATE CASCADE ON DELETE RESTRICT,
+    scheme VARCHAR(10) CHECK (scheme IN ('CUSTOM', 'CPV', 'OKPD2')) NOT NULL DEFAULT 'CUSTOM',
+    names JSONB NOT NULL DEFAULT '{}',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT service_types_parent_check CHECK (parent_code <> code)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS service_types_code_lower_idx ON service_types (lower(code));
+CREATE INDEX IF NOT EXISTS service_types_parent_code_idx ON service_types (parent_code);
+
+INSERT INTO service_types (code, names) VALUES
+    ('Construction', '{"ru": "Строительство", "en": "Construction"}'),
+    ('Delivery', '{"ru": "Поставка", "en": "Delivery"}'),
+    ('Manufacture', '{"ru": "Производство", "en": "Manufacture"}')
+ON CONFLICT DO NOTHING;
+
+-- Service types already in use join the catalog, spellings differing only
+-- in case are merged into one.
+INSERT INTO service_types (code, names)
+SELECT DISTINCT ON (lower(t.service_type)) t.service_type, jsonb_build_object('ru', t.service_type, 'en', t.service_type)
+FROM tender t
+WHERE NOT EXISTS (SELECT 1 FROM service_types s WHERE lower(s.code) = lower(t.service_type))
+ORDER BY lower(t.service_type), t.service_type;
+
+UPDATE tender t
+SET service_type = s.code
+FROM service_types s
+WHERE lower(s.code) = lower(t.service_type) AND s.code <> t.service_type;
+
+UPDATE tender_versions v
+SET service_type = s.code
+FROM service_types s
+WHERE lower(s.code) = lower(v.service_type) AND s.code <> v.service_type;
+
+ALTER TABLE tender
+    ADD CONSTRAINT fk_service_type FOREIGN KEY (service_type) REFERENCES service_types(code) ON UPDATE CASCADE;
+
+COMMIT;
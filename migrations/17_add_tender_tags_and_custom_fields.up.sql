@@ -0,0 +1,26 @@
+BEGIN;
+
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS tags VARCHAR(50)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS tender_tags_idx ON tender USING GIN (tags);
+CREATE INDEX IF NOT EXISTS tender_custom_fields_idx ON tender USING GIN (custom_fields jsonb_path_ops);
+
+ALTER TABLE tender_versions
+    ADD COLUMN IF NOT EXISTS tags VARCHAR(50)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS custom_field (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(10) CHECK (type IN ('STRING', 'NUMBER', 'DATE', 'ENUM')) NOT NULL,
+    options VARCHAR(100)[] NOT NULL DEFAULT '{}',
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT custom_field_key_unique UNIQUE (organization_id, key)
+);
+
+COMMIT;
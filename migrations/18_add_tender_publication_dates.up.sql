@@ -0,0 +1,41 @@
+BEGIN;
+
+-- Versions were not dated so far, the ones already stored get the creation
+-- time of their tender.
+ALTER TABLE tender_versions
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP;
+
+UPDATE tender_versions v
+SET created_at = t.created_at
+FROM tender t
+WHERE t.id = v.tender_id AND v.created_at IS NULL;
+
+ALTER TABLE tender_versions
+    ALTER COLUMN created_at SET DEFAULT CURRENT_TIMESTAMP,
+    ALTER COLUMN created_at SET NOT NULL;
+
+-- Status changes do not create versions, their time is kept on the tender.
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP;
+
+UPDATE tender SET status_changed_at = created_at WHERE status_changed_at IS NULL;
+
+ALTER TABLE tender
+    ALTER COLUMN status_changed_at SET DEFAULT CURRENT_TIMESTAMP,
+    ALTER COLUMN status_changed_at SET NOT NULL;
+
+CREATE OR REPLACE FUNCTION tender_status_changed() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.status_changed_at := CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tender_status_changed ON tender;
+CREATE TRIGGER tender_status_changed
+    BEFORE UPDATE OF status ON tender
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION tender_status_changed();
+
+COMMIT;
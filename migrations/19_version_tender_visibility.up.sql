@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Versions saved so far recorded neither, rolling back to one of them keeps
+-- the current visibility and invitations.
+ALTER TABLE tender_versions
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(10) CHECK (visibility IN ('PUBLIC', 'PRIVATE')),
+    ADD COLUMN IF NOT EXISTS invited_organizations UUID[];
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Versions saved so far did not record the criteria, rolling back to one of
+-- them keeps the current criteria.
+ALTER TABLE tender_versions
+    ADD COLUMN IF NOT EXISTS criteria JSONB;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Files attached to bids on sealed tenders are stored encrypted under the
+-- seal key of the tender.
+ALTER TABLE attachment
+    ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
@@ -0,0 +1,35 @@
+BEGIN;
+
+-- Tenders and subcategories follow a renamed code through their foreign
+-- keys. Versions, saved searches and templates keep codes without one, so
+-- that entries deleted later stay in their history, and are renamed here.
+CREATE OR REPLACE FUNCTION service_type_renamed() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE tender_versions
+    SET service_type = NEW.code
+    WHERE service_type = OLD.code;
+
+    UPDATE saved_search
+    SET service_types = ARRAY(
+        SELECT CASE WHEN lower(u.code) = lower(OLD.code) THEN NEW.code ELSE u.code END
+        FROM unnest(service_types) WITH ORDINALITY u(code, i)
+        ORDER BY u.i
+    )
+    WHERE lower(OLD.code) IN (SELECT lower(c) FROM unnest(service_types) c);
+
+    UPDATE tender_template
+    SET tender = jsonb_set(tender, '{serviceType}', to_jsonb(NEW.code))
+    WHERE tender->>'serviceType' = OLD.code;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS service_type_renamed ON service_types;
+CREATE TRIGGER service_type_renamed
+    AFTER UPDATE OF code ON service_types
+    FOR EACH ROW
+    WHEN (OLD.code IS DISTINCT FROM NEW.code)
+    EXECUTE FUNCTION service_type_renamed();
+
+COMMIT;
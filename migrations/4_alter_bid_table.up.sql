@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE bid
+    ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS created_by_user VARCHAR(255) REFERENCES employee(username) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS bid_tender_id_idx ON bid (tender_id);
+
+COMMIT;
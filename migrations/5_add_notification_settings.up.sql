@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE employee
+    ADD COLUMN IF NOT EXISTS email VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(2) CHECK (locale IN ('ru', 'en')) NOT NULL DEFAULT 'ru',
+    ADD COLUMN IF NOT EXISTS notify_on_bid BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS notify_on_closing BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS closes_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS closing_notified_at TIMESTAMP;
+
+COMMIT;
@@ -0,0 +1,40 @@
+BEGIN;
+
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS budget NUMERIC(15, 2) CHECK (budget >= 0);
+
+ALTER TABLE tender_versions
+    ADD COLUMN IF NOT EXISTS budget NUMERIC(15, 2);
+
+CREATE TABLE IF NOT EXISTS saved_search (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    username VARCHAR(255) NOT NULL REFERENCES employee(username) ON DELETE CASCADE,
+    service_types VARCHAR(50)[] NOT NULL DEFAULT '{}',
+    keywords TEXT[] NOT NULL DEFAULT '{}',
+    budget_min NUMERIC(15, 2),
+    budget_max NUMERIC(15, 2),
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    subscribed BOOLEAN NOT NULL DEFAULT TRUE,
+    webhook_url TEXT,
+    webhook_secret TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS saved_search_username_idx ON saved_search (username);
+
+CREATE TABLE IF NOT EXISTS notification (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    username VARCHAR(255) NOT NULL REFERENCES employee(username) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+    saved_search_id UUID REFERENCES saved_search(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS notification_username_idx ON notification (username, created_at DESC);
+
+COMMIT;
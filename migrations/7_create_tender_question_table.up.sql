@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tender_question (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    author_username VARCHAR(255) NOT NULL REFERENCES employee(username) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    answer TEXT,
+    answered_by VARCHAR(255) REFERENCES employee(username) ON DELETE SET NULL,
+    answered_at TIMESTAMP,
+    visibility VARCHAR(10) CHECK (visibility IN ('PUBLIC', 'PRIVATE')) NOT NULL DEFAULT 'PRIVATE',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tender_question_tender_id_idx ON tender_question (tender_id, created_at);
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(10) CHECK (visibility IN ('PUBLIC', 'PRIVATE')) NOT NULL DEFAULT 'PUBLIC';
+
+CREATE TABLE IF NOT EXISTS tender_invitation (
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    invited_by VARCHAR(255) REFERENCES employee(username) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (tender_id, organization_id)
+);
+
+CREATE INDEX IF NOT EXISTS tender_invitation_organization_id_idx ON tender_invitation (organization_id);
+
+COMMIT;
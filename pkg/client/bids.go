@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// CreateBid places a bid on a published tender.
+func (c *Client) CreateBid(ctx context.Context, bid CreateBidRequest) (Bid, error) {
+	if bid.CreatorUsername == "" {
+		bid.CreatorUsername = c.username
+	}
+
+	req := c.newRequest(http.MethodPost, "/api/bids/new", nil)
+	if err := req.json(bid); err != nil {
+		return Bid{}, fmt.Errorf("client.CreateBid: %w", err)
+	}
+
+	var created Bid
+	if err := c.do(ctx, req, &created); err != nil {
+		return Bid{}, fmt.Errorf("client.CreateBid: %w", err)
+	}
+
+	return created, nil
+}
+
+// MyBids returns a page of the bids created by the employee.
+func (c *Client) MyBids(ctx context.Context, limit, offset int) ([]Bid, error) {
+	var bids []Bid
+	if err := c.do(ctx, c.newRequest(http.MethodGet, "/api/bids/my", pageQuery(limit, offset)), &bids); err != nil {
+		return nil, fmt.Errorf("client.MyBids: %w", err)
+	}
+
+	return bids, nil
+}
+
+func (c *Client) IterateMyBids(pageSize int) *Pager[Bid] {
+	return newPager(pageSize, 0, c.MyBids)
+}
+
+// TenderBids returns a page of the bids on a tender. Bids on a sealed tender
+// are listed once they are revealed.
+func (c *Client) TenderBids(ctx context.Context, tenderID string, limit, offset int) ([]Bid, error) {
+	var bids []Bid
+	path := "/api/bids/" + url.PathEscape(tenderID) + "/list"
+	if err := c.do(ctx, c.newRequest(http.MethodGet, path, pageQuery(limit, offset)), &bids); err != nil {
+		return nil, fmt.Errorf("client.TenderBids: %w", err)
+	}
+
+	return bids, nil
+}
+
+func (c *Client) IterateTenderBids(tenderID string, pageSize int) *Pager[Bid] {
+	return newPager(pageSize, 0, func(ctx context.Context, limit, offset int) ([]Bid, error) {
+		return c.TenderBids(ctx, tenderID, limit, offset)
+	})
+}
+
+// CountTenderBids returns the number of bids on a tender, which is known
+// before sealed bids are revealed.
+func (c *Client) CountTenderBids(ctx context.Context, tenderID string) (BidCount, error) {
+	var count BidCount
+	path := "/api/bids/" + url.PathEscape(tenderID) + "/count"
+	if err := c.do(ctx, c.newRequest(http.MethodGet, path, nil), &count); err != nil {
+		return BidCount{}, fmt.Errorf("client.CountTenderBids: %w", err)
+	}
+
+	return count, nil
+}
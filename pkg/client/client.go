@@ -0,0 +1,284 @@
+// Package client is the Go client of the tender service HTTP API.
+//
+// Requests are made on behalf of the employee given by WithUsername, the
+// way the API takes it from the username query parameter. Idempotent calls
+// are retried with exponential backoff on network errors and on 429, 502,
+// 503 and 504 responses; error responses are returned as *Error.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRetries = 3
+	DefaultBackoff = 200 * time.Millisecond
+
+	maxBackoff = 5 * time.Second
+)
+
+type Client struct {
+	baseURL  *url.URL
+	http     *http.Client
+	username string
+	retries  int
+	backoff  time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client requests are sent with, the default
+// one is http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.http = httpClient
+	}
+}
+
+// WithUsername sets the employee requests are made on behalf of.
+func WithUsername(username string) Option {
+	return func(c *Client) {
+		c.username = username
+	}
+}
+
+// WithRetries sets how many times an idempotent call is retried and the
+// delay before the first retry, which doubles with every further one.
+// Zero retries turns retrying off.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client of the service at baseURL, such as
+// "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("client.New: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("client.New: base URL %q is not an http(s) URL", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	c := &Client{
+		baseURL: u,
+		http:    http.DefaultClient,
+		retries: DefaultRetries,
+		backoff: DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// As returns a copy of the client making requests on behalf of username.
+func (c *Client) As(username string) *Client {
+	clone := *c
+	clone.username = username
+
+	return &clone
+}
+
+func (c *Client) Username() string {
+	return c.username
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+	accept      string
+	// idempotent requests are retried. They are the ones other than POST
+	// and PATCH unless the call says otherwise.
+	idempotent bool
+}
+
+func (c *Client) newRequest(method, path string, query url.Values) *request {
+	if query == nil {
+		query = url.Values{}
+	}
+	if c.username != "" && !query.Has("username") {
+		query.Set("username", c.username)
+	}
+
+	return &request{
+		method:     method,
+		path:       path,
+		query:      query,
+		idempotent: method != http.MethodPost && method != http.MethodPatch,
+	}
+}
+
+func (r *request) json(v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	r.body = body
+	r.contentType = "application/json"
+
+	return nil
+}
+
+// do sends the request and decodes a JSON response into out, unless out is
+// nil.
+func (c *Client) do(ctx context.Context, req *request, out any) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding %s %s response: %w", req.method, req.path, err)
+	}
+
+	return nil
+}
+
+// send returns the successful response of the request, whose body the caller
+// closes.
+func (c *Client) send(ctx context.Context, req *request) (*http.Response, error) {
+	u := *c.baseURL
+	u.Path += req.path
+	u.RawQuery = req.query.Encode()
+
+	for attempt := 0; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), bytes.NewReader(req.body))
+		if err != nil {
+			return nil, err
+		}
+		if req.body == nil {
+			httpReq.Body = nil
+		}
+		if req.contentType != "" {
+			httpReq.Header.Set("Content-Type", req.contentType)
+		}
+		if req.accept != "" {
+			httpReq.Header.Set("Accept", req.accept)
+		} else {
+			httpReq.Header.Set("Accept", "application/json")
+		}
+
+		resp, err := c.http.Do(httpReq)
+		if err == nil && resp.StatusCode < http.StatusBadRequest {
+			return resp, nil
+		}
+
+		var wait time.Duration
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			err = fmt.Errorf("%s %s: %w", req.method, req.path, err)
+		} else {
+			wait = retryAfter(resp)
+			err = decodeError(resp)
+		}
+
+		if !req.idempotent || attempt >= c.retries || !retryable(err) {
+			return nil, err
+		}
+
+		if wait == 0 {
+			wait = c.backoffFor(attempt)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoffFor doubles the backoff with every attempt and adds up to a quarter
+// of jitter, so that clients retrying together spread out.
+func (c *Client) backoffFor(attempt int) time.Duration {
+	wait := c.backoff << attempt
+	if wait <= 0 || wait > maxBackoff {
+		wait = maxBackoff
+	}
+
+	return wait + rand.N(wait/4+1)
+}
+
+func retryable(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return min(time.Duration(seconds)*time.Second, maxBackoff)
+}
+
+// decodeError reads the error response. Bodies that are not JSON errors,
+// such as those of proxies, become the message.
+func decodeError(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	apiErr := &Error{StatusCode: resp.StatusCode}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return apiErr
+	}
+
+	if json.Unmarshal(body, apiErr) == nil && apiErr.Message != "" {
+		return apiErr
+	}
+
+	// The GraphQL endpoint rejects queries it cannot parse or validate
+	// with a GraphQL error response.
+	var graphQL struct {
+		Errors []graphQLError `json:"errors"`
+	}
+	if json.Unmarshal(body, &graphQL) == nil && len(graphQL.Errors) > 0 {
+		apiErr.Message = graphQL.Errors[0].Message
+		return apiErr
+	}
+
+	apiErr.Message = strings.TrimSpace(string(body))
+
+	return apiErr
+}
@@ -0,0 +1,406 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Te8va/Tender/internal/tender/domain"
+	"github.com/Te8va/Tender/internal/tender/graph"
+	"github.com/Te8va/Tender/internal/tender/handler"
+	"github.com/Te8va/Tender/internal/tender/middleware"
+	"github.com/Te8va/Tender/internal/tender/openapi"
+	"github.com/Te8va/Tender/internal/tender/repository/memory"
+	"github.com/Te8va/Tender/internal/tender/service"
+)
+
+const (
+	orgAlice = "6f1c0e52-3b4a-4c1e-9d2f-000000000001"
+	orgBob   = "6f1c0e52-3b4a-4c1e-9d2f-000000000002"
+)
+
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(context.Context, domain.Notification) error { return nil }
+
+type anyServiceType struct{}
+
+func (anyServiceType) ResolveServiceType(_ context.Context, code string) (string, error) {
+	return code, nil
+}
+
+type noCustomFields struct{}
+
+func (noCustomFields) GetOrganizationFields(context.Context, string) ([]domain.CustomField, error) {
+	return nil, nil
+}
+
+// newMemoryRepo seeds the in-memory repository with alice, responsible for
+// orgAlice, bob, responsible for orgBob, and the administrator root.
+func newMemoryRepo() *memory.TenderService {
+	repo := memory.NewTenderService()
+	repo.AddOrganization(domain.Organization{ID: orgAlice, Name: "Alice LLC", Type: domain.OrganizationTypeLLC})
+	repo.AddOrganization(domain.Organization{ID: orgBob, Name: "Bob JSC", Type: domain.OrganizationTypeJSC})
+	repo.AddEmployee(domain.Employee{ID: "e1", Username: "alice", FirstName: "Alice"})
+	repo.AddEmployee(domain.Employee{ID: "e2", Username: "bob", FirstName: "Bob"})
+	repo.AddEmployee(domain.Employee{ID: "e3", Username: "root", FirstName: "Root"})
+	repo.MakeAdmin("root")
+	repo.AddResponsible(domain.OrganizationResponsible{UserID: "e1", OrganizationID: orgAlice})
+	repo.AddResponsible(domain.OrganizationResponsible{UserID: "e2", OrganizationID: orgBob})
+
+	return repo
+}
+
+// newServer serves the tender and directory routes of the service from the
+// in-memory repository, behind the same request validation.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo := newMemoryRepo()
+	tenders := service.NewTender(repo, nopNotifier{}, nil, anyServiceType{}, noCustomFields{})
+	tenderHandler := handler.NewTenderHandler(tenders, nil, nil)
+	graphHandler := graph.NewHandler(tenders, nil, service.NewGraph(repo, nil))
+	directoryHandler := handler.NewDirectoryHandler(service.NewDirectory(repo))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/tenders", tenderHandler.ListTenderHandler)
+	mux.HandleFunc("POST /api/tender/new", tenderHandler.CreateTenderHandler)
+	mux.HandleFunc("GET /api/tenders/my", tenderHandler.GetUserTendersHandler)
+	mux.HandleFunc("PATCH /api/tenders/{tenderId}/edit", tenderHandler.UpdatePartTenderHandler)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/status", tenderHandler.GetTenderStatusHandler)
+	mux.HandleFunc("PUT /api/tenders/{tenderId}/status", tenderHandler.UpdateTenderStatusHandler)
+	mux.HandleFunc("PUT /api/tenders/{tenderId}/rollback/{version}", tenderHandler.RollbackTenderHandler)
+	mux.HandleFunc("GET /api/tenders/{tenderId}", tenderHandler.GetTenderHandler)
+	mux.HandleFunc("POST /api/tenders/status:batch", tenderHandler.UpdateTenderStatusesHandler)
+	mux.HandleFunc("POST /api/tenders/{tenderId}/clone", tenderHandler.CloneTenderHandler)
+	mux.HandleFunc("GET /api/organizations", directoryHandler.ListOrganizationsHandler)
+	mux.HandleFunc("POST /api/organizations", directoryHandler.CreateOrganizationHandler)
+	mux.HandleFunc("PATCH /api/organizations/{organizationId}", directoryHandler.UpdateOrganizationHandler)
+	mux.HandleFunc("DELETE /api/organizations/{organizationId}", directoryHandler.DeleteOrganizationHandler)
+	mux.HandleFunc("PUT /api/organizations/{organizationId}/responsibles/{employee}", directoryHandler.AddOrganizationResponsibleHandler)
+	mux.HandleFunc("DELETE /api/organizations/{organizationId}/responsibles/{employee}", directoryHandler.RemoveOrganizationResponsibleHandler)
+	mux.HandleFunc("GET /api/employees", directoryHandler.ListEmployeesHandler)
+	mux.HandleFunc("POST /api/employees", directoryHandler.CreateEmployeeHandler)
+	mux.HandleFunc("PATCH /api/employees/{employee}", directoryHandler.UpdateEmployeeHandler)
+	mux.HandleFunc("DELETE /api/employees/{employee}", directoryHandler.DeleteEmployeeHandler)
+	mux.Handle("POST /api/graphql", graphHandler)
+
+	server := httptest.NewServer(middleware.Validate(openapi.NewValidator(openapi.Build()), mux))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newClient(t *testing.T, baseURL string, opts ...Option) *Client {
+	t.Helper()
+
+	c, err := New(baseURL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestTenders(t *testing.T) {
+	ctx := context.Background()
+	server := newServer(t)
+	alice := newClient(t, server.URL, WithUsername("alice"))
+	bob := alice.As("bob")
+
+	budget := 500000.0
+	created, err := alice.CreateTender(ctx, CreateTenderRequest{
+		Name:           "Поставка серверов",
+		Description:    "Две стойки",
+		ServiceType:    "Delivery",
+		OrganizationID: orgAlice,
+		Budget:         &budget,
+		Tags:           []string{"IT"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+	if created.Status != StatusCreated || created.Version != 1 || created.Visibility != "PUBLIC" || len(created.Tags) != 1 || created.Tags[0] != "it" {
+		t.Errorf("created tender = %+v", created)
+	}
+
+	for _, name := range []string{"Ремонт офиса", "Уборка", "Охрана"} {
+		if _, err := alice.CreateTender(ctx, CreateTenderRequest{Name: name, ServiceType: "Construction", OrganizationID: orgAlice, Visibility: "PRIVATE"}); err != nil {
+			t.Fatalf("CreateTender: %v", err)
+		}
+	}
+
+	if me, err := alice.Me(ctx); err != nil || me.Username != "alice" || len(me.Organizations) != 1 || me.Organizations[0].ID != orgAlice {
+		t.Errorf("Me = %+v, %v", me, err)
+	}
+
+	if tenders, err := bob.ListTenders(ctx, ListTendersOptions{}); err != nil || len(tenders) != 1 || tenders[0].ID != created.ID {
+		t.Errorf("tenders of another organization = %v, %v, want only the public one", tenders, err)
+	}
+
+	if _, err := alice.SetTenderStatus(ctx, created.ID, StatusPublished); err != nil {
+		t.Fatalf("SetTenderStatus: %v", err)
+	}
+	if _, err := bob.SetTenderStatus(ctx, created.ID, StatusClosed); !errors.Is(err, ErrForbidden) {
+		t.Errorf("SetTenderStatus of another organization: error = %v, want %v", err, ErrForbidden)
+	}
+	if status, err := alice.TenderStatus(ctx, created.ID); err != nil || status != StatusPublished {
+		t.Errorf("TenderStatus = %q, %v", status, err)
+	}
+	if tender, err := bob.GetTender(ctx, created.ID); err != nil || tender.Name != created.Name {
+		t.Errorf("published tender = %+v, %v", tender, err)
+	}
+
+	all, err := alice.IterateTenders(ListTendersOptions{Limit: 3}).All(ctx)
+	if err != nil || len(all) != 4 {
+		t.Errorf("IterateTenders = %d tenders, %v", len(all), err)
+	}
+	if found, err := alice.ListTenders(ctx, ListTendersOptions{Text: "стойк"}); err != nil || len(found) != 1 || found[0].ID != created.ID {
+		t.Errorf("tenders matching the text = %v, %v, want the one with it in the description", found, err)
+	}
+	mine, err := alice.IterateMyTenders(2).All(ctx)
+	if err != nil || len(mine) != 4 {
+		t.Errorf("IterateMyTenders = %d tenders, %v", len(mine), err)
+	}
+
+	name := "Поставка серверов и СХД"
+	edited, err := alice.EditTender(ctx, created.ID, TenderUpdate{Name: &name, Tags: []string{"it", "storage"}})
+	if err != nil {
+		t.Fatalf("EditTender: %v", err)
+	}
+	if edited.Name != name || edited.Version != 2 || len(edited.Tags) != 2 {
+		t.Errorf("edited tender = %+v", edited)
+	}
+
+	versions, err := alice.TenderVersions(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("TenderVersions: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Name != created.Name || versions[1].Name != name {
+		t.Errorf("versions = %+v", versions)
+	}
+	if _, err := bob.TenderVersions(ctx, created.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("TenderVersions of another organization: %v", err)
+	}
+
+	rolledBack, err := alice.RollbackTender(ctx, created.ID, 1)
+	if err != nil {
+		t.Fatalf("RollbackTender: %v", err)
+	}
+	if rolledBack.Name != created.Name || rolledBack.Version != 3 {
+		t.Errorf("rolled back tender = %+v", rolledBack)
+	}
+
+	clone, err := alice.CloneTender(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("CloneTender: %v", err)
+	}
+	if clone.ID == created.ID || clone.Name != created.Name || clone.Status != StatusCreated || clone.Version != 1 {
+		t.Errorf("clone = %+v", clone)
+	}
+
+	result, err := alice.SetTenderStatuses(ctx, BatchStatusRequest{TenderIDs: []string{created.ID, clone.ID}, Status: StatusClosed})
+	if err != nil {
+		t.Fatalf("SetTenderStatuses: %v", err)
+	}
+	if result.Applied != 2 || result.Failed != 0 {
+		t.Errorf("batch result = %+v", result)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	server := newServer(t)
+	alice := newClient(t, server.URL, WithUsername("alice"))
+
+	created, err := alice.CreateTender(ctx, CreateTenderRequest{Name: "Охрана", ServiceType: "Delivery", OrganizationID: orgAlice, Visibility: "PRIVATE"})
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"unknown employee", func() error { _, err := alice.As("carol").GetTender(ctx, created.ID); return err }, ErrUnauthorized},
+		{"missing username", func() error { _, err := alice.As("").GetTender(ctx, created.ID); return err }, ErrUnauthorized},
+		{"missing username for own tenders", func() error { _, err := alice.As("").MyTenders(ctx, 5, 0); return err }, ErrUnauthorized},
+		{"private tender of another organization", func() error { _, err := alice.As("bob").GetTender(ctx, created.ID); return err }, ErrNotFound},
+		{"another organization", func() error {
+			_, err := alice.CreateTender(ctx, CreateTenderRequest{Name: "Охрана", ServiceType: "Delivery", OrganizationID: orgBob})
+			return err
+		}, ErrForbidden},
+		{"versions of a missing tender", func() error { _, err := alice.TenderVersions(ctx, orgAlice); return err }, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.Message == "" {
+				t.Errorf("error = %#v, want *Error with a message", err)
+			}
+		})
+	}
+
+	_, err = alice.GetTender(ctx, "not-a-uuid")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || len(apiErr.Details) != 1 || apiErr.Details[0].Field != "tenderId" {
+		t.Errorf("invalid tender ID = %#v", err)
+	}
+}
+
+func TestDirectory(t *testing.T) {
+	ctx := context.Background()
+	server := newServer(t)
+	root := newClient(t, server.URL, WithUsername("root"))
+
+	created, err := root.CreateOrganization(ctx, CreateOrganizationRequest{Name: "Carol IE", Type: OrganizationIE})
+	if err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	if created.ID == "" || created.Name != "Carol IE" || created.Type != OrganizationIE {
+		t.Errorf("created organization = %+v", created)
+	}
+
+	description := "Охрана объектов"
+	updated, err := root.UpdateOrganization(ctx, created.ID, OrganizationUpdate{Description: &description})
+	if err != nil || updated.Name != "Carol IE" || updated.Description != description {
+		t.Errorf("UpdateOrganization = %+v, %v", updated, err)
+	}
+
+	organizations, err := root.IterateOrganizations(2).All(ctx)
+	if err != nil || len(organizations) != 3 || organizations[0].Name != "Alice LLC" || organizations[2].ID != created.ID {
+		t.Errorf("organizations = %+v, %v", organizations, err)
+	}
+
+	if _, err := root.CreateEmployee(ctx, CreateEmployeeRequest{Username: "carol", FirstName: "Carol"}); err != nil {
+		t.Fatalf("CreateEmployee: %v", err)
+	}
+	if _, err := root.CreateEmployee(ctx, CreateEmployeeRequest{Username: "carol"}); !errors.Is(err, ErrConflict) {
+		t.Errorf("CreateEmployee with a taken username: %v, want %v", err, ErrConflict)
+	}
+	lastName := "Smith"
+	if employee, err := root.UpdateEmployee(ctx, "carol", EmployeeUpdate{LastName: &lastName}); err != nil || employee.FirstName != "Carol" || employee.LastName != lastName {
+		t.Errorf("UpdateEmployee = %+v, %v", employee, err)
+	}
+
+	// The new employee acts for the organization once responsible for it.
+	carol := root.As("carol")
+	if err := root.AddResponsible(ctx, created.ID, "carol"); err != nil {
+		t.Fatalf("AddResponsible: %v", err)
+	}
+	me, err := carol.Me(ctx)
+	if err != nil || len(me.Organizations) != 1 || me.Organizations[0].ID != created.ID {
+		t.Errorf("Me = %+v, %v", me, err)
+	}
+	tender, err := carol.CreateTender(ctx, CreateTenderRequest{Name: "Охрана склада", ServiceType: "Delivery", OrganizationID: created.ID})
+	if err != nil {
+		t.Fatalf("CreateTender: %v", err)
+	}
+
+	if err := root.DeleteOrganization(ctx, created.ID); !errors.Is(err, ErrConflict) {
+		t.Errorf("DeleteOrganization with a tender: %v, want %v", err, ErrConflict)
+	}
+	if err := root.DeleteEmployee(ctx, "carol"); !errors.Is(err, ErrConflict) {
+		t.Errorf("DeleteEmployee with a tender: %v, want %v", err, ErrConflict)
+	}
+
+	if err := root.RemoveResponsible(ctx, created.ID, "carol"); err != nil {
+		t.Fatalf("RemoveResponsible: %v", err)
+	}
+	if _, err := carol.SetTenderStatus(ctx, tender.ID, StatusPublished); !errors.Is(err, ErrForbidden) {
+		t.Errorf("SetTenderStatus after the responsibility was withdrawn: %v, want %v", err, ErrForbidden)
+	}
+	if err := root.RemoveResponsible(ctx, created.ID, "carol"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RemoveResponsible twice: %v, want %v", err, ErrNotFound)
+	}
+
+	if _, err := root.CreateEmployee(ctx, CreateEmployeeRequest{Username: "dave"}); err != nil {
+		t.Fatalf("CreateEmployee: %v", err)
+	}
+	if err := root.DeleteEmployee(ctx, "dave"); err != nil {
+		t.Errorf("DeleteEmployee: %v", err)
+	}
+	employees, err := root.ListEmployees(ctx, 10, 0)
+	if err != nil || len(employees) != 4 || employees[0].Username != "alice" || employees[1].Username != "bob" || employees[2].Username != "carol" {
+		t.Errorf("employees = %+v, %v", employees, err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"not an administrator", func() error { _, err := root.As("alice").ListEmployees(ctx, 10, 0); return err }, ErrForbidden},
+		{"unknown employee", func() error { _, err := root.As("carl").ListOrganizations(ctx, 10, 0); return err }, ErrUnauthorized},
+		{"missing organization type", func() error {
+			_, err := root.CreateOrganization(ctx, CreateOrganizationRequest{Name: "Dave LLC"})
+			return err
+		}, ErrBadRequest},
+		{"unknown organization type", func() error {
+			_, err := root.CreateOrganization(ctx, CreateOrganizationRequest{Name: "Dave LLC", Type: "PLC"})
+			return err
+		}, ErrBadRequest},
+		{"missing organization", func() error { return root.DeleteOrganization(ctx, orgAlice[:len(orgAlice)-1]+"9") }, ErrNotFound},
+		{"missing employee", func() error { return root.AddResponsible(ctx, orgAlice, "dave") }, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status": "PUBLISHED"}`))
+	}))
+	t.Cleanup(flaky.Close)
+
+	ctx := context.Background()
+	c := newClient(t, flaky.URL, WithUsername("alice"), WithRetries(3, time.Millisecond))
+
+	if status, err := c.TenderStatus(ctx, "tender-1"); err != nil || status != StatusPublished || calls.Load() != 3 {
+		t.Errorf("TenderStatus = %q, %v after %d calls", status, err, calls.Load())
+	}
+
+	calls.Store(0)
+	_, err := c.CreateTender(ctx, CreateTenderRequest{Name: "Охрана"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Errorf("CreateTender = %v after %d calls, want no retries", err, calls.Load())
+	}
+
+	calls.Store(0)
+	_, err = c.RollbackTender(ctx, "tender-1", 1)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Errorf("RollbackTender = %v after %d calls, want no retries", err, calls.Load())
+	}
+
+	calls.Store(0)
+	c = newClient(t, flaky.URL, WithRetries(3, time.Hour))
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.TenderStatus(ctx, "tender-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("TenderStatus while backing off = %v, want deadline exceeded", err)
+	}
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type Employee struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	// Organizations are those the employee is responsible for, Me fills
+	// them in.
+	Organizations []Organization `json:"organizations,omitempty"`
+}
+
+type Organization struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+// Organization types.
+const (
+	OrganizationIE  = "IE"
+	OrganizationLLC = "LLC"
+	OrganizationJSC = "JSC"
+)
+
+type CreateOrganizationRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type"`
+}
+
+// OrganizationUpdate is a partial update, fields left nil are kept.
+type OrganizationUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Type        *string `json:"type,omitempty"`
+}
+
+type CreateEmployeeRequest struct {
+	Username  string `json:"username"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+}
+
+// EmployeeUpdate is a partial update, fields left nil are kept. The
+// username can not be changed.
+type EmployeeUpdate struct {
+	FirstName *string `json:"firstName,omitempty"`
+	LastName  *string `json:"lastName,omitempty"`
+}
+
+const meQuery = `query Me {
+  me {
+    id username firstName lastName
+    organizations { id name description type }
+  }
+}`
+
+// Me returns the employee of the client with the organizations they are
+// responsible for.
+func (c *Client) Me(ctx context.Context) (Employee, error) {
+	var data struct {
+		Me Employee `json:"me"`
+	}
+	if err := c.Query(ctx, meQuery, nil, &data); err != nil {
+		return Employee{}, fmt.Errorf("client.Me: %w", err)
+	}
+
+	return data.Me, nil
+}
+
+// The calls below manage the directory of organizations and employees, only
+// administrators may make them.
+
+// ListOrganizations returns a page of all organizations, ordered by name.
+func (c *Client) ListOrganizations(ctx context.Context, limit, offset int) ([]Organization, error) {
+	var organizations []Organization
+	if err := c.do(ctx, c.newRequest(http.MethodGet, "/api/organizations", pageQuery(limit, offset)), &organizations); err != nil {
+		return nil, fmt.Errorf("client.ListOrganizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (c *Client) IterateOrganizations(pageSize int) *Pager[Organization] {
+	return newPager(pageSize, 0, c.ListOrganizations)
+}
+
+func (c *Client) CreateOrganization(ctx context.Context, organization CreateOrganizationRequest) (Organization, error) {
+	req := c.newRequest(http.MethodPost, "/api/organizations", nil)
+	if err := req.json(organization); err != nil {
+		return Organization{}, fmt.Errorf("client.CreateOrganization: %w", err)
+	}
+
+	var created Organization
+	if err := c.do(ctx, req, &created); err != nil {
+		return Organization{}, fmt.Errorf("client.CreateOrganization: %w", err)
+	}
+
+	return created, nil
+}
+
+func (c *Client) UpdateOrganization(ctx context.Context, organizationID string, update OrganizationUpdate) (Organization, error) {
+	req := c.newRequest(http.MethodPatch, organizationPath(organizationID, ""), nil)
+	if err := req.json(update); err != nil {
+		return Organization{}, fmt.Errorf("client.UpdateOrganization: %w", err)
+	}
+
+	var updated Organization
+	if err := c.do(ctx, req, &updated); err != nil {
+		return Organization{}, fmt.Errorf("client.UpdateOrganization: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteOrganization deletes an organization. Organizations with tenders or
+// bids are kept and ErrConflict is returned.
+func (c *Client) DeleteOrganization(ctx context.Context, organizationID string) error {
+	if err := c.do(ctx, c.newRequest(http.MethodDelete, organizationPath(organizationID, ""), nil), nil); err != nil {
+		return fmt.Errorf("client.DeleteOrganization: %w", err)
+	}
+
+	return nil
+}
+
+// AddResponsible makes the employee responsible for the organization, which
+// lets them act on its behalf.
+func (c *Client) AddResponsible(ctx context.Context, organizationID, username string) error {
+	req := c.newRequest(http.MethodPut, organizationPath(organizationID, "/responsibles/"+url.PathEscape(username)), nil)
+	if err := c.do(ctx, req, nil); err != nil {
+		return fmt.Errorf("client.AddResponsible: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) RemoveResponsible(ctx context.Context, organizationID, username string) error {
+	req := c.newRequest(http.MethodDelete, organizationPath(organizationID, "/responsibles/"+url.PathEscape(username)), nil)
+	if err := c.do(ctx, req, nil); err != nil {
+		return fmt.Errorf("client.RemoveResponsible: %w", err)
+	}
+
+	return nil
+}
+
+// ListEmployees returns a page of all employees, ordered by username.
+func (c *Client) ListEmployees(ctx context.Context, limit, offset int) ([]Employee, error) {
+	var employees []Employee
+	if err := c.do(ctx, c.newRequest(http.MethodGet, "/api/employees", pageQuery(limit, offset)), &employees); err != nil {
+		return nil, fmt.Errorf("client.ListEmployees: %w", err)
+	}
+
+	return employees, nil
+}
+
+func (c *Client) IterateEmployees(pageSize int) *Pager[Employee] {
+	return newPager(pageSize, 0, c.ListEmployees)
+}
+
+// CreateEmployee adds an employee, ErrConflict is returned when the
+// username is taken.
+func (c *Client) CreateEmployee(ctx context.Context, employee CreateEmployeeRequest) (Employee, error) {
+	req := c.newRequest(http.MethodPost, "/api/employees", nil)
+	if err := req.json(employee); err != nil {
+		return Employee{}, fmt.Errorf("client.CreateEmployee: %w", err)
+	}
+
+	var created Employee
+	if err := c.do(ctx, req, &created); err != nil {
+		return Employee{}, fmt.Errorf("client.CreateEmployee: %w", err)
+	}
+
+	return created, nil
+}
+
+func (c *Client) UpdateEmployee(ctx context.Context, username string, update EmployeeUpdate) (Employee, error) {
+	req := c.newRequest(http.MethodPatch, employeePath(username), nil)
+	if err := req.json(update); err != nil {
+		return Employee{}, fmt.Errorf("client.UpdateEmployee: %w", err)
+	}
+
+	var updated Employee
+	if err := c.do(ctx, req, &updated); err != nil {
+		return Employee{}, fmt.Errorf("client.UpdateEmployee: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteEmployee deletes an employee. Employees who authored tenders, bids,
+// offers, scores or questions are kept and ErrConflict is returned.
+func (c *Client) DeleteEmployee(ctx context.Context, username string) error {
+	if err := c.do(ctx, c.newRequest(http.MethodDelete, employeePath(username), nil), nil); err != nil {
+		return fmt.Errorf("client.DeleteEmployee: %w", err)
+	}
+
+	return nil
+}
+
+func organizationPath(organizationID, suffix string) string {
+	return "/api/organizations/" + url.PathEscape(organizationID) + suffix
+}
+
+func employeePath(username string) string {
+	return "/api/employees/" + url.PathEscape(username)
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Errors matched by errors.Is against an *Error, by its status code.
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrTooLarge     = errors.New("payload too large")
+)
+
+var statusErrors = map[int]error{
+	http.StatusBadRequest:            ErrBadRequest,
+	http.StatusUnauthorized:          ErrUnauthorized,
+	http.StatusForbidden:             ErrForbidden,
+	http.StatusNotFound:              ErrNotFound,
+	http.StatusConflict:              ErrConflict,
+	http.StatusRequestEntityTooLarge: ErrTooLarge,
+}
+
+// Error is an error response of the service, decoded from its JSON body.
+// Details lists the fields a request was rejected for.
+type Error struct {
+	StatusCode int          `json:"-"`
+	Message    string       `json:"error"`
+	Details    []FieldError `json:"details,omitempty"`
+}
+
+type FieldError struct {
+	In    string `json:"in"`
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "tender service: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		b.WriteString(": " + e.Message)
+	}
+	for _, detail := range e.Details {
+		fmt.Fprintf(&b, "; %s %s %s", detail.In, detail.Field, detail.Error)
+	}
+
+	return b.String()
+}
+
+func (e *Error) Is(target error) bool {
+	return statusErrors[e.StatusCode] == target
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// graphQLStatus maps the error codes of the GraphQL API to the status codes
+// the HTTP API answers with.
+var graphQLStatus = map[string]int{
+	"BAD_USER_INPUT":        http.StatusBadRequest,
+	"UNAUTHENTICATED":       http.StatusUnauthorized,
+	"FORBIDDEN":             http.StatusForbidden,
+	"NOT_FOUND":             http.StatusNotFound,
+	"INTERNAL_SERVER_ERROR": http.StatusInternalServerError,
+}
+
+type graphQLError struct {
+	Message    string `json:"message"`
+	Path       []any  `json:"path"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+// Query runs a GraphQL query against /api/graphql and decodes its data into
+// out. Errors of the query are returned as an *Error with the status code of
+// the first one.
+func (c *Client) Query(ctx context.Context, query string, variables map[string]any, out any) error {
+	req := c.newRequest(http.MethodPost, "/api/graphql", nil)
+	if err := req.json(map[string]any{"query": query, "variables": variables}); err != nil {
+		return fmt.Errorf("client.Query: %w", err)
+	}
+	req.idempotent = !strings.HasPrefix(strings.TrimSpace(query), "mutation")
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphQLError  `json:"errors"`
+	}
+	if err := c.do(ctx, req, &response); err != nil {
+		return fmt.Errorf("client.Query: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		apiErr := &Error{StatusCode: http.StatusInternalServerError, Message: response.Errors[0].Message}
+		if status, ok := graphQLStatus[response.Errors[0].Extensions.Code]; ok {
+			apiErr.StatusCode = status
+		}
+		for _, e := range response.Errors[1:] {
+			apiErr.Details = append(apiErr.Details, FieldError{In: "graphql", Field: graphQLPath(e.Path), Error: e.Message})
+		}
+
+		return fmt.Errorf("client.Query: %w", apiErr)
+	}
+
+	if out == nil || len(response.Data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("client.Query: %w", err)
+	}
+
+	return nil
+}
+
+func graphQLPath(path []any) string {
+	parts := make([]string, len(path))
+	for i, part := range path {
+		parts[i] = fmt.Sprint(part)
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,83 @@
+package client
+
+import "context"
+
+// DefaultPageSize is the page size of iterators, the default limit of the
+// API.
+const DefaultPageSize = 10
+
+// Pager iterates over a listing page by page:
+//
+//	pager := c.IterateTenders(ListTendersOptions{})
+//	for pager.Next(ctx) {
+//		tender := pager.Value()
+//	}
+//	if err := pager.Err(); err != nil {
+//		return err
+//	}
+type Pager[T any] struct {
+	fetch    func(ctx context.Context, limit, offset int) ([]T, error)
+	pageSize int
+	offset   int
+
+	page []T
+	i    int
+	done bool
+	err  error
+}
+
+func newPager[T any](pageSize, offset int, fetch func(ctx context.Context, limit, offset int) ([]T, error)) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return &Pager[T]{fetch: fetch, pageSize: pageSize, offset: offset, i: -1}
+}
+
+// Next advances to the next item, fetching the next page when needed. It
+// returns false at the end of the listing or on an error.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+
+	if p.i+1 < len(p.page) {
+		p.i++
+		return true
+	}
+
+	if p.done {
+		return false
+	}
+
+	page, err := p.fetch(ctx, p.pageSize, p.offset)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.offset += len(page)
+	p.page, p.i = page, 0
+	// A short page is the last one.
+	p.done = len(page) < p.pageSize
+
+	return len(page) > 0
+}
+
+func (p *Pager[T]) Value() T {
+	return p.page[p.i]
+}
+
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All collects the remaining items.
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for p.Next(ctx) {
+		items = append(items, p.Value())
+	}
+
+	return items, p.Err()
+}
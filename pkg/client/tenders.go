@@ -0,0 +1,271 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Ping checks that the service and its database are up.
+func (c *Client) Ping(ctx context.Context) error {
+	req := c.newRequest(http.MethodGet, "/api/ping", nil)
+	req.accept = "*/*"
+
+	return c.do(ctx, req, nil)
+}
+
+func (o ListTendersOptions) query() url.Values {
+	query := url.Values{}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		query.Set("offset", strconv.Itoa(o.Offset))
+	}
+	for _, serviceType := range o.ServiceTypes {
+		query.Add("service_type", serviceType)
+	}
+	for _, tag := range o.Tags {
+		query.Add("tag", tag)
+	}
+	for key, value := range o.CustomFields {
+		query.Set("cf."+key, value)
+	}
+	if o.Text != "" {
+		query.Set("q", o.Text)
+	}
+
+	return query
+}
+
+// ListTenders returns a page of the published tenders, and of the private
+// ones the employee may see.
+func (c *Client) ListTenders(ctx context.Context, opts ListTendersOptions) ([]Tender, error) {
+	var tenders []Tender
+	if err := c.do(ctx, c.newRequest(http.MethodGet, "/api/tenders", opts.query()), &tenders); err != nil {
+		return nil, fmt.Errorf("client.ListTenders: %w", err)
+	}
+
+	return tenders, nil
+}
+
+// IterateTenders iterates over the tenders from opts.Offset on, opts.Limit
+// at a time.
+func (c *Client) IterateTenders(opts ListTendersOptions) *Pager[Tender] {
+	return newPager(opts.Limit, opts.Offset, func(ctx context.Context, limit, offset int) ([]Tender, error) {
+		opts.Limit, opts.Offset = limit, offset
+		return c.ListTenders(ctx, opts)
+	})
+}
+
+// ExportTenders downloads the tenders as a csv or xlsx file, the whole
+// listing unless opts.Limit is set. The caller closes the file.
+func (c *Client) ExportTenders(ctx context.Context, opts ListTendersOptions, format string) (io.ReadCloser, error) {
+	query := opts.query()
+	query.Set("format", format)
+
+	resp, err := c.send(ctx, c.newRequest(http.MethodGet, "/api/tenders", query))
+	if err != nil {
+		return nil, fmt.Errorf("client.ExportTenders: %w", err)
+	}
+
+	return resp.Body, nil
+}
+
+// MyTenders returns a page of the tenders created by the employee.
+func (c *Client) MyTenders(ctx context.Context, limit, offset int) ([]Tender, error) {
+	var tenders []Tender
+	if err := c.do(ctx, c.newRequest(http.MethodGet, "/api/tenders/my", pageQuery(limit, offset)), &tenders); err != nil {
+		return nil, fmt.Errorf("client.MyTenders: %w", err)
+	}
+
+	return tenders, nil
+}
+
+func (c *Client) IterateMyTenders(pageSize int) *Pager[Tender] {
+	return newPager(pageSize, 0, c.MyTenders)
+}
+
+// CreateTender creates a tender in the CREATED status.
+func (c *Client) CreateTender(ctx context.Context, tender CreateTenderRequest) (Tender, error) {
+	if tender.CreatorUsername == "" {
+		tender.CreatorUsername = c.username
+	}
+
+	req := c.newRequest(http.MethodPost, "/api/tender/new", nil)
+	if err := req.json(tender); err != nil {
+		return Tender{}, fmt.Errorf("client.CreateTender: %w", err)
+	}
+
+	var created Tender
+	if err := c.do(ctx, req, &created); err != nil {
+		return Tender{}, fmt.Errorf("client.CreateTender: %w", err)
+	}
+
+	return created, nil
+}
+
+// CloneTender creates a tender from the fields of an existing one.
+func (c *Client) CloneTender(ctx context.Context, tenderID string) (Tender, error) {
+	var clone Tender
+	if err := c.do(ctx, c.newRequest(http.MethodPost, tenderPath(tenderID, "/clone"), nil), &clone); err != nil {
+		return Tender{}, fmt.Errorf("client.CloneTender: %w", err)
+	}
+
+	return clone, nil
+}
+
+func (c *Client) GetTender(ctx context.Context, tenderID string) (Tender, error) {
+	var tender Tender
+	if err := c.do(ctx, c.newRequest(http.MethodGet, tenderPath(tenderID, ""), nil), &tender); err != nil {
+		return Tender{}, fmt.Errorf("client.GetTender: %w", err)
+	}
+
+	return tender, nil
+}
+
+// EditTender applies a partial update, which creates a new version of the
+// tender.
+func (c *Client) EditTender(ctx context.Context, tenderID string, update TenderUpdate) (Tender, error) {
+	req := c.newRequest(http.MethodPatch, tenderPath(tenderID, "/edit"), nil)
+	if err := req.json(update); err != nil {
+		return Tender{}, fmt.Errorf("client.EditTender: %w", err)
+	}
+
+	var tender Tender
+	if err := c.do(ctx, req, &tender); err != nil {
+		return Tender{}, fmt.Errorf("client.EditTender: %w", err)
+	}
+
+	return tender, nil
+}
+
+func (c *Client) TenderStatus(ctx context.Context, tenderID string) (string, error) {
+	var response struct {
+		Status string `json:"status"`
+	}
+	if err := c.do(ctx, c.newRequest(http.MethodGet, tenderPath(tenderID, "/status"), nil), &response); err != nil {
+		return "", fmt.Errorf("client.TenderStatus: %w", err)
+	}
+
+	return response.Status, nil
+}
+
+func (c *Client) SetTenderStatus(ctx context.Context, tenderID string, status string) (Tender, error) {
+	query := url.Values{}
+	query.Set("status", status)
+
+	var tender Tender
+	if err := c.do(ctx, c.newRequest(http.MethodPut, tenderPath(tenderID, "/status"), query), &tender); err != nil {
+		return Tender{}, fmt.Errorf("client.SetTenderStatus: %w", err)
+	}
+
+	return tender, nil
+}
+
+// SetTenderStatuses changes the status of several tenders. An atomic batch,
+// the default, changes none of them when any change fails; the outcome of
+// each tender is in the result either way.
+func (c *Client) SetTenderStatuses(ctx context.Context, batch BatchStatusRequest) (BatchStatusResult, error) {
+	req := c.newRequest(http.MethodPost, "/api/tenders/status:batch", nil)
+	if err := req.json(batch); err != nil {
+		return BatchStatusResult{}, fmt.Errorf("client.SetTenderStatuses: %w", err)
+	}
+	// Applying a status twice leaves the tenders as they are.
+	req.idempotent = true
+
+	var result BatchStatusResult
+	if err := c.do(ctx, req, &result); err != nil {
+		return BatchStatusResult{}, fmt.Errorf("client.SetTenderStatuses: %w", err)
+	}
+
+	return result, nil
+}
+
+// RollbackTender restores the fields of an earlier version as a new version
+// of the tender.
+func (c *Client) RollbackTender(ctx context.Context, tenderID string, version int) (Tender, error) {
+	var tender Tender
+	req := c.newRequest(http.MethodPut, tenderPath(tenderID, "/rollback/"+strconv.Itoa(version)), nil)
+	// Every rollback saves another version, a retry after a lost response
+	// would save it twice.
+	req.idempotent = false
+	if err := c.do(ctx, req, &tender); err != nil {
+		return Tender{}, fmt.Errorf("client.RollbackTender: %w", err)
+	}
+
+	return tender, nil
+}
+
+const tenderVersionsQuery = `query TenderVersions($id: ID!) {
+  tender(id: $id) {
+    versions { version name description serviceType status budget tags customFields createdAt }
+  }
+}`
+
+// TenderVersions returns the version history of a tender, oldest first. Only
+// employees responsible for its organization may read it.
+func (c *Client) TenderVersions(ctx context.Context, tenderID string) ([]TenderVersion, error) {
+	var data struct {
+		Tender struct {
+			Versions []TenderVersion `json:"versions"`
+		} `json:"tender"`
+	}
+	if err := c.Query(ctx, tenderVersionsQuery, map[string]any{"id": tenderID}, &data); err != nil {
+		return nil, fmt.Errorf("client.TenderVersions: %w", err)
+	}
+
+	return data.Tender.Versions, nil
+}
+
+// ImportTenders creates tenders from a csv or ndjson file. With dryRun the
+// rows are only validated.
+func (c *Client) ImportTenders(ctx context.Context, file io.Reader, format string, dryRun bool) (ImportReport, error) {
+	body, err := io.ReadAll(file)
+	if err != nil {
+		return ImportReport{}, fmt.Errorf("client.ImportTenders: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("format", format)
+	if dryRun {
+		query.Set("dryRun", "true")
+	}
+
+	req := c.newRequest(http.MethodPost, "/api/tenders/import", query)
+	req.body = body
+	req.contentType = importContentTypes[format]
+	// A dry run changes nothing.
+	req.idempotent = dryRun
+
+	var report ImportReport
+	if err := c.do(ctx, req, &report); err != nil {
+		return ImportReport{}, fmt.Errorf("client.ImportTenders: %w", err)
+	}
+
+	return report, nil
+}
+
+var importContentTypes = map[string]string{
+	FormatCSV:    "text/csv",
+	FormatNDJSON: "application/x-ndjson",
+}
+
+func tenderPath(tenderID, suffix string) string {
+	return "/api/tenders/" + url.PathEscape(tenderID) + suffix
+}
+
+func pageQuery(limit, offset int) url.Values {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+
+	return query
+}
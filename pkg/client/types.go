@@ -0,0 +1,192 @@
+package client
+
+import "time"
+
+// Tender statuses.
+const (
+	StatusCreated   = "CREATED"
+	StatusPublished = "PUBLISHED"
+	StatusClosed    = "CLOSED"
+)
+
+type Tender struct {
+	ID           string         `json:"id"`
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	Status       string         `json:"status"`
+	ServiceType  string         `json:"serviceType"`
+	Version      int            `json:"version"`
+	Type         string         `json:"type"`
+	Visibility   string         `json:"visibility"`
+	Sealed       bool           `json:"sealed"`
+	Budget       *float64       `json:"budget,omitempty"`
+	ClosesAt     *time.Time     `json:"closesAt,omitempty"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	Lots         []Lot          `json:"lots,omitempty"`
+	Criteria     []Criterion    `json:"criteria,omitempty"`
+	Tags         []string       `json:"tags,omitempty"`
+	CustomFields map[string]any `json:"customFields,omitempty"`
+}
+
+type Lot struct {
+	ID           string     `json:"id,omitempty"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description,omitempty"`
+	Quantity     float64    `json:"quantity"`
+	Unit         string     `json:"unit,omitempty"`
+	Budget       *float64   `json:"budget,omitempty"`
+	AwardedBidID *string    `json:"awardedBidId,omitempty"`
+	AwardedAt    *time.Time `json:"awardedAt,omitempty"`
+}
+
+type Criterion struct {
+	ID     string  `json:"id,omitempty"`
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+}
+
+// CreateTenderRequest creates a tender on behalf of CreatorUsername, the
+// employee of the client when left empty.
+type CreateTenderRequest struct {
+	Name            string         `json:"name"`
+	Description     string         `json:"description"`
+	ServiceType     string         `json:"serviceType"`
+	OrganizationID  string         `json:"organizationId"`
+	CreatorUsername string         `json:"creatorUsername"`
+	Type            string         `json:"type,omitempty"`
+	Visibility      string         `json:"visibility,omitempty"`
+	Sealed          bool           `json:"sealed,omitempty"`
+	Budget          *float64       `json:"budget,omitempty"`
+	ClosesAt        *time.Time     `json:"closesAt,omitempty"`
+	Lots            []Lot          `json:"lots,omitempty"`
+	Criteria        []Criterion    `json:"criteria,omitempty"`
+	Tags            []string       `json:"tags,omitempty"`
+	CustomFields    map[string]any `json:"customFields,omitempty"`
+	TemplateID      string         `json:"templateId,omitempty"`
+}
+
+// TenderUpdate is a partial update, fields left nil are kept. Every edit
+// creates a new version of the tender.
+type TenderUpdate struct {
+	Name         *string        `json:"name,omitempty"`
+	Description  *string        `json:"description,omitempty"`
+	ServiceType  *string        `json:"serviceType,omitempty"`
+	Visibility   *string        `json:"visibility,omitempty"`
+	Budget       *float64       `json:"budget,omitempty"`
+	ClosesAt     *time.Time     `json:"closesAt,omitempty"`
+	Tags         []string       `json:"tags,omitempty"`
+	CustomFields map[string]any `json:"customFields,omitempty"`
+	Lots         []Lot          `json:"lots,omitempty"`
+	Criteria     []Criterion    `json:"criteria,omitempty"`
+}
+
+type TenderVersion struct {
+	Version      int            `json:"version"`
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	ServiceType  string         `json:"serviceType"`
+	Status       string         `json:"status"`
+	Budget       *float64       `json:"budget"`
+	Tags         []string       `json:"tags"`
+	CustomFields map[string]any `json:"customFields"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// ListTendersOptions filters the tender list. ServiceTypes match their
+// subcategories too, a tender has to carry all Tags and CustomFields values.
+type ListTendersOptions struct {
+	Limit        int
+	Offset       int
+	ServiceTypes []string
+	Tags         []string
+	CustomFields map[string]string
+	// Text has to occur in the name or the description, ignoring case.
+	Text string
+}
+
+// Batch modes of SetTenderStatuses.
+const (
+	BatchAtomic     = "atomic"
+	BatchBestEffort = "bestEffort"
+)
+
+type BatchStatusRequest struct {
+	TenderIDs []string `json:"tenderIds"`
+	Status    string   `json:"status"`
+	Mode      string   `json:"mode,omitempty"`
+}
+
+type TenderStatusChange struct {
+	TenderID       string `json:"tenderId"`
+	PreviousStatus string `json:"previousStatus,omitempty"`
+	Status         string `json:"status,omitempty"`
+	Applied        bool   `json:"applied"`
+	Error          string `json:"error,omitempty"`
+}
+
+type BatchStatusResult struct {
+	Status  string               `json:"status"`
+	Mode    string               `json:"mode"`
+	Applied int                  `json:"applied"`
+	Failed  int                  `json:"failed"`
+	Results []TenderStatusChange `json:"results"`
+}
+
+// File formats of imports and exports.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+type ImportRowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type ImportReport struct {
+	DryRun   bool             `json:"dryRun"`
+	Rows     int              `json:"rows"`
+	Valid    int              `json:"valid"`
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+type Bid struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Status         string    `json:"status"`
+	TenderID       string    `json:"tenderId"`
+	OrganizationID string    `json:"organizationId"`
+	Version        int       `json:"version"`
+	Price          *float64  `json:"price,omitempty"`
+	Sealed         bool      `json:"sealed,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	Lots           []BidLot  `json:"lots,omitempty"`
+}
+
+type BidLot struct {
+	LotID string   `json:"lotId"`
+	Price *float64 `json:"price,omitempty"`
+}
+
+// CreateBidRequest creates a bid on behalf of CreatorUsername, the employee
+// of the client when left empty.
+type CreateBidRequest struct {
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	TenderID        string   `json:"tenderId"`
+	OrganizationID  string   `json:"organizationId"`
+	CreatorUsername string   `json:"creatorUsername"`
+	Price           *float64 `json:"price,omitempty"`
+	Lots            []BidLot `json:"lots,omitempty"`
+}
+
+type BidCount struct {
+	TenderID string     `json:"tenderId"`
+	Count    int        `json:"count"`
+	Sealed   bool       `json:"sealed"`
+	Revealed bool       `json:"revealed"`
+	RevealAt *time.Time `json:"revealAt,omitempty"`
+}